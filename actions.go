@@ -23,6 +23,10 @@
 
 package riverboat
 
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
 // Action is the generic type of all state machine transitions, formalized to better allow external agents to interact with the game.
 // For all Actions, g is the game in which it is performed and pn is the player number performing the action.
 // data represents different things for different Actions.
@@ -41,6 +45,31 @@ package riverboat
 // it is your responsibility to ensure the integrity of those numbers.
 type Action func(g *Game, pn uint, data uint) error
 
+// ActionsByName maps the names that front ends use on the wire to the Actions they perform.
+var ActionsByName = map[string]Action{
+	"bet":         Bet,
+	"buyIn":       BuyIn,
+	"changeSeat":  ChangeSeat,
+	"deal":        Deal,
+	"emote":       Emote,
+	"fold":        Fold,
+	"leave":       Leave,
+	"misdeal":     Misdeal,
+	"muck":        Muck,
+	"discard":     Discard,
+	"postDead":    PostDead,
+	"postMissed":  PostMissedBlinds,
+	"sitIn":       SitIn,
+	"sitOut":      SitOut,
+	"straddle":    Straddle,
+	"rematch":     Rematch,
+	"show":        Show,
+	"showCards":   ShowCards,
+	"toggleAway":  ToggleAway,
+	"toggleReady": ToggleReady,
+	"undo":        Undo,
+}
+
 // Bet is the Action that covers checking, opening betting, calling, and raising.
 // For Bet, data is the amount of the bet (with a check being 0). If Bet is called out of turn, or
 // the value passed to data does not constitute a legal bet, Bet will return an error value. If bet is successful,
@@ -54,13 +83,17 @@ func Bet(g *Game, pn uint, data uint) error {
 		return ErrIllegalAction
 	}
 
+	snap := g.snapshotForUndo(pn)
+
 	p := g.getPlayer(pn)
 
 	//rename this for readability
 	betVal := data
 
 	var minBet uint = g.toCall()
-	var maxBet uint = g.getLimit()
+	var maxBet uint = g.getLimit(pn)
+	var allInBet uint = g.maxCommit(pn)
+	var minRaise uint = g.raiseMin()
 
 	var betLegalError error = nil
 
@@ -71,16 +104,31 @@ func Bet(g *Game, pn uint, data uint) error {
 	if !g.canOpen(pn) {
 		//Won't hit now, reserved for future implementations
 		betLegalError = ErrIllegalAction
-	} else if betVal >= maxBet {
-		//You can always go all-in
+	} else if betVal >= allInBet && allInBet <= maxBet {
+		//You can always go all-in, unless it's over the limit
 		betLegalError = nil
+		betVal = allInBet
+		if betVal+p.Bet > minBet {
+			// Going all-in for more than the call reopens the action, but it only changes
+			// the minimum raise if it was a full raise
+			if betVal+p.Bet-minBet >= minRaise {
+				g.minRaise = betVal + p.Bet - minBet
+			}
+			for i := range g.players {
+				g.players[i].Called = false
+			}
+			g.calledNum = pn
+		}
+	} else if betVal > maxBet {
+		//Over the limit
+		betLegalError = ErrIllegalAction
 	} else if betVal < (minBet - p.Bet) {
 		//Not calling the minimum needed
 		betLegalError = ErrIllegalAction
 	} else if betVal == (minBet - p.Bet) {
 		//Calling exactly
 		betLegalError = nil
-	} else if betVal < (minBet + g.minRaise - p.Bet) {
+	} else if betVal < (minBet + minRaise - p.Bet) {
 		// More than calling, but less than minimum raise
 		betLegalError = ErrIllegalAction
 	} else {
@@ -99,26 +147,59 @@ func Bet(g *Game, pn uint, data uint) error {
 		return betLegalError
 	}
 
-	g.players[pn].putInChips(betVal)
+	g.recordDecision(pn)
+
+	before := g.players[pn].Stack
+	g.players[pn].putInChips(betVal, g.config.HandCap)
 	g.players[pn].Called = true
+	g.players[pn].Straddle = false
+	g.players[pn].Kill = false
+
+	g.emit(Event{Kind: EventBet, PlayerNum: pn, Amount: before - g.players[pn].Stack, Away: g.players[pn].Away})
 
-	return g.updateRoundInfo()
+	return g.saveUndo(snap, pn, g.updateRoundInfo())
 }
 
 // BuyIn buys more chips for the player. For BuyIn, data is the amount to buy in for.
-// BuyIn will return an error if the player attempting it is in the current round, or if
-// the buy would cause the player's stack to exceed the maximum configured buy in.
+// BuyIn will return an error if data is 0, or if the player attempting it has been dealt into (or is
+// ready for) a hand that is in progress: players can only top up between hands. BuyIn returns
+// ErrBuyTooSmall if the player's stack afterwards would be less than the configured MinBuy (or, at
+// tables that play RuleSet.TableStakes, than the stack they last cashed out with), or
+// ErrBuyTooBig if the buy would cause the player's stack to exceed the maximum configured buy in.
 func BuyIn(g *Game, pn uint, data uint) error {
+	return g.buyIn(pn, data, true)
+}
+
+// buyIn is BuyIn, except that a buy-in after the player's first only counts as a rebuy if rebuy is true
+func (g *Game) buyIn(pn uint, data uint, rebuy bool) error {
 	p := g.getPlayer(pn)
 
-	//Can't buy in while playing
-	if p.In {
+	if data == 0 {
+		return ErrIllegalAction
+	}
+
+	//Can't buy in while playing (this includes players that have folded, until the hand is over)
+	if g.getStage() != PreDeal && (p.In || p.Ready) {
 		return ErrIllegalAction
 	}
 
+	//Can't buy less than the minimum buy, if it's configured
+	if p.Stack+data < g.config.MinBuy {
+		return ErrBuyTooSmall
+	}
+
+	//Can't come back with less than you cashed out with, at table stakes
+	if g.config.Rules.TableStakes && p.Stack+data < p.CashedOut {
+		return ErrBuyTooSmall
+	}
+
 	//Can't buy more than the maximum buy, if it's configured
 	if g.config.MaxBuy != 0 && p.Stack+data > g.config.MaxBuy {
-		return ErrIllegalAction
+		return ErrBuyTooBig
+	}
+
+	if rebuy && p.TotalBuyIn != 0 {
+		p.Rebuys++
 	}
 
 	//Otherwise, add it to the stack
@@ -126,24 +207,31 @@ func BuyIn(g *Game, pn uint, data uint) error {
 
 	//And add it to your total
 	p.TotalBuyIn = p.TotalBuyIn + data
+	p.CashedOut = 0
+
+	g.emit(Event{Kind: EventBuyIn, PlayerNum: pn, Amount: data})
 
 	return nil
 }
 
-// Deal deals the next set of cards, as appropriate per g's internal state. If g is currently betting,
-// or pn is not the dealer, Deal will return an error. Otherwise, if g is stage PreDeal when Deal is called,
-// Deal shuffles the deck and deals each player who is ready 2 cards. If g is stage PreFlop, Deal deals the flop; if g
-// is stage Flop, Deal deals the turn, and if g is stage Turn, Deal deals the river. g is never stage River and not betting,
-// so calling Deal during stage River will result in an error.
-// Deal ignores the value passed in as data.
+// Deal deals the next street of the hand, as laid out by the Schedule of g's Variant. If g is currently betting, or pn
+// is not the dealer (or, when the button is dead, the first ready player after it), Deal will return an error.
+// Otherwise, if g is stage PreDeal when Deal is called, Deal shuffles the deck, deals each player who is ready their
+// hole cards, and posts the blinds (in stud, the antes and bring-in). From any later stage, Deal deals the community
+// cards for the next street (in hold'em: the flop, then the turn, then the river), or in stud, each player's cards
+// for it. g is never on its last street and not betting, so calling Deal then will result in an error. Before dealing
+// anything, Deal checks the cards: if one has been dealt twice (ErrDuplicateCard), the community cards don't match
+// the stage (ErrBadBoard), or the deck is too short to deal the rest of the hand (ErrShortDeck), Deal returns the
+// error without dealing, and the hand can only be called off (see Misdeal). While g is paused, Deal returns
+// ErrGamePaused rather than deal a new hand (see Game.Pause). Deal ignores the value passed in as data.
 func Deal(g *Game, pn uint, data uint) error {
-	if pn != g.dealerNum {
+	if pn != g.dealingNum() {
 		return ErrIllegalAction
 	}
 
 	stage, betting := g.getStageAndBetting()
 
-	if betting {
+	if betting || g.discardsPending() || g.showdownPending() {
 		return ErrIllegalAction
 	}
 
@@ -151,92 +239,209 @@ func Deal(g *Game, pn uint, data uint) error {
 		return ErrIllegalAction
 	}
 
+	if stage == PreDeal && g.paused {
+		return ErrGamePaused
+	}
+
+	street, ok := g.variant().street(stage + 1)
+	if !ok {
+		return errInternalBadGameStage
+	}
+
+	if err := g.checkDeck(stage); err != nil {
+		return err
+	}
+
 	for i := range g.players {
 		g.players[i].Bet = 0
 		g.players[i].Called = false
 	}
 
-	g.minRaise = g.config.BigBlind
+	g.minRaise = g.killStakes(g.config.BigBlind)
 
 	//TODO: if all or all but one are all-in and its not the end, don't set betting to true on the next deal
 
-	switch stage {
-	case PreDeal:
+	if stage == PreDeal {
+		g.dealtFrom = g.recordDealtFrom()
+		g.startHand(street)
+	} else {
+		g.dealStreet(street)
+	}
 
-		// Zero all the community cards from last round
-		for i := range g.communityCards {
-			g.communityCards[i] = 0
-		}
+	g.setStage(street.Stage)
 
-		g.pots = []Pot{}
+	if g.discardsPending() {
+		// Betting opens once everybody has discarded
+		g.markActionAvailable()
+		return g.discardForAway()
+	}
 
-		g.updateBlindNums()
+	return g.openBetting()
+}
 
-		g.actionNum = g.utgNum
+// startHand shuffles up, deals the hole cards for the first street of a hand, and posts the blinds (or in stud, the
+// antes and bring-in)
+func (g *Game) startHand(street Street) {
+	// Zero all the community cards from last round
+	for i := range g.communityCards {
+		g.communityCards[i] = 0
+	}
 
-		for i := 0; i < 3; i++ {
-			g.deck.Shuffle(g.rand)
-		}
+	g.pots = []Pot{}
+	g.showdown = []ShowdownReveal{}
+	g.burns = []eval.Card{}
+	g.rematch = nil
+	g.potWon = false
+	g.potScooped = false
 
-		g.advanceRand()
+	stud := g.variant().stud()
 
-		for i, p := range g.players {
-			g.players[i].PreviousBet = 0
-			g.players[i].PreviouslyIn = false
-			g.players[i].PreviouslyAllIn = false
-
-			if p.Ready {
-				g.players[i].Cards[0] = g.deck.Pop()
-				g.players[i].Cards[1] = g.deck.Pop()
-				g.players[i].In = true
-			} else {
-				g.players[i].Cards[0] = 0
-				g.players[i].Cards[1] = 0
-			}
+	g.updateBlindNums()
+	if !stud {
+		g.trackMissedBlinds()
+	}
 
-			g.players[i].Called = false
+	// Players waiting for the big blind aren't dealt in, so the first of them to act may be further round
+	for g.waiting(g.utgNum) {
+		g.utgNum = g.nextReady(g.utgNum)
+	}
+	g.actionNum = g.utgNum
+
+	g.shuffle()
+
+	g.startStacks = make([]uint, len(g.players))
+	g.knockouts = nil
+
+	for i, p := range g.players {
+		g.players[i].PreviousBet = 0
+		g.players[i].PreviouslyIn = false
+		g.players[i].PreviouslyAllIn = false
+		g.players[i].AllInStage = 0
+
+		g.players[i].ThirdCard = 0
+		g.players[i].Discarded = 0
+		g.players[i].ExtraCards = [2]eval.Card{}
+		g.players[i].UpCards = [4]eval.Card{}
+		g.players[i].Shown = [2]bool{}
+
+		if p.Ready && !g.waiting(uint(i)) {
+			g.players[i].Cards[0] = g.draw()
+			g.players[i].Cards[1] = g.draw()
+			if street.HoleCards == 3 {
+				g.players[i].ThirdCard = g.draw()
+			}
+			if street.HoleCards == 4 {
+				g.players[i].ExtraCards[0] = g.draw()
+				g.players[i].ExtraCards[1] = g.draw()
+			}
+			if street.UpCards == 1 {
+				g.players[i].UpCards[0] = g.draw()
+			}
+			g.players[i].In = true
+			g.startStacks[i] = p.Stack + p.DeadChips
+		} else {
+			g.players[i].Cards[0] = 0
+			g.players[i].Cards[1] = 0
+			g.players[i].In = false
 		}
 
-		g.players[g.sbNum].putInChips(g.config.SmallBlind)
-		g.players[g.bbNum].putInChips(g.config.BigBlind)
+		g.players[i].Called = false
+	}
 
-	case PreFlop:
+	g.emit(Event{Kind: EventHandStart, Stage: street.Stage, PlayerNum: g.dealerNum})
+	g.trimEvents()
+	for i, p := range g.players {
+		if p.In {
+			cards := p.holeCards()
+			if p.ThirdCard != 0 {
+				cards = append(cards, p.ThirdCard)
+			}
+			g.emit(Event{Kind: EventHoleCards, Stage: street.Stage, PlayerNum: uint(i), Cards: cards, Away: p.Away})
+		}
+	}
 
-		g.actionNum = (g.dealerNum + 1) % uint(len(g.players))
-		for !g.players[g.actionNum].In {
-			g.actionNum = (g.actionNum + 1) % uint(len(g.players))
+	if stud {
+		for i, p := range g.players {
+			if p.In {
+				g.emit(Event{Kind: EventUpCard, Stage: street.Stage, PlayerNum: uint(i), Cards: []eval.Card{p.UpCards[0]}})
+			}
 		}
-		g.calledNum = g.actionNum
 
-		g.communityCards[0] = g.deck.Pop()
-		g.communityCards[1] = g.deck.Pop()
-		g.communityCards[2] = g.deck.Pop()
+		g.postBringIn(street.Stage)
+		return
+	}
 
-	case Flop:
-		g.actionNum = (g.dealerNum + 1) % uint(len(g.players))
-		for !g.players[g.actionNum].In {
-			g.actionNum = (g.actionNum + 1) % uint(len(g.players))
-		}
-		g.calledNum = g.actionNum
+	// Antes each player posts come out of their stack before the blinds
+	g.postAntes(street.Stage)
+
+	// The small blind is dead if the player due to post it has busted or left (see moveButton)
+	sbLive := g.players[g.sbNum].In
+	if sbLive {
+		g.players[g.sbNum].putInChips(g.config.SmallBlind, g.config.HandCap)
+	}
+	g.players[g.bbNum].putInChips(g.config.BigBlind, g.config.HandCap)
+	// What each blind posted, before a kill blind tops either of them up
+	sb, bb := g.players[g.sbNum].Bet, g.players[g.bbNum].Bet
+	kill := g.postKill()
+	straddle := g.postStraddle()
+
+	if sbLive {
+		g.emit(Event{Kind: EventBlind, Stage: street.Stage, PlayerNum: g.sbNum, Amount: sb})
+	}
+	g.emit(Event{Kind: EventBlind, Stage: street.Stage, PlayerNum: g.bbNum, Amount: bb})
+	if kill != 0 {
+		g.emit(Event{Kind: EventBlind, Stage: street.Stage, PlayerNum: g.killNum, Amount: kill})
+	}
+	if straddle != 0 {
+		g.emit(Event{Kind: EventBlind, Stage: street.Stage, PlayerNum: g.dealerNum, Amount: straddle})
+	}
 
-		g.communityCards[3] = g.deck.Pop()
+	g.postTableAnte(street.Stage)
+	g.postMissedBlinds(street.Stage)
+}
 
-	case Turn:
-		g.actionNum = (g.dealerNum + 1) % uint(len(g.players))
-		for !g.players[g.actionNum].In {
-			g.actionNum = (g.actionNum + 1) % uint(len(g.players))
-		}
+// dealStreet deals the community cards for a later street of a hand, and gives the action to the first player in
+// the hand to the dealer's left. In stud, it deals each player their own cards instead, and the player showing the
+// best low acts first.
+func (g *Game) dealStreet(street Street) {
+	if g.config.BurnCards {
+		burn := g.draw()
+		g.burns = append(g.burns, burn)
+		g.emit(Event{Kind: EventBurn, Stage: street.Stage, Cards: []eval.Card{burn}})
+	}
+
+	if g.variant().stud() {
+		g.dealUpCards(street)
+		g.actionNum = g.bestShowingNum()
 		g.calledNum = g.actionNum
+		return
+	}
+
+	g.actionNum = g.next(g.dealerNum)
+	for !g.players[g.actionNum].In {
+		g.actionNum = g.next(g.actionNum)
+	}
+	g.calledNum = g.actionNum
 
-		g.communityCards[4] = g.deck.Pop()
+	// The new cards go after the ones already dealt
+	start := 0
+	for start < len(g.communityCards) && g.communityCards[start] != 0 {
+		start++
+	}
 
-	default:
-		return errInternalBadGameStage
+	for i := start; i < start+street.CommunityCards; i++ {
+		g.communityCards[i] = g.draw()
 	}
 
-	g.setStageAndBetting(stage+1, true)
+	g.emit(Event{Kind: EventCommunityCards, Stage: street.Stage, Cards: append([]eval.Card{}, g.communityCards[start:start+street.CommunityCards]...)})
+}
 
-	return nil
+// openBetting starts the betting round for the current stage
+func (g *Game) openBetting() error {
+	g.setBetting(true)
+	g.markActionAvailable()
+
+	return g.actForAway()
 }
 
 // Fold folds a player's hand. Fold will return an error if
@@ -245,24 +450,39 @@ func Deal(g *Game, pn uint, data uint) error {
 // players have called) or terminating the hand (if after folding, only one other player is in).
 // Fold ignores the value passed in as data
 func Fold(g *Game, pn uint, data uint) error {
+	if !g.getBetting() {
+		return ErrIllegalAction
+	}
+
 	p := g.getPlayer(pn)
 
 	if g.actionNum != pn {
 		return ErrIllegalAction
 	}
 
+	snap := g.snapshotForUndo(pn)
+
 	p.In = false
 
-	return g.updateRoundInfo()
+	g.recordDecision(pn)
+	g.emit(Event{Kind: EventFold, PlayerNum: pn, Away: p.Away})
+
+	return g.saveUndo(snap, pn, g.updateRoundInfo())
 }
 
 // Leave marks a player as having left the game. This is essentially the same as marking a player
 // "not ready" (see ToggleReady) except it also marks the player as "left", which provides a distinct
 // state (e.g. so that frontends can render "left" players and "not ready" players differently)
 func Leave(g *Game, pn uint, data uint) error {
+	return g.leave(pn, false)
+}
+
+// leave is Leave. If the player is being removed (see RemovePlayer), it records that they were credited their whole
+// stack for leaving, which by then includes any dead chips ToggleReady handed back.
+func (g *Game) leave(pn uint, removed bool) error {
 	p := g.getPlayer(pn)
 	if p.Ready {
-		err := ToggleReady(g, pn, data)
+		err := ToggleReady(g, pn, 0)
 		if err != nil {
 			return err
 		}
@@ -270,12 +490,20 @@ func Leave(g *Game, pn uint, data uint) error {
 
 	p.Left = true
 
+	var cashOut uint
+	if removed {
+		cashOut = p.Stack
+	}
+
+	g.emit(Event{Kind: EventLeave, PlayerNum: pn, Amount: cashOut})
+
 	return nil
 }
 
 // ToggleReady marks a player as "ready" if they are currently "not ready"
 // or "not ready" if they are currently "ready." If the player attempting it is in the current round
-// ToggleReady will return an error. If the player attempting it has no money, ToggleReady will return an error.
+// ToggleReady will return an error. If the player attempting it has no money, ToggleReady will return an error,
+// and if they have less than the table's MinBigBlinds, it returns ErrStackTooSmall.
 // ToggleReady ignores the value passed in as data.
 func ToggleReady(g *Game, pn uint, data uint) error {
 	p := g.getPlayer(pn)
@@ -289,14 +517,31 @@ func ToggleReady(g *Game, pn uint, data uint) error {
 		p.Ready = false
 		p.Cards[0] = 0
 		p.Cards[1] = 0
+		p.ThirdCard = 0
+		p.ExtraCards = [2]eval.Card{}
+		p.UpCards = [4]eval.Card{}
+
+		// Dead chips posted for a hand that hasn't been dealt yet are returned. Once it has, they are in the pot,
+		// whether or not the player is still in the hand.
+		if stage == PreDeal {
+			p.Stack += p.DeadChips
+			p.DeadChips = 0
+		}
 	} else {
 		if p.Stack == 0 {
 			return ErrIllegalAction
 		}
+		if g.shortStacked(pn) {
+			return ErrStackTooSmall
+		}
+		// Including a player coming back after leaving, whose seat has been taken
+		if p.SeatNum == 0 || g.seatTaken(p.SeatNum, pn) {
+			return ErrSeatTaken
+		}
 		p.Ready = true
 	}
 
-	if pn == g.dealerNum {
+	if pn == g.dealerNum && !g.blindsCarried() {
 		if err := g.ensureValidDealer(); err != nil {
 			return err
 		}
@@ -308,6 +553,46 @@ func ToggleReady(g *Game, pn uint, data uint) error {
 
 	p.Left = false
 
+	if p.Ready {
+		g.emit(Event{Kind: EventReady, PlayerNum: pn})
+	} else {
+		g.emit(Event{Kind: EventNotReady, PlayerNum: pn})
+	}
+
+	return nil
+}
+
+// PostDead puts chips into the pot that don't count towards the player's bet, like a missed small blind, or a penalty.
+// For PostDead, data is the amount to post. Dead chips always go into the main pot, and the player does not get them back,
+// even if they win. PostDead can be used before the deal by a player who is ready (in which case the chips go into the next
+// hand's pot), or during a hand by a player who is in it. PostDead will return an error if the player can't post dead
+// at this time, if data is 0, or if data is more than the player's stack. If dead chips are disabled in g's RuleSet,
+// PostDead returns ErrFeatureDisabled.
+func PostDead(g *Game, pn uint, data uint) error {
+	if !g.config.Rules.DeadChips {
+		return ErrFeatureDisabled
+	}
+
+	p := g.getPlayer(pn)
+	stage, betting := g.getStageAndBetting()
+
+	if stage == PreDeal && !betting {
+		if !p.Ready {
+			return ErrIllegalAction
+		}
+	} else if !p.In {
+		return ErrIllegalAction
+	}
+
+	if data == 0 || data > p.Stack {
+		return ErrIllegalAction
+	}
+
+	p.Stack -= data
+	p.DeadChips += data
+
+	g.emit(Event{Kind: EventDeadChips, PlayerNum: pn, Amount: data})
+
 	return nil
 }
 
@@ -24,6 +24,7 @@
 package riverboat
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -598,3 +599,329 @@ func TestIntegration_Scenarios(t *testing.T) {
 	})
 
 }
+
+func TestIntegration_SidePotProvenance(t *testing.T) {
+	var err error
+	g := NewGame(nil)
+
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+	pn_c := g.AddPlayer()
+
+	for pn, amt := range []uint{100, 50, 100} {
+		if err = BuyIn(g, uint(pn), amt); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err = ToggleReady(g, uint(pn), 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err = Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	for _, b := range []struct {
+		pn  uint
+		amt uint
+	}{{pn_a, 25}, {pn_b, 40}, {pn_c, 25}, {pn_a, 25}} {
+		if err = Bet(g, b.pn, b.amt); err != nil {
+			t.Fatalf("Test failed - error betting: %s", err)
+		}
+	}
+
+	view := g.GenerateOmniView()
+
+	if len(view.Pots) != 2 {
+		t.Fatalf("Test failed - expected 2 pots, got %d", len(view.Pots))
+	}
+
+	main := view.Pots[0]
+	if main.Name != "Main pot" || main.Side {
+		t.Errorf("Test failed - first pot should be the main pot, got %+v", main)
+	}
+	if !main.Capped || main.TopShare != 50 {
+		t.Errorf("Test failed - main pot should be capped at player %d's 50, got %+v", pn_b, main)
+	}
+	if main.Amt != 150 || !reflect.DeepEqual(main.Contributions, []uint{50, 50, 50}) {
+		t.Errorf("Test failed - bad main pot contributions: %+v", main)
+	}
+
+	side := view.Pots[1]
+	if side.Name != "Side pot 1" || !side.Side || side.Capped {
+		t.Errorf("Test failed - second pot should be an uncapped side pot, got %+v", side)
+	}
+	if side.CreatedByPlayerNum != pn_b || side.CreatedStage != PreFlop {
+		t.Errorf("Test failed - side pot should be created by player %d going all-in on PreFlop, got %+v", pn_b, side)
+	}
+}
+
+func TestIntegration_EqualAllIns(t *testing.T) {
+	g := seatedGame(t, nil, 4, 1000)
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Two players are all-in for 100 each before the flop, and the other two call
+	short := []uint{g.actionNum, g.next(g.actionNum)}
+	for _, pn := range short {
+		g.players[pn].Stack = 100 - g.players[pn].Bet
+	}
+	for g.getStage() == PreFlop {
+		pn := g.actionNum
+		bet := g.ToCall(pn)
+		if pn == short[0] || pn == short[1] {
+			bet = g.players[pn].Stack
+		}
+		if err := Bet(g, pn, bet); err != nil {
+			t.Fatalf("Test failed - error betting: %s", err)
+		}
+	}
+
+	// The side pot is created by the first of them in seat order
+	first := short[0]
+	if short[1] < first {
+		first = short[1]
+	}
+
+	view := g.GenerateOmniView()
+	if len(view.Pots) != 2 {
+		t.Fatalf("Test failed - expected a main pot and one side pot, got %+v", view.Pots)
+	}
+	if main := view.Pots[0]; main.Amt != 400 || main.TopShare != 100 || len(main.EligiblePlayerNums) != 4 {
+		t.Errorf("Test failed - expected a main pot of 400 capped at 100, that everybody can win, got %+v", main)
+	}
+	if side := view.Pots[1]; side.Amt != 0 || side.CreatedByPlayerNum != first || side.CreatedStage != PreFlop ||
+		len(side.EligiblePlayerNums) != 2 {
+		t.Errorf("Test failed - expected an empty side pot for the two players still betting, created by player %d, got %+v",
+			first, side)
+	}
+}
+
+func TestIntegration_HandCap(t *testing.T) {
+	var err error
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, HandCap: 100})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err = BuyIn(g, pn, 200); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err = ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err = Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Player 0 tries to bet 150, but can only commit 100
+	if err = Bet(g, 0, 150); err != nil {
+		t.Fatalf("Test failed - error betting: %s", err)
+	}
+
+	if p := g.players[0]; p.Stack != 100 || p.TotalBet != 100 || !g.allIn(0) {
+		t.Errorf("Test failed - player 0 should be capped all-in for 100, got %+v", p)
+	}
+
+	// Player 1 calls for more than they can commit, player 2 folds
+	if err = Bet(g, 1, 1000); err != nil {
+		t.Fatalf("Test failed - error betting: %s", err)
+	}
+	if err = Fold(g, 2, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	view := g.GenerateOmniView()
+	if view.Pots[0].Amt != 225 || !view.Pots[0].Capped {
+		t.Errorf("Test failed - expected a capped 225 main pot, got %+v", view.Pots)
+	}
+
+	if view.Players[1].Stack != 100 {
+		t.Errorf("Test failed - player 1 should have 100 behind, got %d", view.Players[1].Stack)
+	}
+}
+
+func TestIntegration_BuyIn(t *testing.T) {
+	var err error
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, MinBuy: 100, MaxBuy: 500})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err = BuyIn(g, pn, 0); err != ErrIllegalAction {
+			t.Errorf("Test failed - buying in for nothing should fail, got %v", err)
+		}
+		if err = BuyIn(g, pn, 50); err != ErrBuyTooSmall {
+			t.Errorf("Test failed - buying in under MinBuy should fail, got %v", err)
+		}
+		if err = BuyIn(g, pn, 501); err != ErrBuyTooBig {
+			t.Errorf("Test failed - buying in over MaxBuy should fail, got %v", err)
+		}
+		if err = BuyIn(g, pn, 200); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err = ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err = Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Once player 0 folds they are out of the hand, but still can't top up until it's over
+	if err = Fold(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+	if err = BuyIn(g, 0, 100); err != ErrIllegalAction {
+		t.Errorf("Test failed - topping up during a hand should fail, got %v", err)
+	}
+
+	// A player who isn't playing can buy in at any time
+	if err = BuyIn(g, g.AddPlayer(), 100); err != nil {
+		t.Errorf("Test failed - error buying in a new player mid-hand: %s", err)
+	}
+
+	if err = Fold(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	// Top-ups are counted against MaxBuy, but not MinBuy
+	if err = BuyIn(g, 0, 301); err != ErrBuyTooBig {
+		t.Errorf("Test failed - topping up over MaxBuy should fail, got %v", err)
+	}
+	if err = BuyIn(g, 0, 50); err != nil {
+		t.Errorf("Test failed - error topping up between hands: %s", err)
+	}
+	if p := g.players[0]; p.Stack != 250 || p.TotalBuyIn != 250 {
+		t.Errorf("Test failed - expected a stack and total buy-in of 250, got %+v", p)
+	}
+}
+
+func TestIntegration_TableStakes(t *testing.T) {
+	config := GameConfig{BigBlind: 25, SmallBlind: 10, MinBigBlinds: 4}
+	config.Rules.TableStakes = true
+	g := NewGame(&config)
+
+	for _, stack := range []uint{200, 105, 200} {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, stack)
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if pn := g.AddPlayer(); BuyIn(g, pn, 50) != nil || ToggleReady(g, pn, 0) != ErrStackTooSmall {
+		t.Errorf("Test failed - a player with less than 4 big blinds shouldn't be dealt in")
+	}
+
+	// Player 1 posts the small blind and folds, leaving them with less than 4 big blinds
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	for _, pn := range []uint{0, 1} {
+		if err := Fold(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+
+	if p := g.players[1]; p.Stack != 95 || p.Ready {
+		t.Errorf("Test failed - expected player 1 to be sat out with 95, got %+v", p)
+	}
+	if err := BuyIn(g, 1, 5); err != nil {
+		t.Fatalf("Test failed - error topping up: %s", err)
+	}
+	if err := ToggleReady(g, 1, 0); err != nil {
+		t.Errorf("Test failed - expected player 1 to be dealt back in once topped up, got %v", err)
+	}
+
+	// Player 2 won the small blind, and can't come back with less than they cashed out
+	credit, err := g.RemovePlayer(2)
+	if err != nil || credit != 210 {
+		t.Fatalf("Test failed - expected to cash out 210, got %d, %v", credit, err)
+	}
+	if err := BuyIn(g, 2, 200); err != ErrBuyTooSmall {
+		t.Errorf("Test failed - buying back in for less than was cashed out should fail, got %v", err)
+	}
+	if err := BuyIn(g, 2, 210); err != nil || g.players[2].CashedOut != 0 {
+		t.Errorf("Test failed - error buying back in: %v", err)
+	}
+}
+
+func TestIntegration_PostDead(t *testing.T) {
+	var err error
+	g := NewGame(nil)
+
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+	pn_c := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b, pn_c} {
+		BuyIn(g, pn, 100)
+	}
+
+	if err = PostDead(g, pn_c, 10); err != ErrIllegalAction {
+		t.Errorf("Test failed - PostDead must return ErrIllegalAction for a player who isn't ready")
+	}
+
+	for _, pn := range []uint{pn_a, pn_b, pn_c} {
+		ToggleReady(g, pn, 0)
+	}
+
+	// Player c posts a missed small blind before the deal
+	if err = PostDead(g, pn_c, 10); err != nil {
+		t.Fatalf("Test failed - error posting dead: %s", err)
+	}
+
+	if err = Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// The dead chips don't count towards player c's call of the big blind
+	if err = Bet(g, pn_a, 25); err != nil {
+		t.Fatalf("Test failed - error betting: %s", err)
+	}
+	if err = Bet(g, pn_b, 15); err != nil {
+		t.Fatalf("Test failed - error betting: %s", err)
+	}
+	if err = Bet(g, pn_c, 0); err != nil {
+		t.Fatalf("Test failed - error betting: %s", err)
+	}
+
+	view := g.GenerateOmniView()
+	if view.Pots[0].Amt != 85 || view.Pots[0].Contributions[pn_c] != 35 {
+		t.Errorf("Test failed - expected the dead chips in the main pot, got %+v", view.Pots[0])
+	}
+
+	if view.Players[pn_c].Stack != 65 {
+		t.Errorf("Test failed - expected player c to have 65 behind, got %d", view.Players[pn_c].Stack)
+	}
+
+	if err = PostDead(g, pn_a, 1000); err != ErrIllegalAction {
+		t.Errorf("Test failed - PostDead must return ErrIllegalAction for more than the player's stack")
+	}
+}
+
+func TestIntegration_PostDeadThenFold(t *testing.T) {
+	g := seatedGame(t, nil, 3, 1000)
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Player 0 posts dead mid-hand, then folds and stands up. The dead chips stay in the pot.
+	if err := PostDead(g, 0, 50); err != nil {
+		t.Fatalf("Test failed - error posting dead: %s", err)
+	}
+	if err := Fold(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+	if err := ToggleReady(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error marking not ready: %s", err)
+	}
+
+	if p := g.players[0]; p.Stack != 950 || p.DeadChips != 50 {
+		t.Errorf("Test failed - expected the dead chips to stay in the pot, got a stack of %d", p.Stack)
+	}
+}
@@ -0,0 +1,165 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"math"
+)
+
+// The helpers below compute the standard figures of hand analysis from a GameView, for consumers like trainers
+// and HUDs. Equities and frequencies are fractions between 0 and 1. They are exact for heads-up spots, and the usual
+// approximation multiway, where they treat the whole field as a single opponent.
+
+// PotSize returns how many chips are in the middle: everything committed to the hand so far, including the current
+// street's bets, dead chips, and any carryover.
+func (gv *GameView) PotSize() uint {
+	total := gv.Carryover
+	for _, p := range gv.Players {
+		total += p.TotalBet + p.DeadChips
+	}
+
+	return total
+}
+
+// ToCall returns how many more chips player pn has to put in to call the current bet, which is less than the bet if
+// it would put them all in.
+func (gv *GameView) ToCall(pn uint) uint {
+	var bet uint
+	for _, p := range gv.Players {
+		if p.Bet > bet {
+			bet = p.Bet
+		}
+	}
+
+	p := gv.Players[pn]
+	if bet-p.Bet > p.Stack {
+		return p.Stack
+	}
+
+	return bet - p.Bet
+}
+
+// EffectiveStack returns the most player pn can win or lose from here on: the smaller of their stack and the
+// largest stack of anyone else still in the hand.
+func (gv *GameView) EffectiveStack(pn uint) uint {
+	var largest uint
+	for i, p := range gv.Players {
+		if uint(i) != pn && p.In && p.Stack > largest {
+			largest = p.Stack
+		}
+	}
+
+	if gv.Players[pn].Stack < largest {
+		return gv.Players[pn].Stack
+	}
+
+	return largest
+}
+
+// SPR returns player pn's stack-to-pot ratio: their effective stack over the pot. It is +Inf if the pot is empty.
+func (gv *GameView) SPR(pn uint) float64 {
+	return ratio(gv.EffectiveStack(pn), gv.PotSize())
+}
+
+// PotOdds returns the equity player pn needs to break even by calling the current bet: the call over the pot
+// they'd be playing for once they had called.
+func (gv *GameView) PotOdds(pn uint) float64 {
+	call := gv.ToCall(pn)
+	if call == 0 {
+		return 0
+	}
+
+	return ratio(call, gv.PotSize()+call)
+}
+
+// MinimumDefense returns the break-even calling frequency against the bet player pn is facing: how often they need
+// to continue so that the bet can't profit as a bluff, which is the pot before the bet over the pot after it.
+func (gv *GameView) MinimumDefense(pn uint) float64 {
+	call := gv.ToCall(pn)
+	if call == 0 {
+		return 0
+	}
+
+	pot := gv.PotSize()
+	return ratio(pot-call, pot)
+}
+
+// ShoveFoldEquity returns how often the opponents must fold for player pn to break even by going all in, given pn's
+// equity when called. The shove puts in pn's effective stack, and is called for the same amount on top of
+// whatever pn is facing. It is 0 if the shove is profitable even when always called, and 1 if it can't break even.
+func (gv *GameView) ShoveFoldEquity(pn uint, equity float64) float64 {
+	pot := float64(gv.PotSize())
+	shove := float64(gv.EffectiveStack(pn))
+	call := shove - float64(gv.ToCall(pn))
+
+	// When called, pn wins the pot with both shoves in it equity of the time, and loses the shove otherwise
+	called := equity*(pot+shove+call) - shove
+	if called >= 0 {
+		return 0
+	}
+
+	// When everyone folds, pn wins the pot as it stands
+	return math.Min(1, -called/(pot-called))
+}
+
+// PotSize returns how many chips are in the middle, like GameView.PotSize, including the current street's bets, which
+// aren't gathered into Pots until the street ends.
+func (g *Game) PotSize() uint {
+	total := g.carryover
+	for _, p := range g.players {
+		total += p.TotalBet + p.DeadChips
+	}
+
+	return total
+}
+
+// ToCall returns how many more chips player pn has to put in to call the current bet, which is less than the bet if
+// it would put them all in, or over the hand cap.
+func (g *Game) ToCall(pn uint) uint {
+	call := g.toCall() - g.players[pn].Bet
+	if max := g.maxCommit(pn); call > max {
+		return max
+	}
+
+	return call
+}
+
+// PotOdds returns the equity player pn needs to break even by calling the current bet, like GameView.PotOdds.
+func (g *Game) PotOdds(pn uint) float64 {
+	call := g.ToCall(pn)
+	if call == 0 {
+		return 0
+	}
+
+	return ratio(call, g.PotSize()+call)
+}
+
+// ratio returns a over b, or +Inf if b is 0
+func ratio(a, b uint) float64 {
+	if b == 0 {
+		return math.Inf(1)
+	}
+
+	return float64(a) / float64(b)
+}
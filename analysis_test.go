@@ -0,0 +1,111 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGameView_Analysis(t *testing.T) {
+	// Player 0 bets 100 into a pot of 100 on the flop, and player 1 has 400 behind
+	gv := &GameView{Players: []Player{
+		{In: true, Stack: 850, Bet: 100, TotalBet: 150},
+		{In: true, Stack: 400, TotalBet: 50},
+		{Stack: 1000},
+	}}
+
+	approx := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+	if gv.PotSize() != 200 {
+		t.Errorf("Test failed - expected a pot of 200, got %d", gv.PotSize())
+	}
+
+	if gv.ToCall(1) != 100 || gv.ToCall(0) != 0 {
+		t.Errorf("Test failed - expected player 1 to face 100, and player 0 nothing, got %d and %d", gv.ToCall(1), gv.ToCall(0))
+	}
+
+	// Player 2 has folded, so their stack doesn't count
+	if gv.EffectiveStack(0) != 400 || gv.EffectiveStack(1) != 400 {
+		t.Errorf("Test failed - expected an effective stack of 400")
+	}
+
+	if !approx(gv.SPR(1), 2) {
+		t.Errorf("Test failed - expected an SPR of 2, got %f", gv.SPR(1))
+	}
+
+	// Calling 100 for a pot of 300 needs a third, and a pot-sized bet must be defended half the time
+	if !approx(gv.PotOdds(1), 1.0/3) || !approx(gv.MinimumDefense(1), 0.5) {
+		t.Errorf("Test failed - expected pot odds of 1/3 and MDF of 1/2, got %f and %f", gv.PotOdds(1), gv.MinimumDefense(1))
+	}
+	if gv.PotOdds(0) != 0 || gv.MinimumDefense(0) != 0 {
+		t.Errorf("Test failed - a player facing no bet needs no odds")
+	}
+
+	// Shoving 400 over the bet: called, player 1 risks 400 to win 200 + 400 + 300.
+	// With no equity, they need to win the 200 often enough to make up for losing 400: 2/3 of the time.
+	if got := gv.ShoveFoldEquity(1, 0); !approx(got, 2.0/3) {
+		t.Errorf("Test failed - expected a pure bluff to need 2/3 folds, got %f", got)
+	}
+	// With 4/9 equity, the shove breaks even when called
+	if got := gv.ShoveFoldEquity(1, 4.0/9); got != 0 {
+		t.Errorf("Test failed - expected a shove with 4/9 equity to need no folds, got %f", got)
+	}
+
+	if empty := (&GameView{Players: []Player{{Stack: 100}, {In: true, Stack: 100}}}); !math.IsInf(empty.SPR(0), 1) {
+		t.Errorf("Test failed - the SPR of an empty pot should be infinite")
+	}
+}
+
+func TestGame_PotOdds(t *testing.T) {
+	g := NewGame(nil)
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	Deal(g, 0, 0)
+
+	// The blinds are in, and the first to act raises to 100
+	Bet(g, g.actionNum, 100)
+	pn := g.actionNum
+
+	if g.PotSize() != 135 {
+		t.Errorf("Test failed - expected the pot to include the unswept blinds and raise, got %d", g.PotSize())
+	}
+
+	if g.ToCall(pn) != 100-g.players[pn].Bet {
+		t.Errorf("Test failed - expected player %d to face %d, got %d", pn, 100-g.players[pn].Bet, g.ToCall(pn))
+	}
+
+	want := float64(g.ToCall(pn)) / float64(135+g.ToCall(pn))
+	if got := g.PotOdds(pn); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Test failed - expected pot odds of %f, got %f", want, got)
+	}
+
+	if got := g.GenerateOmniView().PotOdds(pn); math.Abs(got-g.PotOdds(pn)) > 1e-9 {
+		t.Errorf("Test failed - expected the Game and GameView to agree on pot odds, got %f", got)
+	}
+}
@@ -0,0 +1,60 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"math/rand"
+)
+
+// AnonymizedEvents returns every Event recorded by g, like Events, but with each player number replaced by an
+// alias, for sharing hand histories with analysts, or publishing them, without giving away who played them. (Player
+// numbers are the only identities the engine knows, but a player keeps theirs for as long as they are seated, so
+// histories exported at different times could otherwise be matched up to follow a player between them.)
+//
+// Each player keeps the same alias throughout an export, so the hands still read correctly. The aliases are a
+// random reordering of the player numbers, drawn afresh from seed for each export, so the same player gets
+// unrelated aliases in different exports. Aliases are not seats, so positions must be worked out from the dealer
+// recorded with each EventHandStart, rather than from the aliases themselves.
+func (g *Game) AnonymizedEvents(seed int64) []Event {
+	aliases := rand.New(rand.NewSource(seed)).Perm(len(g.players))
+
+	events := copyEvents(g.events)
+	for i := range events {
+		if kindHasPlayer(events[i].Kind) {
+			events[i].PlayerNum = uint(aliases[events[i].PlayerNum])
+		}
+	}
+
+	return events
+}
+
+// kindHasPlayer reports whether PlayerNum is meaningful for Events of kind k
+func kindHasPlayer(k EventKind) bool {
+	switch k {
+	case EventCommunityCards, EventHandEnd, EventVariant, EventRematchOffer, EventRematch, EventBurn:
+		return false
+	}
+
+	return true
+}
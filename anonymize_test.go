@@ -0,0 +1,86 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestGame_AnonymizedEvents(t *testing.T) {
+	g := NewGame(nil)
+
+	for i := 0; i < 4; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	playFoldedHand(t, g)
+
+	events := g.Events()
+	exports := [][]Event{g.AnonymizedEvents(1), g.AnonymizedEvents(2)}
+
+	for n, anon := range exports {
+		if len(anon) != len(events) {
+			t.Fatalf("Test failed - export %d has %d events, expected %d", n, len(anon), len(events))
+		}
+
+		// Every player keeps one alias, and no two players share one
+		aliases := make(map[uint]uint)
+		used := make(map[uint]bool)
+		for i, e := range events {
+			a := anon[i]
+			if a.Kind != e.Kind || a.Amount != e.Amount || len(a.Cards) != len(e.Cards) {
+				t.Fatalf("Test failed - export %d changed more than the player numbers of event %d", n, i)
+			}
+			if !kindHasPlayer(e.Kind) {
+				continue
+			}
+			if alias, ok := aliases[e.PlayerNum]; !ok {
+				if used[a.PlayerNum] {
+					t.Fatalf("Test failed - export %d gave alias %d to two players", n, a.PlayerNum)
+				}
+				aliases[e.PlayerNum] = a.PlayerNum
+				used[a.PlayerNum] = true
+			} else if alias != a.PlayerNum {
+				t.Fatalf("Test failed - export %d gave player %d two aliases", n, e.PlayerNum)
+			}
+		}
+	}
+
+	same := true
+	for i := range events {
+		same = same && exports[0][i].PlayerNum == exports[1][i].PlayerNum
+	}
+	if same {
+		t.Errorf("Test failed - exports with different seeds should use different aliases")
+	}
+
+	// Anonymizing doesn't touch the game's own log
+	if g.Events()[0].PlayerNum != events[0].PlayerNum {
+		t.Errorf("Test failed - the event log itself should be left as it is")
+	}
+}
@@ -0,0 +1,89 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// AnteMode decides who posts the ante at a table that plays one (see GameConfig.Ante)
+type AnteMode uint8
+
+const (
+	// AntePerPlayer has every player dealt in post the ante. This is the default.
+	AntePerPlayer AnteMode = iota
+	// AnteBigBlind has the big blind post the ante for the whole table
+	AnteBigBlind
+	// AnteButton has the button post the ante for the whole table, as in some live series. If the button is dead,
+	// the first player dealt in after it posts it.
+	AnteButton
+)
+
+// postAntes has every player dealt in post the ante, at a table that plays AntePerPlayer. Antes are dead chips,
+// which go in the main pot, and are posted before the blinds, so a player who can't cover both goes all in on the
+// ante first.
+func (g *Game) postAntes(stage GameStage) {
+	if g.config.Ante == 0 || g.config.AnteMode != AntePerPlayer {
+		return
+	}
+
+	for i, p := range g.players {
+		if p.In {
+			g.postAnte(stage, uint(i))
+		}
+	}
+}
+
+// postTableAnte has one player post the ante for the whole table, at a table that plays AnteBigBlind or AnteButton.
+// The table ante is posted after the blinds, so a player who can't cover both posts their blind in full and as much
+// of the ante as they have left.
+func (g *Game) postTableAnte(stage GameStage) {
+	if g.config.Ante == 0 {
+		return
+	}
+
+	switch g.config.AnteMode {
+	case AnteBigBlind:
+		g.postAnte(stage, g.bbNum)
+	case AnteButton:
+		pn := g.dealerNum
+		for !g.players[pn].In {
+			pn = g.next(pn)
+		}
+		g.postAnte(stage, pn)
+	}
+}
+
+// postAnte has player pn post the ante, or as much of it as they have
+func (g *Game) postAnte(stage GameStage, pn uint) {
+	p := &g.players[pn]
+
+	ante := g.config.Ante
+	if ante > p.Stack {
+		ante = p.Stack
+	}
+	if ante == 0 {
+		return
+	}
+
+	p.Stack -= ante
+	p.DeadChips += ante
+	g.emit(Event{Kind: EventDeadChips, Stage: stage, PlayerNum: pn, Amount: ante})
+}
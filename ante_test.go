@@ -0,0 +1,142 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestGame_Antes(t *testing.T) {
+	tests := []struct {
+		mode AnteMode
+		ante uint
+		// The dead chips posted by the dealer, the small blind and the big blind
+		want [3]uint
+	}{
+		{AntePerPlayer, 5, [3]uint{5, 5, 5}},
+		{AnteBigBlind, 25, [3]uint{0, 0, 25}},
+		{AnteButton, 15, [3]uint{15, 0, 0}},
+	}
+
+	for _, tt := range tests {
+		config := defaultConfig
+		config.Ante = tt.ante
+		config.AnteMode = tt.mode
+		g := NewGame(&config)
+
+		for i := 0; i < 3; i++ {
+			pn := g.AddPlayer()
+			BuyIn(g, pn, 1000)
+			ToggleReady(g, pn, 0)
+		}
+
+		if err := Deal(g, g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+
+		for i, pn := range []uint{g.dealerNum, g.sbNum, g.bbNum} {
+			if got := g.players[pn].DeadChips; got != tt.want[i] {
+				t.Errorf("Test failed - with ante mode %d, expected player %d to post an ante of %d, got %d", tt.mode, pn, tt.want[i], got)
+			}
+		}
+
+		// The antes go to whoever wins the pot
+		winner := g.bbNum
+		for g.getStage() != PreDeal {
+			Fold(g, g.actionNum, 0)
+		}
+		if got := g.players[winner].Stack; got != 1000+config.SmallBlind+tt.want[0]+tt.want[1] {
+			t.Errorf("Test failed - with ante mode %d, expected the big blind to win the antes, got a stack of %d", tt.mode, got)
+		}
+	}
+}
+
+func TestGame_AntesShortStacked(t *testing.T) {
+	config := defaultConfig
+	config.Ante = 25
+	config.AnteMode = AnteBigBlind
+	g := NewGame(&config)
+
+	for _, stack := range []uint{1000, 1000, 30} {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, stack)
+		ToggleReady(g, pn, 0)
+	}
+
+	// Player 2 is in the big blind, which comes before the ante
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if p := g.players[2]; p.Bet != 25 || p.DeadChips != 5 || p.Stack != 0 {
+		t.Errorf("Test failed - expected the big blind to post in full and an ante of 5, got %+v", p)
+	}
+}
+
+func TestGame_AntesBeforeBlinds(t *testing.T) {
+	config := defaultConfig
+	config.Ante = 5
+	g := NewGame(&config)
+
+	for _, stack := range []uint{1000, 1000, 20} {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, stack); err != nil {
+			t.Fatalf("Test failed - error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - error marking ready: %s", err)
+		}
+	}
+
+	// Player 2 is in the big blind, and posts the ante in full before as much of the blind as they have left
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if p := g.players[2]; p.DeadChips != 5 || p.Bet != 15 || p.Stack != 0 {
+		t.Errorf("Test failed - expected the big blind to post an ante of 5 and a blind of 15, got %+v", p)
+	}
+}
+
+func TestGame_ButtonAnteDeadButton(t *testing.T) {
+	config := defaultConfig
+	config.Ante = 30
+	config.AnteMode = AnteButton
+	g := NewGame(&config)
+
+	for i := 0; i < 4; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	playFoldedHand(t, g)
+
+	// Player 1 is due the button but leaves, so the button is dead, and the first player after it posts the ante
+	if err := Leave(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error leaving: %s", err)
+	}
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if g.dealerNum != 1 || g.players[2].DeadChips != 30 {
+		t.Errorf("Test failed - expected player 2 to post the button ante for the dead button, got %+v", g.players)
+	}
+}
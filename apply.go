@@ -0,0 +1,81 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+)
+
+// GameConfig.RejectLimit counts rejected attempts over this window
+const rejectWindow = time.Second
+
+// Apply performs Action a for player pn, like calling a(g, pn, data) directly, but enforces g's RejectLimit: once a
+// player has had RejectLimit Actions rejected within the last second, Apply returns ErrRateLimited for that player
+// without performing anything, until enough of those rejections have aged out of the window. Attempts that Apply
+// itself throttles don't count against the limit.
+//
+// Servers that accept Actions from untrusted clients should perform them through Apply, so a misbehaving client
+// spamming invalid moves can't monopolize the Game (or whatever lock protects it).
+//
+// If g is saved to a GameStore (see SetStore), Apply saves it once the Action has succeeded, and returns the store's
+// error if that fails, though the Action has still been performed.
+func (g *Game) Apply(a Action, pn uint, data uint) error {
+	now := g.currentTime()
+
+	if g.config.RejectLimit != 0 {
+		g.expireRejections(pn, now)
+		if uint(len(g.rejections[pn])) >= g.config.RejectLimit {
+			return ErrRateLimited
+		}
+	}
+
+	err := a(g, pn, data)
+	if err != nil && err != ErrRateLimited && g.config.RejectLimit != 0 {
+		if g.rejections == nil {
+			g.rejections = make(map[uint][]time.Time)
+		}
+		g.rejections[pn] = append(g.rejections[pn], now)
+	}
+	if err != nil {
+		return err
+	}
+
+	return g.SaveNow()
+}
+
+// expireRejections forgets pn's rejections that are older than rejectWindow
+func (g *Game) expireRejections(pn uint, now time.Time) {
+	times := g.rejections[pn]
+
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= rejectWindow {
+		i++
+	}
+
+	if i == len(times) {
+		delete(g.rejections, pn)
+	} else {
+		g.rejections[pn] = times[i:]
+	}
+}
@@ -0,0 +1,66 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGame_ApplyRejectLimit(t *testing.T) {
+	config := defaultConfig
+	config.RejectLimit = 2
+	g := NewGame(&config)
+
+	now := time.Unix(0, 0)
+	g.now = func() time.Time { return now }
+
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	// Nobody can bet before the hand is dealt, so these are all rejected
+	for i := 0; i < 2; i++ {
+		if err := g.Apply(Bet, pn_a, 10); err != ErrIllegalAction {
+			t.Fatalf("Test failed - attempt %d should be rejected as illegal, got %v", i, err)
+		}
+	}
+
+	if err := g.Apply(BuyIn, pn_a, 100); err != ErrRateLimited {
+		t.Fatalf("Test failed - a player over the limit should be throttled, even for legal Actions, got %v", err)
+	}
+
+	if err := g.Apply(BuyIn, pn_b, 100); err != nil {
+		t.Fatalf("Test failed - other players should not be throttled, got %v", err)
+	}
+
+	now = now.Add(rejectWindow / 2)
+	if err := g.Apply(BuyIn, pn_a, 100); err != ErrRateLimited {
+		t.Fatalf("Test failed - the player should still be throttled, got %v", err)
+	}
+
+	now = now.Add(rejectWindow / 2)
+	if err := g.Apply(BuyIn, pn_a, 100); err != nil {
+		t.Fatalf("Test failed - the player should no longer be throttled, got %v", err)
+	}
+}
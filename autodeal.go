@@ -0,0 +1,72 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+)
+
+// NextAdvance returns when the next dealer duty is due, for Games with AutoDeal turned on, so servers can schedule a
+// call to Advance. The second return value is false if there is no duty to schedule: AutoDeal is off, a hand is being
+// played, there aren't enough players ready for a new one, or the Game is paused between hands.
+func (g *Game) NextAdvance() (time.Time, bool) {
+	if !g.config.AutoDeal {
+		return time.Time{}, false
+	}
+
+	stage, betting := g.getStageAndBetting()
+	if betting || g.discardsPending() || g.showdownPending() || g.readyCount() < 2 {
+		return time.Time{}, false
+	}
+
+	if stage != PreDeal {
+		// A street is waiting to be dealt
+		return g.currentTime(), true
+	}
+
+	if g.paused {
+		return time.Time{}, false
+	}
+
+	return g.handEnded.Add(g.config.DealDelay), true
+}
+
+// Advance performs the dealer's duties, if any are due, so the Game can run without anyone calling Deal: it deals
+// the next hand once DealDelay has passed since the last one ended. (The flop, turn and river are always dealt as
+// soon as betting closes.) Advance returns true if it dealt anything. If AutoDeal is off, Advance does nothing.
+//
+// Advance doesn't run on its own: servers should call it at the time returned by NextAdvance, and again every time
+// an Action changes what NextAdvance returns.
+func (g *Game) Advance() (bool, error) {
+	at, ok := g.NextAdvance()
+	if !ok || g.currentTime().Before(at) {
+		return false, nil
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		return false, err
+	}
+
+	return true, g.SaveNow()
+}
@@ -0,0 +1,142 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGame_Advance(t *testing.T) {
+	config := defaultConfig
+	config.AutoDeal = true
+	config.DealDelay = 5 * time.Second
+	g := NewGame(&config)
+
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	if _, ok := g.NextAdvance(); ok {
+		t.Errorf("Test failed - there should be nothing to advance without players ready")
+	}
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	// No hand has been played yet, so the first one is dealt right away
+	if dealt, err := g.Advance(); !dealt || err != nil || g.getStage() != PreFlop {
+		t.Fatalf("Test failed - Advance should deal the first hand, got %t, %v", dealt, err)
+	}
+
+	if err := Fold(g, g.actionNum, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	if at, ok := g.NextAdvance(); !ok || !at.Equal(now.Add(config.DealDelay)) {
+		t.Errorf("Test failed - the next hand should be due %s after the last, got %s, %t", config.DealDelay, at, ok)
+	}
+
+	now = now.Add(config.DealDelay - time.Second)
+	if dealt, _ := g.Advance(); dealt {
+		t.Errorf("Test failed - Advance should not deal before the delay has passed")
+	}
+
+	now = now.Add(time.Second)
+	if dealt, err := g.Advance(); !dealt || err != nil || g.getStage() != PreFlop {
+		t.Errorf("Test failed - Advance should deal once the delay has passed, got %t, %v", dealt, err)
+	}
+}
+
+func TestGame_AdvanceOff(t *testing.T) {
+	g := NewGame(nil)
+
+	for i := 0; i < 2; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 100)
+		ToggleReady(g, pn, 0)
+	}
+
+	if dealt, _ := g.Advance(); dealt || g.getStage() != PreDeal {
+		t.Errorf("Test failed - Advance should do nothing when AutoDeal is off")
+	}
+}
+
+func TestGame_AdvanceRestored(t *testing.T) {
+	config := defaultConfig
+	config.AutoDeal = true
+	config.DealDelay = 5 * time.Second
+	g := NewGame(&config)
+
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 100)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Deal(g, g.dealerNum, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := Fold(g, g.actionNum, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	// The server goes down for a couple of seconds, and restores the game from storage
+	b, err := json.Marshal(g.GenerateOmniView())
+	if err != nil {
+		t.Fatalf("Test failed - error encoding the view: %s", err)
+	}
+	now = now.Add(2 * time.Second)
+
+	var fromJSON, fromProto GameView
+	if err := json.Unmarshal(b, &fromJSON); err != nil {
+		t.Fatalf("Test failed - error decoding the view: %s", err)
+	}
+	fromProto.FromProto(g.GenerateOmniView().ToProto())
+
+	for _, gv := range []*GameView{&fromJSON, &fromProto} {
+		restored := NewGame(nil)
+		restored.now = g.now
+		restored.FillFromView(gv)
+
+		if at, ok := restored.NextAdvance(); !ok || !at.Equal(time.Unix(1005, 0)) {
+			t.Errorf("Test failed - the restored game should still deal at the time it was due, got %s, %t", at, ok)
+		}
+		if dealt, _ := restored.Advance(); dealt {
+			t.Errorf("Test failed - the restored game shouldn't skip the rest of the delay")
+		}
+	}
+}
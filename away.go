@@ -0,0 +1,75 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// ToggleAway marks a player as away if they are present, or present if they are away. A player who is away stays
+// ready, so they keep posting blinds and being dealt in (as tournaments require of absent players), but whenever the
+// action reaches them, the engine acts for them: it checks if it can, and folds to any bet. Those checks and folds
+// are recorded with Away set in the event log, so they can be told apart from the player's own decisions (see VPIP).
+// If the action is on the player when they are marked away, the engine acts for them right away.
+// ToggleAway will return an error if the player has left. ToggleAway ignores the value passed in as data.
+func ToggleAway(g *Game, pn uint, data uint) error {
+	p := g.getPlayer(pn)
+
+	// Players sitting out come back with SitIn
+	if p.Left || p.SittingOut {
+		return ErrIllegalAction
+	}
+
+	p.Away = !p.Away
+
+	if !p.Away {
+		g.emit(Event{Kind: EventBack, PlayerNum: pn})
+		return nil
+	}
+
+	g.emit(Event{Kind: EventAway, PlayerNum: pn})
+
+	return g.actForAway()
+}
+
+// actForAway checks or folds for the player the action is on, if they are away (players sitting out always fold). It
+// should be called whenever the action moves to a new player. Acting moves the action along in turn, so this carries on
+// through every away player until the action reaches one who is present, or the betting round ends.
+func (g *Game) actForAway() error {
+	if g.showdownPending() {
+		return g.continueShowdown()
+	}
+
+	if !g.getBetting() || !g.players[g.actionNum].Away {
+		return nil
+	}
+
+	return g.checkOrFold(g.actionNum)
+}
+
+// checkOrFold checks for player pn if they can, and folds for them if they can't (players sitting out always fold).
+// The action must be on pn.
+func (g *Game) checkOrFold(pn uint) error {
+	if g.toCall() == g.players[pn].Bet && !g.players[pn].SittingOut {
+		return Bet(g, pn, 0)
+	}
+
+	return Fold(g, pn, 0)
+}
@@ -0,0 +1,97 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestToggleAway(t *testing.T) {
+	g := NewGame(nil)
+
+	pns := []uint{g.AddPlayer(), g.AddPlayer(), g.AddPlayer()}
+	for _, pn := range pns {
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	// Player 2 is the big blind, and is away for the hand
+	if err := ToggleAway(g, 2, 0); err != nil {
+		t.Fatalf("Test failed - error marking away: %s", err)
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	if !g.players[2].In || g.players[2].Bet != g.config.BigBlind {
+		t.Fatalf("Test failed - an away player should still be dealt in and post their blind")
+	}
+
+	if err := Bet(g, 0, 75); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+	if err := Bet(g, 1, 65); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+
+	// Facing a raise, the away big blind is folded automatically, and the flop is dealt
+	if g.players[2].In || g.getStage() != Flop {
+		t.Fatalf("Test failed - the away player should have been folded, and the flop dealt")
+	}
+
+	// Marking the player first to act away checks for them
+	if g.actionNum != 1 {
+		t.Fatalf("Test failed - action should be on player 1, but is on %d", g.actionNum)
+	}
+	if err := ToggleAway(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error marking away: %s", err)
+	}
+	if g.actionNum != 0 || !g.players[1].In {
+		t.Fatalf("Test failed - player 1 should have been checked for, leaving the action on player 0")
+	}
+
+	var autoFolds, autoChecks uint
+	for _, e := range g.Events() {
+		if e.Away && e.Kind == EventFold && e.PlayerNum == 2 {
+			autoFolds++
+		}
+		if e.Away && e.Kind == EventBet && e.PlayerNum == 1 && e.Amount == 0 {
+			autoChecks++
+		}
+	}
+	if autoFolds != 1 || autoChecks != 1 {
+		t.Errorf("Test failed - expected one automatic fold and one automatic check, got %d and %d", autoFolds, autoChecks)
+	}
+
+	// The hand player 2 was away for doesn't count towards their VPIP, but player 0's raise counts towards theirs
+	if voluntary, dealt := g.VPIP(2); voluntary != 0 || dealt != 0 {
+		t.Errorf("Test failed - player 2's VPIP should count no hands, got %d/%d", voluntary, dealt)
+	}
+	if voluntary, dealt := g.VPIP(0); voluntary != 1 || dealt != 1 {
+		t.Errorf("Test failed - player 0's VPIP should be 1/1, got %d/%d", voluntary, dealt)
+	}
+}
@@ -0,0 +1,96 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// Strategy decides what a bot does when the action reaches it. Act is given the bot's own (censored) view of the
+// Game and its player number, and returns the Action to perform and the data to perform it with.
+type Strategy interface {
+	Act(view *GameView, pn uint) (Action, uint)
+}
+
+// StrategyFunc is an adapter to allow the use of ordinary functions as Strategies.
+type StrategyFunc func(view *GameView, pn uint) (Action, uint)
+
+// Act calls f(view, pn).
+func (f StrategyFunc) Act(view *GameView, pn uint) (Action, uint) {
+	return f(view, pn)
+}
+
+// CallingStation is a Strategy that never folds or raises: it checks when it can, and calls (or goes all-in trying
+// to) when it can't.
+var CallingStation Strategy = StrategyFunc(func(view *GameView, pn uint) (Action, uint) {
+	var toCall uint
+	for _, p := range view.Players {
+		if p.Bet > toCall {
+			toCall = p.Bet
+		}
+	}
+
+	return Bet, toCall - view.Players[pn].Bet
+})
+
+// Driver seats bots at a Game, and plays for them whenever the action reaches one. Drivers should not be initialized
+// directly, only through the NewDriver factory function.
+//
+// A Driver is not safe for concurrent use, and neither is the Game it drives: anything that acts on the Game must
+// be serialized with calls to Play.
+type Driver struct {
+	g    *Game
+	bots map[uint]Strategy
+}
+
+// NewDriver returns a Driver for g, with no bots seated.
+func NewDriver(g *Game) *Driver {
+	return &Driver{g: g, bots: make(map[uint]Strategy)}
+}
+
+// Seat adds a new player to the Game, controlled by s, and returns its player number. The bot still needs to buy in
+// and ready up like any other player; Seat does not do either.
+func (d *Driver) Seat(s Strategy) uint {
+	pn := d.g.AddPlayer()
+	d.bots[pn] = s
+	return pn
+}
+
+// Play performs Actions for bots until the action is on a player who isn't one, or there is no betting to be done
+// (e.g. the hand is over). Applications should call it after every Action they perform on the Game.
+//
+// If an Action chosen by a bot's Strategy fails, Play stops and returns the error, leaving the action on that bot.
+func (d *Driver) Play() error {
+	for d.g.getBetting() {
+		pn := d.g.actionNum
+
+		s, ok := d.bots[pn]
+		if !ok {
+			return nil
+		}
+
+		action, data := s.Act(d.g.GeneratePlayerView(pn), pn)
+		if err := action(d.g, pn, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestDriver_Play(t *testing.T) {
+	g := NewGame(nil)
+	d := NewDriver(g)
+
+	human := g.AddPlayer()
+	bots := []uint{d.Seat(CallingStation), d.Seat(CallingStation)}
+
+	for _, pn := range append([]uint{human}, bots...) {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, human, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Preflop, the human is under the gun; the bots don't act out of turn
+	if err := d.Play(); err != nil {
+		t.Fatalf("Test failed - error playing bots: %s", err)
+	}
+	if g.actionNum != human {
+		t.Fatalf("Test failed - action should be on the human, but is on %d", g.actionNum)
+	}
+
+	// Every time the human checks or calls, the bots play until it's the human's turn again
+	for g.getBetting() {
+		if g.actionNum != human {
+			t.Fatalf("Test failed - Play returned with the action on bot %d", g.actionNum)
+		}
+
+		action, data := CallingStation.Act(g.GeneratePlayerView(human), human)
+		if err := action(g, human, data); err != nil {
+			t.Fatalf("Test failed - error calling: %s", err)
+		}
+
+		if err := d.Play(); err != nil {
+			t.Fatalf("Test failed - error playing bots: %s", err)
+		}
+	}
+
+	if g.getStage() != PreDeal {
+		t.Errorf("Test failed - the hand should have been played to showdown, but is at stage %d", g.getStage())
+	}
+}
+
+func TestDriver_PlayError(t *testing.T) {
+	g := NewGame(nil)
+	d := NewDriver(g)
+
+	overbet := StrategyFunc(func(view *GameView, pn uint) (Action, uint) {
+		return Bet, 1
+	})
+
+	pn_a := d.Seat(overbet)
+	pn_b := d.Seat(overbet)
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	action := g.actionNum
+
+	if err := d.Play(); err != ErrIllegalAction {
+		t.Fatalf("Test failed - Play should return the illegal Bet's error, got %v", err)
+	}
+
+	if g.actionNum != action {
+		t.Errorf("Test failed - the action should stay on bot %d, but is on %d", action, g.actionNum)
+	}
+}
@@ -0,0 +1,154 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "sort"
+
+// recordKnockouts works out, at the end of a hand, who knocked out each player who was dealt in and has no chips
+// left: the winners of the last pot the player was eligible for (the one holding their last chips), or if there were
+// no pots to speak of, every one of winners (the players who won a pot this hand, who may be listed more than once).
+func (g *Game) recordKnockouts(winners []uint) {
+	g.knockouts = nil
+
+	for pn, p := range g.players {
+		if g.handStartStack(uint(pn)) == 0 || p.Stack != 0 {
+			continue
+		}
+
+		var by []uint
+		for i := len(g.pots) - 1; i >= 0 && by == nil; i-- {
+			for _, eligible := range g.pots[i].EligiblePlayerNums {
+				if eligible == uint(pn) {
+					by = appendUnique(by, g.pots[i].WinningPlayerNums...)
+					by = appendUnique(by, g.pots[i].LowWinningPlayerNums...)
+					break
+				}
+			}
+		}
+
+		if len(by) == 0 {
+			by = appendUnique(nil, winners...)
+		}
+
+		if g.knockouts == nil {
+			g.knockouts = make(map[uint][]uint)
+		}
+		g.knockouts[uint(pn)] = by
+	}
+}
+
+// appendUnique appends each of pns to s that isn't in it already
+func appendUnique(s []uint, pns ...uint) []uint {
+	for _, pn := range pns {
+		found := false
+		for _, have := range s {
+			if have == pn {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			s = append(s, pn)
+		}
+	}
+
+	return s
+}
+
+// KnockedOutBy returns the players who knocked player pn out in the last hand, that is, who won the pot holding the
+// last of pn's chips. Usually there is just one, but a split pot knocks a player out jointly. KnockedOutBy returns nil
+// if pn wasn't knocked out in the last hand, or once the next hand has been dealt.
+func (g *Game) KnockedOutBy(pn uint) []uint {
+	return append([]uint(nil), g.knockouts[pn]...)
+}
+
+// Bounty returns the bounty currently on entrant en's head: TournamentConfig.Bounty to start with, plus, in a
+// progressive knockout, half of each bounty they have collected since, until they are knocked out.
+func (t *Tournament) Bounty(en uint) (uint, error) {
+	if en >= uint(len(t.seats)) {
+		return 0, ErrUnknownEntrant
+	}
+
+	return t.bounties[en], nil
+}
+
+// BountiesWon returns the total of the bounties entrant en has been paid for knocking other entrants out. In a
+// progressive knockout, that is only the half of each bounty that was paid out straight away.
+func (t *Tournament) BountiesWon(en uint) (uint, error) {
+	if en >= uint(len(t.seats)) {
+		return 0, ErrUnknownEntrant
+	}
+
+	return t.bountiesWon[en], nil
+}
+
+// collectBounty pays the bounty on the head of entrant en, who was just knocked out of a hand at table tn, to the
+// entrants who knocked them out. A bounty shared between several of them is split evenly, and any remainder goes to
+// those with the lowest entrant numbers, one each. In a progressive knockout, half of each share (rounded down) is
+// added to the collector's own bounty instead.
+func (t *Tournament) collectBounty(en uint, tn uint, pn uint) {
+	bounty := t.bounties[en]
+	if bounty == 0 {
+		return
+	}
+
+	by := []uint{}
+	for _, knocker := range t.tables[tn].KnockedOutBy(pn) {
+		if ken, ok := t.entrants[Seat{tn, knocker}]; ok {
+			by = append(by, ken)
+		}
+	}
+
+	if len(by) == 0 {
+		return
+	}
+
+	sort.Slice(by, func(i, j int) bool { return by[i] < by[j] })
+
+	n := uint(len(by))
+	for i, ken := range by {
+		share := bounty / n
+		if uint(i) < bounty%n {
+			share++
+		}
+
+		if t.config.Progressive {
+			t.setBounty(ken, t.bounties[ken]+share/2)
+			share -= share / 2
+		}
+		t.bountiesWon[ken] += share
+	}
+
+	t.setBounty(en, 0)
+}
+
+// setBounty sets the bounty on entrant en's head, and shows it on their player at the table they are seated at
+func (t *Tournament) setBounty(en uint, amt uint) {
+	t.bounties[en] = amt
+
+	if s := t.seats[en]; int(s.TableNum) < len(t.tables) {
+		t.tables[s.TableNum].getPlayer(s.PlayerNum).Bounty = amt
+	}
+}
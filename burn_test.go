@@ -0,0 +1,101 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestGame_BurnCards(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, BurnCards: true})
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := Bet(g, g.actionNum, 15); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	for g.getStage() != PreDeal {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+	}
+
+	view := g.GenerateOmniView()
+	if len(view.Burns) != 3 {
+		t.Fatalf("Test failed - a card should be burned before each street, got %v", view.Burns)
+	}
+	if want := len(eval.DefaultDeck) - 2*2 - 5 - 3; len(view.Deck) != want {
+		t.Errorf("Test failed - the deck should have %d cards left, got %d", want, len(view.Deck))
+	}
+
+	seen := map[eval.Card]bool{}
+	for _, c := range append(view.Burns, view.CommunityCards...) {
+		if seen[c] {
+			t.Errorf("Test failed - %s was dealt twice", c)
+		}
+		seen[c] = true
+	}
+
+	if burns := g.GeneratePlayerView(pn_a).Burns; len(burns) != 0 {
+		t.Errorf("Test failed - players should not see the burned cards, got %v", burns)
+	}
+
+	kinds := []EventKind{}
+	for _, e := range g.PlayerEventsSince(pn_a, 0) {
+		switch e.Kind {
+		case EventBurn:
+			if len(e.Cards) != 0 {
+				t.Errorf("Test failed - players should not see the burned cards, got %v", e)
+			}
+			fallthrough
+		case EventCommunityCards:
+			kinds = append(kinds, e.Kind)
+		}
+	}
+
+	want := []EventKind{EventBurn, EventCommunityCards, EventBurn, EventCommunityCards, EventBurn, EventCommunityCards}
+	if len(kinds) != len(want) {
+		t.Fatalf("Test failed - each street should be dealt after a burn, got %v", kinds)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Errorf("Test failed - each street should be dealt after a burn, got %v", kinds)
+			break
+		}
+	}
+}
@@ -0,0 +1,188 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestGame_DeadButton(t *testing.T) {
+	g := NewGame(nil)
+
+	for i := 0; i < 4; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	positions := func() [4]uint { return [4]uint{g.dealerNum, g.sbNum, g.bbNum, g.utgNum} }
+
+	playFoldedHand(t, g)
+
+	if got, want := positions(), [4]uint{1, 2, 3, 0}; got != want {
+		t.Fatalf("Test failed - expected button, blinds and first to act at %v, got %v", want, got)
+	}
+
+	// Player 2 is due the small blind, but leaves, so the small blind is dead
+	if err := Leave(g, 2, 0); err != nil {
+		t.Fatalf("Test failed - error leaving: %s", err)
+	}
+
+	if err := Deal(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if g.players[2].Bet != 0 || g.players[3].Bet != g.config.BigBlind || g.actionNum != 0 {
+		t.Errorf("Test failed - expected a dead small blind, and player 3 to post the big blind")
+	}
+	for g.getStage() != PreDeal {
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+
+	// The button moves to the empty seat, and the player after it deals
+	if got, want := positions(), [4]uint{2, 3, 0, 1}; got != want {
+		t.Fatalf("Test failed - expected button, blinds and first to act at %v, got %v", want, got)
+	}
+	if err := Deal(g, 2, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a player who has left can't deal, got %v", err)
+	}
+	if err := Deal(g, 3, 0); err != nil {
+		t.Fatalf("Test failed - error dealing with a dead button: %s", err)
+	}
+	if g.players[3].Bet != g.config.SmallBlind || g.players[0].Bet != g.config.BigBlind {
+		t.Errorf("Test failed - expected player 3 to post the small blind after the big, and player 0 the big blind")
+	}
+}
+
+func TestGame_HeadsUpPositions(t *testing.T) {
+	for _, rule := range []HeadsUpRule{HeadsUpButtonSmallBlind, HeadsUpButtonBigBlind} {
+		config := defaultConfig
+		config.Rules.HeadsUp = rule
+		g := seatedGame(t, &config, 2, 1000)
+
+		for hand := 0; hand < 2; hand++ {
+			if err := Deal(g, g.dealingNum(), 0); err != nil {
+				t.Fatalf("Test failed - error dealing: %s", err)
+			}
+
+			button, other := g.dealerNum, g.next(g.dealerNum)
+			sb, bb := button, other
+			if rule == HeadsUpButtonBigBlind {
+				sb, bb = other, button
+			}
+
+			if g.players[sb].Bet != config.SmallBlind || g.players[bb].Bet != config.BigBlind {
+				t.Errorf("Test failed - with rule %d, expected player %d to post the small blind and %d the big blind, got %+v", rule, sb, bb, g.players)
+			}
+
+			// The small blind acts first before the flop, and the big blind closes the action
+			if g.actionNum != sb {
+				t.Errorf("Test failed - with rule %d, expected player %d to act first before the flop, got %d", rule, sb, g.actionNum)
+			}
+			if err := Bet(g, sb, config.BigBlind-config.SmallBlind); err != nil {
+				t.Fatalf("Test failed - error calling: %s", err)
+			}
+			if err := Bet(g, bb, 0); err != nil {
+				t.Fatalf("Test failed - error checking: %s", err)
+			}
+
+			// After the flop, the button always acts last
+			for _, stage := range []GameStage{Flop, Turn, River} {
+				if g.getStage() != stage || g.actionNum != other {
+					t.Fatalf("Test failed - with rule %d, expected player %d to act first on stage %d, got player %d on stage %d", rule, other, stage, g.actionNum, g.getStage())
+				}
+				if err := Bet(g, other, 0); err != nil {
+					t.Fatalf("Test failed - error checking: %s", err)
+				}
+				if err := Bet(g, button, 0); err != nil {
+					t.Fatalf("Test failed - error checking: %s", err)
+				}
+			}
+
+			if g.getStage() != PreDeal || g.dealerNum != other {
+				t.Fatalf("Test failed - expected the button to move to player %d once the hand was over", other)
+			}
+		}
+
+		// Once a third player is dealt in, both blinds are to the button's left
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - error marking ready: %s", err)
+		}
+		if err := Deal(g, g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+		if g.sbNum != g.next(g.dealerNum) || g.bbNum != g.next(g.sbNum) || g.actionNum != g.dealerNum {
+			t.Errorf("Test failed - with rule %d, expected the blinds to the left of the button at %d, got %d and %d", rule, g.dealerNum, g.sbNum, g.bbNum)
+		}
+	}
+}
+
+func TestGame_WinTheButton(t *testing.T) {
+	config := defaultConfig
+	config.WinTheButton = true
+	g := seatedGame(t, &config, 3, 1000)
+
+	// Everybody folds to the big blind, who wins the button
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	winner := g.bbNum
+	for g.getStage() != PreDeal {
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+	if g.dealerNum != winner || g.sbNum != g.next(winner) || g.bbNum != g.next(g.sbNum) || g.utgNum != winner {
+		t.Fatalf("Test failed - expected player %d to win the button, with the blinds to their left, got button %d and blinds %d and %d", winner, g.dealerNum, g.sbNum, g.bbNum)
+	}
+
+	// Heads up, the winner of the button posts the small blind and acts first
+	if err := Leave(g, g.next(winner), 0); err != nil {
+		t.Fatalf("Test failed - error leaving: %s", err)
+	}
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if g.sbNum != winner || g.actionNum != winner {
+		t.Errorf("Test failed - expected player %d to post the small blind and act first, got %d and %d", winner, g.sbNum, g.actionNum)
+	}
+
+	// Winning from the button keeps it
+	if err := Bet(g, winner, 100); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+	if err := Fold(g, g.actionNum, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+	if g.dealerNum != winner {
+		t.Errorf("Test failed - expected player %d to keep the button, got %d", winner, g.dealerNum)
+	}
+}
@@ -0,0 +1,109 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestGame_ChipsConserved plays many hands of random (but legal) betting, with stacks and raises that leave pots
+// that don't divide evenly, and checks that no chips are ever created or lost along the way.
+func TestGame_ChipsConserved(t *testing.T) {
+	configs := []GameConfig{
+		{BigBlind: 25, SmallBlind: 10, Seed: 1, Rules: RuleSet{OddChip: OddChipLeftOfButton}},
+		{BigBlind: 25, SmallBlind: 10, Seed: 2, Rules: RuleSet{OddChip: OddChipLowestPlayerNum}},
+		{BigBlind: 25, SmallBlind: 10, Seed: 3, Rules: RuleSet{OddChip: OddChipCarryOver}},
+		{BigBlind: 25, SmallBlind: 10, Seed: 4, Rules: RuleSet{OddChip: OddChipLeftOfButton, HiLo: true}},
+		{BigBlind: 25, SmallBlind: 10, Seed: 5, Rules: RuleSet{OddChip: OddChipCarryOver, HiLo: true}},
+		{BigBlind: 25, SmallBlind: 10, Seed: 6, Rules: RuleSet{OddChip: OddChipLeftOfButton, ShowOrMuck: true}},
+		{BigBlind: 25, SmallBlind: 10, Seed: 7, Rules: RuleSet{OddChip: OddChipLeftOfButton, Undo: true}},
+		{BigBlind: 25, SmallBlind: 10, Seed: 8, Rules: RuleSet{OddChip: OddChipLeftOfButton}, SevenDeuceBounty: 100},
+	}
+
+	for _, config := range configs {
+		config := config
+		g := NewGame(&config)
+		r := rand.New(rand.NewSource(config.Seed))
+
+		var total uint
+		for _, stack := range []uint{997, 1003, 1000, 451, 1777} {
+			pn := g.AddPlayer()
+			if err := BuyIn(g, pn, stack); err != nil {
+				t.Fatalf("Test failed - Error buying in: %s", err)
+			}
+			if err := ToggleReady(g, pn, 0); err != nil {
+				t.Fatalf("Test failed - Error marking ready: %s", err)
+			}
+			total += stack
+		}
+
+		check := func(what string) {
+			if got := g.ChipsInPlay(); got != total {
+				t.Fatalf("Test failed - with rules %+v, expected %d chips in play after %s, got %d", config.Rules, total, what, got)
+			}
+		}
+
+		for hand := 0; hand < 200 && g.readyCount() >= 2; hand++ {
+			if err := Deal(g, g.dealingNum(), 0); err != nil {
+				t.Fatalf("Test failed - error dealing: %s", err)
+			}
+			check("dealing")
+
+			for g.getStage() != PreDeal {
+				pn := g.actionNum
+				if g.showdownPending() {
+					if err := []Action{Show, Muck}[r.Intn(2)](g, pn, 0); err != nil {
+						t.Fatalf("Test failed - error showing down: %s", err)
+					}
+					check("showing down")
+					continue
+				}
+
+				p := g.players[pn]
+				call := g.toCall() - p.Bet
+				raise := call + g.minRaise + uint(r.Intn(40))
+
+				acted := false
+				for _, i := range r.Perm(4) {
+					amt := []uint{0, call, raise, p.Stack}[i]
+					if Bet(g, pn, amt) == nil {
+						acted = true
+						break
+					}
+				}
+				if !acted {
+					if err := Fold(g, pn, 0); err != nil {
+						t.Fatalf("Test failed - error folding: %s", err)
+					}
+				}
+				check("betting")
+
+				if config.Rules.Undo && r.Intn(4) == 0 && Undo(g, pn, 0) == nil {
+					check("undoing")
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,302 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"math"
+	"sort"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// ChopKind is the way a chop (a deal between the entrants left in a Tournament) divides the prize pool that remains.
+type ChopKind uint8
+
+const (
+	// ChipChop pays each entrant their share of the chips in play
+	ChipChop ChopKind = iota + 1
+	// ICMChop pays each entrant their equity under the Independent Chip Model (see eval.ICM)
+	ICMChop
+)
+
+// maxICMChop is the most entrants an ICM chop can be made between: a full final table. Working out ICM takes time
+// exponential in the number of entrants, so it can't be left to any entrant to propose for a bigger field.
+const maxICMChop = 10
+
+// Chop is a deal proposed to end a Tournament by dividing what remains of the prize pool between the entrants still in.
+// Entrants holds those entrants, in ascending order, and Stacks and Amounts their chips and what each would be paid,
+// in the same order. Accepted holds the entrants who have agreed to it so far.
+type Chop struct {
+	Kind     ChopKind
+	Entrants []uint
+	Stacks   []uint
+	Amounts  []uint
+	Accepted []uint
+}
+
+// ChopNumbers works out what each entrant still in would be paid by a chop of the given kind, without proposing it.
+// It returns ErrChopUnavailable if the Tournament isn't running, or an entrant still in is in the middle of a hand,
+// or has no chips left (Balance should be called first, to eliminate them), or for an ICM chop between more entrants
+// than a final table seats (10).
+func (t *Tournament) ChopNumbers(kind ChopKind) (*Chop, error) {
+	if !t.started || t.finished {
+		return nil, ErrChopUnavailable
+	}
+
+	c := &Chop{Kind: kind, Entrants: []uint{}, Stacks: []uint{}, Accepted: []uint{}}
+	for en := range t.seats {
+		if t.busted[en] {
+			continue
+		}
+
+		s := t.seats[en]
+		stack := t.tables[s.TableNum].getPlayer(s.PlayerNum).Stack
+		if !t.betweenHands(s.TableNum) || stack == 0 {
+			return nil, ErrChopUnavailable
+		}
+
+		c.Entrants = append(c.Entrants, uint(en))
+		c.Stacks = append(c.Stacks, stack)
+	}
+
+	payouts, err := t.Payouts()
+	if err != nil {
+		return nil, err
+	}
+	if len(payouts) > len(c.Entrants) {
+		payouts = payouts[:len(c.Entrants)]
+	}
+
+	var remaining uint
+	for _, amt := range payouts {
+		remaining += amt
+	}
+
+	var equity []float64
+	switch kind {
+	case ChipChop:
+		var chips uint
+		for _, stack := range c.Stacks {
+			chips += stack
+		}
+
+		equity = make([]float64, len(c.Stacks))
+		for i, stack := range c.Stacks {
+			equity[i] = float64(remaining) * float64(stack) / float64(chips)
+		}
+	case ICMChop:
+		if len(c.Entrants) > maxICMChop {
+			return nil, ErrChopUnavailable
+		}
+
+		prizes := make([]float64, len(payouts))
+		for i, amt := range payouts {
+			prizes[i] = float64(amt)
+		}
+
+		if equity, err = eval.ICM(c.Stacks, prizes); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrChopUnavailable
+	}
+
+	c.Amounts = roundShares(equity, remaining)
+
+	return c, nil
+}
+
+// roundShares rounds each of shares down, and then gives the amount left over from total one each to the shares
+// that lost the most to rounding
+func roundShares(shares []float64, total uint) []uint {
+	amounts := make([]uint, len(shares))
+	var paid uint
+	for i, share := range shares {
+		amounts[i] = uint(math.Floor(share))
+		paid += amounts[i]
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return shares[order[i]]-math.Floor(shares[order[i]]) > shares[order[j]]-math.Floor(shares[order[j]])
+	})
+
+	for i := 0; paid < total && len(order) > 0; i = (i + 1) % len(order) {
+		amounts[order[i]]++
+		paid++
+	}
+
+	return amounts
+}
+
+// ProposeChop proposes a chop of the given kind on behalf of entrant en, who is taken to have accepted it, and
+// returns it. It replaces any chop proposed before. If nobody else is left to accept, the chop is made at once.
+// ProposeChop returns the same errors as ChopNumbers, or ErrUnknownEntrant if en isn't still in.
+func (t *Tournament) ProposeChop(en uint, kind ChopKind) (*Chop, error) {
+	if en >= uint(len(t.seats)) || t.busted[en] {
+		return nil, ErrUnknownEntrant
+	}
+
+	c, err := t.ChopNumbers(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	t.chop = c
+	if _, err := t.AcceptChop(en); err != nil {
+		return nil, err
+	}
+
+	return copyChop(t.chop), nil
+}
+
+// PendingChop returns the chop that has been proposed, or nil if there isn't one.
+func (t *Tournament) PendingChop() *Chop {
+	if !t.chopCurrent() {
+		return nil
+	}
+
+	return copyChop(t.chop)
+}
+
+// AcceptChop records entrant en's agreement to the chop that has been proposed. Once every entrant still in has
+// accepted it, the chop is made: each of them is paid their Amount, and the Tournament is finished. AcceptChop
+// reports whether that has happened. It returns ErrNoChop if no chop has been proposed, or the chip counts have
+// changed since it was, or ErrUnknownEntrant if en isn't one of the entrants it is between.
+func (t *Tournament) AcceptChop(en uint) (bool, error) {
+	if !t.chopCurrent() {
+		t.chop = nil
+		return false, ErrNoChop
+	}
+
+	found := false
+	for _, e := range t.chop.Entrants {
+		found = found || e == en
+	}
+	if !found {
+		return false, ErrUnknownEntrant
+	}
+
+	for _, e := range t.chop.Accepted {
+		if e == en {
+			return false, nil
+		}
+	}
+	t.chop.Accepted = append(t.chop.Accepted, en)
+
+	if len(t.chop.Accepted) < len(t.chop.Entrants) {
+		return false, nil
+	}
+
+	for i, e := range t.chop.Entrants {
+		t.chopped[e] = t.chop.Amounts[i]
+	}
+	t.finished = true
+	t.chop = nil
+
+	return true, nil
+}
+
+// RejectChop turns down the chop that has been proposed on behalf of entrant en, and withdraws it. It returns ErrNoChop
+// if there isn't one, or ErrUnknownEntrant if en isn't one of the entrants it is between.
+func (t *Tournament) RejectChop(en uint) error {
+	if !t.chopCurrent() {
+		t.chop = nil
+		return ErrNoChop
+	}
+
+	for _, e := range t.chop.Entrants {
+		if e == en {
+			t.chop = nil
+			return nil
+		}
+	}
+
+	return ErrUnknownEntrant
+}
+
+// Finished returns true once the Tournament has been ended by a chop.
+func (t *Tournament) Finished() bool {
+	return t.finished
+}
+
+// Winnings returns the prize entrant en has won so far: the payout for the place they finished in if they have
+// busted, what they were paid by a chop that ended the Tournament, or 0 if they are still playing.
+func (t *Tournament) Winnings(en uint) (uint, error) {
+	if en >= uint(len(t.seats)) {
+		return 0, ErrUnknownEntrant
+	}
+
+	if amt, ok := t.chopped[en]; ok {
+		return amt, nil
+	}
+
+	for i, busted := range t.bustOrder {
+		if busted != en {
+			continue
+		}
+
+		payouts, err := t.Payouts()
+		if err != nil {
+			return 0, err
+		}
+
+		// The first entrant to bust finished last
+		place := len(t.seats) - 1 - i
+		if place < len(payouts) {
+			return payouts[place], nil
+		}
+	}
+
+	return 0, nil
+}
+
+// chopCurrent reports whether a chop has been proposed, and nobody's chip count has changed since
+func (t *Tournament) chopCurrent() bool {
+	if t.chop == nil || t.finished {
+		return false
+	}
+
+	for i, en := range t.chop.Entrants {
+		s := t.seats[en]
+		if t.busted[en] || t.tables[s.TableNum].getPlayer(s.PlayerNum).Stack != t.chop.Stacks[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func copyChop(c *Chop) *Chop {
+	return &Chop{
+		Kind:     c.Kind,
+		Entrants: append([]uint{}, c.Entrants...),
+		Stacks:   append([]uint{}, c.Stacks...),
+		Amounts:  append([]uint{}, c.Amounts...),
+		Accepted: append([]uint{}, c.Accepted...),
+	}
+}
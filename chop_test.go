@@ -0,0 +1,131 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTournament_Chop(t *testing.T) {
+	config := TournamentConfig{StartingStack: 100, BuyIn: 100, Payouts: PayoutTable{{Percents: []float64{40, 30, 20, 10}}}}
+	tr, _ := NewTournament(&config)
+	for i := 0; i < 4; i++ {
+		tr.Register()
+	}
+	if _, err := tr.ProposeChop(0, ChipChop); err != ErrChopUnavailable {
+		t.Errorf("Test failed - a chop can't be made before the tournament starts, got %v", err)
+	}
+	tr.Start()
+
+	// Entrant 3 finishes fourth, for 40 of the 400 in the prize pool, leaving 360 to play for
+	bust(t, tr, 3)
+	tr.Balance()
+	g, _ := tr.Table(0)
+	for pn, stack := range []uint{500, 300, 200} {
+		g.players[pn].Stack = stack
+	}
+
+	c, err := tr.ChopNumbers(ChipChop)
+	if err != nil || !reflect.DeepEqual(c.Amounts, []uint{180, 108, 72}) {
+		t.Errorf("Test failed - expected a chip chop of [180 108 72], got %+v (error %v)", c, err)
+	}
+	c, err = tr.ChopNumbers(ICMChop)
+	if err != nil || !reflect.DeepEqual(c.Amounts, []uint{134, 119, 107}) {
+		t.Errorf("Test failed - expected an ICM chop of [134 119 107], got %+v (error %v)", c, err)
+	}
+
+	if _, err := tr.ProposeChop(3, ChipChop); err != ErrUnknownEntrant {
+		t.Errorf("Test failed - a busted entrant can't propose a chop, got %v", err)
+	}
+	if c, err := tr.ProposeChop(0, ChipChop); err != nil || !reflect.DeepEqual(c.Accepted, []uint{0}) {
+		t.Fatalf("Test failed - expected the proposer to have accepted the chop, got %+v (error %v)", c, err)
+	}
+	if done, err := tr.AcceptChop(1); done || err != nil {
+		t.Errorf("Test failed - the chop shouldn't be made until everybody accepts, got %v (error %v)", done, err)
+	}
+
+	// A chop lapses once the chip counts change
+	g.players[0].Stack += 10
+	if _, err := tr.AcceptChop(2); err != ErrNoChop {
+		t.Errorf("Test failed - accepting a lapsed chop should return ErrNoChop, got %v", err)
+	}
+
+	tr.ProposeChop(1, ICMChop)
+	if err := tr.RejectChop(2); err != nil || tr.PendingChop() != nil {
+		t.Errorf("Test failed - expected the chop to be withdrawn once rejected, got %v", err)
+	}
+
+	g.players[0].Stack -= 10
+	tr.ProposeChop(1, ICMChop)
+	for _, en := range []uint{0, 2} {
+		if _, err := tr.AcceptChop(en); err != nil {
+			t.Fatalf("Test failed - error accepting the chop: %s", err)
+		}
+	}
+
+	if !tr.Finished() {
+		t.Fatalf("Test failed - the tournament should be finished once everybody accepts")
+	}
+	for en, want := range []uint{134, 119, 107, 40} {
+		if got, _ := tr.Winnings(uint(en)); got != want {
+			t.Errorf("Test failed - expected entrant %d to win %d, got %d", en, want, got)
+		}
+	}
+	if _, err := tr.Register(); err != ErrTournamentStarted {
+		t.Errorf("Test failed - nobody can register once the tournament is finished, got %v", err)
+	}
+}
+
+func TestTournament_ICMChopFinalTable(t *testing.T) {
+	config := TournamentConfig{TableSize: 6, StartingStack: 100, BuyIn: 100, Game: defaultConfig,
+		Payouts: PayoutTable{{Percents: []float64{50, 30, 20}}}}
+	tr, err := NewTournament(&config)
+	if err != nil {
+		t.Fatalf("Test failed - error creating tournament: %s", err)
+	}
+	for i := 0; i < maxICMChop+1; i++ {
+		if _, err := tr.Register(); err != nil {
+			t.Fatalf("Test failed - error registering: %s", err)
+		}
+	}
+	if err := tr.Start(); err != nil {
+		t.Fatalf("Test failed - error starting: %s", err)
+	}
+
+	// Too many entrants are left for ICM, though a chip chop can still be made
+	if _, err := tr.ProposeChop(0, ICMChop); err != ErrChopUnavailable {
+		t.Errorf("Test failed - expected ErrChopUnavailable for an ICM chop before the final table, got %v", err)
+	}
+	if _, err := tr.ChopNumbers(ChipChop); err != nil {
+		t.Errorf("Test failed - error working out a chip chop: %s", err)
+	}
+
+	// Once the field is down to a final table, it can
+	bust(t, tr, maxICMChop)
+	tr.Balance()
+	if _, err := tr.ChopNumbers(ICMChop); err != nil {
+		t.Errorf("Test failed - error working out an ICM chop at the final table: %s", err)
+	}
+}
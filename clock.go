@@ -0,0 +1,226 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+)
+
+// BlindLevel is a single level of a tournament's blind structure. A Duration of 0 means the level
+// never ends on its own. The last level of a structure also never ends on its own, whatever its Duration.
+type BlindLevel struct {
+	SmallBlind uint
+	BigBlind   uint
+	Duration   time.Duration
+}
+
+// ClockAction is the type of an administrative adjustment to the tournament clock
+type ClockAction uint8
+
+const (
+	ClockPaused ClockAction = iota + 1
+	ClockResumed
+	ClockExtended
+	ClockLevelSet
+)
+
+// AuditEntry records a single administrative adjustment to the tournament clock. Level is the
+// level the clock was on after the adjustment. Amount is only meaningful for ClockExtended.
+type AuditEntry struct {
+	Time   time.Time
+	Action ClockAction
+	Level  uint
+	Amount time.Duration
+}
+
+// clock keeps track of the current blind level. levelStart is shifted forward when the clock is
+// resumed, so the time spent paused never counts towards the level.
+type clock struct {
+	levels     []BlindLevel
+	levelNum   uint
+	levelStart time.Time
+	extension  time.Duration
+	paused     bool
+	pausedAt   time.Time
+	audit      []AuditEntry
+}
+
+func (c *clock) start(now time.Time) {
+	c.levelNum = 0
+	c.levelStart = now
+	c.extension = 0
+	c.paused = false
+}
+
+// elapsed is how long the clock has been running on the current level
+func (c *clock) elapsed(now time.Time) time.Duration {
+	if c.paused {
+		return c.pausedAt.Sub(c.levelStart)
+	}
+
+	return now.Sub(c.levelStart)
+}
+
+func (c *clock) levelLength() time.Duration {
+	return c.levels[c.levelNum].Duration + c.extension
+}
+
+func (c *clock) timed() bool {
+	return c.levelNum < uint(len(c.levels)-1) && c.levels[c.levelNum].Duration != 0
+}
+
+// update advances the clock through any levels that have expired by now
+func (c *clock) update(now time.Time) {
+	for c.timed() && c.elapsed(now) >= c.levelLength() {
+		c.levelStart = c.levelStart.Add(c.levelLength())
+		c.levelNum++
+		c.extension = 0
+	}
+}
+
+func (c *clock) remaining(now time.Time) time.Duration {
+	c.update(now)
+	if !c.timed() {
+		return 0
+	}
+
+	return c.levelLength() - c.elapsed(now)
+}
+
+func (c *clock) record(now time.Time, action ClockAction, amount time.Duration) {
+	c.audit = append(c.audit, AuditEntry{Time: now, Action: action, Level: c.levelNum, Amount: amount})
+}
+
+// Level returns the number of the current blind level, and the level itself.
+func (t *Tournament) Level() (uint, BlindLevel) {
+	t.clock.update(t.now())
+	return t.clock.levelNum, t.clock.levels[t.clock.levelNum]
+}
+
+// LevelRemaining returns how much time is left in the current blind level. It returns 0 if the current
+// level is untimed. While the clock is paused, the time remaining does not change.
+func (t *Tournament) LevelRemaining() time.Duration {
+	return t.clock.remaining(t.now())
+}
+
+// Paused returns true if the tournament clock is paused.
+func (t *Tournament) Paused() bool {
+	return t.clock.paused
+}
+
+// PauseClock stops the tournament clock, so the current level does not run down until ResumeClock is called.
+// PauseClock returns an error if the tournament has not started, or if the clock is already paused.
+func (t *Tournament) PauseClock() error {
+	if !t.started {
+		return ErrTournamentNotStarted
+	}
+
+	if t.clock.paused {
+		return ErrClockPaused
+	}
+
+	now := t.now()
+	t.clock.update(now)
+	t.clock.paused = true
+	t.clock.pausedAt = now
+	t.clock.record(now, ClockPaused, 0)
+
+	return nil
+}
+
+// ResumeClock restarts a paused tournament clock. ResumeClock returns an error if the tournament has not
+// started, or if the clock is not paused.
+func (t *Tournament) ResumeClock() error {
+	if !t.started {
+		return ErrTournamentNotStarted
+	}
+
+	if !t.clock.paused {
+		return ErrClockRunning
+	}
+
+	now := t.now()
+	t.clock.levelStart = t.clock.levelStart.Add(now.Sub(t.clock.pausedAt))
+	t.clock.paused = false
+	t.clock.record(now, ClockResumed, 0)
+
+	return nil
+}
+
+// ExtendLevel adds d to the length of the current blind level. ExtendLevel returns an error if the
+// tournament has not started.
+func (t *Tournament) ExtendLevel(d time.Duration) error {
+	if !t.started {
+		return ErrTournamentNotStarted
+	}
+
+	now := t.now()
+	t.clock.update(now)
+	t.clock.extension += d
+	t.clock.record(now, ClockExtended, d)
+
+	return nil
+}
+
+// SetLevel jumps the clock to the start of level n, discarding any extension to the current level.
+// If the clock is paused, it stays paused. The new blinds take effect at each table the next time
+// Balance is called while that table is between hands. SetLevel returns an error if the tournament
+// has not started, or if n is not a valid level.
+func (t *Tournament) SetLevel(n uint) error {
+	if !t.started {
+		return ErrTournamentNotStarted
+	}
+
+	if n >= uint(len(t.clock.levels)) {
+		return ErrBadLevel
+	}
+
+	now := t.now()
+	t.clock.levelNum = n
+	t.clock.extension = 0
+	if t.clock.paused {
+		t.clock.levelStart = t.clock.pausedAt
+	} else {
+		t.clock.levelStart = now
+	}
+	t.clock.record(now, ClockLevelSet, 0)
+
+	return nil
+}
+
+// AuditLog returns every administrative adjustment made to the tournament clock, in the order they were made.
+func (t *Tournament) AuditLog() []AuditEntry {
+	return append([]AuditEntry{}, t.clock.audit...)
+}
+
+// applyLevel sets the blinds for the current level at every table that is between hands
+func (t *Tournament) applyLevel() {
+	_, level := t.Level()
+	for _, tn := range t.TableNums() {
+		if t.betweenHands(tn) {
+			t.tables[tn].config.SmallBlind = level.SmallBlind
+			t.tables[tn].config.BigBlind = level.BigBlind
+		}
+	}
+}
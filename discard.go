@@ -0,0 +1,96 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// Discard is the Action for throwing away one of three hole cards, in variants that deal three (like Pineapple).
+// For Discard, data is which card to discard: 0 or 1 for the first or second of the player's Cards, or 2 for their
+// ThirdCard. The player keeps the other two as their Cards.
+//
+// Players discard at the start of the variant's discard stage (before the betting before the flop in Pineapple, or
+// after the flop is dealt in Crazy Pineapple), in any order, and betting doesn't open until every player in the hand
+// has discarded. Discard will return an error if it isn't the discard stage, the player isn't in the hand or has
+// already discarded, or data is more than 2.
+func Discard(g *Game, pn uint, data uint) error {
+	p := g.getPlayer(pn)
+
+	if g.getBetting() || g.getStage() != g.variant().discardStage() {
+		return ErrIllegalAction
+	}
+
+	if !p.In || p.ThirdCard == 0 || data > 2 {
+		return ErrIllegalAction
+	}
+
+	if data < 2 {
+		p.Cards[data], p.ThirdCard = p.ThirdCard, p.Cards[data]
+	}
+	p.Discarded = p.ThirdCard
+	p.ThirdCard = 0
+
+	// Everybody decides at once, so the clock keeps running for whoever hasn't yet
+	if !g.actionSince.IsZero() {
+		g.spendTimeBank(pn, g.currentTime().Sub(g.actionSince))
+	}
+
+	g.emit(Event{Kind: EventDiscard, PlayerNum: pn, Cards: []eval.Card{p.Discarded}, Away: p.Away})
+
+	if g.discardsPending() {
+		return nil
+	}
+
+	return g.openBetting()
+}
+
+// discardsPending returns true if it is the discard stage, and someone in the hand still has to discard
+func (g *Game) discardsPending() bool {
+	stage := g.variant().discardStage()
+	if stage == 0 || g.getStage() != stage || g.getBetting() {
+		return false
+	}
+
+	for _, p := range g.players {
+		if p.In && p.ThirdCard != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// discardForAway discards the third card of every player who is away and still has to discard.
+func (g *Game) discardForAway() error {
+	for i, p := range g.players {
+		if p.In && p.Away && p.ThirdCard != 0 {
+			if err := Discard(g, uint(i), 2); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+// seatedGame returns a new Game with config, and players players bought in for stack and ready to be dealt in
+func seatedGame(t *testing.T, config *GameConfig, players int, stack uint) *Game {
+	t.Helper()
+
+	g := NewGame(config)
+
+	for i := 0; i < players; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, stack); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	return g
+}
+
+func newVariantGame(t *testing.T, v Variant, players int) *Game {
+	config := defaultConfig
+	config.Variant = v
+	g := seatedGame(t, &config, players, 1000)
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	return g
+}
+
+func TestDiscard_Pineapple(t *testing.T) {
+	g := newVariantGame(t, Pineapple, 3)
+
+	for i, p := range g.players {
+		if p.ThirdCard == 0 {
+			t.Fatalf("Test failed - player %d should have been dealt a third card", i)
+		}
+	}
+
+	if g.getBetting() {
+		t.Fatalf("Test failed - betting should not open until everyone has discarded")
+	}
+	if err := Bet(g, g.actionNum, 25); err != ErrIllegalAction {
+		t.Errorf("Test failed - betting before discarding should be illegal, got %v", err)
+	}
+	if err := Deal(g, 0, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - dealing before discarding should be illegal, got %v", err)
+	}
+	if err := Discard(g, 0, 3); err != ErrIllegalAction {
+		t.Errorf("Test failed - discarding a fourth card should be illegal, got %v", err)
+	}
+
+	first, third := g.players[0].Cards[0], g.players[0].ThirdCard
+	if err := Discard(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error discarding: %s", err)
+	}
+	if p := g.players[0]; p.Discarded != first || p.Cards[0] != third || p.ThirdCard != 0 {
+		t.Errorf("Test failed - player 0 should have discarded %s and kept %s, got %+v", first, third, p)
+	}
+	if err := Discard(g, 0, 2); err != ErrIllegalAction {
+		t.Errorf("Test failed - discarding twice should be illegal, got %v", err)
+	}
+
+	// Nobody else sees what was discarded, or anyone's third card
+	view := g.GeneratePlayerView(1)
+	if view.Players[0].Discarded != 0 || view.Players[2].ThirdCard != 0 || view.Players[1].ThirdCard == 0 {
+		t.Errorf("Test failed - player 1 should only see their own third card, got %+v", view.Players)
+	}
+
+	for _, pn := range []uint{1, 2} {
+		if err := Discard(g, pn, 2); err != nil {
+			t.Fatalf("Test failed - error discarding: %s", err)
+		}
+	}
+
+	if !g.getBetting() || g.actionNum != g.utgNum {
+		t.Errorf("Test failed - once everyone has discarded, betting should open with the player under the gun")
+	}
+}
+
+func TestDiscard_CrazyPineapple(t *testing.T) {
+	g := newVariantGame(t, CrazyPineapple, 2)
+
+	if !g.getBetting() {
+		t.Fatalf("Test failed - in Crazy Pineapple, betting opens before anyone discards")
+	}
+	if err := Discard(g, g.actionNum, 2); err != ErrIllegalAction {
+		t.Errorf("Test failed - discarding before the flop should be illegal, got %v", err)
+	}
+
+	if err := Bet(g, g.actionNum, 15); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if err := Bet(g, g.actionNum, 0); err != nil {
+		t.Fatalf("Test failed - error checking: %s", err)
+	}
+
+	if g.getStage() != Flop || g.getBetting() {
+		t.Fatalf("Test failed - the flop should be dealt, and wait for discards")
+	}
+
+	for _, pn := range []uint{0, 1} {
+		if err := Discard(g, pn, 1); err != nil {
+			t.Fatalf("Test failed - error discarding: %s", err)
+		}
+	}
+
+	if !g.getBetting() {
+		t.Errorf("Test failed - betting on the flop should open once everyone has discarded")
+	}
+}
@@ -0,0 +1,88 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ChipFormat describes how chip amounts should be displayed, so that one rendering pipeline can serve both
+// cash games (e.g. 1000 chips shown as "$10.00") and tournaments (e.g. 1000 chips shown as "T1,000").
+// Amounts inside the engine are always whole chips; ChipFormat only affects how they are presented.
+//
+// Scale is the number of chips per display unit (0 is treated as 1). Decimals is the number of digits
+// shown after the decimal point. Prefix is prepended to every amount, and if Separator is not empty,
+// it is used to group the whole units into thousands.
+type ChipFormat struct {
+	Prefix    string `json:"prefix"`
+	Scale     uint   `json:"scale"`
+	Decimals  uint   `json:"decimals"`
+	Separator string `json:"separator"`
+}
+
+// CashChipFormat is a ChipFormat for cash games played in cents, e.g. 1050 is shown as "$10.50"
+var CashChipFormat = ChipFormat{Prefix: "$", Scale: 100, Decimals: 2, Separator: ","}
+
+// TournamentChipFormat is a ChipFormat for tournament chips, e.g. 1000 is shown as "T1,000"
+var TournamentChipFormat = ChipFormat{Prefix: "T", Scale: 1, Decimals: 0, Separator: ","}
+
+// Format returns amt, a number of chips, formatted for display.
+func (f ChipFormat) Format(amt uint) string {
+	scale := uint64(f.Scale)
+	if scale == 0 {
+		scale = 1
+	}
+
+	whole := strconv.FormatUint(uint64(amt)/scale, 10)
+	if f.Separator != "" {
+		var b strings.Builder
+		for i, r := range whole {
+			if i != 0 && (len(whole)-i)%3 == 0 {
+				b.WriteString(f.Separator)
+			}
+			b.WriteRune(r)
+		}
+		whole = b.String()
+	}
+
+	if f.Decimals == 0 {
+		return f.Prefix + whole
+	}
+
+	var pow uint64 = 1
+	for i := uint(0); i < f.Decimals; i++ {
+		pow *= 10
+	}
+
+	frac := strconv.FormatUint((uint64(amt)%scale)*pow/scale, 10)
+	frac = strings.Repeat("0", int(f.Decimals)-len(frac)) + frac
+
+	return f.Prefix + whole + "." + frac
+}
+
+// FormatChips returns amt, a number of chips, formatted for display according to the view's configured ChipFormat.
+func (gv *GameView) FormatChips(amt uint) string {
+	return gv.Config.ChipFormat.Format(amt)
+}
@@ -0,0 +1,61 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+)
+
+func TestChipFormat_Format(t *testing.T) {
+	tests := []struct {
+		name   string
+		format ChipFormat
+		amt    uint
+		want   string
+	}{
+		{"Unformatted", ChipFormat{}, 1234567, "1234567"},
+		{"Cash", CashChipFormat, 1050, "$10.50"},
+		{"Cash, large", CashChipFormat, 123456789, "$1,234,567.89"},
+		{"Cash, cents only", CashChipFormat, 5, "$0.05"},
+		{"Tournament", TournamentChipFormat, 1000, "T1,000"},
+		{"Tournament, small", TournamentChipFormat, 100, "T100"},
+		{"One decimal", ChipFormat{Scale: 10, Decimals: 1}, 1234, "123.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.Format(tt.amt); got != tt.want {
+				t.Errorf("ChipFormat.Format() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameView_FormatChips(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 50, SmallBlind: 25, ChipFormat: CashChipFormat})
+	pn := g.AddPlayer()
+
+	if got := g.GeneratePlayerView(pn).FormatChips(2500); got != "$25.00" {
+		t.Errorf("GameView.FormatChips() = %v, want $25.00", got)
+	}
+}
@@ -0,0 +1,161 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+)
+
+// DuplicateConfig holds the settings for a Duplicate. Game is the config every table is created with (its Seed
+// decides the cards, which every table shares), Entrants is the number of entrants, seated at every table, and
+// StartingStack is the number of chips each entrant starts each table with. Tables is how many tables are played,
+// each with the entrants seated one seat further round than the last; if it is 0, there is one table per entrant,
+// so every entrant plays every seat's cards.
+type DuplicateConfig struct {
+	Game          GameConfig
+	Entrants      uint
+	StartingStack uint
+	Tables        uint
+}
+
+// Duplicate manages a game of duplicate poker, where luck is taken out of the results by dealing the same cards at
+// several tables, and scoring each entrant against everyone else who was dealt the same cards. Each table's Game
+// has the same config, and so deals the same sequence of decks. Entrant en sits in seat (en + tn) % Entrants at
+// table tn (the player number of that seat in the table's Game), so at each table they play a different seat's cards.
+//
+// Like a Tournament, a Duplicate does not play hands itself: the tables are driven with Actions exactly like any
+// other Game, and the same number of hands should be played at every table (the cards of each hand only match up if
+// they do). Duplicates should not be initialized directly, only through the NewDuplicate factory function.
+type Duplicate struct {
+	config DuplicateConfig
+	tables []*Game
+}
+
+// NewDuplicate is a factory method that returns a pointer to an initialized Duplicate, with every entrant seated,
+// bought in for the starting stack, and ready at every table. Once it returns, each table's dealer may Deal the first
+// hand. NewDuplicate returns an error if there are too few or too many entrants to seat at a table.
+func NewDuplicate(config DuplicateConfig) (*Duplicate, error) {
+	if config.Entrants < minPlayers {
+		return nil, ErrNotEnoughEntrants
+	}
+
+	if config.Entrants > maxPlayers {
+		return nil, ErrBadTableSize
+	}
+
+	if config.Tables == 0 {
+		config.Tables = config.Entrants
+	}
+
+	// Left to themselves, the tables would each pick their own seed, and deal different cards
+	if config.Game.Seed == 0 {
+		config.Game.Seed = time.Now().UnixNano()
+	}
+
+	// The tables keep every Event, so Scores can tell how many hands each has dealt
+	config.Game.EventHistory = 0
+
+	d := &Duplicate{config: config}
+
+	for tn := uint(0); tn < config.Tables; tn++ {
+		g := NewGame(&config.Game)
+		for pn := uint(0); pn < config.Entrants; pn++ {
+			g.AddPlayer()
+			if err := BuyIn(g, pn, config.StartingStack); err != nil {
+				return nil, err
+			}
+			if err := ToggleReady(g, pn, 0); err != nil {
+				return nil, err
+			}
+		}
+		d.tables = append(d.tables, g)
+	}
+
+	return d, nil
+}
+
+// Table returns the Game being played at table tn.
+func (d *Duplicate) Table(tn uint) (*Game, error) {
+	if tn >= uint(len(d.tables)) {
+		return nil, ErrUnknownTable
+	}
+
+	return d.tables[tn], nil
+}
+
+// PlayerNum returns entrant en's player number (their seat) at table tn.
+func (d *Duplicate) PlayerNum(en uint, tn uint) uint {
+	return (en + tn) % d.config.Entrants
+}
+
+// EntrantAt returns the entrant seated at player number pn of table tn.
+func (d *Duplicate) EntrantAt(tn uint, pn uint) uint {
+	return (pn + d.config.Entrants - tn%d.config.Entrants) % d.config.Entrants
+}
+
+// Scores returns each entrant's duplicate score, indexed by entrant number: the sum, over every table, of how many
+// more chips they won (or fewer they lost) than the average of everyone who played the same seat, with the same
+// cards, at the tables. Scores add up to 0. Scores returns ErrUnevenTables unless every table is between hands, and
+// has played the same number of hands as every other, as otherwise the cards don't match up.
+func (d *Duplicate) Scores() ([]float64, error) {
+	hands := d.tables[0].handCount()
+	for _, g := range d.tables {
+		stage, betting := g.getStageAndBetting()
+		if stage != PreDeal || betting || g.handCount() != hands {
+			return nil, ErrUnevenTables
+		}
+	}
+
+	// net[tn][pn] is how many chips the player in seat pn of table tn has won (or, if negative, lost)
+	net := make([][]float64, len(d.tables))
+	seatMean := make([]float64, d.config.Entrants)
+	for tn, g := range d.tables {
+		net[tn] = make([]float64, d.config.Entrants)
+		for pn, p := range g.players {
+			net[tn][pn] = float64(p.Stack) - float64(p.TotalBuyIn) + float64(p.TotalCashOut)
+			seatMean[pn] += net[tn][pn] / float64(len(d.tables))
+		}
+	}
+
+	scores := make([]float64, d.config.Entrants)
+	for tn := range d.tables {
+		for pn := range net[tn] {
+			scores[d.EntrantAt(uint(tn), uint(pn))] += net[tn][pn] - seatMean[pn]
+		}
+	}
+
+	return scores, nil
+}
+
+// handCount returns how many hands g has dealt
+func (g *Game) handCount() uint {
+	var count uint
+	for _, e := range g.events {
+		if e.Kind == EventHandStart {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,85 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestDuplicate(t *testing.T) {
+	if _, err := NewDuplicate(DuplicateConfig{Game: defaultConfig, Entrants: 1}); err != ErrNotEnoughEntrants {
+		t.Errorf("Test failed - expected ErrNotEnoughEntrants, got %v", err)
+	}
+
+	d, err := NewDuplicate(DuplicateConfig{Game: defaultConfig, Entrants: 2, StartingStack: 1000})
+	if err != nil {
+		t.Fatalf("Test failed - error creating duplicate: %s", err)
+	}
+
+	tables := make([]*Game, 2)
+	for tn := range tables {
+		if tables[tn], err = d.Table(uint(tn)); err != nil {
+			t.Fatalf("Test failed - error getting table %d: %s", tn, err)
+		}
+		if err := Deal(tables[tn], 0, 0); err != nil {
+			t.Fatalf("Test failed - error dealing at table %d: %s", tn, err)
+		}
+	}
+
+	for pn := range tables[0].players {
+		if tables[0].players[pn].Cards != tables[1].players[pn].Cards {
+			t.Fatalf("Test failed - seat %d should be dealt the same cards at both tables", pn)
+		}
+	}
+
+	if d.EntrantAt(1, d.PlayerNum(0, 1)) != 0 || d.PlayerNum(0, 1) != 1 {
+		t.Errorf("Test failed - entrant 0 should sit in seat 1 at table 1")
+	}
+
+	// Checked down at table 0
+	for tables[0].getStage() != PreDeal {
+		if err := Bet(tables[0], tables[0].actionNum, tables[0].toCall()-tables[0].players[tables[0].actionNum].Bet); err != nil {
+			t.Fatalf("Test failed - error checking down: %s", err)
+		}
+	}
+
+	if _, err := d.Scores(); err != ErrUnevenTables {
+		t.Errorf("Test failed - tables that haven't finished the same hands can't be scored, got %v", err)
+	}
+
+	// At table 1, seat 0 (entrant 1) folds the small blind
+	if err := Fold(tables[1], 0, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	scores, err := d.Scores()
+	if err != nil {
+		t.Fatalf("Test failed - error scoring: %s", err)
+	}
+
+	// Entrant 0 did as well as seat 0 checking down, and 10 better than entrant 1 did with seat 1's cards by
+	// folding seat 0's
+	won := float64(tables[0].players[0].Stack) - 1000
+	if scores[0] != won+10 || scores[1] != -scores[0] {
+		t.Errorf("Test failed - expected scores of %f and %f, got %v", won+10, -won-10, scores)
+	}
+}
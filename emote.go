@@ -0,0 +1,81 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+)
+
+// EmoteKind is the type of a lightweight, non-game gesture a player can make at the table
+type EmoteKind uint8
+
+const (
+	EmoteNiceHand EmoteKind = iota + 1
+	EmoteGoodLuck
+	EmoteThinking
+	// EmoteWillShow signals that the player intends to show their hand
+	EmoteWillShow
+	// EmoteWillMuck signals that the player intends to muck their hand
+	EmoteWillMuck
+	// EmoteRabbitHunt asks to see the cards that would have come, had the hand continued
+	EmoteRabbitHunt
+)
+
+// A player may emote at most once per emoteInterval
+const emoteInterval = 2 * time.Second
+
+// Emote is the Action for table gestures that don't affect the state of the hand (like "nice hand", or a
+// request to rabbit hunt). For Emote, data is the EmoteKind. Emote records an EventEmote in g's event log, so
+// gestures appear in the same ordered stream as everything else, but changes nothing else about g.
+// Emote returns ErrFeatureDisabled if emotes (or for EmoteRabbitHunt, rabbit hunts) are disabled in g's RuleSet,
+// ErrIllegalAction if data is not a valid EmoteKind or the player has left, and ErrRateLimited if the player has
+// emoted too recently.
+func Emote(g *Game, pn uint, data uint) error {
+	kind := EmoteKind(data)
+	if data == 0 || kind > EmoteRabbitHunt {
+		return ErrIllegalAction
+	}
+
+	if !g.config.Rules.Emotes || (kind == EmoteRabbitHunt && !g.config.Rules.RabbitHunt) {
+		return ErrFeatureDisabled
+	}
+
+	if g.getPlayer(pn).Left {
+		return ErrIllegalAction
+	}
+
+	now := g.currentTime()
+	if last, ok := g.lastEmote[pn]; ok && now.Sub(last) < emoteInterval {
+		return ErrRateLimited
+	}
+
+	if g.lastEmote == nil {
+		g.lastEmote = make(map[uint]time.Time)
+	}
+	g.lastEmote[pn] = now
+
+	g.emit(Event{Kind: EventEmote, PlayerNum: pn, Emote: kind})
+
+	return nil
+}
@@ -34,11 +34,165 @@ import (
 // illegal bet amounts, out-of-turn plays, or other violations of the rules.
 var ErrIllegalAction = errors.New("this action cannot be performed at this time")
 
-/*
+// ErrBuyTooBig is returned when a BuyIn would leave the player with a stack over the table's MaxBuy.
 var ErrBuyTooBig = errors.New("this would exceed the maximum configured purchased stack size")
+
+// ErrPatchMismatch is returned when a ViewPatch is applied to a view other than the one it was made from.
+var ErrPatchMismatch = errors.New("the patch was not made from this view")
+
+// ErrBadSeat is returned when a player tries to sit in a seat the table doesn't have.
+var ErrBadSeat = errors.New("no such seat at this table")
+
+// ErrStackTooSmall is returned when a player without the table's MinBigBlinds in their stack tries to be dealt in.
+var ErrStackTooSmall = errors.New("not enough chips to be dealt in")
+
+// ErrTableFull is returned when seating a new player at a table whose every seat is taken (see Game.SeatPlayer), or
+// joining a Lobby table that players are waiting for a seat at (see Lobby.Join).
+var ErrTableFull = errors.New("every seat at this table is taken")
+
+// ErrSeatTaken is returned when a player tries to sit in a seat somebody else is sitting in, or to play without
+// a seat.
+var ErrSeatTaken = errors.New("this seat is taken")
+
+// ErrBuyTooSmall is returned when a BuyIn would leave the player with a stack under the table's MinBuy, or, at
+// tables that play RuleSet.TableStakes, under what the player last cashed out with.
+var ErrBuyTooSmall = errors.New("this would leave a stack under the minimum configured buy-in")
+
+/*
 var ErrNotEnoughPlayers = errors.New("need more players to start the round")
 */
 
 var errInternalBadGameStage = errors.New("internal error: bad game stage")
 
 var ErrNoValidDealer = errors.New("No valid dealer found")
+
+// ErrTournamentStarted is returned when an operation that is only valid before a Tournament
+// has started (like registering a new entrant, once late registration has closed) is attempted after it has started.
+var ErrTournamentStarted = errors.New("the tournament has already started")
+
+// ErrTournamentNotStarted is returned when an operation that is only valid once a Tournament
+// is running is attempted before Start has been called.
+var ErrTournamentNotStarted = errors.New("the tournament has not started yet")
+
+// ErrNotEnoughEntrants is returned when a Tournament is started without enough entrants to play.
+var ErrNotEnoughEntrants = errors.New("not enough entrants to start the tournament")
+
+// ErrBadTableSize is returned when a Tournament is configured with a table size that no Game can seat.
+var ErrBadTableSize = errors.New("table size must be between 2 and 23")
+
+// ErrUnknownEntrant is returned when a Tournament is asked about an entrant it does not have.
+var ErrUnknownEntrant = errors.New("no such entrant in this tournament")
+
+// ErrUnknownTable is returned when a Tournament (or a Duplicate, or a Lobby) is asked about a table it does not have,
+// or one that has already been broken or closed.
+var ErrUnknownTable = errors.New("no such table")
+
+// ErrClockPaused is returned when the tournament clock is paused (or adjusted) in a way that requires
+// it to be running, but it is already paused.
+var ErrClockPaused = errors.New("the tournament clock is paused")
+
+// ErrClockRunning is returned when the tournament clock is resumed, but it is not paused.
+var ErrClockRunning = errors.New("the tournament clock is running")
+
+// ErrGamePaused is returned when dealing a new hand while the Game is paused, or when pausing it again.
+var ErrGamePaused = errors.New("the game is paused")
+
+// ErrGameRunning is returned when a Game is resumed, but it is not paused.
+var ErrGameRunning = errors.New("the game is not paused")
+
+// ErrBadLevel is returned when the tournament clock is asked to jump to a level that does not exist.
+var ErrBadLevel = errors.New("no such blind level")
+
+// ErrRateLimited is returned when a player attempts something more often than the Game allows.
+// The attempt has no effect, and can be retried later.
+var ErrRateLimited = errors.New("too many attempts, try again later")
+
+// ErrFeatureDisabled is returned when an Action depends on a feature that is turned off in the Game's RuleSet.
+var ErrFeatureDisabled = errors.New("this feature is not enabled at this table")
+
+// ErrUnevenTables is returned when a Duplicate is scored while its tables haven't all played the same hands.
+var ErrUnevenTables = errors.New("every table must have finished the same number of hands")
+
+// ErrRematchExpired is returned when a rematch is accepted after the offer has lapsed.
+var ErrRematchExpired = errors.New("the rematch offer has expired")
+
+// ErrUnknownAction is returned when replaying an Action whose name isn't one of the keys of ActionsByName.
+var ErrUnknownAction = errors.New("no such action")
+
+// ErrBadCommitment is returned when a revealed shuffle doesn't match its commitment.
+var ErrBadCommitment = errors.New("the revealed seed does not match the commitment")
+
+// ErrBadShuffle is returned when a revealed shuffle doesn't deal the cards that were dealt.
+var ErrBadShuffle = errors.New("the revealed seed does not deal these cards")
+
+// ErrUnknownField is returned when parsing the name of a GameView field that doesn't exist.
+var ErrUnknownField = errors.New("no such view field")
+
+// ErrBadRange is returned when parsing range notation that isn't valid, like "AKx" or "22-AK".
+var ErrBadRange = errors.New("invalid range notation")
+
+// ErrEmptyRange is returned when a Range has no combos that can be dealt alongside the known cards.
+var ErrEmptyRange = errors.New("the range has no live combos")
+
+// ErrDuplicateCard is returned when dealing from a deck that holds a card that has already been dealt this hand, or
+// that has been dealt twice.
+var ErrDuplicateCard = errors.New("a card has been dealt more than once")
+
+// ErrBadBoard is returned when dealing a hand whose community cards don't number what its stage calls for.
+var ErrBadBoard = errors.New("the wrong number of community cards have been dealt")
+
+// ErrShortDeck is returned when the deck doesn't have enough cards left to deal the rest of the hand.
+var ErrShortDeck = errors.New("not enough cards left in the deck")
+
+// ErrBadPHH is returned when reading a PHH hand history that isn't valid, or that records a hand riverboat can't deal,
+// and when writing a hand to PHH that it can't express (see ParsePHH).
+var ErrBadPHH = errors.New("invalid or unsupported PHH hand history")
+
+// ErrBadActionIndex is returned when asking for a point in a HandRecord past its last Action.
+var ErrBadActionIndex = errors.New("no such action in the hand record")
+
+// ErrJournalMismatch is returned when replaying an event log that the Game being rebuilt doesn't reproduce, like one
+// recorded with a different GameConfig.
+var ErrJournalMismatch = errors.New("the event log does not match the game being rebuilt")
+
+// ErrNotReplayable is returned when replaying an event log recorded with a GameConfig whose log can't be replayed:
+// one without a Seed, that commits to each hand's shuffle, or that doesn't retain every hand (see ReplayEvents).
+var ErrNotReplayable = errors.New("the game's event log cannot be replayed")
+
+// ErrGameNotFound is returned when loading a Game that isn't in the GameStore.
+var ErrGameNotFound = errors.New("no such game in the store")
+
+// ErrStaleVersion is returned when saving a Game to a GameStore that somebody else has saved it to since it was
+// loaded.
+var ErrStaleVersion = errors.New("the game has been saved since this version was loaded")
+
+// ErrTableExists is returned when opening a table in a Lobby under an ID another of its tables already has.
+var ErrTableExists = errors.New("a table with this ID is already open")
+
+// ErrUnknownPlayer is returned when acting for a player at a Lobby table they haven't joined.
+var ErrUnknownPlayer = errors.New("the player has not joined this table")
+
+// ErrAlreadySeated is returned when putting a player on the waitlist of a Lobby table they are already seated at.
+var ErrAlreadySeated = errors.New("the player is already seated at this table")
+
+// ErrNotWaitlisted is returned when taking a player off the waitlist of a Lobby table they aren't waiting for.
+var ErrNotWaitlisted = errors.New("the player is not on this table's waitlist")
+
+// ErrCannotReEnter is returned when an entrant who hasn't busted, or has no re-entries left, tries to re-enter a
+// Tournament.
+var ErrCannotReEnter = errors.New("the entrant cannot re-enter the tournament")
+
+// ErrAddOnUnavailable is returned when an entrant tries to buy an add-on they can't have right now: the tournament
+// doesn't offer them, it isn't the break, or the entrant has already bought one or is in the middle of a hand.
+var ErrAddOnUnavailable = errors.New("an add-on is not available")
+
+// ErrBadPayouts is returned when a PayoutTable has no tier for the size of the field, or a tier has no places to pay.
+var ErrBadPayouts = errors.New("the payout structure does not cover this field")
+
+// ErrChopUnavailable is returned when a chop is proposed in a Tournament that isn't running, or while an entrant
+// still in is in the middle of a hand, or has no chips left, or when an ICM chop is proposed before the final table.
+var ErrChopUnavailable = errors.New("a chop cannot be made right now")
+
+// ErrNoChop is returned when accepting or rejecting a chop that hasn't been proposed, or has lapsed because the chip
+// counts have changed since.
+var ErrNoChop = errors.New("no chop has been proposed")
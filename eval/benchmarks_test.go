@@ -325,3 +325,49 @@ func BenchmarkSevenRiverboat(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkSevenRiverboatLookup(b *testing.B) {
+	var cardsRiverboat7 [][]Card
+	for _, s := range dataRiverboat7 {
+		var cards []Card
+		for _, ss := range s {
+			c, _ := ParseCardBytes(ss)
+			cards = append(cards, c)
+		}
+		cardsRiverboat7 = append(cardsRiverboat7, cards)
+	}
+
+	UseLookupTables()
+	defer func() { lookupInUse = false }()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, cards := range cardsRiverboat7 {
+			BestFiveOfSeven(cards[0], cards[1], cards[2], cards[3], cards[4], cards[5], cards[6])
+		}
+	}
+}
+
+func BenchmarkSevenCardValue(b *testing.B) {
+	var cardsRiverboat7 [][]Card
+	for _, s := range dataRiverboat7 {
+		var cards []Card
+		for _, ss := range s {
+			c, _ := ParseCardBytes(ss)
+			cards = append(cards, c)
+		}
+		cardsRiverboat7 = append(cardsRiverboat7, cards)
+	}
+
+	SevenCardValue(cardsRiverboat7[0][0], cardsRiverboat7[0][1], cardsRiverboat7[0][2], cardsRiverboat7[0][3],
+		cardsRiverboat7[0][4], cardsRiverboat7[0][5], cardsRiverboat7[0][6])
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, cards := range cardsRiverboat7 {
+			SevenCardValue(cards[0], cards[1], cards[2], cards[3], cards[4], cards[5], cards[6])
+		}
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"io"
 	"math/rand"
 	"regexp"
+	"strconv"
 	"unicode"
 	//"log"
 )
@@ -136,6 +137,45 @@ func (c Card) String() string {
 	return string(numToChrRanks[rank]) + string(numToChrSuits[suit])
 }
 
+// MarshalJSON encodes c as its human-readable string (e.g. "AS"), or null if c is the zero Card
+// (which is used to represent a card that is hidden, or hasn't been dealt).
+func (c Card) MarshalJSON() ([]byte, error) {
+	if c == 0 {
+		return []byte("null"), nil
+	}
+
+	return []byte(`"` + c.String() + `"`), nil
+}
+
+// UnmarshalJSON decodes a Card from a string in any format accepted by ParseCardBytes, or from null or the empty
+// string, both of which decode to the zero Card. For compatibility with payloads encoded before MarshalJSON existed,
+// it also accepts the Card's native 32 bit representation as a number.
+func (c *Card) UnmarshalJSON(b []byte) error {
+	s := string(bytes.TrimSpace(b))
+
+	if s == "null" || s == `""` {
+		*c = 0
+		return nil
+	}
+
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		card, err := ParseCardBytes([]byte(s[1 : len(s)-1]))
+		if err != nil {
+			return err
+		}
+		*c = card
+		return nil
+	}
+
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return ErrBadCard
+	}
+
+	*c = Card(n)
+	return nil
+}
+
 // Deck is the basic type representing a deck of playing cards
 type Deck []Card
 
@@ -202,7 +242,12 @@ func (d *Deck) Push(card Card) { *d = append(*d, card) }
 
 // Shuffle resets the contents of d and performs a Fisher-Yates shuffle. Post-condition: d contains all 52 unique cards, in a normally distributed random order.
 func (d *Deck) Shuffle(rand *rand.Rand) {
-	*d = append([]Card{}, DefaultDeck...)
+	d.ShuffleFrom(DefaultDeck, rand)
+}
+
+// ShuffleFrom is like Shuffle, but resets the contents of d to the cards in full (e.g. ShortDeck) instead of all 52.
+func (d *Deck) ShuffleFrom(full Deck, rand *rand.Rand) {
+	*d = append([]Card{}, full...)
 	rand.Shuffle(len(*d), func(i, j int) { (*d)[i], (*d)[j] = (*d)[j], (*d)[i] })
 }
 
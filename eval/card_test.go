@@ -283,3 +283,42 @@ func TestPop(t *testing.T) {
 	})
 
 }
+
+func TestCardJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    Card
+		wantErr error
+	}{
+		{"String", `"JH"`, 33564957, nil},
+		{"Lowercase string", `"jh"`, 33564957, nil},
+		{"Number", `33564957`, 33564957, nil},
+		{"Null", `null`, 0, nil},
+		{"Empty string", `""`, 0, nil},
+		{"Bad string", `"ZZ"`, 0, ErrBadCard},
+		{"Bad value", `true`, 0, ErrBadCard},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Card
+			err := got.UnmarshalJSON([]byte(tt.json))
+			if err != tt.wantErr {
+				t.Fatalf("Card.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Card.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	b, _ := Card(33564957).MarshalJSON()
+	if string(b) != `"JH"` {
+		t.Errorf("Card.MarshalJSON() = %s, want \"JH\"", b)
+	}
+
+	b, _ = Card(0).MarshalJSON()
+	if string(b) != `null` {
+		t.Errorf("Card.MarshalJSON() = %s, want null", b)
+	}
+}
@@ -0,0 +1,111 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+// Category is the category of a poker hand, like a flush or two pair. Better categories are greater.
+type Category uint8
+
+const (
+	HighCard Category = iota + 1
+	Pair
+	TwoPair
+	ThreeOfAKind
+	Straight
+	Flush
+	FullHouse
+	FourOfAKind
+	StraightFlush
+)
+
+// The last (worst) HandValue of each Category. Each Category's values start right after the last of the Category
+// above it, and a royal flush is the only hand valued 1.
+const (
+	LastStraightFlush = 10
+	LastFourOfAKind   = 166
+	LastFullHouse     = 322
+	LastFlush         = 1599
+	LastStraight      = 1609
+	LastThreeOfAKind  = 2467
+	LastTwoPair       = 3325
+	LastPair          = 6185
+	LastHighCard      = 7462
+)
+
+var categoryNames = map[Category]string{
+	HighCard:      "High Card",
+	Pair:          "Pair",
+	TwoPair:       "Two Pair",
+	ThreeOfAKind:  "Three of a Kind",
+	Straight:      "Straight",
+	Flush:         "Flush",
+	FullHouse:     "Full House",
+	FourOfAKind:   "Four of a Kind",
+	StraightFlush: "Straight Flush",
+}
+
+func (c Category) String() string {
+	return categoryNames[c]
+}
+
+// HandCategory returns the Category of the hand with the given HandValue (or BestFiveOfSeven score). It returns 0 if
+// score isn't a valid HandValue.
+func HandCategory(score int) Category {
+	switch {
+	case score < 1:
+		return 0
+	case score <= LastStraightFlush:
+		return StraightFlush
+	case score <= LastFourOfAKind:
+		return FourOfAKind
+	case score <= LastFullHouse:
+		return FullHouse
+	case score <= LastFlush:
+		return Flush
+	case score <= LastStraight:
+		return Straight
+	case score <= LastThreeOfAKind:
+		return ThreeOfAKind
+	case score <= LastTwoPair:
+		return TwoPair
+	case score <= LastPair:
+		return Pair
+	case score <= LastHighCard:
+		return HighCard
+	}
+
+	return 0
+}
+
+// ShortDeckHandCategory is like HandCategory, but for values from ShortDeckHandValue, which ranks flushes above full
+// houses.
+func ShortDeckHandCategory(score int) Category {
+	switch c := HandCategory(score); {
+	case score <= LastFourOfAKind || score > LastFlush:
+		return c
+	case score <= LastFourOfAKind+(LastFlush-LastFullHouse):
+		return Flush
+	}
+
+	return FullHouse
+}
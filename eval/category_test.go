@@ -0,0 +1,86 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "testing"
+
+func TestHandCategory(t *testing.T) {
+	tests := []struct {
+		hand string
+		want Category
+	}{
+		{"As Ks Qs Js Ts", StraightFlush},
+		{"5d 4d 3d 2d Ad", StraightFlush},
+		{"Kc Kd Kh Ks 2c", FourOfAKind},
+		{"Kc Kd Kh Tc Td", FullHouse},
+		{"Ac 9c 7c 4c 2c", Flush},
+		{"5c 4d 3h 2s Ac", Straight},
+		{"7c 7d 7h Ks 2c", ThreeOfAKind},
+		{"Ac Ad 8h 8s 2c", TwoPair},
+		{"Jc Jd 8h 6s 2c", Pair},
+		{"7c 5d 4h 3s 2c", HighCard},
+	}
+
+	for _, tt := range tests {
+		var c [5]Card
+		for i := range c {
+			c[i] = MustParseCardString(tt.hand[3*i : 3*i+2])
+		}
+
+		if got := HandCategory(HandValue(c[0], c[1], c[2], c[3], c[4])); got != tt.want {
+			t.Errorf("Test failed - HandCategory(%s) = %s, want %s", tt.hand, got, tt.want)
+		}
+	}
+
+	for _, score := range []int{0, LastHighCard + 1} {
+		if got := HandCategory(score); got != 0 {
+			t.Errorf("Test failed - HandCategory(%d) should be invalid, got %s", score, got)
+		}
+	}
+
+	if HandCategory(LastPair) != Pair || HandCategory(LastPair+1) != HighCard {
+		t.Errorf("Test failed - LastPair should be the boundary between Pair and HighCard")
+	}
+}
+
+func TestShortDeckHandCategory(t *testing.T) {
+	tests := []struct {
+		hand string
+		want Category
+	}{
+		{"Ah 9h 8h 7h 6h", StraightFlush},
+		{"6c 6d 6h 6s Ac", FourOfAKind},
+		{"7h 9h 8h 6h Jh", Flush},
+		{"Ac Ad Ah Kc Kd", FullHouse},
+		{"6c 6d 6h 7c 7d", FullHouse},
+		{"Ac 9d 8h 7c 6d", Straight},
+		{"Ac Kd Qh Jc 9d", HighCard},
+	}
+
+	for _, tt := range tests {
+		if got := ShortDeckHandCategory(shortDeckValue(tt.hand)); got != tt.want {
+			t.Errorf("Test failed - ShortDeckHandCategory(%s) = %s, want %s", tt.hand, got, tt.want)
+		}
+	}
+}
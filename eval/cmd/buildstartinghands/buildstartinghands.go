@@ -0,0 +1,156 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"math/rand"
+	"sort"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// The number of random runouts to estimate each starting hand's equity from
+const runouts = 3000000
+
+const ranks = "23456789TJQKA"
+
+type startingHand struct {
+	name   string
+	cards  [2]eval.Card
+	equity float64
+}
+
+func main() {
+	card := func(rank int, suit string) eval.Card {
+		return eval.MustParseCardString(string(ranks[rank]) + suit)
+	}
+
+	var hands []startingHand
+	for hi := 12; hi >= 0; hi-- {
+		hands = append(hands, startingHand{name: string([]byte{ranks[hi], ranks[hi]}), cards: [2]eval.Card{card(hi, "s"), card(hi, "h")}})
+		for lo := hi - 1; lo >= 0; lo-- {
+			hands = append(hands,
+				startingHand{name: string([]byte{ranks[hi], ranks[lo], 's'}), cards: [2]eval.Card{card(hi, "s"), card(lo, "s")}},
+				startingHand{name: string([]byte{ranks[hi], ranks[lo], 'o'}), cards: [2]eval.Card{card(hi, "s"), card(lo, "h")}},
+			)
+		}
+	}
+
+	// Each hand's equity is estimated from its own seeded generator, so the output is the same every time
+	var wg sync.WaitGroup
+	for i := range hands {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hands[i].equity = equity(hands[i].cards, rand.New(rand.NewSource(int64(i)+1)))
+		}(i)
+	}
+	wg.Wait()
+
+	sort.SliceStable(hands, func(i, j int) bool { return hands[i].equity > hands[j].equity })
+
+	names := ""
+	equities := ""
+	for i, h := range hands {
+		if i%13 == 0 {
+			names += "\n\t"
+			equities += "\n\t"
+		}
+		names += fmt.Sprintf("%q, ", h.name)
+		equities += fmt.Sprintf("%.4f, ", h.equity)
+	}
+
+	buf := bytes.Buffer{}
+	t.Execute(&buf, struct {
+		Timestamp time.Time
+		Runouts   int
+		Names     string
+		Equities  string
+	}{
+		Timestamp: time.Now(),
+		Runouts:   runouts,
+		Names:     names,
+		Equities:  equities,
+	})
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if err := ioutil.WriteFile("starting_hands.go", src, 0644); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// equity estimates the share of the pot hand wins all in against a random hand
+func equity(hand [2]eval.Card, r *rand.Rand) float64 {
+	rest := []eval.Card{}
+	for _, c := range eval.DefaultDeck {
+		if c != hand[0] && c != hand[1] {
+			rest = append(rest, c)
+		}
+	}
+
+	var share float64
+	for i := 0; i < runouts; i++ {
+		// The first two cards are the opponent's, and the next five the board
+		for j := 0; j < 7; j++ {
+			k := j + r.Intn(len(rest)-j)
+			rest[j], rest[k] = rest[k], rest[j]
+		}
+
+		a := eval.SevenCardValue(hand[0], hand[1], rest[2], rest[3], rest[4], rest[5], rest[6])
+		b := eval.SevenCardValue(rest[0], rest[1], rest[2], rest[3], rest[4], rest[5], rest[6])
+		if a < b {
+			share++
+		} else if a == b {
+			share += 0.5
+		}
+	}
+
+	return share / runouts
+}
+
+var t = template.Must(template.New("").Parse(`// Code generated by go generate; DO NOT EDIT.
+// This file was generated by automatically at {{ .Timestamp }}
+
+package eval
+
+// startingHandOrder lists the 169 kinds of Texas Hold'em starting hand from best to worst, and startingHandEquity
+// their all-in equity against a random hand, each estimated from {{ .Runouts }} random runouts
+var startingHandOrder = []string{ {{- .Names }}
+}
+
+var startingHandEquity = []float64{ {{- .Equities }}
+}
+`))
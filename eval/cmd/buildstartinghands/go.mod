@@ -0,0 +1,7 @@
+module github.com/alexclewontin/riverboat/eval/cmd/buildstartinghands/main
+
+go 1.13
+
+require github.com/alexclewontin/riverboat/eval v0.0.0
+
+replace github.com/alexclewontin/riverboat/eval => ../../../eval
@@ -0,0 +1,110 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "sort"
+
+var rankNames = [13]string{"Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten", "Jack", "Queen", "King", "Ace"}
+var pluralRankNames = [13]string{"Twos", "Threes", "Fours", "Fives", "Sixes", "Sevens", "Eights", "Nines", "Tens", "Jacks", "Queens", "Kings", "Aces"}
+
+// DescribeHand returns a description of the best five card hand (see BestFiveOfSeven) that can be made from cards,
+// which must hold five, six or seven cards, like "Full House, Kings full of Tens", "Ace-high Flush", or "Pair of
+// Jacks". Hands are described by their standard ranking, and only by the cards that decide their category (so
+// kickers aren't mentioned). DescribeHand returns "" if it isn't passed five to seven cards.
+//
+// WARNING: See the warning associated with HandValue.
+func DescribeHand(cards []Card) string {
+	var hand []Card
+	switch len(cards) {
+	case 5:
+		hand = cards
+	case 6:
+		hand, _ = BestFiveOfSix(cards[0], cards[1], cards[2], cards[3], cards[4], cards[5])
+	case 7:
+		hand, _ = BestFiveOfSeven(cards[0], cards[1], cards[2], cards[3], cards[4], cards[5], cards[6])
+	default:
+		return ""
+	}
+
+	counts := [13]int{}
+	suits := hand[0] & 0xF000
+	for _, c := range hand {
+		counts[(c>>8)&0xF]++
+		suits &= c
+	}
+
+	// The ranks in the hand, most often first, and highest first among those as often
+	ranks := []int{}
+	for r := range counts {
+		if counts[r] > 0 {
+			ranks = append(ranks, r)
+		}
+	}
+	sort.Slice(ranks, func(i, j int) bool {
+		if counts[ranks[i]] != counts[ranks[j]] {
+			return counts[ranks[i]] > counts[ranks[j]]
+		}
+		return ranks[i] > ranks[j]
+	})
+
+	flush := suits != 0
+	high, straight := straightHigh(ranks)
+
+	switch {
+	case straight && flush && high == 12:
+		return "Royal Flush"
+	case straight && flush:
+		return rankNames[high] + "-high Straight Flush"
+	case counts[ranks[0]] == 4:
+		return "Four of a Kind, " + pluralRankNames[ranks[0]]
+	case counts[ranks[0]] == 3 && counts[ranks[1]] == 2:
+		return "Full House, " + pluralRankNames[ranks[0]] + " full of " + pluralRankNames[ranks[1]]
+	case flush:
+		return rankNames[ranks[0]] + "-high Flush"
+	case straight:
+		return rankNames[high] + "-high Straight"
+	case counts[ranks[0]] == 3:
+		return "Three of a Kind, " + pluralRankNames[ranks[0]]
+	case counts[ranks[0]] == 2 && counts[ranks[1]] == 2:
+		return "Two Pair, " + pluralRankNames[ranks[0]] + " and " + pluralRankNames[ranks[1]]
+	case counts[ranks[0]] == 2:
+		return "Pair of " + pluralRankNames[ranks[0]]
+	}
+
+	return rankNames[ranks[0]] + " High"
+}
+
+// straightHigh returns the rank of the highest card of the straight made by ranks (five distinct ranks, highest
+// first), and whether they make one at all. In the wheel (5-4-3-2-A), the ace plays low, so the five is highest.
+func straightHigh(ranks []int) (int, bool) {
+	if len(ranks) != 5 {
+		return 0, false
+	}
+
+	if ranks[0] == 12 && ranks[1] == 3 && ranks[4] == 0 {
+		return 3, true
+	}
+
+	return ranks[0], ranks[0]-ranks[4] == 4
+}
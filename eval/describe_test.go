@@ -0,0 +1,69 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDescribeHand(t *testing.T) {
+	tests := []struct {
+		hand string
+		want string
+	}{
+		{"As Ks Qs Js Ts", "Royal Flush"},
+		{"9h 8h 7h 6h 5h", "Nine-high Straight Flush"},
+		{"5d 4d 3d 2d Ad", "Five-high Straight Flush"},
+		{"Kc Kd Kh Ks 2c", "Four of a Kind, Kings"},
+		{"Kc Kd Kh Tc Td", "Full House, Kings full of Tens"},
+		{"Tc Td Th Kc Kd", "Full House, Tens full of Kings"},
+		{"Ac 9c 7c 4c 2c", "Ace-high Flush"},
+		{"Tc 9d 8h 7s 6c", "Ten-high Straight"},
+		{"5c 4d 3h 2s Ac", "Five-high Straight"},
+		{"7c 7d 7h Ks 2c", "Three of a Kind, Sevens"},
+		{"Ac Ad 8h 8s 2c", "Two Pair, Aces and Eights"},
+		{"Jc Jd 8h 6s 2c", "Pair of Jacks"},
+		{"Ac Jd 8h 6s 2c", "Ace High"},
+		// The best five of seven
+		{"Kc Kd Kh Tc Td 2s 3s", "Full House, Kings full of Tens"},
+		{"Ac 2c 7c 9c 4c Ad Ah", "Ace-high Flush"},
+		{"Kc Kd 7h 7s 2c 2d 9h", "Two Pair, Kings and Sevens"},
+	}
+
+	for _, tt := range tests {
+		cards := []Card{}
+		for _, s := range strings.Fields(tt.hand) {
+			cards = append(cards, MustParseCardString(s))
+		}
+
+		if got := DescribeHand(cards); got != tt.want {
+			t.Errorf("Test failed - DescribeHand(%s) = %q, want %q", tt.hand, got, tt.want)
+		}
+	}
+
+	if got := DescribeHand([]Card{MustParseCardString("As")}); got != "" {
+		t.Errorf("Test failed - DescribeHand should not describe a single card, got %q", got)
+	}
+}
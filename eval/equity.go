@@ -0,0 +1,177 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// ErrBadEquity is the error returned by Equity if it is passed fewer than two hands, hands that don't have exactly
+// two cards, more than five board cards, or the same card twice
+var ErrBadEquity = errors.New("equity: need at least two hands of two cards, at most five board cards, and no duplicates")
+
+// HandEquity is one hand's result from Equity. Win and Tie are the fractions of runouts in which the hand wins
+// outright and ties for the best hand, respectively. Equity is the fraction of the pot the hand wins on average,
+// counting each tie as an even split among the tied hands.
+type HandEquity struct {
+	Win    float64
+	Tie    float64
+	Equity float64
+}
+
+// Equity calculates the all-in equity of each of the given Texas Hold'em hands on the given board (which may have
+// anywhere from zero to five cards), in the same order as hands.
+//
+// If there are no more than iterations ways to complete the board, or iterations is zero or less, Equity enumerates
+// every one of them, and the result is exact. Otherwise, Equity samples iterations random runouts, and the result is a
+// Monte Carlo estimate.
+func Equity(hands [][]Card, board []Card, iterations int) ([]HandEquity, error) {
+	if len(hands) < 2 || len(board) > 5 {
+		return nil, ErrBadEquity
+	}
+
+	used := map[Card]bool{}
+	for _, h := range append([][]Card{board}, hands...) {
+		for _, c := range h {
+			if used[c] {
+				return nil, ErrBadEquity
+			}
+			used[c] = true
+		}
+	}
+
+	for _, h := range hands {
+		if len(h) != 2 {
+			return nil, ErrBadEquity
+		}
+	}
+
+	remaining := Deck{}
+	for _, c := range DefaultDeck {
+		if !used[c] {
+			remaining = append(remaining, c)
+		}
+	}
+
+	need := 5 - len(board)
+	if need > len(remaining) {
+		return nil, ErrBadEquity
+	}
+
+	t := equityTally{hands: hands, wins: make([]int, len(hands)), ties: make([]int, len(hands)), shares: make([]float64, len(hands))}
+
+	var runout [5]Card
+	copy(runout[:], board)
+
+	if iterations <= 0 || combinations(len(remaining), need) <= iterations {
+		t.enumerate(runout, len(board), remaining)
+	} else {
+		r := rand.New(rand.NewSource(time.Now().UnixNano()))
+		for i := 0; i < iterations; i++ {
+			// A partial Fisher-Yates shuffle picks the cards still to come
+			for j := 0; j < need; j++ {
+				k := j + r.Intn(len(remaining)-j)
+				remaining[j], remaining[k] = remaining[k], remaining[j]
+				runout[len(board)+j] = remaining[j]
+			}
+			t.add(runout)
+		}
+	}
+
+	return t.results(), nil
+}
+
+// equityTally accumulates the outcomes of the runouts that Equity considers
+type equityTally struct {
+	hands  [][]Card
+	wins   []int
+	ties   []int
+	shares []float64
+	total  int
+}
+
+// enumerate adds every runout that completes the first n cards of runout with cards from remaining
+func (t *equityTally) enumerate(runout [5]Card, n int, remaining []Card) {
+	if n == 5 {
+		t.add(runout)
+		return
+	}
+
+	for i := range remaining {
+		runout[n] = remaining[i]
+		t.enumerate(runout, n+1, remaining[i+1:])
+	}
+}
+
+func (t *equityTally) add(b [5]Card) {
+	best := LastHighCard + 1
+	winners := []int{}
+
+	for i, h := range t.hands {
+		_, score := BestFiveOfSeven(h[0], h[1], b[0], b[1], b[2], b[3], b[4])
+		if score < best {
+			best = score
+			winners = winners[:0]
+		}
+		if score == best {
+			winners = append(winners, i)
+		}
+	}
+
+	for _, i := range winners {
+		if len(winners) == 1 {
+			t.wins[i]++
+		} else {
+			t.ties[i]++
+		}
+		t.shares[i] += 1 / float64(len(winners))
+	}
+
+	t.total++
+}
+
+func (t *equityTally) results() []HandEquity {
+	res := make([]HandEquity, len(t.hands))
+	for i := range res {
+		res[i] = HandEquity{
+			Win:    float64(t.wins[i]) / float64(t.total),
+			Tie:    float64(t.ties[i]) / float64(t.total),
+			Equity: t.shares[i] / float64(t.total),
+		}
+	}
+
+	return res
+}
+
+// combinations returns n choose k
+func combinations(n, k int) int {
+	c := 1
+	for i := 0; i < k; i++ {
+		c = c * (n - i) / (i + 1)
+	}
+
+	return c
+}
@@ -0,0 +1,89 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func parseCards(s string) []Card {
+	cards := []Card{}
+	for _, f := range strings.Fields(s) {
+		cards = append(cards, MustParseCardString(f))
+	}
+
+	return cards
+}
+
+func TestEquity(t *testing.T) {
+	approx := func(a, b, tolerance float64) bool { return math.Abs(a-b) <= tolerance }
+
+	// On the river, the result is certain
+	res, err := Equity([][]Card{parseCards("As Ad"), parseCards("Ks Kd")}, parseCards("Kh 7c 2d 3s 9h"), 1000)
+	if err != nil {
+		t.Fatalf("Test failed - unexpected error %v", err)
+	}
+	if res[0] != (HandEquity{}) || res[1] != (HandEquity{Win: 1, Equity: 1}) {
+		t.Errorf("Test failed - set kings should win the river every time, got %+v", res)
+	}
+
+	// On the turn, the aces have two outs among 44 cards
+	res, _ = Equity([][]Card{parseCards("As Ad"), parseCards("Ks Kd")}, parseCards("Kh 7c 2d 3s"), 1000)
+	if !approx(res[0].Win, 2.0/44, 1e-9) || !approx(res[1].Win, 42.0/44, 1e-9) {
+		t.Errorf("Test failed - the aces should win 2/44 on the turn, got %+v", res)
+	}
+
+	// The same hands split every runout of a board that plays
+	res, _ = Equity([][]Card{parseCards("2s 3d"), parseCards("2c 3h")}, parseCards("As Ks Qd Jc Th"), 0)
+	for i, e := range res {
+		if e != (HandEquity{Tie: 1, Equity: 0.5}) {
+			t.Errorf("Test failed - hand %d should split the pot, got %+v", i, e)
+		}
+	}
+
+	// Preflop is too big to enumerate in 20000 iterations, so this is a Monte Carlo estimate of about 82% to 18%
+	res, _ = Equity([][]Card{parseCards("As Ad"), parseCards("Kc Kh")}, nil, 20000)
+	if !approx(res[0].Equity, 0.82, 0.02) || !approx(res[0].Equity+res[1].Equity, 1, 1e-9) {
+		t.Errorf("Test failed - aces should have about 82%% equity against kings, got %+v", res)
+	}
+
+	bad := []struct {
+		hands [][]Card
+		board []Card
+	}{
+		{[][]Card{parseCards("As Ad")}, nil},
+		{[][]Card{parseCards("As Ad"), parseCards("Kc")}, nil},
+		{[][]Card{parseCards("As Ad"), parseCards("As Kc")}, nil},
+		{[][]Card{parseCards("As Ad"), parseCards("Ks Kc")}, parseCards("Ad 2c 3c")},
+		{[][]Card{parseCards("As Ad"), parseCards("Ks Kc")}, parseCards("2c 3c 4c 5c 6c 7c")},
+	}
+
+	for _, b := range bad {
+		if _, err := Equity(b.hands, b.board, 1000); err != ErrBadEquity {
+			t.Errorf("Test failed - expected ErrBadEquity for hands %v and board %v, got %v", b.hands, b.board, err)
+		}
+	}
+}
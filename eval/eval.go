@@ -72,8 +72,14 @@ func HandValue(c0, c1, c2, c3, c4 Card) int {
 // the 7 passed in. BestFiveOfSeven returns a slice of the 5 cards which make up the best hand,
 // and the score associated with that hand (lower is better).
 //
+// If UseLookupTables has been called, BestFiveOfSeven uses the lookup tables to find the score.
+//
 // WARNING: See the warning associated with HandValue.
 func BestFiveOfSeven(c0, c1, c2, c3, c4, c5, c6 Card) ([]Card, int) {
+	if lookupInUse {
+		return bestFiveOfSevenLookup(c0, c1, c2, c3, c4, c5, c6)
+	}
+
 	base := [7]Card{c0, c1, c2, c3, c4, c5, c6}
 	var bestHand []Card
 	bestScore := 8000 // larger value than the worst hand, so the first real hand will always be better
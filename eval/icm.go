@@ -0,0 +1,106 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrBadStacks is the error returned by ICM if it is passed no stacks, or more stacks than it can handle
+var ErrBadStacks = errors.New("icm: must have between 1 and 64 stacks")
+
+// ICM calculates each player's equity in a tournament's prize pool using the Independent Chip Model
+// (the Malmuth-Harville method). stacks holds each player's chip count, and payouts holds the prize for each
+// finishing position, starting with first place. ICM returns each player's equity, in the same order as stacks,
+// in the same units as payouts.
+//
+// The probability of a player finishing in first place is their share of the chips in play. The
+// probability of a player finishing in any later place is calculated the same way, among the chips that remain
+// once the players who finished ahead of them are removed. Players with no chips always finish behind players
+// with chips, and split the places left to them evenly.
+//
+// Payouts beyond the number of players are ignored. The running time is exponential in the number of paid
+// places (but not the number of players), so it is quite fast for the prize structures typically found at a
+// final table.
+func ICM(stacks []uint, payouts []float64) ([]float64, error) {
+	n := len(stacks)
+	if n == 0 || n > 64 {
+		return nil, ErrBadStacks
+	}
+
+	if len(payouts) > n {
+		payouts = payouts[:n]
+	}
+
+	var total uint
+	for _, s := range stacks {
+		total += s
+	}
+
+	equity := make([]float64, n)
+
+	// level maps a set of players (as a bitmask) to the probability that exactly those players took the
+	// places paid so far, in any order
+	level := map[uint64]float64{0: 1}
+
+	for _, payout := range payouts {
+		next := make(map[uint64]float64, len(level)*n)
+
+		for mask, prob := range level {
+			var remaining uint = total
+			for i := range stacks {
+				if mask&(1<<uint(i)) != 0 {
+					remaining -= stacks[i]
+				}
+			}
+
+			left := n - bits.OnesCount64(mask)
+
+			for i := range stacks {
+				if mask&(1<<uint(i)) != 0 {
+					continue
+				}
+
+				var p float64
+				if remaining == 0 {
+					p = prob / float64(left)
+				} else {
+					p = prob * float64(stacks[i]) / float64(remaining)
+				}
+
+				if p == 0 {
+					continue
+				}
+
+				equity[i] += p * payout
+				next[mask|(1<<uint(i))] += p
+			}
+		}
+
+		level = next
+	}
+
+	return equity, nil
+}
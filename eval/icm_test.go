@@ -0,0 +1,87 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"math"
+	"testing"
+)
+
+func TestICM(t *testing.T) {
+	tests := []struct {
+		name    string
+		stacks  []uint
+		payouts []float64
+		want    []float64
+	}{
+		{
+			name:    "Heads up, winner take all",
+			stacks:  []uint{300, 100},
+			payouts: []float64{100},
+			want:    []float64{75, 25},
+		},
+		{
+			name:    "Equal stacks",
+			stacks:  []uint{1000, 1000, 1000, 1000},
+			payouts: []float64{50, 30, 20},
+			want:    []float64{25, 25, 25, 25},
+		},
+		{
+			name:    "Three handed",
+			stacks:  []uint{50, 30, 20},
+			payouts: []float64{0.5, 0.3, 0.2},
+			want:    []float64{0.38393, 0.32750, 0.28857},
+		},
+		{
+			name:    "Busted player",
+			stacks:  []uint{100, 0, 100},
+			payouts: []float64{60, 30, 10},
+			want:    []float64{45, 10, 45},
+		},
+		{
+			name:    "More payouts than players",
+			stacks:  []uint{10, 10},
+			payouts: []float64{10, 6, 4},
+			want:    []float64{8, 8},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ICM(tt.stacks, tt.payouts)
+			if err != nil {
+				t.Fatalf("ICM() error = %v", err)
+			}
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 1e-4 {
+					t.Errorf("ICM() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	if _, err := ICM(nil, []float64{1}); err != ErrBadStacks {
+		t.Errorf("ICM() with no stacks should return ErrBadStacks, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// The lookup tables rank 7-card hands directly, rather than trying each of the 21 5-card hands in them. A hand with
+// five or more cards of a suit is ranked by the flush table, indexed by the ranks of that suit as a 13-bit mask (it
+// can't also make a full house or quads, so the best flush is the best hand). Any other hand depends only on its
+// ranks, and is ranked by the rank table, indexed by a perfect hash of how many cards it has of each rank.
+var (
+	lookupOnce  sync.Once
+	lookupInUse bool
+	flush7      [1 << 13]int16
+	ranks7      []int16
+	// quinary[n][k] is the number of ways to make k cards from n ranks, with at most four of each rank
+	quinary [14][8]int
+)
+
+// UseLookupTables builds the lookup tables that SevenCardValue uses, and makes BestFiveOfSeven use them too, which is
+// several times faster when many hands are evaluated. The tables take a few tens of milliseconds to build, and use
+// about 100KB of memory. UseLookupTables is not safe to call while hands are being evaluated, so it should be called
+// during initialization, e.g. from an init function.
+func UseLookupTables() {
+	lookupOnce.Do(buildLookupTables)
+	lookupInUse = true
+}
+
+// SevenCardValue returns the same score for the 7 cards passed in as BestFiveOfSeven does, using lookup tables
+// (building them the first time it is called, if UseLookupTables hasn't). It doesn't find the cards which make up the
+// best hand.
+//
+// WARNING: See the warning associated with HandValue.
+func SevenCardValue(c0, c1, c2, c3, c4, c5, c6 Card) int {
+	lookupOnce.Do(buildLookupTables)
+
+	var counts [13]int
+	var suitRanks, suitCounts [16]int
+	for _, c := range [7]Card{c0, c1, c2, c3, c4, c5, c6} {
+		counts[(c>>8)&0xF]++
+		suitRanks[(c>>12)&0xF] |= int(c >> 16)
+		suitCounts[(c>>12)&0xF]++
+	}
+
+	for _, s := range [4]int{1, 2, 4, 8} {
+		if suitCounts[s] >= 5 {
+			return int(flush7[suitRanks[s]])
+		}
+	}
+
+	return int(ranks7[rankHash(counts)])
+}
+
+// rankHash numbers a set of 7 ranks (given as the count of each rank) from 0, in lexicographic order of the counts
+func rankHash(counts [13]int) int {
+	idx, k := 0, 7
+	for i, n := range counts {
+		for v := 0; v < n; v++ {
+			idx += quinary[12-i][k-v]
+		}
+		k -= n
+	}
+
+	return idx
+}
+
+func buildLookupTables() {
+	quinary[0][0] = 1
+	for n := 1; n <= 13; n++ {
+		for k := 0; k <= 7; k++ {
+			for v := 0; v <= 4 && v <= k; v++ {
+				quinary[n][k] += quinary[n-1][k-v]
+			}
+		}
+	}
+
+	// The best flush among the ranks in a mask is its best 5 card submask
+	for mask := range flush7 {
+		if bits.OnesCount(uint(mask)) < 5 {
+			continue
+		}
+
+		best := int16(LastHighCard + 1)
+		for sub := mask; sub > 0; sub = (sub - 1) & mask {
+			if bits.OnesCount(uint(sub)) == 5 && flushes[sub] < best {
+				best = flushes[sub]
+			}
+		}
+		flush7[mask] = best
+	}
+
+	ranks7 = make([]int16, quinary[13][7])
+
+	var counts [13]int
+	var fill func(rank, left int)
+	fill = func(rank, left int) {
+		if rank == 13 {
+			if left == 0 {
+				ranks7[rankHash(counts)] = int16(bestOfRanks(counts))
+			}
+			return
+		}
+
+		for n := 0; n <= 4 && n <= left; n++ {
+			counts[rank] = n
+			fill(rank+1, left-n)
+		}
+		counts[rank] = 0
+	}
+	fill(0, 7)
+}
+
+// bestOfRanks returns the score of the best non-flush hand made from 7 cards with the given ranks
+func bestOfRanks(counts [13]int) int {
+	// Sorted by rank, cycling through the suits means no more than two cards share a suit, so there's no flush, and
+	// cards of the same rank all have different suits
+	var c [7]Card
+	i := 0
+	for r, n := range counts {
+		for ; n > 0; n-- {
+			c[i] = Card((1 << (16 + r)) | (0x1000 << (i % 4)) | (r << 8) | int(primeRanks[r]))
+			i++
+		}
+	}
+
+	best := LastHighCard + 1
+	for skip0 := 0; skip0 < 7; skip0++ {
+		for skip1 := skip0 + 1; skip1 < 7; skip1++ {
+			var h [5]Card
+			j := 0
+			for k := range c {
+				if k != skip0 && k != skip1 {
+					h[j] = c[k]
+					j++
+				}
+			}
+
+			if v := HandValue(h[0], h[1], h[2], h[3], h[4]); v < best {
+				best = v
+			}
+		}
+	}
+
+	return best
+}
+
+// bestFiveOfSevenLookup is BestFiveOfSeven using the lookup tables: knowing the score, it only has to search for a
+// hand that makes it.
+func bestFiveOfSevenLookup(c0, c1, c2, c3, c4, c5, c6 Card) ([]Card, int) {
+	score := SevenCardValue(c0, c1, c2, c3, c4, c5, c6)
+	base := [7]Card{c0, c1, c2, c3, c4, c5, c6}
+
+	for skip0 := 0; skip0 < 7; skip0++ {
+		for skip1 := skip0 + 1; skip1 < 7; skip1++ {
+			hand := make([]Card, 0, 5)
+			for k := range base {
+				if k != skip0 && k != skip1 {
+					hand = append(hand, base[k])
+				}
+			}
+
+			if HandValue(hand[0], hand[1], hand[2], hand[3], hand[4]) == score {
+				return hand, score
+			}
+		}
+	}
+
+	return nil, score
+}
@@ -0,0 +1,70 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSevenCardValue(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var d Deck
+
+	for i := 0; i < 100000; i++ {
+		d.Shuffle(r)
+		c := d[:7]
+
+		_, want := BestFiveOfSeven(c[0], c[1], c[2], c[3], c[4], c[5], c[6])
+		if got := SevenCardValue(c[0], c[1], c[2], c[3], c[4], c[5], c[6]); got != want {
+			t.Fatalf("Test failed - SevenCardValue(%v) = %d, BestFiveOfSeven scored it %d", c, got, want)
+		}
+	}
+}
+
+func TestUseLookupTables(t *testing.T) {
+	UseLookupTables()
+	defer func() { lookupInUse = false }()
+
+	r := rand.New(rand.NewSource(2))
+	var d Deck
+
+	for i := 0; i < 10000; i++ {
+		d.Shuffle(r)
+		c := d[:7]
+
+		hand, score := BestFiveOfSeven(c[0], c[1], c[2], c[3], c[4], c[5], c[6])
+		if len(hand) != 5 || HandValue(hand[0], hand[1], hand[2], hand[3], hand[4]) != score {
+			t.Fatalf("Test failed - BestFiveOfSeven(%v) returned %v, which doesn't score %d", c, hand, score)
+		}
+
+		lookupInUse = false
+		_, want := BestFiveOfSeven(c[0], c[1], c[2], c[3], c[4], c[5], c[6])
+		lookupInUse = true
+
+		if score != want {
+			t.Fatalf("Test failed - BestFiveOfSeven(%v) scored %d with the lookup tables, and %d without", c, score, want)
+		}
+	}
+}
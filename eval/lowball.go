@@ -0,0 +1,152 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "sort"
+
+// lowRank returns the rank of c for ace-to-five lowball: 1 for an ace, 2 for a deuce, and so on up to 13 for a king.
+func lowRank(c Card) int {
+	r := int((c >> 8) & 0xF)
+	return (r+1)%13 + 1
+}
+
+// LowHandValue takes five cards, and returns an integer representing their rank among all possible 5-card hands
+// under ace-to-five lowball rules (as used in Razz, and for the low half of hi-lo games): aces are low, and straights
+// and flushes don't count against a hand, but pairs do. Like HandValue, lower is better, so 5-4-3-2-A (the wheel)
+// scores lowest. The values are not contiguous, and can only be compared with other values from LowHandValue.
+//
+// WARNING: See the warning associated with HandValue.
+func LowHandValue(c0, c1, c2, c3, c4 Card) int {
+	var counts [14]int
+	for _, c := range [5]Card{c0, c1, c2, c3, c4} {
+		counts[lowRank(c)]++
+	}
+
+	// Order the ranks the way hands are compared: the largest group (e.g. the pair) first, then the highest rank
+	ranks := []int{}
+	for r := 13; r >= 1; r-- {
+		if counts[r] > 0 {
+			ranks = append(ranks, r)
+		}
+	}
+	sort.SliceStable(ranks, func(i, j int) bool {
+		return counts[ranks[i]] > counts[ranks[j]]
+	})
+
+	// Any pair is worse than every unpaired hand, two pair is worse than any one pair, and so on
+	var category int
+	switch counts[ranks[0]] {
+	case 1:
+		category = 0
+	case 2:
+		category = 1
+		if len(ranks) == 3 {
+			category = 2
+		}
+	case 3:
+		category = 3
+		if len(ranks) == 2 {
+			category = 4
+		}
+	default:
+		category = 5
+	}
+
+	value := category
+	for _, r := range ranks {
+		for i := 0; i < counts[r]; i++ {
+			value = value*14 + r
+		}
+	}
+
+	return value
+}
+
+// BestLowFiveOfSeven uses LowHandValue as an oracle to find the best ace-to-five low from the 7 cards passed in.
+// BestLowFiveOfSeven returns a slice of the 5 cards which make up the best low, and the score associated with that
+// hand (lower is better).
+//
+// WARNING: See the warning associated with HandValue.
+func BestLowFiveOfSeven(c0, c1, c2, c3, c4, c5, c6 Card) ([]Card, int) {
+	base := [7]Card{c0, c1, c2, c3, c4, c5, c6}
+	var bestHand []Card
+	bestScore := -1
+
+	// Every five card hand leaves out exactly two of the seven
+	for i := 0; i < 7; i++ {
+		for j := i + 1; j < 7; j++ {
+			hand := make([]Card, 0, 5)
+			for k := range base {
+				if k != i && k != j {
+					hand = append(hand, base[k])
+				}
+			}
+
+			score := LowHandValue(hand[0], hand[1], hand[2], hand[3], hand[4])
+			if bestScore < 0 || score < bestScore {
+				bestScore = score
+				bestHand = hand
+			}
+		}
+	}
+
+	return bestHand, bestScore
+}
+
+// QualifiesLow returns true if the ace-to-five low scored by LowHandValue has no pair, and no card higher than
+// limit, where limit is a lowball rank (1 for an ace up to 13 for a king). Hi-lo games typically only award the
+// low half of the pot to a hand that qualifies with a limit of 8 ("eight or better").
+func QualifiesLow(value int, limit int) bool {
+	// An unpaired value has category 0, so it's just the five ranks, highest first
+	if value >= 14*14*14*14*14 {
+		return false
+	}
+
+	return value/(14*14*14*14) <= limit
+}
+
+// DeuceToSevenValue takes five cards, and returns an integer representing their rank among all possible 5-card
+// hands under deuce-to-seven lowball rules (as used in 2-7 Triple Draw): the worst high hand is the best low, so aces
+// are high, and straights, flushes and pairs all count against a hand. Like HandValue, lower is better, so 7-5-4-3-2
+// unsuited scores lowest. The values are not contiguous, and can only be compared with other values from
+// DeuceToSevenValue.
+//
+// WARNING: See the warning associated with HandValue.
+func DeuceToSevenValue(c0, c1, c2, c3, c4 Card) int {
+	v := HandValue(c0, c1, c2, c3, c4)
+	if v != LastStraight && v != LastStraightFlush {
+		return 2 * (LastHighCard + 1 - v)
+	}
+
+	// 5-4-3-2-A isn't a straight, just an ace high hand, which is a little better than the same cards with the five
+	// made a six
+	cards := [5]Card{c0, c1, c2, c3, c4}
+	for i, c := range cards {
+		if (c>>8)&0xF == 3 {
+			cards[i] = Card((1 << (16 + 4)) | (int32(c) & 0xF000) | (4 << 8) | primeRanks[4])
+		}
+	}
+
+	return 2*(LastHighCard+1-HandValue(cards[0], cards[1], cards[2], cards[3], cards[4])) - 1
+}
@@ -0,0 +1,117 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "testing"
+
+func lowValue(t *testing.T, hand string) int {
+	var c [5]Card
+	for i := range c {
+		c[i] = MustParseCardString(hand[3*i : 3*i+2])
+	}
+
+	return LowHandValue(c[0], c[1], c[2], c[3], c[4])
+}
+
+func TestLowHandValue(t *testing.T) {
+	// Each hand is a better low than the next
+	order := []string{
+		"5c 4d 3h 2s Ac", // the wheel, even though it's a straight
+		"6c 4c 3c 2c Ac", // a flush doesn't count against it
+		"6d 5c 4d 3h 2s",
+		"8d 7c 6d 5h As",
+		"8d 7c 6d 5h 4s", // ace is low, so the previous hand is better
+		"Kd Qc Jd Th 9s",
+		"Ad Ac 2d 3h 4s", // any pair is worse than no pair
+		"2d 2c 3d 4h 5s",
+		"3d 3c 2d 4h 5s",
+		"Ad Ac 2d 2h 3s", // two pair is worse than one pair
+		"Ad Ac As 2h 3s",
+		"Ad Ac As 2h 2s",
+		"Ad Ac As Ah 2s",
+	}
+
+	for i := 0; i+1 < len(order); i++ {
+		if a, b := lowValue(t, order[i]), lowValue(t, order[i+1]); a >= b {
+			t.Errorf("Test failed - %s (%d) should be a better low than %s (%d)", order[i], a, order[i+1], b)
+		}
+	}
+}
+
+func TestBestLowFiveOfSeven(t *testing.T) {
+	c := []Card{}
+	for _, s := range []string{"Kd", "2c", "Ac", "9h", "3d", "As", "5s"} {
+		c = append(c, MustParseCardString(s))
+	}
+
+	hand, score := BestLowFiveOfSeven(c[0], c[1], c[2], c[3], c[4], c[5], c[6])
+	if want := lowValue(t, "9h 5s 3d 2c Ac"); score != want || len(hand) != 5 {
+		t.Errorf("Test failed - expected 9-5-3-2-A (%d), got %v (%d)", want, hand, score)
+	}
+
+	if QualifiesLow(score, 8) || !QualifiesLow(score, 9) {
+		t.Errorf("Test failed - 9-5-3-2-A should only qualify for a nine or better low")
+	}
+
+	if QualifiesLow(lowValue(t, "Ad Ac 2d 3h 4s"), 8) {
+		t.Errorf("Test failed - a paired hand should never qualify")
+	}
+}
+
+func TestDeuceToSevenValue(t *testing.T) {
+	value := func(hand string) int {
+		var c [5]Card
+		for i := range c {
+			c[i] = MustParseCardString(hand[3*i : 3*i+2])
+		}
+
+		return DeuceToSevenValue(c[0], c[1], c[2], c[3], c[4])
+	}
+
+	// Each hand is a better low than the next
+	order := []string{
+		"7c 5d 4h 3s 2c", // the nuts
+		"7c 6d 4h 3s 2c",
+		"8c 5d 4h 3s 2c",
+		"Kd Qc Jd Th 8s",
+		"Ac 5d 4h 3s 2c", // not a straight, just ace high
+		"Ac 6d 4h 3s 2c",
+		"Ad Kc Qd Jh 9s",
+		"2d 2c 3d 4h 5s", // any pair is worse than no pair
+		"6c 5d 4h 3s 2c", // straights count
+		"Ad Kc Qc Jc Tc",
+		"8c 7c 5c 4c 2c", // flushes count
+		"Ac 5c 4c 3c 2c", // a suited wheel is just an ace high flush
+		"Ac 6c 4c 3c 2c",
+		"Ad Ac As Ah Ks",
+		"6c 5c 4c 3c 2c",
+		"Ac Kc Qc Jc Tc",
+	}
+
+	for i := 0; i+1 < len(order); i++ {
+		if a, b := value(order[i]), value(order[i+1]); a >= b {
+			t.Errorf("Test failed - %s (%d) should be a better deuce-to-seven low than %s (%d)", order[i], a, order[i+1], b)
+		}
+	}
+}
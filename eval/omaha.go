@@ -0,0 +1,81 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+// BestOmahaHand uses HandValue as an oracle to find the optimal Omaha hand: exactly two of the four hole cards, and
+// exactly three of the five board cards. BestOmahaHand returns a slice of the 5 cards which make up the best hand,
+// and the score associated with that hand (lower is better).
+//
+// WARNING: See the warning associated with HandValue.
+func BestOmahaHand(hole [4]Card, board [5]Card) ([]Card, int) {
+	var bestHand []Card
+	bestScore := LastHighCard + 1 // larger value than the worst hand, so the first real hand will always be better
+
+	for h0 := 0; h0 < 4; h0++ {
+		for h1 := h0 + 1; h1 < 4; h1++ {
+			for b0 := 0; b0 < 5; b0++ {
+				for b1 := b0 + 1; b1 < 5; b1++ {
+					for b2 := b1 + 1; b2 < 5; b2++ {
+						score := HandValue(hole[h0], hole[h1], board[b0], board[b1], board[b2])
+						if score < bestScore {
+							bestScore = score
+							bestHand = []Card{hole[h0], hole[h1], board[b0], board[b1], board[b2]}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return bestHand, bestScore
+}
+
+// BestLowOmahaHand uses LowHandValue as an oracle to find the best ace-to-five Omaha low: exactly two of the four hole
+// cards, and exactly three of the five board cards. BestLowOmahaHand returns a slice of the 5 cards which make up the
+// best low, and the score associated with that hand (lower is better). Whether the low qualifies is up to the caller
+// (see QualifiesLow).
+//
+// WARNING: See the warning associated with HandValue.
+func BestLowOmahaHand(hole [4]Card, board [5]Card) ([]Card, int) {
+	var bestHand []Card
+	bestScore := -1
+
+	for h0 := 0; h0 < 4; h0++ {
+		for h1 := h0 + 1; h1 < 4; h1++ {
+			for b0 := 0; b0 < 5; b0++ {
+				for b1 := b0 + 1; b1 < 5; b1++ {
+					for b2 := b1 + 1; b2 < 5; b2++ {
+						score := LowHandValue(hole[h0], hole[h1], board[b0], board[b1], board[b2])
+						if bestScore < 0 || score < bestScore {
+							bestScore = score
+							bestHand = []Card{hole[h0], hole[h1], board[b0], board[b1], board[b2]}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return bestHand, bestScore
+}
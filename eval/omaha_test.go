@@ -0,0 +1,103 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBestOmahaHand(t *testing.T) {
+	tests := []struct {
+		hole  string
+		board string
+		want  string
+	}{
+		// Four spades in hand and one on board is not a flush
+		{"As Ks Qs Js", "Ts 2c 3d 7h 8c", "Ace High"},
+		// Four to a flush on board needs two of the suit in hand
+		{"Ah 2c 3d 4s", "Kh Qh Jh 9h 5c", "Ace High"},
+		{"Ah 8h 3d 4s", "Kh Qh Jh 9h 5c", "Ace-high Flush"},
+		{"Ah Th 3d 4s", "Kh Qh Jh 2c 5c", "Royal Flush"},
+		// Trips on board only play with a pair in hand
+		{"Ac Kd 2h 3s", "7c 7d 7h Qs 9c", "Three of a Kind, Sevens"},
+		{"Ac Ad 2h 3s", "7c 7d 7h Qs 9c", "Full House, Sevens full of Aces"},
+		// Quads in hand only count as a pair
+		{"Kc Kd Kh Ks", "2c 5d 8h Js 3c", "Pair of Kings"},
+	}
+
+	for _, tt := range tests {
+		var hole [4]Card
+		var board [5]Card
+		for i, s := range strings.Fields(tt.hole) {
+			hole[i] = MustParseCardString(s)
+		}
+		for i, s := range strings.Fields(tt.board) {
+			board[i] = MustParseCardString(s)
+		}
+
+		cards, score := BestOmahaHand(hole, board)
+		if got := DescribeHand(cards); got != tt.want {
+			t.Errorf("Test failed - BestOmahaHand(%s, %s) = %q, want %q", tt.hole, tt.board, got, tt.want)
+		}
+		if score != HandValue(cards[0], cards[1], cards[2], cards[3], cards[4]) {
+			t.Errorf("Test failed - BestOmahaHand(%s, %s) returned a score that doesn't match its hand", tt.hole, tt.board)
+		}
+	}
+}
+
+func TestBestLowOmahaHand(t *testing.T) {
+	tests := []struct {
+		hole    string
+		board   string
+		want    string
+		qualify bool
+	}{
+		// Only two of the four low cards in hand play
+		{"Ac 2d 3h 4s", "Kc Qd 7h 8s 9c", "9c 8s 7h 2d Ac", false},
+		{"Ac 2d 3h 4s", "Kc 5d 7h 8s 9c", "7h 5d 2d Ac 8s", true},
+		// A low board needs two unpaired low cards in hand
+		{"Ac Ad Kh Ks", "2c 3d 4h 5s 6c", "4h 3d 2c Ac Kh", false},
+		{"Ac 7d Kh Ks", "2c 3d 4h 5s 6c", "4h 3d 2c Ac 7d", true},
+	}
+
+	for _, tt := range tests {
+		var hole [4]Card
+		var board [5]Card
+		for i, s := range strings.Fields(tt.hole) {
+			hole[i] = MustParseCardString(s)
+		}
+		for i, s := range strings.Fields(tt.board) {
+			board[i] = MustParseCardString(s)
+		}
+
+		cards, score := BestLowOmahaHand(hole, board)
+		if want := lowValue(t, tt.want); score != want || len(cards) != 5 {
+			t.Errorf("Test failed - BestLowOmahaHand(%s, %s) = %v (%d), want %s (%d)", tt.hole, tt.board, cards, score, tt.want, want)
+		}
+		if QualifiesLow(score, 8) != tt.qualify {
+			t.Errorf("Test failed - BestLowOmahaHand(%s, %s) should qualify for an eight or better low: %t", tt.hole, tt.board, tt.qualify)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "errors"
+
+// ErrBadOuts is the error returned by Outs if it isn't passed two hole cards and a flop or turn, or is passed the
+// same card twice
+var ErrBadOuts = errors.New("outs: need two hole cards, three or four board cards, and no duplicates")
+
+// Outs finds the cards that would improve a Texas Hold'em hand to a better Category if they came next. It returns
+// the outs grouped by the Category they make the hand, so on a flush draw, the Flush entry holds the remaining cards
+// of the suit. Cards that make a better hand within the hand's current Category (like a better kicker) aren't outs.
+// Cards that only improve the board aren't outs either. The board must be a flop or a turn.
+func Outs(hole []Card, board []Card) (map[Category][]Card, error) {
+	if len(hole) != 2 || len(board) < 3 || len(board) > 4 {
+		return nil, ErrBadOuts
+	}
+
+	cards := append(append([]Card{}, hole...), board...)
+
+	used := make(map[Card]bool)
+	for _, c := range cards {
+		if used[c] {
+			return nil, ErrBadOuts
+		}
+		used[c] = true
+	}
+
+	current := HandCategory(bestScore(cards))
+
+	outs := make(map[Category][]Card)
+	for _, c := range DefaultDeck {
+		if used[c] {
+			continue
+		}
+
+		// A card that just improves the board, like one that pairs it, improves every player's hand, so it isn't an out
+		cat := HandCategory(bestScore(append(cards, c)))
+		if cat > current && cat > boardCategory(append(board[:len(board):len(board)], c)) {
+			outs[cat] = append(outs[cat], c)
+		}
+	}
+
+	return outs, nil
+}
+
+// boardCategory returns the Category of the best hand on board alone, which may have four or five cards
+func boardCategory(board []Card) Category {
+	if len(board) >= 5 {
+		return HandCategory(bestScore(board))
+	}
+
+	// Without five cards, only rank matches count
+	var ranks [13]int
+	pairs := 0
+	cat := HighCard
+	for _, c := range board {
+		ranks[(c>>8)&0xF]++
+		switch ranks[(c>>8)&0xF] {
+		case 2:
+			pairs++
+		case 3:
+			cat = ThreeOfAKind
+		case 4:
+			cat = FourOfAKind
+		}
+	}
+
+	if cat == HighCard && pairs > 0 {
+		cat = Pair
+		if pairs > 1 {
+			cat = TwoPair
+		}
+	}
+
+	return cat
+}
+
+// bestScore returns the score of the best five card hand among five to seven cards
+func bestScore(c []Card) int {
+	switch len(c) {
+	case 5:
+		return HandValue(c[0], c[1], c[2], c[3], c[4])
+	case 6:
+		_, score := BestFiveOfSix(c[0], c[1], c[2], c[3], c[4], c[5])
+		return score
+	default:
+		_, score := BestFiveOfSeven(c[0], c[1], c[2], c[3], c[4], c[5], c[6])
+		return score
+	}
+}
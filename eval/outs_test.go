@@ -0,0 +1,67 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestOuts(t *testing.T) {
+	cards := func(s string) []Card {
+		ret := []Card{}
+		for _, f := range strings.Fields(s) {
+			ret = append(ret, MustParseCardString(f))
+		}
+		return ret
+	}
+
+	// An open-ended straight draw and a flush draw: nine flush outs, six more for the straight (the Jh and 6h are
+	// already counted as flush outs), and six to pair a hole card
+	outs, err := Outs(cards("9h 8h"), cards("7h 2h Tc"))
+	if err != nil {
+		t.Fatalf("Test failed - unexpected error %v", err)
+	}
+	if len(outs[Flush]) != 9 || len(outs[Straight]) != 6 || len(outs[Pair]) != 6 || len(outs) != 3 {
+		t.Errorf("Test failed - expected 9 flush, 6 straight, and 6 pair outs, got %v", outs)
+	}
+
+	// Two overcards to a rainbow board have six outs to a pair, and pairing the board isn't an out
+	outs, _ = Outs(cards("Ac Kd"), cards("7h 2s 9c 4d"))
+	if len(outs) != 1 || len(outs[Pair]) != 6 {
+		t.Errorf("Test failed - expected only 6 outs to a pair, got %v", outs)
+	}
+
+	// A set has outs to a full house and quads
+	outs, _ = Outs(cards("7c 7d"), cards("7h 2s 9c"))
+	if len(outs[FullHouse]) != 6 || len(outs[FourOfAKind]) != 1 || len(outs) != 2 {
+		t.Errorf("Test failed - expected 6 full house outs and 1 quads out, got %v", outs)
+	}
+
+	for _, bad := range [][2]string{{"Ac", "7h 2s 9c"}, {"Ac Kd", "7h 2s"}, {"Ac Kd", "7h 2s 9c 4d 5d"}, {"Ac Kd", "Ac 2s 9c"}} {
+		if _, err := Outs(cards(bad[0]), cards(bad[1])); err != ErrBadOuts {
+			t.Errorf("Test failed - expected ErrBadOuts for %s on %s, got %v", bad[0], bad[1], err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+// ShortDeck is the 36 card deck used for short deck (6+) hold'em: DefaultDeck without the deuces through fives.
+var ShortDeck Deck
+
+func init() {
+	for _, c := range DefaultDeck {
+		// Ranks are deuce=0 through ace=12, so six is 4
+		if (c>>8)&0xF >= 4 {
+			ShortDeck.Push(c)
+		}
+	}
+}
+
+// The ranks (as the rank bits of a Card) of A-9-8-7-6, which is the lowest straight in short deck
+const shortDeckWheel = (1 << 12) | (1 << 7) | (1 << 6) | (1 << 5) | (1 << 4)
+
+// ShortDeckHandValue takes five cards from ShortDeck, and returns an integer [1, 7462] representing their rank
+// among all possible 5-card hands under short deck rules. Lower is better, as with HandValue, and the values are
+// the same as HandValue's, except that:
+//
+// - a flush beats a full house, and
+//
+// - A-9-8-7-6 is a straight (the lowest one), and if suited, a straight flush (the lowest one).
+//
+// WARNING: See the warning associated with HandValue.
+func ShortDeckHandValue(c0, c1, c2, c3, c4 Card) int {
+	// Only a hand of five distinct ranks can be a wheel
+	if (c0|c1|c2|c3|c4)>>16 == shortDeckWheel {
+		if (c0 & c1 & c2 & c3 & c4 & 0xF000) != 0 {
+			return LastStraightFlush
+		}
+		return LastStraight
+	}
+
+	v := HandValue(c0, c1, c2, c3, c4)
+
+	// Swap the full houses and the flushes, keeping the order within each category
+	const fullHouses = LastFullHouse - LastFourOfAKind
+	const flushes = LastFlush - LastFullHouse
+
+	switch {
+	case v > LastFourOfAKind && v <= LastFullHouse:
+		return v + flushes
+	case v > LastFullHouse && v <= LastFlush:
+		return v - fullHouses
+	}
+
+	return v
+}
+
+// ShortDeckBestFiveOfSeven uses ShortDeckHandValue as an oracle to find the optimal combination of 5 cards from the
+// 7 passed in. ShortDeckBestFiveOfSeven returns a slice of the 5 cards which make up the best hand, and the score
+// associated with that hand (lower is better).
+//
+// WARNING: See the warning associated with HandValue.
+func ShortDeckBestFiveOfSeven(c0, c1, c2, c3, c4, c5, c6 Card) ([]Card, int) {
+	base := [7]Card{c0, c1, c2, c3, c4, c5, c6}
+	var bestHand []Card
+	bestScore := 8000 // larger value than the worst hand, so the first real hand will always be better
+
+	// Every five card hand leaves out exactly two of the seven
+	for i := 0; i < 7; i++ {
+		for j := i + 1; j < 7; j++ {
+			hand := make([]Card, 0, 5)
+			for k := range base {
+				if k != i && k != j {
+					hand = append(hand, base[k])
+				}
+			}
+
+			score := ShortDeckHandValue(hand[0], hand[1], hand[2], hand[3], hand[4])
+			if score < bestScore {
+				bestScore = score
+				bestHand = hand
+			}
+		}
+	}
+
+	return bestHand, bestScore
+}
@@ -0,0 +1,79 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "testing"
+
+func shortDeckValue(hand string) int {
+	var c [5]Card
+	for i := range c {
+		c[i] = MustParseCardString(hand[3*i : 3*i+2])
+	}
+
+	return ShortDeckHandValue(c[0], c[1], c[2], c[3], c[4])
+}
+
+func TestShortDeck(t *testing.T) {
+	if len(ShortDeck) != 36 {
+		t.Errorf("Test failed - the short deck should have 36 cards, got %d", len(ShortDeck))
+	}
+
+	for _, c := range ShortDeck {
+		if r := (c >> 8) & 0xF; r < 4 {
+			t.Errorf("Test failed - the short deck should not contain %s", c)
+		}
+	}
+}
+
+func TestShortDeckHandValue(t *testing.T) {
+	// Each hand is better than the next
+	order := []string{
+		"Th 9h 8h 7h 6h",
+		"Ah 9h 8h 7h 6h", // the lowest straight flush
+		"6c 6d 6h 6s Ac",
+		"Ah Kh 9h 8h 6h", // a flush beats a full house
+		"7h 9h 8h 6h Jh",
+		"Ac Ad Ah Kc Kd",
+		"6c 6d 6h 7c 7d",
+		"Tc 9d 8h 7c 6d",
+		"Ac 9d 8h 7c 6d", // the lowest straight
+		"Ac Ad Ah Kc Qd",
+		"Ac Kd Qh Jc 9d",
+	}
+
+	for i := 0; i+1 < len(order); i++ {
+		if a, b := shortDeckValue(order[i]), shortDeckValue(order[i+1]); a >= b {
+			t.Errorf("Test failed - %s (%d) should beat %s (%d)", order[i], a, order[i+1], b)
+		}
+	}
+
+	c := []Card{}
+	for _, s := range []string{"Ac", "9d", "Ks", "7c", "6d", "8h", "Ts"} {
+		c = append(c, MustParseCardString(s))
+	}
+
+	if _, score := ShortDeckBestFiveOfSeven(c[0], c[1], c[2], c[3], c[4], c[5], c[6]); score != shortDeckValue("Tc 9d 8h 7c 6d") {
+		t.Errorf("Test failed - the best hand should be the ten high straight, got %d", score)
+	}
+}
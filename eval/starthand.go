@@ -0,0 +1,104 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "strings"
+
+const startingHandRankChars = "23456789TJQKA"
+
+// startingHandRanks holds each kind of starting hand's rank, from 1. Pairs are at [r][r], suited hands at [hi][lo],
+// and offsuit hands at [lo][hi].
+var startingHandRanks [13][13]int
+
+// startingHandWorse holds how many of the 1326 combos of starting hands rank below each rank
+var startingHandWorse [170]int
+
+//go:generate go run ./cmd/buildstartinghands/buildstartinghands.go
+func init() {
+	for i, name := range startingHandOrder {
+		hi := strings.IndexByte(startingHandRankChars, name[0])
+		lo := strings.IndexByte(startingHandRankChars, name[1])
+		if len(name) == 3 && name[2] == 'o' {
+			hi, lo = lo, hi
+		}
+		startingHandRanks[hi][lo] = i + 1
+	}
+
+	for rank := len(startingHandOrder); rank > 1; rank-- {
+		startingHandWorse[rank-1] = startingHandWorse[rank] + startingHandCombos(startingHandOrder[rank-1])
+	}
+}
+
+// startingHandCombos returns how many combos of cards make the named kind of starting hand
+func startingHandCombos(name string) int {
+	switch {
+	case len(name) == 2:
+		return 6
+	case name[2] == 's':
+		return 4
+	default:
+		return 12
+	}
+}
+
+// startingHandIndex returns where c0 and c1's kind of starting hand is in startingHandRanks
+func startingHandIndex(c0, c1 Card) (int, int) {
+	hi, lo := int((c0>>8)&0xF), int((c1>>8)&0xF)
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+	if c0&c1&0xF000 == 0 {
+		return lo, hi
+	}
+
+	return hi, lo
+}
+
+// StartingHand returns the name of the kind of Texas Hold'em starting hand that c0 and c1 make, in the usual
+// notation: "QQ" for a pair, "AKs" for suited cards, and "T9o" for offsuit cards.
+func StartingHand(c0, c1 Card) string {
+	return startingHandOrder[StartingHandRank(c0, c1)-1]
+}
+
+// StartingHandRank ranks the Texas Hold'em starting hand that c0 and c1 make among the 169 kinds of starting hand,
+// from 1 (a pair of aces) to 169 (three-deuce offsuit). The ranking is by all-in equity against a random hand (see
+// StartingHandEquity), which is a simple and widely used measure, but doesn't account for how well a hand plays
+// after the flop.
+func StartingHandRank(c0, c1 Card) int {
+	hi, lo := startingHandIndex(c0, c1)
+	return startingHandRanks[hi][lo]
+}
+
+// StartingHandEquity returns the all-in equity of the Texas Hold'em starting hand that c0 and c1 make against a random
+// hand, as the fraction of the pot it wins on average. The equities are precomputed estimates, accurate to about
+// 0.1%.
+func StartingHandEquity(c0, c1 Card) float64 {
+	return startingHandEquity[StartingHandRank(c0, c1)-1]
+}
+
+// StartingHandPercentile returns the fraction of all 1326 possible Texas Hold'em starting hands that rank below the
+// one c0 and c1 make (see StartingHandRank), so a pair of aces is about 0.995, and three-deuce offsuit is 0.
+func StartingHandPercentile(c0, c1 Card) float64 {
+	return float64(startingHandWorse[StartingHandRank(c0, c1)]) / 1326
+}
@@ -0,0 +1,92 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "testing"
+
+func TestStartingHand(t *testing.T) {
+	hand := func(s string) (Card, Card) {
+		return MustParseCardString(s[:2]), MustParseCardString(s[2:])
+	}
+
+	tests := []struct {
+		cards string
+		name  string
+	}{
+		{"AsAh", "AA"},
+		{"KdAd", "AKs"},
+		{"AcKd", "AKo"},
+		{"9hTc", "T9o"},
+		{"3c2d", "32o"},
+	}
+
+	for _, tt := range tests {
+		if got := StartingHand(hand(tt.cards)); got != tt.name {
+			t.Errorf("Test failed - StartingHand(%s) = %q, want %q", tt.cards, got, tt.name)
+		}
+	}
+
+	if r := StartingHandRank(hand("AsAh")); r != 1 {
+		t.Errorf("Test failed - aces should rank first, got %d", r)
+	}
+	if r := StartingHandRank(hand("3c2d")); r != 169 {
+		t.Errorf("Test failed - three-deuce offsuit should rank last, got %d", r)
+	}
+
+	// Each hand is better than the next
+	order := []string{"AsAh", "KsKh", "TsTh", "AsKs", "AsKh", "Ts9s", "Ts9h", "7s2h", "3s2h"}
+	for i := 0; i+1 < len(order); i++ {
+		a, b := order[i], order[i+1]
+		if StartingHandRank(hand(a)) >= StartingHandRank(hand(b)) {
+			t.Errorf("Test failed - %s should rank above %s", a, b)
+		}
+		if StartingHandEquity(hand(a)) <= StartingHandEquity(hand(b)) || StartingHandPercentile(hand(a)) <= StartingHandPercentile(hand(b)) {
+			t.Errorf("Test failed - %s should have more equity and a higher percentile than %s", a, b)
+		}
+	}
+
+	if p := StartingHandPercentile(hand("AsAh")); p != 1320.0/1326 {
+		t.Errorf("Test failed - aces should be better than all but 6 combos, got %f", p)
+	}
+	if p := StartingHandPercentile(hand("3s2h")); p != 0 {
+		t.Errorf("Test failed - three-deuce offsuit should be better than nothing, got %f", p)
+	}
+
+	if e := StartingHandEquity(hand("AsAh")); e < 0.85 || e > 0.855 {
+		t.Errorf("Test failed - aces should have about 85%% equity against a random hand, got %f", e)
+	}
+
+	// Every kind of starting hand is ranked exactly once
+	seen := map[int]bool{}
+	for _, c0 := range DefaultDeck {
+		for _, c1 := range DefaultDeck {
+			if c0 != c1 {
+				seen[StartingHandRank(c0, c1)] = true
+			}
+		}
+	}
+	if len(seen) != 169 || seen[0] {
+		t.Errorf("Test failed - expected all 169 ranks to be used, got %d", len(seen))
+	}
+}
@@ -0,0 +1,38 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated by automatically at 2026-10-15 17:13:41.693872939 +0000 UTC m=+156.951498305
+
+package eval
+
+// startingHandOrder lists the 169 kinds of Texas Hold'em starting hand from best to worst, and startingHandEquity
+// their all-in equity against a random hand, each estimated from 3000000 random runouts
+var startingHandOrder = []string{
+	"AA", "KK", "QQ", "JJ", "TT", "99", "88", "AKs", "77", "AQs", "AJs", "AKo", "ATs",
+	"AQo", "AJo", "KQs", "66", "ATo", "A9s", "KJs", "A8s", "KTs", "KQo", "A7s", "A9o", "KJo",
+	"55", "QJs", "K9s", "A5s", "A6s", "A8o", "KTo", "QTs", "A4s", "A7o", "K8s", "A3s", "QJo",
+	"K9o", "A6o", "A5o", "Q9s", "K7s", "JTs", "A2s", "QTo", "44", "A4o", "K6s", "K8o", "Q8s",
+	"A3o", "K5s", "J9s", "Q9o", "JTo", "K7o", "A2o", "K4s", "Q7s", "K6o", "T9s", "K3s", "J8s",
+	"33", "Q6s", "Q8o", "K5o", "J9o", "K2s", "Q5s", "J7s", "K4o", "T8s", "Q4s", "Q7o", "T9o",
+	"J8o", "K3o", "Q3s", "Q6o", "98s", "T7s", "J6s", "K2o", "22", "Q2s", "Q5o", "J5s", "T8o",
+	"J7o", "97s", "Q4o", "J4s", "T6s", "J3s", "Q3o", "98o", "T7o", "87s", "J6o", "96s", "J2s",
+	"Q2o", "J5o", "T5s", "T4s", "97o", "86s", "J4o", "T6o", "T3s", "95s", "76s", "J3o", "87o",
+	"T2s", "85s", "96o", "J2o", "T5o", "94s", "75s", "T4o", "93s", "86o", "65s", "95o", "84s",
+	"T3o", "92s", "76o", "74s", "T2o", "54s", "85o", "64s", "83s", "94o", "75o", "82s", "73s",
+	"93o", "65o", "53s", "63s", "84o", "92o", "43s", "74o", "72s", "54o", "64o", "52s", "62s",
+	"83o", "42s", "82o", "73o", "53o", "63o", "32s", "43o", "72o", "52o", "62o", "42o", "32o",
+}
+
+var startingHandEquity = []float64{
+	0.8523, 0.8244, 0.7993, 0.7745, 0.7501, 0.7207, 0.6919, 0.6708, 0.6620, 0.6619, 0.6535, 0.6532, 0.6460,
+	0.6441, 0.6356, 0.6337, 0.6329, 0.6275, 0.6274, 0.6256, 0.6193, 0.6179, 0.6150, 0.6101, 0.6073, 0.6059,
+	0.6034, 0.6026, 0.6003, 0.5996, 0.5991, 0.5986, 0.5978, 0.5945, 0.5904, 0.5882, 0.5831, 0.5824, 0.5815,
+	0.5783, 0.5770, 0.5769, 0.5766, 0.5756, 0.5747, 0.5735, 0.5731, 0.5700, 0.5670, 0.5661, 0.5606, 0.5597,
+	0.5578, 0.5573, 0.5567, 0.5536, 0.5528, 0.5522, 0.5497, 0.5489, 0.5427, 0.5421, 0.5408, 0.5406, 0.5404,
+	0.5371, 0.5361, 0.5358, 0.5333, 0.5326, 0.5324, 0.5275, 0.5231, 0.5230, 0.5226, 0.5186, 0.5177, 0.5159,
+	0.5150, 0.5139, 0.5102, 0.5099, 0.5079, 0.5064, 0.5063, 0.5049, 0.5033, 0.5015, 0.5009, 0.4998, 0.4971,
+	0.4969, 0.4913, 0.4909, 0.4906, 0.4894, 0.4824, 0.4819, 0.4812, 0.4794, 0.4793, 0.4787, 0.4743, 0.4738,
+	0.4723, 0.4720, 0.4718, 0.4652, 0.4630, 0.4623, 0.4617, 0.4609, 0.4572, 0.4571, 0.4536, 0.4531, 0.4510,
+	0.4484, 0.4452, 0.4448, 0.4438, 0.4424, 0.4385, 0.4368, 0.4354, 0.4330, 0.4319, 0.4314, 0.4267, 0.4266,
+	0.4262, 0.4239, 0.4233, 0.4182, 0.4163, 0.4151, 0.4147, 0.4136, 0.4087, 0.4062, 0.4053, 0.4026, 0.4008,
+	0.4002, 0.3996, 0.3968, 0.3954, 0.3941, 0.3914, 0.3866, 0.3856, 0.3816, 0.3813, 0.3801, 0.3785, 0.3763,
+	0.3752, 0.3683, 0.3680, 0.3660, 0.3632, 0.3605, 0.3602, 0.3511, 0.3462, 0.3428, 0.3405, 0.3319, 0.3234,
+}
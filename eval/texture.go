@@ -0,0 +1,89 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+// BoardTexture describes the community cards in the terms players use to talk about how a board plays.
+type BoardTexture struct {
+	// Paired is true if at least two board cards share a rank.
+	Paired bool
+	// Monotone is true if there are at least three board cards, all of one suit.
+	Monotone bool
+	// TwoTone is true if the board cards are of exactly two suits.
+	TwoTone bool
+	// Rainbow is true if no two board cards share a suit.
+	Rainbow bool
+	// FlushPossible is true if at least three board cards share a suit, so a player can hold a flush.
+	FlushPossible bool
+	// StraightPossible is true if at least three board cards fit in one straight, so a player can hold a straight.
+	StraightPossible bool
+	// Connectedness is the most distinct board ranks that fit in one straight (counting an ace as either high or
+	// low). A higher number means more straights and straight draws are possible.
+	Connectedness int
+}
+
+// Texture returns the BoardTexture of board, which may have any number of cards.
+func Texture(board []Card) BoardTexture {
+	var ranks [13]int
+	suits := make(map[Card]int)
+	for _, c := range board {
+		ranks[(c>>8)&0xF]++
+		suits[c&0xF000]++
+	}
+
+	var t BoardTexture
+	for _, n := range ranks {
+		if n > 1 {
+			t.Paired = true
+		}
+	}
+
+	for _, n := range suits {
+		if n >= 3 {
+			t.FlushPossible = true
+		}
+	}
+
+	t.Monotone = len(suits) == 1 && len(board) >= 3
+	t.TwoTone = len(suits) == 2
+	t.Rainbow = len(suits) == len(board) && len(board) > 0
+
+	// Each straight is five consecutive ranks, from 5-4-3-2-A (with the ace low) up to A-K-Q-J-T
+	for top := 3; top < 13; top++ {
+		n := 0
+		for r := top - 4; r <= top; r++ {
+			// Rank -1 is the ace, playing low
+			if (r >= 0 && ranks[r] > 0) || (r < 0 && ranks[12] > 0) {
+				n++
+			}
+		}
+
+		if n > t.Connectedness {
+			t.Connectedness = n
+		}
+	}
+
+	t.StraightPossible = t.Connectedness >= 3
+
+	return t
+}
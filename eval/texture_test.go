@@ -0,0 +1,53 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package eval
+
+import "testing"
+
+func TestTexture(t *testing.T) {
+	tests := []struct {
+		board string
+		want  BoardTexture
+	}{
+		{"", BoardTexture{}},
+		{"Ac 7d 2h", BoardTexture{Rainbow: true, Connectedness: 2}},
+		{"Ah 4d 5c", BoardTexture{Rainbow: true, StraightPossible: true, Connectedness: 3}},
+		{"Kh 8h 8c", BoardTexture{Paired: true, TwoTone: true, Connectedness: 1}},
+		{"Js Ts 9s", BoardTexture{Monotone: true, FlushPossible: true, StraightPossible: true, Connectedness: 3}},
+		{"Js Ts 9s 8d", BoardTexture{TwoTone: true, FlushPossible: true, StraightPossible: true, Connectedness: 4}},
+		{"Qc Qd 2h 2s", BoardTexture{Paired: true, Rainbow: true, Connectedness: 1}},
+		{"Ac Kd Th 3s 9c", BoardTexture{StraightPossible: true, Connectedness: 3}},
+	}
+
+	for _, tt := range tests {
+		board := []Card{}
+		for i := 0; i+1 < len(tt.board); i += 3 {
+			board = append(board, MustParseCardString(tt.board[i:i+2]))
+		}
+
+		if got := Texture(board); got != tt.want {
+			t.Errorf("Test failed - Texture(%s) = %+v, want %+v", tt.board, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// EventKind is the type of an Event
+type EventKind uint8
+
+const (
+	// EventBuyIn is recorded when a player buys in. Amount is the amount bought.
+	EventBuyIn EventKind = iota + 1
+	// EventReady is recorded when a player is marked ready.
+	EventReady
+	// EventNotReady is recorded when a player is marked not ready.
+	EventNotReady
+	// EventLeave is recorded when a player leaves the game. If they were removed (see Game.RemovePlayer), Amount is
+	// the stack they were credited back.
+	EventLeave
+	// EventHandStart is recorded when a new hand is dealt. PlayerNum is the dealer.
+	EventHandStart
+	// EventHoleCards is recorded once per player dealt into a hand. Cards are the player's hole cards.
+	EventHoleCards
+	// EventBlind is recorded when a player posts a blind, or in stud, the bring-in. Amount is the amount actually
+	// posted.
+	EventBlind
+	// EventBet is recorded when a player checks, calls, bets or raises. Amount is the amount put in
+	// (so a check is 0).
+	EventBet
+	// EventFold is recorded when a player folds.
+	EventFold
+	// EventCommunityCards is recorded when the flop, turn, or river is dealt. Stage is the stage
+	// being entered, and Cards are the newly dealt cards.
+	EventCommunityCards
+	// EventShow is recorded when a player shows their hand at showdown. Cards are the hole cards shown.
+	EventShow
+	// EventMuck is recorded when a player mucks their hand at showdown.
+	EventMuck
+	// EventPotAward is recorded once per player per pot won. PotNum is the index of the pot, and
+	// Amount is the player's share. In hi-lo games, it is recorded separately for each half of the pot
+	// the player wins, and Low is true for the low half.
+	EventPotAward
+	// EventHandEnd is recorded when a hand is over, after every pot has been awarded.
+	EventHandEnd
+	// EventEmote is recorded when a player makes a table gesture. Emote is the gesture made.
+	EventEmote
+	// EventDeadChips is recorded when a player posts dead chips. Amount is the amount posted.
+	EventDeadChips
+	// EventAway is recorded when a player is marked away.
+	EventAway
+	// EventBack is recorded when a player who was away comes back.
+	EventBack
+	// EventDiscard is recorded when a player discards a hole card. Cards holds the discarded card.
+	EventDiscard
+	// EventVariant is recorded when a mixed game rotates to its next variant (see GameConfig.Rotation). Variant
+	// holds the variant now being dealt.
+	EventVariant
+	// EventRematchOffer is recorded when the players of a heads-up match that just ended are offered a rematch.
+	EventRematchOffer
+	// EventRematchAccept is recorded when a player accepts a rematch.
+	EventRematchAccept
+	// EventRematch is recorded when both players have accepted, and the rematch begins. Amount holds the stack
+	// each of them starts it with.
+	EventRematch
+	// EventSitOut is recorded when a player sits out.
+	EventSitOut
+	// EventSitIn is recorded when a player who was sitting out sits back in.
+	EventSitIn
+	// EventTimeout is recorded when a player runs out of time to act (see Timeout).
+	EventTimeout
+	// EventSeat is recorded when a player changes seats. Amount is the seat they moved to.
+	EventSeat
+	// EventBurn is recorded when a card is burned, before the community cards of a street are dealt (see
+	// GameConfig.BurnCards). Stage is the stage being entered, and Cards holds the burned card.
+	EventBurn
+	// EventShowCards is recorded when a player turns hole cards face up between hands (see ShowCards). Cards holds
+	// the cards shown.
+	EventShowCards
+	// EventUndo is recorded when a player takes back their last Bet or Fold (see Undo). The Events recorded by the
+	// action taken back are kept.
+	EventUndo
+	// EventMisdeal is recorded when a hand is called off as a misdeal (see Misdeal). Stage is the stage the hand had
+	// reached, and PlayerNum is the dealer who called it.
+	EventMisdeal
+	// EventPause is recorded when the Game is paused, and EventResume when it is resumed (see Game.Pause).
+	EventPause
+	EventResume
+	// EventSevenDeuce is recorded, after the pots are awarded, for each player who collects the seven-deuce bounty
+	// (see GameConfig.SevenDeuceBounty). Amount is the total collected, and Cards holds the seven-deuce.
+	EventSevenDeuce
+	// EventHighHand is recorded when a hand shown down takes the lead in the high-hand promotion (see
+	// GameConfig.HighHandQualifier). Cards holds the five card hand.
+	EventHighHand
+	// EventStraddle is recorded when the player on the button announces a straddle for the next hand (Amount is 1),
+	// or takes it back (Amount is 0). The straddle itself is recorded as an EventBlind when the hand is dealt.
+	EventStraddle
+	// EventPostMissed is recorded when a player who has missed blinds chooses to post them for the next hand (Amount
+	// is 1), or to wait for the big blind (Amount is 0). The blinds themselves are recorded when the hand is dealt.
+	EventPostMissed
+	// EventUpCard is recorded once per player in a hand of stud each time they are dealt a card face up (see
+	// Player.UpCards), and EventDownCard each time they are dealt one face down after their hole cards (in Razz, on
+	// seventh street). Stage is the stage being entered, and Cards holds the card.
+	EventUpCard
+	EventDownCard
+)
+
+// Event is a single, typed record of something that happened in a Game. Every Event is given a
+// sequence number, starting at 1 and increasing by 1 with every Event, so consumers can tell exactly
+// what order things happened in, and whether they've missed anything. Stage is the stage the Game
+// was in when the Event was recorded. Which other fields are meaningful depends on Kind. Away is true on
+// the EventHoleCards, EventBet and EventFold records of a player who was away at the time, and so didn't
+// act for themselves.
+type Event struct {
+	Seq       uint64      `json:"seq"`
+	Kind      EventKind   `json:"kind"`
+	Stage     GameStage   `json:"stage"`
+	PlayerNum uint        `json:"playerNum"`
+	Amount    uint        `json:"amount"`
+	PotNum    uint        `json:"potNum"`
+	Cards     []eval.Card `json:"cards"`
+	Emote     EmoteKind   `json:"emote"`
+	Low       bool        `json:"low"`
+	Away      bool        `json:"away"`
+	Variant   Variant     `json:"variant"`
+}
+
+// Events returns every Event recorded by g, in order, including every player's Private ones. If the Game has an
+// EventHistory, the Events of older hands have been dropped.
+func (g *Game) Events() []Event {
+	return copyEvents(g.events)
+}
+
+// EventsSince returns every Event recorded by g with a sequence number greater than seq, in order.
+func (g *Game) EventsSince(seq uint64) []Event {
+	for i := range g.events {
+		if g.events[i].Seq > seq {
+			return copyEvents(g.events[i:])
+		}
+	}
+
+	return []Event{}
+}
+
+// LastEventSeq returns the sequence number of the last Event recorded by g, or 0 if it hasn't recorded any.
+func (g *Game) LastEventSeq() uint64 {
+	return g.eventSeq
+}
+
+// Private reports whether e holds something not everybody may see: the hole cards a player was dealt
+// (EventHoleCards or EventDownCard), a card they discarded (EventDiscard), or a burned card (EventBurn), which nobody
+// may see.
+func (e Event) Private() bool {
+	return e.Kind == EventHoleCards || e.Kind == EventDownCard || e.Kind == EventDiscard || e.Kind == EventBurn
+}
+
+// PlayerAction reports whether e records a player performing one of the Actions in ActionsByName. Every Action records
+// one of these kinds of Event when it succeeds, so the kind a new Action records must be added here. Some of them are
+// also recorded when the Game acts or posts for a player, like the EventFold of a player who ran out of time or the
+// EventDeadChips of an ante, and a hand dealt by Advance counts as the dealer's Deal.
+func (e Event) PlayerAction() bool {
+	switch e.Kind {
+	case EventBuyIn, EventReady, EventNotReady, EventLeave, EventHandStart, EventBet, EventFold, EventShow, EventMuck,
+		EventEmote, EventDeadChips, EventAway, EventBack, EventDiscard, EventRematchAccept, EventSitOut, EventSitIn,
+		EventSeat, EventShowCards, EventUndo, EventMisdeal, EventStraddle, EventPostMissed:
+		return true
+	}
+
+	return false
+}
+
+// For returns e as player pn should see it. If e is Private and not about pn, its Cards are removed; everything
+// else is public. A pn that isn't any player's number, like that of a spectator, sees no Private Cards.
+func (e Event) For(pn uint) Event {
+	ret := copyEvents([]Event{e})[0]
+	if e.Private() && (e.Kind == EventBurn || e.PlayerNum != pn) {
+		ret.Cards = nil
+	}
+
+	return ret
+}
+
+// PlayerEventsSince is like EventsSince, but returns every Event as player pn should see it (see Event.For), so
+// that they can be sent to pn without giving away anybody else's cards.
+func (g *Game) PlayerEventsSince(pn uint, seq uint64) []Event {
+	events := g.EventsSince(seq)
+	for i := range events {
+		events[i] = events[i].For(pn)
+	}
+
+	return events
+}
+
+// Subscribe registers fn to be called with every Event g records from now on, synchronously and in order.
+// Subscribe returns a function that cancels the subscription. fn is called while the Action that caused the
+// Event is still in progress, so it must not perform Actions on g itself.
+func (g *Game) Subscribe(fn func(Event)) (cancel func()) {
+	ndx := len(g.subscribers)
+	g.subscribers = append(g.subscribers, fn)
+
+	return func() { g.subscribers[ndx] = nil }
+}
+
+// SubscribePlayer is like Subscribe, but fn is called with every Event as player pn should see it (see Event.For).
+func (g *Game) SubscribePlayer(pn uint, fn func(Event)) (cancel func()) {
+	return g.Subscribe(func(e Event) { fn(e.For(pn)) })
+}
+
+func (g *Game) emit(e Event) {
+	g.eventSeq++
+	e.Seq = g.eventSeq
+	if e.Stage == 0 {
+		e.Stage = g.getStage()
+	}
+
+	g.events = append(g.events, e)
+
+	for _, fn := range g.subscribers {
+		if fn != nil {
+			fn(copyEvents([]Event{e})[0])
+		}
+	}
+}
+
+// handStartIndex returns the index in g's event log of the EventHandStart of the current hand (or, between hands, the
+// last one), or 0 if no hand has been dealt
+func (g *Game) handStartIndex() int {
+	for i := len(g.events) - 1; i > 0; i-- {
+		if g.events[i].Kind == EventHandStart {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// trimEvents drops the Events from before the last EventHistory hands, if the Game has one
+func (g *Game) trimEvents() {
+	keep := g.config.EventHistory
+	if keep == 0 {
+		return
+	}
+
+	for i := len(g.events) - 1; i >= 0; i-- {
+		if g.events[i].Kind != EventHandStart {
+			continue
+		}
+
+		if keep--; keep == 0 {
+			g.events = append([]Event{}, g.events[i:]...)
+			return
+		}
+	}
+}
+
+func copyEvents(src []Event) []Event {
+	ret := make([]Event, len(src))
+	for i := range src {
+		ret[i] = src[i]
+		ret[i].Cards = append([]eval.Card{}, src[i].Cards...)
+	}
+
+	return ret
+}
@@ -0,0 +1,530 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGame_Events(t *testing.T) {
+	g := NewGame(nil)
+
+	received := []Event{}
+	cancel := g.Subscribe(func(e Event) { received = append(received, e) })
+
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// An illegal action must not record anything
+	if err := Bet(g, pn_b, 0); err != ErrIllegalAction {
+		t.Fatalf("Test failed - Bet out of turn must return ErrIllegalAction")
+	}
+
+	if err := Bet(g, pn_a, 15); err != nil {
+		t.Fatalf("Test failed - error betting: %s", err)
+	}
+
+	cancel()
+
+	if err := Fold(g, pn_b, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	wantKinds := []EventKind{
+		EventBuyIn, EventReady, EventBuyIn, EventReady,
+		EventHandStart, EventHoleCards, EventHoleCards, EventBlind, EventBlind,
+		EventBet,
+		EventFold, EventPotAward, EventHandEnd,
+	}
+
+	events := g.Events()
+	gotKinds := []EventKind{}
+	for i, e := range events {
+		if e.Seq != uint64(i+1) {
+			t.Errorf("Test failed - event %d has sequence number %d", i, e.Seq)
+		}
+		gotKinds = append(gotKinds, e.Kind)
+	}
+
+	if !reflect.DeepEqual(gotKinds, wantKinds) {
+		t.Fatalf("Test failed - got events %v, want %v", gotKinds, wantKinds)
+	}
+
+	if award := events[11]; award.PlayerNum != pn_a || award.Amount != 50 {
+		t.Errorf("Test failed - expected player %d to be awarded 50, got %+v", pn_a, award)
+	}
+
+	if !reflect.DeepEqual(received, events[:10]) {
+		t.Errorf("Test failed - subscriber should have received the first 10 events, got %+v", received)
+	}
+
+	if since := g.EventsSince(10); len(since) != 3 || since[0].Kind != EventFold {
+		t.Errorf("Test failed - EventsSince(10) = %+v", since)
+	}
+}
+
+func TestEvent_JSON(t *testing.T) {
+	e := Event{Seq: 3, Kind: EventBet, Stage: Flop, PlayerNum: 1, Amount: 50}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("Test failed - error marshalling: %s", err)
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatalf("Test failed - error unmarshalling: %s", err)
+	}
+	for _, name := range []string{"seq", "kind", "stage", "playerNum", "amount", "potNum", "cards", "emote", "low", "away", "variant"} {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("Test failed - expected the JSON encoding to have a %q field, got %s", name, b)
+		}
+	}
+
+	// Events encoded before they had tags still decode
+	var old Event
+	if err := json.Unmarshal([]byte(`{"Seq":3,"Kind":4,"PlayerNum":1,"Amount":50}`), &old); err != nil || old.PlayerNum != 1 || old.Amount != 50 {
+		t.Errorf("Test failed - expected an untagged encoding to decode, got %+v, %v", old, err)
+	}
+}
+
+func TestGame_EventHistory(t *testing.T) {
+	config := defaultConfig
+	config.Seed = 3
+	config.EventHistory = 2
+	g := NewGame(&config)
+
+	var journal []Event
+	g.Subscribe(func(e Event) { journal = append(journal, e) })
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	for i := 0; i < 4; i++ {
+		playFoldedHand(t, g)
+	}
+
+	// Only the last two hands are kept, and sequence numbers carry on regardless
+	events := g.Events()
+	var hands int
+	for _, e := range events {
+		if e.Kind == EventHandStart {
+			hands++
+		}
+	}
+	if hands != 2 || events[0].Kind != EventHandStart {
+		t.Errorf("Test failed - expected the log to start with the first of the last 2 hands, got %d hands", hands)
+	}
+	if last := events[len(events)-1]; last.Seq != g.LastEventSeq() || last.Seq != journal[len(journal)-1].Seq {
+		t.Errorf("Test failed - expected the last Event to be %d, got %d", g.LastEventSeq(), last.Seq)
+	}
+	if got := g.EventsSince(0); len(got) != len(events) {
+		t.Errorf("Test failed - expected EventsSince(0) to return the %d Events kept, got %d", len(events), len(got))
+	}
+
+	// The whole journal still replays, to a Game that keeps as little of it
+	rebuilt, err := ReplayEvents(&config, journal)
+	if err != nil {
+		t.Fatalf("Test failed - ReplayEvents returned %s", err)
+	}
+	if got := rebuilt.Events(); !reflect.DeepEqual(got, events) {
+		t.Errorf("Test failed - expected the rebuilt log to be %+v\nwant %+v", got, events)
+	}
+}
+
+func TestGame_PlayerEventsSince(t *testing.T) {
+	g := NewGame(nil)
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	seq := g.LastEventSeq()
+
+	received := []Event{}
+	cancel := g.SubscribePlayer(pn_a, func(e Event) { received = append(received, e) })
+	defer cancel()
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	events := g.PlayerEventsSince(pn_a, seq)
+	if !reflect.DeepEqual(events, received) {
+		t.Errorf("Test failed - PlayerEventsSince returned %v, but the subscriber got %v", events, received)
+	}
+
+	holeCards := 0
+	for _, e := range events {
+		if e.Kind != EventHoleCards {
+			continue
+		}
+		holeCards++
+
+		if mine := e.PlayerNum == pn_a; mine != (len(e.Cards) == 2) {
+			t.Errorf("Test failed - player %d should only see their own hole cards, got %v for player %d", pn_a, e.Cards, e.PlayerNum)
+		}
+	}
+	if holeCards != 2 {
+		t.Errorf("Test failed - every player dealt in should have an EventHoleCards, got %d", holeCards)
+	}
+
+	for _, e := range g.PlayerEventsSince(^uint(0), seq) {
+		if e.Private() && len(e.Cards) != 0 {
+			t.Errorf("Test failed - spectators should not see private cards, got %v", e)
+		}
+	}
+
+	// The public log still holds everything
+	for _, e := range g.EventsSince(seq) {
+		if e.Kind == EventHoleCards && len(e.Cards) != 2 {
+			t.Errorf("Test failed - Events should hold every player's hole cards, got %v", e)
+		}
+	}
+}
+
+func TestEmote(t *testing.T) {
+	g := NewGame(nil)
+	now := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	pn := g.AddPlayer()
+	before := g.GenerateOmniView()
+
+	if err := Emote(g, pn, uint(EmoteNiceHand)); err != nil {
+		t.Fatalf("Test failed - error emoting: %s", err)
+	}
+
+	if err := Emote(g, pn, uint(EmoteGoodLuck)); err != ErrRateLimited {
+		t.Errorf("Test failed - a second emote right away must return ErrRateLimited, got %v", err)
+	}
+
+	if err := Emote(g, pn, 99); err != ErrIllegalAction {
+		t.Errorf("Test failed - an unknown emote must return ErrIllegalAction, got %v", err)
+	}
+
+	now = now.Add(emoteInterval)
+	if err := Emote(g, pn, uint(EmoteRabbitHunt)); err != nil {
+		t.Fatalf("Test failed - error emoting: %s", err)
+	}
+
+	events := g.Events()
+	if len(events) != 2 || events[0].Emote != EmoteNiceHand || events[1].Emote != EmoteRabbitHunt {
+		t.Errorf("Test failed - expected two emote events, got %+v", events)
+	}
+
+	// Only the sequence number of the last event moves on
+	after := g.GenerateOmniView()
+	if after.Seq != before.Seq+2 {
+		t.Errorf("Test failed - expected the view's Seq to count the two emotes, got %d", after.Seq)
+	}
+	after.Seq = before.Seq
+	if !reflect.DeepEqual(before, after) {
+		t.Error("Test failed - emoting must not change the state of the game")
+	}
+}
+
+func TestGame_Hooks(t *testing.T) {
+	g := NewGame(nil)
+
+	stages := []GameStage{}
+	actions := []EventKind{}
+	handsEnded := 0
+	awarded := uint(0)
+
+	g.OnStageChange(func(s GameStage) { stages = append(stages, s) })
+	g.OnPlayerAction(func(e Event) { actions = append(actions, e.Kind) })
+	g.OnHandEnd(func() { handsEnded++ })
+	cancel := g.OnPotAwarded(func(potNum uint, pn uint, amt uint) { awarded += amt })
+
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+	for _, pn := range []uint{pn_a, pn_b} {
+		BuyIn(g, pn, 100)
+		ToggleReady(g, pn, 0)
+	}
+
+	Deal(g, pn_a, 0)
+	Bet(g, pn_a, 15)
+	Bet(g, pn_b, 0)
+	Fold(g, pn_b, 0)
+
+	cancel()
+
+	// Player b is now the dealer
+	Deal(g, pn_b, 0)
+	Fold(g, pn_b, 0)
+
+	wantStages := []GameStage{PreFlop, Flop, PreDeal, PreFlop, PreDeal}
+	if !reflect.DeepEqual(stages, wantStages) {
+		t.Errorf("Test failed - got stage changes %v, want %v", stages, wantStages)
+	}
+
+	wantActions := []EventKind{
+		EventBuyIn, EventReady, EventBuyIn, EventReady,
+		EventHandStart, EventBet, EventBet, EventFold, EventHandStart, EventFold,
+	}
+	if !reflect.DeepEqual(actions, wantActions) {
+		t.Errorf("Test failed - got player actions %v, want %v", actions, wantActions)
+	}
+
+	if handsEnded != 2 || awarded != 50 {
+		t.Errorf("Test failed - expected 2 hands ended and 50 awarded, got %d and %d", handsEnded, awarded)
+	}
+}
+
+func TestGame_OnStageChangeMisdeal(t *testing.T) {
+	g := newVariantGame(t, HoldEm, 3)
+
+	stages := []GameStage{}
+	g.OnStageChange(func(s GameStage) { stages = append(stages, s) })
+
+	for g.getStage() == PreFlop {
+		if err := Bet(g, g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+			t.Fatalf("Test failed - error calling: %s", err)
+		}
+	}
+	if err := Misdeal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error calling a misdeal: %s", err)
+	}
+
+	// The hand called off on the flop is gone, so subscribers go back to PreDeal
+	if want := []GameStage{Flop, PreDeal}; !reflect.DeepEqual(stages, want) {
+		t.Errorf("Test failed - got stage changes %v, want %v", stages, want)
+	}
+}
+
+func TestRuleSet(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Rules: RuleSet{Emotes: true}})
+	pn := g.AddPlayer()
+	BuyIn(g, pn, 100)
+	ToggleReady(g, pn, 0)
+
+	if rules := g.GeneratePlayerView(pn).Config.Rules; !rules.Emotes || rules.RabbitHunt || rules.DeadChips {
+		t.Errorf("Test failed - the view should carry the table's rules, got %+v", rules)
+	}
+
+	if err := Emote(g, pn, uint(EmoteRabbitHunt)); err != ErrFeatureDisabled {
+		t.Errorf("Test failed - rabbit hunting must return ErrFeatureDisabled, got %v", err)
+	}
+
+	if err := PostDead(g, pn, 10); err != ErrFeatureDisabled {
+		t.Errorf("Test failed - posting dead must return ErrFeatureDisabled, got %v", err)
+	}
+
+	if err := Emote(g, pn, uint(EmoteNiceHand)); err != nil {
+		t.Errorf("Test failed - error emoting: %s", err)
+	}
+}
+
+func TestGame_OnPlayerActionEveryAction(t *testing.T) {
+	// Each case sets up a Game in which the Action can be performed, and returns the pn and data to perform it with
+	cases := map[string]func(t *testing.T) (g *Game, pn uint, data uint){
+		"bet": func(t *testing.T) (*Game, uint, uint) {
+			g := newVariantGame(t, HoldEm, 3)
+			return g, g.actionNum, g.toCall() - g.players[g.actionNum].Bet
+		},
+		"buyIn": func(t *testing.T) (*Game, uint, uint) {
+			g := NewGame(nil)
+			return g, g.AddPlayer(), 100
+		},
+		"changeSeat": func(t *testing.T) (*Game, uint, uint) {
+			// Players can only change seats while they aren't ready
+			g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seats: 4})
+			return g, g.AddPlayer(), 4
+		},
+		"deal": func(t *testing.T) (*Game, uint, uint) {
+			g := seatedGame(t, &defaultConfig, 3, 1000)
+			return g, g.dealingNum(), 0
+		},
+		"discard": func(t *testing.T) (*Game, uint, uint) {
+			return newVariantGame(t, Pineapple, 3), 0, 0
+		},
+		"emote": func(t *testing.T) (*Game, uint, uint) {
+			return seatedGame(t, &defaultConfig, 2, 1000), 0, uint(EmoteNiceHand)
+		},
+		"fold": func(t *testing.T) (*Game, uint, uint) {
+			g := newVariantGame(t, HoldEm, 3)
+			return g, g.actionNum, 0
+		},
+		"leave": func(t *testing.T) (*Game, uint, uint) {
+			return seatedGame(t, &defaultConfig, 2, 1000), 0, 0
+		},
+		"misdeal": func(t *testing.T) (*Game, uint, uint) {
+			g := newVariantGame(t, HoldEm, 3)
+			return g, g.dealingNum(), 0
+		},
+		"muck": func(t *testing.T) (*Game, uint, uint) {
+			g := showdownGame(t)
+			return g, g.actionNum, 0
+		},
+		"postDead": func(t *testing.T) (*Game, uint, uint) {
+			return seatedGame(t, nil, 3, 100), 2, 10
+		},
+		"postMissed": func(t *testing.T) (*Game, uint, uint) {
+			config := defaultConfig
+			config.Rules.MissedBlinds = true
+			g := seatedGame(t, &config, 4, 1000)
+			playFoldedHand(t, g)
+
+			// A player joining mid-orbit owes the big blind
+			late := g.AddPlayer()
+			if err := BuyIn(g, late, 1000); err != nil {
+				t.Fatalf("Test failed - Error buying in: %s", err)
+			}
+			if err := ToggleReady(g, late, 0); err != nil {
+				t.Fatalf("Test failed - Error marking ready: %s", err)
+			}
+			return g, late, 1
+		},
+		"rematch": func(t *testing.T) (*Game, uint, uint) {
+			config := defaultConfig
+			config.RematchTimeout = time.Minute
+			config.RematchStack = 100
+			g := seatedGame(t, &config, 2, 100)
+			g.SetRandSource(unshuffled{})
+			if err := Deal(g, g.dealingNum(), 0); err != nil {
+				t.Fatalf("Test failed - error dealing: %s", err)
+			}
+			for g.getStage() != PreDeal {
+				if err := Bet(g, g.actionNum, g.players[g.actionNum].Stack); err != nil {
+					t.Fatalf("Test failed - error playing the hand out: %s", err)
+				}
+			}
+			return g, 0, 0
+		},
+		"show": func(t *testing.T) (*Game, uint, uint) {
+			g := showdownGame(t)
+			return g, g.actionNum, 0
+		},
+		"showCards": func(t *testing.T) (*Game, uint, uint) {
+			g := newVariantGame(t, HoldEm, 2)
+			folder := g.actionNum
+			if err := Fold(g, folder, 0); err != nil {
+				t.Fatalf("Test failed - error folding: %s", err)
+			}
+			return g, folder, 3
+		},
+		"sitIn": func(t *testing.T) (*Game, uint, uint) {
+			g := seatedGame(t, &defaultConfig, 3, 1000)
+			if err := SitOut(g, 1, 0); err != nil {
+				t.Fatalf("Test failed - error sitting out: %s", err)
+			}
+			return g, 1, 0
+		},
+		"sitOut": func(t *testing.T) (*Game, uint, uint) {
+			return seatedGame(t, &defaultConfig, 3, 1000), 1, 0
+		},
+		"straddle": func(t *testing.T) (*Game, uint, uint) {
+			g := seatedGame(t, straddleConfig(), 4, 1000)
+			return g, g.dealerNum, 1
+		},
+		"toggleAway": func(t *testing.T) (*Game, uint, uint) {
+			return seatedGame(t, &defaultConfig, 2, 1000), 0, 0
+		},
+		"toggleReady": func(t *testing.T) (*Game, uint, uint) {
+			return seatedGame(t, &defaultConfig, 2, 1000), 0, 0
+		},
+		"undo": func(t *testing.T) (*Game, uint, uint) {
+			config := defaultConfig
+			config.Rules.Undo = true
+			g := seatedGame(t, &config, 3, 1000)
+			if err := Deal(g, g.dealingNum(), 0); err != nil {
+				t.Fatalf("Test failed - error dealing: %s", err)
+			}
+			pn := g.actionNum
+			if err := Fold(g, pn, 0); err != nil {
+				t.Fatalf("Test failed - error folding: %s", err)
+			}
+			return g, pn, 0
+		},
+	}
+
+	for name, action := range ActionsByName {
+		setup, ok := cases[name]
+		if !ok {
+			t.Errorf("Test failed - no case for the %q Action", name)
+			continue
+		}
+
+		g, pn, data := setup(t)
+		fired := 0
+		g.OnPlayerAction(func(e Event) { fired++ })
+
+		if err := action(g, pn, data); err != nil {
+			t.Errorf("Test failed - error performing %q: %s", name, err)
+		} else if fired == 0 {
+			t.Errorf("Test failed - %q didn't fire OnPlayerAction", name)
+		}
+	}
+}
+
+// showdownGame returns a heads-up Game played with ShowOrMuck down to the showdown, waiting on the second player to
+// show or muck
+func showdownGame(t *testing.T) *Game {
+	config := defaultConfig
+	config.Rules.ShowOrMuck = true
+	g := seatedGame(t, &config, 2, 100)
+	g.SetRandSource(unshuffled{})
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := Bet(g, g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	for g.getBetting() {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+	}
+
+	return g
+}
@@ -24,7 +24,7 @@
 package riverboat
 
 import (
-	"math"
+	"fmt"
 	"math/rand"
 	"sort"
 	"time"
@@ -52,6 +52,7 @@ SSS - Status
 	011 : Flop
 	100 : Turn
 	101 : River
+	110 : SeventhStreet
 
 B - Betting
 	1 :Yes, still betting
@@ -66,22 +67,142 @@ const (
 	Flop
 	Turn
 	River
+	// SeventhStreet is the last street of stud variants like Razz, which deal no flop, turn or river, and so go by
+	// the streets below instead
+	SeventhStreet
 )
 
+// The streets of stud variants, which are named for the number of cards each player has once they are dealt
+const (
+	ThirdStreet  = PreFlop
+	FourthStreet = Flop
+	FifthStreet  = Turn
+	SixthStreet  = River
+)
+
+// Pot represents a single pot (main or side) in the current hand. Pots are rebuilt every time
+// round info is updated, ordered from the main pot (index 0) through each successive side pot.
+//
+// Name, Side, Capped, CreatedStage, CreatedByPlayerNum and Contributions describe where the pot
+// came from, so that a frontend can render something like
+// "Side pot 2 ($340, created when player 5 went all-in on the Turn)" straight from a view.
 type Pot struct {
-	TopShare           uint
-	Amt                uint
-	EligiblePlayerNums []uint
-	WinningPlayerNums  []uint
-	WinningHand        []eval.Card
-	WinningScore       int
+	TopShare           uint        `json:"topShare"`
+	Amt                uint        `json:"amt"`
+	EligiblePlayerNums []uint      `json:"eligiblePlayerNums"`
+	WinningPlayerNums  []uint      `json:"winningPlayerNums"`
+	WinningHand        []eval.Card `json:"winningHand"`
+	WinningScore       int         `json:"winningScore"`
+
+	// Name is "Main pot" for the first pot, and "Side pot n" for the nth side pot
+	Name string `json:"name"`
+	// Side is false for the main pot, true otherwise
+	Side bool `json:"side"`
+	// Capped is true if the pot was closed off by a player going all-in
+	Capped bool `json:"capped"`
+	// CreatedStage is the stage during which the all-in that created this side pot happened: the all-in that capped
+	// the pot below it. If more than one player went all-in for the same amount, it is the first of them, in seat
+	// order. CreatedStage and CreatedByPlayerNum are meaningless for the main pot.
+	CreatedStage GameStage `json:"createdStage"`
+	// CreatedByPlayerNum is the player whose all-in created this side pot
+	CreatedByPlayerNum uint `json:"createdByPlayerNum"`
+	// Contributions holds the amount each player put into this pot, indexed by player number
+	Contributions []uint `json:"contributions"`
+
+	// In hi-lo games, the Low fields describe the winners of the low half of the pot, like their Winning
+	// counterparts do for the high half. If no hand qualified for low, LowWinningPlayerNums is empty, and the
+	// high hand wins the whole pot.
+	LowWinningPlayerNums []uint      `json:"lowWinningPlayerNums"`
+	LowWinningHand       []eval.Card `json:"lowWinningHand"`
+	LowWinningScore      int         `json:"lowWinningScore"`
 }
 
 type GameConfig struct {
-	MaxBuy     uint
-	BigBlind   uint
-	SmallBlind uint
-	Seed       int64
+	// MaxBuy is the largest stack a player can buy up to (0 is unlimited), and MinBuy the smallest stack a player
+	// must have after buying in (see BuyIn)
+	MaxBuy     uint       `json:"maxBuy"`
+	MinBuy     uint       `json:"minBuy"`
+	BigBlind   uint       `json:"bigBlind"`
+	SmallBlind uint       `json:"smallBlind"`
+	Seed       int64      `json:"seed"`
+	ChipFormat ChipFormat `json:"chipFormat"`
+	// HandCap is the most any player can commit in total during a single hand (0 is uncapped). A player whose
+	// total commitment reaches HandCap is treated as all-in for the rest of the hand.
+	HandCap uint `json:"handCap"`
+	// Betting is the betting structure (see BettingStructure). Under SpreadLimit, every bet and raise must be between
+	// SpreadMin and SpreadMax.
+	Betting   BettingStructure `json:"betting"`
+	SpreadMin uint             `json:"spreadMin"`
+	SpreadMax uint             `json:"spreadMax"`
+	// Kill plays kill pots (see KillMode), but only in a game with a limit betting structure: SpreadLimit or
+	// FixedLimit. It is ignored under NoLimit and PotLimit, where there are no limit stakes to raise.
+	Kill KillMode `json:"kill"`
+	// Ante, if not 0, has an ante posted each hand, which goes in the pot as dead chips. AnteMode decides who posts
+	// it, and so what it is: under AntePerPlayer, Ante is what each player dealt in posts, before the blinds, and
+	// under AnteBigBlind or AnteButton it is the ante for the whole table, which one player posts after the blinds.
+	Ante     uint     `json:"ante"`
+	AnteMode AnteMode `json:"anteMode"`
+	// MinBigBlinds, if not 0, is how many big blinds a player needs in their stack to be dealt in. Players left
+	// with less at the end of a hand aren't dealt into the next one until they top up (see BuyIn) and are ready
+	// again.
+	MinBigBlinds uint `json:"minBigBlinds"`
+	// SevenDeuceBounty, if not 0, is the seven-deuce game: a player who wins a pot holding a seven and a deuce
+	// collects SevenDeuceBounty from every other player dealt into the hand (or what they have left, if less)
+	SevenDeuceBounty uint `json:"sevenDeuceBounty"`
+	// HighHandQualifier, if not 0, runs a high-hand promotion: the best hand shown down that plays both hole cards
+	// and scores HighHandQualifier or better (lower, as scored by eval, like the score of aces full of deuces) leads
+	// it. Each promotion lasts HighHandWindow, starting on the multiples of it (so an hour runs on the hour), or for
+	// as long as the Game does if that is 0 (see GenerateHighHandView).
+	HighHandQualifier int           `json:"highHandQualifier"`
+	HighHandWindow    time.Duration `json:"highHandWindow"`
+	// Rules are the optional rules and features enabled at the table
+	Rules RuleSet `json:"rules"`
+	// AutoDeal has the engine perform the dealer's duties, dealing each new hand DealDelay after the last one
+	// ended, instead of waiting for the dealer to Deal (see Advance)
+	AutoDeal  bool          `json:"autoDeal"`
+	DealDelay time.Duration `json:"dealDelay"`
+	// Variant is the form of poker dealt at the table
+	Variant Variant `json:"variant"`
+	// Rotation, if set, makes the table a mixed game: it deals each of the variants in turn, in place of
+	// Variant, moving on to the next after RotateEvery hands (0 is once per orbit of the button)
+	Rotation    []Variant `json:"rotation,omitempty"`
+	RotateEvery uint      `json:"rotateEvery"`
+	// RematchTimeout, if not 0, turns on rematches: when a heads-up hand ends with one player busted, the two
+	// are offered a rematch, which they have RematchTimeout to accept (see Rematch). Each starts the rematch
+	// with RematchStack (0 is MaxBuy).
+	RematchTimeout time.Duration `json:"rematchTimeout"`
+	RematchStack   uint          `json:"rematchStack"`
+	// RejectLimit is how many rejected Actions per second Apply allows each player before throttling them
+	// (0 is unlimited)
+	RejectLimit uint `json:"rejectLimit"`
+	// Retention is how much of each hand's hidden information the Game keeps once the hand is over
+	Retention Retention `json:"retention"`
+	// EventHistory, if not 0, is how many hands the Game keeps in its event log (see Events): as each hand is dealt,
+	// the Events from before the last EventHistory hands, counting the new one, are dropped. Whatever is worked out
+	// from the log, like VPIP, only covers the hands kept. 0 keeps every Event.
+	EventHistory uint `json:"eventHistory"`
+	// ActionTime, if not 0, turns on action timers: each player has ActionTime to make each decision, after which
+	// they start using up their time bank, which starts at TimeBank (see ActionDeadline)
+	ActionTime time.Duration `json:"actionTime"`
+	TimeBank   time.Duration `json:"timeBank"`
+	// TimeoutAction is what the engine does for a player who runs out of time, and TimeoutsToSitOut, if not 0, is
+	// how many times in a row they can run out before they are sat out as well (see Timeout)
+	TimeoutAction    TimeoutAction `json:"timeoutAction"`
+	TimeoutsToSitOut uint          `json:"timeoutsToSitOut"`
+	// Seats is how many seats the table has, like 2 for a heads-up table or 9 for a full ring (0 is as many as there
+	// are players). Players added once every seat is taken wait for one to become free (see ChangeSeat), unless
+	// they are added with SeatPlayer, which turns them away.
+	Seats uint `json:"seats"`
+	// CommitShuffle has the Game commit to each hand's shuffle before dealing it, and reveal it once the hand is
+	// over, so players can check the deck wasn't stacked (see ShuffleCommitment). Each hand's Seed is drawn from
+	// crypto/rand. Revealing the shuffle shows every card dealt, including mucked hands.
+	CommitShuffle bool `json:"commitShuffle"`
+	// BurnCards has the dealer burn a card before dealing the community cards of each street, as in live games
+	BurnCards bool `json:"burnCards"`
+	// WinTheButton gives the button to whoever won the last hand's main pot outright, as in some heads-up formats,
+	// instead of moving it on a seat. If the main pot was split, or its winner isn't dealt into the next hand, the
+	// button moves on as usual. The blinds and first to act are placed from the button either way.
+	WinTheButton bool `json:"winTheButton"`
 }
 
 // Game represents a game of poker. It internally keeps track of state, can be mutated by actions,
@@ -102,6 +223,47 @@ type Game struct {
 	minRaise       uint
 	calledNum      uint
 	rand           *rand.Rand
+	rng            RNG
+	shuffleCommit  *ShuffleCommitment
+	lastShuffle    *ShuffleCommitment
+	burns          []eval.Card
+	showdownOrder  []uint
+	showdown       []ShowdownReveal
+	events         []Event
+	eventSeq       uint64
+	subscribers    []func(Event)
+	now            func() time.Time
+	actionSince    time.Time
+	decisionTimes  map[uint][]time.Duration
+	lastEmote      map[uint]time.Time
+	rejections     map[uint][]time.Time
+	rangeModel     RangeModel
+	ranges         []Range
+	cancelRanges   func()
+	cancelStats    func()
+	startStacks    []uint
+	knockouts      map[uint][]uint
+	// potWinner is the player who won the last hand's main pot outright, if potWon is true (see WinTheButton)
+	potWinner uint
+	potWon    bool
+	// potScooped is true if, in a hi-lo game, potWinner won both halves of the main pot at showdown (see KillMode)
+	potScooped bool
+	// kill is true if the hand being played, or between hands the next one, is a kill pot, killed by killNum.
+	// lastWinner won the whole of the main pot in the last hand that ended, if lastWon is true (see updateKill).
+	kill          bool
+	killNum       uint
+	lastWinner    uint
+	lastWon       bool
+	carryover     uint
+	handEnded     time.Time
+	rotationNum   uint
+	rotationHands uint
+	rematch       *RematchOffer
+	undo          *undoSnapshot
+	dealtFrom     *dealtFrom
+	paused        bool
+	highHand      *HighHand
+	store         *storeLink
 }
 
 func (g *Game) getStage() GameStage {
@@ -147,40 +309,57 @@ func (g *Game) readyCount() uint {
 	return readyCount
 }
 
+// shortStacked reports whether player pn has fewer chips than the table's MinBigBlinds, and so can't be dealt in
+func (g *Game) shortStacked(pn uint) bool {
+	return g.players[pn].Stack < g.config.MinBigBlinds*g.config.BigBlind
+}
+
 func (g *Game) isCalled(pn uint) bool {
-	return g.players[pn].allIn(g.getStage()) || (g.players[pn].Called)
+	return g.allIn(pn) || (g.players[pn].Called)
 }
 
-//Returns nil if there are more than 2 players ready, ErrIllegalAction otherwise
+// updateBlindNums works out who posts the blinds and who is first to act in the next hand. Once a hand has been
+// played with three or more players, the blinds carry over from it under the dead button rule (see moveButton), and
+// only the big blind and first to act are worked out again, as players may have come or gone since. Otherwise they
+// are placed to the left of the dealer.
 func (g *Game) updateBlindNums() {
 	readyCount := g.readyCount()
 
+	if g.blindsCarried() {
+		// The small blind is on the seat that had the big blind, which moves on to the next ready player
+		g.bbNum = g.nextReady(g.sbNum)
+		g.utgNum = g.nextReady(g.bbNum)
+		return
+	}
+
 	if readyCount < 2 {
 		g.bbNum = g.dealerNum
 		g.sbNum = g.dealerNum
 		g.utgNum = g.dealerNum
 
 	} else if readyCount == 2 {
-		g.sbNum = g.dealerNum
-		g.utgNum = g.dealerNum
-		g.bbNum = (g.dealerNum + 1) % uint(len(g.players))
-		for !g.players[g.bbNum].Ready {
-			g.bbNum = (g.bbNum + 1) % uint(len(g.players))
+		// The blinds are placed according to RuleSet.HeadsUp, and the small blind acts first
+		other := g.nextReady(g.dealerNum)
+		if g.config.Rules.HeadsUp == HeadsUpButtonBigBlind {
+			g.sbNum, g.bbNum = other, g.dealerNum
+		} else {
+			g.sbNum, g.bbNum = g.dealerNum, other
 		}
+		g.utgNum = g.sbNum
 	} else {
-		g.sbNum = (g.dealerNum + 1) % uint(len(g.players))
+		g.sbNum = g.next(g.dealerNum)
 		for !g.players[g.sbNum].Ready {
-			g.sbNum = (g.sbNum + 1) % uint(len(g.players))
+			g.sbNum = g.next(g.sbNum)
 		}
 
-		g.bbNum = (g.sbNum + 1) % uint(len(g.players))
+		g.bbNum = g.next(g.sbNum)
 		for !g.players[g.bbNum].Ready {
-			g.bbNum = (g.bbNum + 1) % uint(len(g.players))
+			g.bbNum = g.next(g.bbNum)
 		}
 
-		g.utgNum = (g.bbNum + 1) % uint(len(g.players))
+		g.utgNum = g.next(g.bbNum)
 		for !g.players[g.utgNum].Ready {
-			g.utgNum = (g.utgNum + 1) % uint(len(g.players))
+			g.utgNum = g.next(g.utgNum)
 		}
 	}
 }
@@ -197,9 +376,13 @@ func (g *Game) toCall() uint {
 	return val
 }
 
-func (g *Game) getLimit() uint {
-	//TODO: implement limits
-	return uint(math.MaxUint64)
+func (g *Game) allIn(pn uint) bool {
+	return g.players[pn].allIn(g.getStage(), g.config.HandCap)
+}
+
+// maxCommit is the most player pn can put in right now, given their stack and the hand cap
+func (g *Game) maxCommit(pn uint) uint {
+	return g.players[pn].maxCommit(g.config.HandCap)
 }
 
 func (g *Game) canOpen(pn uint) bool {
@@ -208,31 +391,115 @@ func (g *Game) canOpen(pn uint) bool {
 }
 
 func (g *Game) resetForNextHand() error {
+	g.showdownOrder = nil
+	g.updateKill()
+	g.revealShuffle()
+	g.rotate(g.readyCount())
+	g.offerRematch()
+
 	for i := range g.players {
 		g.players[i].PreviousBet = g.players[i].Bet
 		g.players[i].PreviouslyIn = g.players[i].In
 		g.players[i].Bet = 0
 		g.players[i].TotalBet = 0
+		g.players[i].DeadChips = 0
+		g.players[i].Straddle = false
+		g.players[i].Kill = false
 
-		if g.players[i].Stack == 0 {
+		if g.players[i].Stack == 0 || g.shortStacked(uint(i)) {
 			g.players[i].In = false
 			g.players[i].Ready = false
 		}
+
+		// Players who sat out during the hand aren't dealt into the next one
+		if g.players[i].SittingOut {
+			g.players[i].Ready = false
+		}
+	}
+
+	g.handEnded = g.currentTime()
+
+	// The hand is over whether or not anybody is ready to deal the next one
+	g.setStageAndBetting(PreDeal, false)
+
+	// With nobody ready, the table just waits for players, and the button is fixed up once somebody readies up
+	if err := g.moveButton(); err != nil && err != ErrNoValidDealer {
+		return err
+	}
+
+	return nil
+}
+
+// moveButton moves the button and the blinds on for the next hand under the dead button rule: the big blind moves to
+// the next ready player, the player who had the big blind posts the small blind, and the button moves to the seat
+// that had the small blind. If the player in that seat has busted or left, the button is dead, and if the player who
+// had the big blind has, the small blind is dead (nobody posts it). That way no player skips a blind or posts one
+// twice. Heads up, and going to or from heads up, the button just moves to the next ready player, and the blinds are
+// placed from there (see RuleSet.HeadsUp).
+func (g *Game) moveButton() error {
+	if g.config.WinTheButton && g.potWon && g.players[g.potWinner].Ready {
+		g.dealerNum = g.potWinner
+		g.sbNum = g.dealerNum
+		g.updateBlindNums()
+		return nil
+	}
+
+	if g.readyCount() > 2 && !g.headsUpBlinds() {
+		g.dealerNum, g.sbNum, g.bbNum = g.sbNum, g.bbNum, g.nextReady(g.bbNum)
+		g.utgNum = g.nextReady(g.bbNum)
+		return nil
 	}
 
-	g.dealerNum = (g.dealerNum + 1) % uint(len(g.players))
+	g.dealerNum = g.next(g.dealerNum)
 	if err := g.ensureValidDealer(); err != nil {
 		return err
 	}
 
-	g.setStageAndBetting(PreDeal, false)
+	// Place the blinds from scratch
+	g.sbNum = g.dealerNum
+	g.updateBlindNums()
+
 	return nil
 }
 
+// blindsCarried reports whether the blinds for the next hand were carried over from the last one by moveButton,
+// rather than placed relative to the dealer. Placing them from scratch always puts one of the blinds on the dealer
+// heads up, and both to the dealer's left otherwise, so a blind on the dealer with three or more players ready means
+// the table has just grown from heads up, and the blinds need placing again. When the button can be won, the blinds
+// are always placed from it.
+func (g *Game) blindsCarried() bool {
+	return !g.config.WinTheButton && !g.handEnded.IsZero() && g.readyCount() > 2 && !g.headsUpBlinds()
+}
+
+// headsUpBlinds reports whether the blinds were placed for heads up play, where the button posts one of them
+func (g *Game) headsUpBlinds() bool {
+	return g.sbNum == g.dealerNum || g.bbNum == g.dealerNum
+}
+
+// nextReady returns the first ready player after pn, going around the table
+func (g *Game) nextReady(pn uint) uint {
+	next := g.next(pn)
+	for !g.players[next].Ready && next != pn {
+		next = g.next(next)
+	}
+
+	return next
+}
+
+// dealingNum returns the player who deals the next hand: the dealer, or, if the button is dead, the first ready
+// player after it
+func (g *Game) dealingNum() uint {
+	if g.players[g.dealerNum].Ready {
+		return g.dealerNum
+	}
+
+	return g.nextReady(g.dealerNum)
+}
+
 func (g *Game) ensureValidDealer() error {
 	start := g.dealerNum
 	for !g.players[g.dealerNum].Ready {
-		g.dealerNum = (g.dealerNum + 1) % uint(len(g.players))
+		g.dealerNum = g.next(g.dealerNum)
 		if g.dealerNum == start {
 			return ErrNoValidDealer
 		}
@@ -250,7 +517,10 @@ func (g *Game) updateRoundInfo() error {
 	for i, p := range g.players {
 		if p.In {
 			inPlayerNums = append(inPlayerNums, uint(i))
-			if p.allIn(g.getStage()) {
+			if g.allIn(uint(i)) {
+				if p.AllInStage == 0 {
+					g.players[i].AllInStage = g.getStage()
+				}
 				allInPlayerNums = append(allInPlayerNums, uint(i))
 			} else if !g.isCalled(uint(i)) {
 				allCalled = false
@@ -258,18 +528,138 @@ func (g *Game) updateRoundInfo() error {
 		}
 	}
 
-	// Update the pot information
+	g.updatePots(allInPlayerNums)
+
+	// If less than two players are still in, the hand has been conceded
+	if len(inPlayerNums) < 2 {
+		//the sole number in the array is the winner by default
+		//TODO: Create a pot here to simplify sending result description
+		// But this is special because cards do not need to be shown
+		won := g.carryover
+		for _, p := range g.players {
+			won += p.TotalBet + p.DeadChips
+		}
+		g.players[inPlayerNums[0]].Stack += won
+		g.carryover = 0
+		g.potWinner, g.potWon = inPlayerNums[0], true
+
+		g.emit(Event{Kind: EventPotAward, PlayerNum: inPlayerNums[0], Amount: won})
+		g.paySevenDeuce(inPlayerNums[:1])
+		g.recordKnockouts(inPlayerNums[:1])
+		g.tallyHand(map[uint]uint{inPlayerNums[0]: won}, false)
+		g.discardHands()
+		g.emit(Event{Kind: EventHandEnd})
+
+		return g.resetForNextHand()
+	}
+
+	// Once everybody else has called a straddle, the straddler has the option
+	if allCalled && g.straddleOption() {
+		g.players[g.dealerNum].Straddle = false
+		g.players[g.dealerNum].Called = false
+		g.actionNum = g.dealerNum
+		allCalled = false
+	}
+
+	// Likewise the player who posted a kill blind
+	if allCalled && g.killOption() {
+		g.players[g.killNum].Kill = false
+		g.players[g.killNum].Called = false
+		g.actionNum = g.killNum
+		allCalled = false
+	}
+
+	// If two or more players are in, but not everybody has called
+	if !allCalled {
+		// just move action to next player
+		for g.isCalled(g.actionNum) || !g.players[g.actionNum].In {
+			g.actionNum = g.next(g.actionNum)
+		}
+
+		g.markActionAvailable()
+
+		return g.actForAway()
+	}
+
+	//If there are two or more players in, and everybody has either called or is all-in, and at this point we determine that only one player is
+	//in but not all in, we take all the money above and beyond the second highest better (who is all in) and return it to the people who bet it
+	//If the only players in are both all in for the exact same amount of money, nothing happens here
+	//(but we can't skip in the "0 not all in" case because technically before this step happens a player who after this step may read as not all in
+	//could return true for the isAllIn method)
+	if (len(inPlayerNums) - len(allInPlayerNums)) < 2 {
+		top := inPlayerNums[0]
+		for _, ndx := range inPlayerNums {
+			if g.players[ndx].TotalBet > g.players[top].TotalBet {
+				top = ndx
+			}
+		}
+
+		// Nobody else put in as much as the top bettor (folded players' bets count as calls), so the rest is uncalled
+		var called uint
+		for i := range g.players {
+			if uint(i) != top && g.players[i].TotalBet > called {
+				called = g.players[i].TotalBet
+			}
+		}
+
+		if g.players[top].TotalBet > called {
+			g.players[top].returnChips(g.players[top].TotalBet - called)
+
+			// The returned chips were counted in the pots above, and the top bettor may not be all in any more
+			stillAllIn := []uint{}
+			for _, pn := range allInPlayerNums {
+				if g.allIn(pn) {
+					stillAllIn = append(stillAllIn, pn)
+				}
+			}
+			g.updatePots(stillAllIn)
+		}
+	}
+
+	//If there are two or more players in, and everybody has called or is all in, then end the hand f we've just finished river betting
+	if last := g.variant().lastStage(); g.getStage() == last {
+		for i := range g.players {
+			g.players[i].PreviouslyAllIn = g.players[i].allIn(last, g.config.HandCap)
+		}
+
+		if g.config.Rules.ShowOrMuck {
+			return g.startShowdown()
+		}
+
+		return g.finishShowdown()
+	}
+
+	// otherwise, just set betting to false so the dealer can deal the next part of the hand. If the cards can't be
+	// dealt, the action that closed the betting still stands, and the hand waits to be called off (see Misdeal).
+	g.setBetting(false)
+	return Deal(g, g.dealingNum(), 0)
+}
 
-	sort.Slice(allInPlayerNums, func(i, j int) bool {
+// updatePots rebuilds the pots from what every player has bet this hand: a capped pot for each amount the players in
+// allInPlayerNums are all in for, and a last pot for everyone else
+func (g *Game) updatePots(allInPlayerNums []uint) {
+	sort.SliceStable(allInPlayerNums, func(i, j int) bool {
 		return g.players[allInPlayerNums[i]].TotalBet < g.players[allInPlayerNums[j]].TotalBet
 	}) //here, the whole slice needs to be sorted by the totalBet amount of the players represented
 
 	tmpPlayers := append([]Player{}, g.players...)
 	g.pots = []Pot{}
+	// cappers[i] is the player whose all-in capped pot i, and so created pot i+1
+	cappers := []uint{}
 	for _, pn := range allInPlayerNums {
+		// Players all-in for the same amount share the pot the first of them capped
+		if tmpPlayers[pn].TotalBet == 0 {
+			continue
+		}
 
 		newPot := Pot{}
 		newPot.TopShare = tmpPlayers[pn].TotalBet
+		newPot.Capped = true
+		newPot.Contributions = make([]uint, len(tmpPlayers))
+		if len(cappers) > 0 {
+			g.setPotCreator(&newPot, cappers[len(cappers)-1])
+		}
+		cappers = append(cappers, pn)
 
 		for i := range tmpPlayers {
 
@@ -278,9 +668,11 @@ func (g *Game) updateRoundInfo() error {
 					newPot.EligiblePlayerNums = append(newPot.EligiblePlayerNums, uint(i))
 				}
 				newPot.Amt += newPot.TopShare
+				newPot.Contributions[i] = newPot.TopShare
 				tmpPlayers[i].TotalBet -= newPot.TopShare
 			} else {
 				newPot.Amt += tmpPlayers[i].TotalBet
+				newPot.Contributions[i] = tmpPlayers[i].TotalBet
 				tmpPlayers[i].TotalBet = 0
 			}
 		}
@@ -292,136 +684,106 @@ func (g *Game) updateRoundInfo() error {
 
 	var finalPot Pot
 	finalPot.EligiblePlayerNums = []uint{}
+	finalPot.Contributions = make([]uint, len(tmpPlayers))
 
 	for i, p := range tmpPlayers {
 		finalPot.Amt += p.TotalBet
-		if p.In && !p.allIn(g.getStage()) {
+		finalPot.Contributions[i] = p.TotalBet
+		if p.In && !g.allIn(uint(i)) {
 			finalPot.EligiblePlayerNums = append(finalPot.EligiblePlayerNums, uint(i))
 		}
 	}
 
-	g.pots = append(g.pots, finalPot)
-
-	// If less than two players are still in, the hand has been conceded
-	if len(inPlayerNums) < 2 {
-		//the sole number in the array is the winner by default
-		//TODO: Create a pot here to simplify sending result description
-		// But this is special because cards do not need to be shown
-		for _, p := range g.players {
-			g.players[inPlayerNums[0]].Stack += p.TotalBet
+	// Chips bet above anything a player still in can win, by players who have since been folded (see RemovePlayer),
+	// are forfeit, so they go to the pot below them rather than to nobody
+	if len(finalPot.EligiblePlayerNums) == 0 && finalPot.Amt > 0 && len(g.pots) > 0 {
+		last := &g.pots[len(g.pots)-1]
+		last.Amt += finalPot.Amt
+		for i, amt := range finalPot.Contributions {
+			last.Contributions[i] += amt
 		}
-
-		return g.resetForNextHand()
-	}
-
-	// If two or more players are in, but not everybody has called
-	if !allCalled {
-		// just move action to next player
-		for g.isCalled(g.actionNum) || !g.players[g.actionNum].In {
-			g.actionNum = (g.actionNum + 1) % uint(len(g.players))
+	} else {
+		if len(cappers) > 0 {
+			g.setPotCreator(&finalPot, cappers[len(cappers)-1])
 		}
-
-		return nil
+		g.pots = append(g.pots, finalPot)
 	}
+	g.namePots()
 
-	//If there are two or more players in, and everybody has either called or is all-in, and at this point we determine that only one player is
-	//in but not all in, we take all the money above and beyond the second highest better (who is all in) and return it to the people who bet it
-	//If the only players in are both all in for the exact same amount of money, nothing happens here
-	//(but we can't skip in the "0 not all in" case because technically before this step happens a player who after this step may read as not all in
-	//could return true for the isAllIn method)
-	if (len(inPlayerNums) - len(allInPlayerNums)) < 2 {
-		var topBettor1 uint = 0
-		var topBettor2 uint = 0
-		// TODO: what if everyone is all in?
-		for _, ndx := range inPlayerNums {
-			if g.players[ndx].TotalBet > g.players[topBettor1].TotalBet {
-				topBettor2 = topBettor1
-				topBettor1 = ndx
-			} else if g.players[ndx].TotalBet > g.players[topBettor2].TotalBet {
-				topBettor2 = ndx
-			}
-		}
-
-		g.players[topBettor1].returnChips(g.players[topBettor1].TotalBet - g.players[topBettor2].TotalBet)
+	// Dead chips always go in the main pot, but don't count towards anyone's share of it
+	for i, p := range g.players {
+		g.pots[0].Amt += p.DeadChips
+		g.pots[0].Contributions[i] += p.DeadChips
 	}
 
-	//If there are two or more players in, and everybody has called or is all in, then end the hand f we've just finished river betting
-	if g.getStage() == River {
-		for i := range g.players {
-			g.players[i].PreviouslyAllIn = g.players[i].allIn(River)
-		}
+	// So do chips carried over from the last hand, which nobody contributed
+	g.pots[0].Amt += g.carryover
+}
 
-		for i := range g.pots {
-			g.pots[i].WinningScore = 8000
-
-			for _, num := range g.pots[i].EligiblePlayerNums {
-
-				hand, score := eval.BestFiveOfSeven(
-					g.players[num].Cards[0],
-					g.players[num].Cards[1],
-					g.communityCards[0],
-					g.communityCards[1],
-					g.communityCards[2],
-					g.communityCards[3],
-					g.communityCards[4],
-				)
-				// lower is better for the score
-				if score < g.pots[i].WinningScore {
-					g.pots[i].WinningScore = score
-					g.pots[i].WinningPlayerNums = []uint{num}
-					g.pots[i].WinningHand = hand
-				} else if score == g.pots[i].WinningScore {
-					g.pots[i].WinningPlayerNums = append(g.pots[i].WinningPlayerNums, num)
-				}
-			}
+// setPotCreator notes on pot that it was created by player pn going all-in
+func (g *Game) setPotCreator(pot *Pot, pn uint) {
+	pot.CreatedStage = g.players[pn].AllInStage
+	pot.CreatedByPlayerNum = pn
+}
 
-			for _, num := range g.pots[i].WinningPlayerNums {
-				g.players[num].Stack += (g.pots[i].Amt / uint(len(g.pots[i].WinningPlayerNums)))
-				//TODO: leave the remainder in the middle! (fractional money will disappear currently)
-			}
-		}
+// namePots labels each pot by its position: the first pot is the main pot, and every pot after it
+// is a side pot, numbered from 1.
+func (g *Game) namePots() {
+	for i := range g.pots {
+		g.pots[i].Name, g.pots[i].Side = potName(i)
+	}
+}
 
-		return g.resetForNextHand()
+// potName returns the name of the pot at index i, and whether it is a side pot
+func potName(i int) (string, bool) {
+	if i == 0 {
+		return "Main pot", false
 	}
 
-	// otherwise, just set betting to false so the dealer can deal the next part of the hand
-	g.setBetting(false)
-	Deal(g, g.dealerNum, 0)
-	return nil
+	return fmt.Sprintf("Side pot %d", i), true
 }
 
 var defaultConfig = GameConfig{
 	BigBlind:   25,
 	SmallBlind: 10,
 	MaxBuy:     0,
+	MinBuy:     0,
+	Rules:      DefaultRuleSet,
 }
 
 //Exported functions related to game management (not "Actions")
 
 // NewGame is a factory method that returns a pointer to an initialized game.
 // This freshly created game will have the following default values:
-// 	Players: []
-// 	GameStage: PreDeal
-// 	Betting: False
-// 	Config: {
-// 		BigBlind:	25
-// 		SmallBlind:	10
-// 		MaxBuy:		0
-// 	}
+//
+//	Players: []
+//	GameStage: PreDeal
+//	Betting: False
+//	Config: {
+//		BigBlind:	25
+//		SmallBlind:	10
+//		MaxBuy:		0
+//		MinBuy:		0
+//		Rules:		DefaultRuleSet
+//	}
 func NewGame(config *GameConfig) *Game {
 	newGame := Game{}
 
 	newGame.setStageAndBetting(PreDeal, false)
-	newGame.deck = eval.DefaultDeck
 	newGame.communityCards = make([]eval.Card, 5)
 
 	if config == nil {
 		newGame.config = defaultConfig
 	} else {
-		newGame.config = *config
+		newGame.config = copyConfig(*config)
 	}
 
+	newGame.deck = newGame.variant().deck()
+
 	newGame.initRand()
+	if newGame.config.CommitShuffle {
+		newGame.commitShuffle()
+	}
 
 	return &newGame
 }
@@ -441,8 +803,110 @@ func (g *Game) advanceRand() {
 	g.rand = rand.New(rand.NewSource(g.config.Seed))
 }
 
+// currentTime is the Game's clock. It can be swapped out (e.g. for testing) by setting g.now.
+func (g *Game) currentTime() time.Time {
+	if g.now == nil {
+		return time.Now()
+	}
+
+	return g.now()
+}
+
+// AddPlayer adds a new player to the Game, in the lowest numbered free seat, and returns their player number. If
+// every seat is taken, the player waits without a seat until one becomes free (see SeatPlayer).
 func (g *Game) AddPlayer() uint {
+	seat := g.freeSeat()
 	g.players = append(g.players, Player{})
 	g.players[len(g.players)-1].initialize()
+	g.players[len(g.players)-1].SeatNum = seat
+	g.players[len(g.players)-1].TimeBank = g.config.TimeBank
+
+	// Joining a game in progress is joining mid-orbit
+	if g.config.Rules.MissedBlinds && !g.handEnded.IsZero() {
+		g.players[len(g.players)-1].MissedBlinds = MissedBigBlind
+	}
+
 	return uint(len(g.players) - 1)
 }
+
+// RemovePlayer takes player pn out of the game, and returns the chips that should be credited back to them: their whole
+// stack. If they are in a hand, they are folded straight away, whether or not it is their turn, and forfeit whatever
+// they have bet in it. The player's number is kept (marked as left, with an empty stack), so the numbers of every other
+// player stay the same; AddPlayer never reuses it, though it can give their seat to somebody else. The chips credited
+// back are recorded as the player's TotalCashOut, and as their CashedOut, which at table-stakes tables they must buy
+// back in for if they come back (see BuyIn). RemovePlayer will return an error if pn is not a player, in which case
+// nothing is changed.
+func (g *Game) RemovePlayer(pn uint) (uint, error) {
+	if pn >= uint(len(g.players)) {
+		return 0, ErrIllegalAction
+	}
+
+	p := g.getPlayer(pn)
+
+	if p.In && g.getStage() != PreDeal {
+		if err := g.foldOutOfTurn(pn); err != nil {
+			return 0, err
+		}
+	}
+
+	// Nobody being left to deal is fine, if pn was the last player at the table
+	if err := g.leave(pn, true); err != nil && err != ErrNoValidDealer {
+		return 0, err
+	}
+
+	// Only now, as leaving before the deal hands back any dead chips
+	credit := p.Stack
+	p.Stack = 0
+	p.TotalCashOut += credit
+	p.CashedOut = credit
+
+	return credit, nil
+}
+
+// foldOutOfTurn folds player pn's hand, even if the action isn't on them, and carries on with the hand from there
+func (g *Game) foldOutOfTurn(pn uint) error {
+	if g.getBetting() && g.actionNum == pn {
+		return Fold(g, pn, 0)
+	}
+
+	showdown := g.leaveShowdown(pn)
+	g.players[pn].In = false
+	g.emit(Event{Kind: EventFold, PlayerNum: pn, Away: g.players[pn].Away})
+
+	var in uint
+	for _, p := range g.players {
+		if p.In {
+			in++
+		}
+	}
+
+	if g.getBetting() || in < 2 {
+		return g.updateRoundInfo()
+	}
+
+	// pn may have been the one the showdown was waiting on
+	if showdown {
+		return g.continueShowdown()
+	}
+
+	// Otherwise, the hand is waiting on discards, and pn may have been the last one left to discard
+	if g.getStage() != g.variant().discardStage() || g.discardsPending() {
+		return nil
+	}
+
+	return g.openBetting()
+}
+
+// ChipsInPlay returns the total number of chips at the table: every player's stack, everything committed to the
+// current hand, and any chips being carried over to the next one. Chips are only ever brought to the table by
+// BuyIn, and only ever taken away when a Rematch resets stacks, or a player is removed (see RemovePlayer), so for a
+// Game driven only by Actions, this always equals the sum of every player's TotalBuyIn less the sum of their
+// TotalCashOut.
+func (g *Game) ChipsInPlay() uint {
+	total := g.carryover
+	for _, p := range g.players {
+		total += p.Stack + p.TotalBet + p.DeadChips
+	}
+
+	return total
+}
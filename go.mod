@@ -5,6 +5,12 @@ go 1.13
 replace github.com/alexclewontin/riverboat/eval => ./eval
 
 require (
-	github.com/alexclewontin/riverboat/eval v0.2.2
-	github.com/stretchr/testify v1.6.1 // indirect
+	github.com/alexclewontin/riverboat/eval v0.2.3-0.20261015204536-743900b09bf0
+	github.com/alicebob/miniredis/v2 v2.14.1
+	github.com/golang/protobuf v1.4.2
+	github.com/gomodule/redigo v1.8.9
+	github.com/gorilla/websocket v1.4.2
+	github.com/mattn/go-sqlite3 v1.14.5
+	google.golang.org/grpc v1.33.2
+	google.golang.org/protobuf v1.25.0
 )
@@ -0,0 +1,145 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// HighHand is a hand shown down in the high-hand promotion (see GameConfig.HighHandQualifier)
+type HighHand struct {
+	PlayerNum uint `json:"playerNum"`
+	// Hand is the five cards played, including both of the player's hole cards, and Score its score
+	Hand  []eval.Card `json:"hand"`
+	Score int         `json:"score"`
+	// At is when the hand was shown down
+	At time.Time `json:"at"`
+}
+
+// HighHandView is a snapshot of a Game's high-hand promotion, for club operators to display: the hand leading the
+// current promotion, if any hand has qualified yet, and when the promotion started and ends. WindowStart and
+// WindowEnd are both the zero time if the promotion lasts as long as the Game.
+type HighHandView struct {
+	Leader      *HighHand `json:"leader,omitempty"`
+	Qualifier   int       `json:"qualifier"`
+	WindowStart time.Time `json:"windowStart"`
+	WindowEnd   time.Time `json:"windowEnd"`
+}
+
+// GenerateHighHandView returns a view of the Game's high-hand promotion, or nil if it doesn't run one. A hand that
+// led an earlier promotion isn't the leader of the current one.
+func (g *Game) GenerateHighHandView() *HighHandView {
+	if g.config.HighHandQualifier == 0 {
+		return nil
+	}
+
+	view := &HighHandView{Qualifier: g.config.HighHandQualifier}
+
+	if g.config.HighHandWindow != 0 {
+		view.WindowStart = g.currentTime().Truncate(g.config.HighHandWindow)
+		view.WindowEnd = view.WindowStart.Add(g.config.HighHandWindow)
+	}
+
+	view.Leader = copyHighHand(g.highHandLeader())
+
+	return view
+}
+
+func copyHighHand(src *HighHand) *HighHand {
+	if src == nil {
+		return nil
+	}
+
+	dst := *src
+	dst.Hand = append([]eval.Card{}, src.Hand...)
+	return &dst
+}
+
+// highHandLeader returns the hand leading the current high-hand promotion, or nil if none has qualified yet
+func (g *Game) highHandLeader() *HighHand {
+	if g.highHand == nil {
+		return nil
+	}
+
+	if w := g.config.HighHandWindow; w != 0 && g.highHand.At.Before(g.currentTime().Truncate(w)) {
+		return nil
+	}
+
+	return g.highHand
+}
+
+// trackHighHand checks the hands shown down against the leader of the high-hand promotion, if the table runs one.
+// Only a better hand takes the lead: of two equal hands, the one shown first keeps it.
+func (g *Game) trackHighHand() {
+	if g.config.HighHandQualifier == 0 || g.variant().stud() {
+		return
+	}
+
+	for _, r := range g.showdown {
+		if r.Mucked {
+			continue
+		}
+
+		hand, score := g.bestWithBoth(r)
+		if score > g.config.HighHandQualifier {
+			continue
+		}
+
+		if leader := g.highHandLeader(); leader != nil && leader.Score <= score {
+			continue
+		}
+
+		g.highHand = &HighHand{PlayerNum: r.PlayerNum, Hand: hand, Score: score, At: g.currentTime()}
+		g.emit(Event{Kind: EventHighHand, PlayerNum: r.PlayerNum, Cards: append([]eval.Card{}, hand...)})
+	}
+}
+
+// bestWithBoth finds the best hand, and its score, that plays both of the hole cards r shows and three of the
+// community cards. In Omaha, every hand plays two of the four.
+func (g *Game) bestWithBoth(r ShowdownReveal) ([]eval.Card, int) {
+	if g.variant() == Omaha {
+		return g.variant().bestHand(r.holeCards(), g.communityCards)
+	}
+
+	hole := r.Cards
+	board := g.communityCards
+	best := 8000
+	var hand []eval.Card
+
+	for i := 0; i < len(board); i++ {
+		for j := i + 1; j < len(board); j++ {
+			for k := j + 1; k < len(board); k++ {
+				score := g.variant().handValue(hole[0], hole[1], board[i], board[j], board[k])
+				if score < best {
+					best = score
+					hand = []eval.Card{hole[0], hole[1], board[i], board[j], board[k]}
+				}
+			}
+		}
+	}
+
+	return hand, best
+}
@@ -0,0 +1,150 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alexclewontin/riverboat/eval"
+	"github.com/alexclewontin/riverboat/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGame_HighHand(t *testing.T) {
+	card := eval.MustParseCardString
+	acesFull := eval.HandValue(card("As"), card("Ah"), card("Ad"), card("2c"), card("2d"))
+
+	config := defaultConfig
+	config.HighHandQualifier = acesFull
+	config.HighHandWindow = time.Hour
+	g := NewGame(&config)
+
+	now := time.Date(2020, 1, 1, 12, 30, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if view := g.GenerateHighHandView(); view.Leader != nil || !view.WindowEnd.Equal(now.Add(30*time.Minute)) {
+		t.Errorf("Test failed - expected no leader in the promotion running until 1pm, got %+v", view)
+	}
+
+	// Player 0 makes aces full with both hole cards, and player 1 only two pair
+	playHand := func(hole0, hole1 []string, board ...string) {
+		if err := Deal(g, g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+		rigHoleCards(g, 0, hole0...)
+		rigHoleCards(g, 1, hole1...)
+
+		for g.getStage() != PreDeal {
+			if g.getStage() == River {
+				for i, s := range board {
+					g.communityCards[i] = card(s)
+				}
+			}
+			if err := Bet(g, g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+				t.Fatalf("Test failed - error calling: %s", err)
+			}
+		}
+	}
+
+	before := g.GenerateOmniView()
+	playHand([]string{"As", "Ad"}, []string{"Kh", "Kd"}, "Ac", "2c", "2d", "7h", "9s")
+
+	view := g.GenerateHighHandView()
+	if view.Leader == nil || view.Leader.PlayerNum != 0 || view.Leader.Score != acesFull {
+		t.Fatalf("Test failed - expected player 0 to lead with aces full, got %+v", view.Leader)
+	}
+
+	var events int
+	for _, e := range g.Events() {
+		if e.Kind == EventHighHand {
+			events++
+		}
+	}
+	if events != 1 {
+		t.Errorf("Test failed - expected one EventHighHand, got %d", events)
+	}
+
+	// The new leader is patched into views
+	patch, err := DiffViews(before, g.GenerateOmniView())
+	if err != nil {
+		t.Fatalf("Test failed - error diffing views: %s", err)
+	}
+	if err := before.Patch(patch); err != nil {
+		t.Fatalf("Test failed - error patching the view: %s", err)
+	}
+	if before.HighHand == nil || before.HighHand.Score != acesFull {
+		t.Errorf("Test failed - expected the patch to carry the new leader, got %+v", before.HighHand)
+	}
+
+	// The leader survives a round trip through a view, as JSON and as protobuf
+	b, err := json.Marshal(g.GenerateOmniView())
+	if err != nil {
+		t.Fatalf("Test failed - error marshalling the view: %s", err)
+	}
+	var decoded GameView
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Test failed - error unmarshalling the view: %s", err)
+	}
+	m := &pb.GameView{}
+	if b, err = proto.Marshal(decoded.ToProto()); err != nil {
+		t.Fatalf("Test failed - error marshalling the protobuf: %s", err)
+	}
+	if err := proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("Test failed - error unmarshalling the protobuf: %s", err)
+	}
+	var fromProto GameView
+	fromProto.FromProto(m)
+
+	restored := &Game{now: g.now}
+	restored.FillFromView(&fromProto)
+	got := restored.GenerateHighHandView().Leader
+	if got == nil || got.PlayerNum != 0 || got.Score != acesFull || !reflect.DeepEqual(got.Hand, view.Leader.Hand) || !got.At.Equal(view.Leader.At) {
+		t.Errorf("Test failed - expected the restored leader to be %+v, got %+v", view.Leader, got)
+	}
+
+	// Quads on the board don't play both hole cards, so don't count
+	playHand([]string{"Kh", "Qd"}, []string{"3h", "4d"}, "9c", "9d", "9h", "9s", "2s")
+	if view := g.GenerateHighHandView(); view.Leader.PlayerNum != 0 {
+		t.Errorf("Test failed - expected a hand the board plays not to take the lead, got %+v", view.Leader)
+	}
+
+	// The next promotion starts without a leader
+	now = now.Add(time.Hour)
+	if view := g.GenerateHighHandView(); view.Leader != nil {
+		t.Errorf("Test failed - expected the lead to end with the promotion, got %+v", view.Leader)
+	}
+
+	if g := NewGame(nil); g.GenerateHighHandView() != nil {
+		t.Errorf("Test failed - expected no view of a promotion the table doesn't run")
+	}
+}
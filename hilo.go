@@ -0,0 +1,97 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// The highest card (as an ace-to-five lowball rank) a hand may have and still qualify for low in a hi-lo game
+const lowQualifier = 8
+
+// potAward is a single share of a pot, awarded to one player
+type potAward struct {
+	playerNum uint
+	amt       uint
+	low       bool
+}
+
+// hiLo reports whether pots are split between the best high and low hands: at tables that play RuleSet.HiLo, in
+// every variant but Razz, which is played for low alone
+func (g *Game) hiLo() bool {
+	return g.config.Rules.HiLo && !g.variant().stud()
+}
+
+// findLowWinners fills in the pot's Low fields with the claimants (see claimants) holding the best qualifying low, if
+// any.
+func (g *Game) findLowWinners(pot *Pot, claimants []uint) {
+	pot.LowWinningPlayerNums = []uint{}
+	pot.LowWinningHand = []eval.Card{}
+	pot.LowWinningScore = 0
+
+	for _, num := range claimants {
+		hand, score := g.variant().bestLow(g.players[num].holeCards(), g.communityCards)
+
+		if !eval.QualifiesLow(score, lowQualifier) {
+			continue
+		}
+
+		// lower is better for the score
+		if len(pot.LowWinningPlayerNums) == 0 || score < pot.LowWinningScore {
+			pot.LowWinningScore = score
+			pot.LowWinningPlayerNums = []uint{num}
+			pot.LowWinningHand = hand
+		} else if score == pot.LowWinningScore {
+			pot.LowWinningPlayerNums = append(pot.LowWinningPlayerNums, num)
+		}
+	}
+}
+
+// potAwards returns every share of the pot that is awarded, high half first, and how much of the pot is carried over
+// to the next hand. If the pot has low winners, it is split in half between the high and low winners, with the odd
+// chip going to the high half; otherwise the high winners split all of it. Each half is split between its winners
+// according to the OddChipRule, so a player who wins both halves, or ties for one, gets each share separately.
+func (g *Game) potAwards(pot *Pot) ([]potAward, uint) {
+	awards := []potAward{}
+
+	highAmt := pot.Amt
+	if len(pot.LowWinningPlayerNums) > 0 {
+		highAmt -= pot.Amt / 2
+	}
+
+	shares, carry := g.splitAmt(highAmt, pot.WinningPlayerNums)
+	for j, share := range shares {
+		awards = append(awards, potAward{playerNum: pot.WinningPlayerNums[j], amt: share})
+	}
+
+	if len(pot.LowWinningPlayerNums) > 0 {
+		shares, lowCarry := g.splitAmt(pot.Amt-highAmt, pot.LowWinningPlayerNums)
+		for j, share := range shares {
+			awards = append(awards, potAward{playerNum: pot.LowWinningPlayerNums[j], amt: share, low: true})
+		}
+		carry += lowCarry
+	}
+
+	return awards, carry
+}
@@ -0,0 +1,121 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestGame_HiLoAwards(t *testing.T) {
+	g := NewGame(nil)
+	g.config.Rules.HiLo = true
+	for i := 0; i < 3; i++ {
+		g.AddPlayer()
+	}
+	g.dealerNum = 0
+
+	cards := func(s ...string) []eval.Card {
+		ret := []eval.Card{}
+		for _, c := range s {
+			ret = append(ret, eval.MustParseCardString(c))
+		}
+		return ret
+	}
+
+	copy(g.communityCards, cards("2c", "5d", "7h", "Kc", "Ks"))
+	// Player 0 has trip kings, but no low. Players 1 and 2 tie for low with 7-5-3-2-A.
+	copy(g.players[0].Cards[:], cards("Kh", "Qh"))
+	copy(g.players[1].Cards[:], cards("Ah", "3s"))
+	copy(g.players[2].Cards[:], cards("Ad", "3d"))
+
+	tests := []struct {
+		eligible []uint
+		want     []potAward
+	}{
+		// The low half is quartered between players 1 and 2; the high half gets the odd chip
+		{[]uint{0, 1, 2}, []potAward{{0, 51, false}, {1, 25, true}, {2, 25, true}}},
+		// Without a qualifying low, the high hand scoops
+		{[]uint{0}, []potAward{{0, 101, false}}},
+	}
+
+	for _, tt := range tests {
+		pot := Pot{Amt: 101, EligiblePlayerNums: tt.eligible, WinningPlayerNums: []uint{0}}
+		g.findLowWinners(&pot, pot.EligiblePlayerNums)
+
+		if got, _ := g.potAwards(&pot); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Test failed - eligible players %v were awarded %v, expected %v", tt.eligible, got, tt.want)
+		}
+	}
+}
+
+func TestGame_OmahaHiLo(t *testing.T) {
+	config := defaultConfig
+	config.Variant = Omaha
+	config.Rules.HiLo = true
+	g := NewGame(&config)
+	for i := 0; i < 3; i++ {
+		g.AddPlayer()
+	}
+	g.dealerNum = 0
+
+	cards := func(s ...string) []eval.Card {
+		ret := []eval.Card{}
+		for _, c := range s {
+			ret = append(ret, eval.MustParseCardString(c))
+		}
+		return ret
+	}
+
+	copy(g.communityCards, cards("2c", "5d", "7h", "Kc", "Ks"))
+	// Player 0 plays Kd-Qd for trip kings, and has no low. Player 1 plays Ah-3h for 7-5-3-2-A, which beats player 2's
+	// 7-5-4-3-2: their 6-5-4-3-2 would take three cards from their hand.
+	hands := [][]eval.Card{
+		cards("Kd", "Qd", "9h", "9c"),
+		cards("Ah", "3h", "Jc", "Jd"),
+		cards("6s", "4s", "3s", "9d"),
+	}
+	for i, h := range hands {
+		copy(g.players[i].Cards[:], h[:2])
+		copy(g.players[i].ExtraCards[:], h[2:])
+	}
+
+	scores := make([]int, len(g.players))
+	for i := range g.players {
+		_, scores[i] = g.variant().bestHand(g.players[i].holeCards(), g.communityCards)
+	}
+	if scores[0] >= scores[1] || scores[0] >= scores[2] {
+		t.Errorf("Test failed - expected player 0's trip kings to be the best high hand, got scores %v", scores)
+	}
+
+	pot := Pot{Amt: 101, EligiblePlayerNums: []uint{0, 1, 2}, WinningPlayerNums: []uint{0}}
+	g.findLowWinners(&pot, pot.EligiblePlayerNums)
+
+	want := []potAward{{0, 51, false}, {1, 50, true}}
+	if got, _ := g.potAwards(&pot); !reflect.DeepEqual(got, want) {
+		t.Errorf("Test failed - expected %v, got %v", want, got)
+	}
+}
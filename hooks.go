@@ -0,0 +1,80 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// The hooks below are conveniences built on Subscribe, for integrators who only care about a few kinds of change.
+// Like Subscribe, each returns a function that cancels the registration, and each callback is called synchronously,
+// while the Action that caused the change is still in progress, so callbacks must not perform Actions on g.
+
+// OnStageChange registers fn to be called with the new stage whenever g moves to a different stage:
+// when a hand is dealt, when the flop, turn or river (or in stud, the next street) is dealt, and when a hand ends or
+// is called off (see Misdeal).
+func (g *Game) OnStageChange(fn func(stage GameStage)) (cancel func()) {
+	// In stud, every player's cards for a street are recorded separately
+	var last GameStage
+
+	return g.Subscribe(func(e Event) {
+		stage := last
+		switch e.Kind {
+		case EventHandStart, EventCommunityCards, EventUpCard, EventDownCard:
+			stage = e.Stage
+		case EventHandEnd, EventMisdeal:
+			stage = PreDeal
+		}
+
+		if stage != last {
+			last = stage
+			fn(stage)
+		}
+	})
+}
+
+// OnPlayerAction registers fn to be called with the Event recorded whenever a player successfully performs an Action
+// (see Event.PlayerAction).
+func (g *Game) OnPlayerAction(fn func(e Event)) (cancel func()) {
+	return g.Subscribe(func(e Event) {
+		if e.PlayerAction() {
+			fn(e)
+		}
+	})
+}
+
+// OnHandEnd registers fn to be called whenever a hand ends, after every pot has been awarded.
+func (g *Game) OnHandEnd(fn func()) (cancel func()) {
+	return g.Subscribe(func(e Event) {
+		if e.Kind == EventHandEnd {
+			fn()
+		}
+	})
+}
+
+// OnPotAwarded registers fn to be called once for every player awarded (a share of) a pot, with the index of the
+// pot, the player number of the winner, and the amount they won.
+func (g *Game) OnPotAwarded(fn func(potNum uint, pn uint, amt uint)) (cancel func()) {
+	return g.Subscribe(func(e Event) {
+		if e.Kind == EventPotAward {
+			fn(e.PotNum, e.PlayerNum, e.Amount)
+		}
+	})
+}
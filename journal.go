@@ -0,0 +1,207 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// ReplayEvents rebuilds a Game from its event log (see Game.Events), so the log can be the record a server keeps of
+// a table, rather than periodic snapshots of its views: after a crash, the table is rebuilt as it was after the last
+// Event journaled. config must be the GameConfig the Game was created with, Seed included, as the log doesn't record
+// it. Every Event that something done to the Game caused, like a Bet or a call to Pause, is done again, and every
+// Event that followed from it, like the cards dealt when the betting on a street closed, is checked against the log,
+// so the rebuilt Game has dealt the same cards, and has its random source at the same place, ready to shuffle the
+// next hand the same way.
+//
+// The log doesn't record time, so the Game's clocks (time banks, deadlines and the like) start afresh, and nor does it
+// record changes made other than by Actions or by the Game's methods that record Events, like FillFromView, or
+// shuffling with an RNG of its own (see SetRandSource). ReplayEvents returns ErrJournalMismatch, with the Game as it
+// was before the Event that didn't match, if the log isn't one the Game would have recorded, and the same for the
+// error of an Action that fails.
+//
+// Only a log that records everything needed to deal the same cards again can be replayed. ReplayEvents returns
+// ErrNotReplayable, and no Game, if config is nil or has no Seed (NewGame seeds those from the clock), if it has
+// CommitShuffle (each hand is shuffled with a fresh Seed the log doesn't record), or if its Retention is anything
+// but RetainAll (the hole cards dealt are discarded from the log once each hand ends). events must be the whole log,
+// as a subscriber journals it (see Subscribe), not what is left of it in a Game with an EventHistory.
+func ReplayEvents(config *GameConfig, events []Event) (*Game, error) {
+	if config == nil || config.Seed == 0 || config.CommitShuffle || config.Retention != RetainAll {
+		return nil, ErrNotReplayable
+	}
+
+	// The log is checked against the Game's own as it is replayed, so the Game keeps all of it until the end
+	full := *config
+	full.EventHistory = 0
+	g := NewGame(&full)
+	defer func() {
+		g.config.EventHistory = config.EventHistory
+		g.trimEvents()
+	}()
+
+	// Time stands still while the log is replayed, except to let what happened in it happen again
+	clock := time.Now()
+	g.now = func() time.Time { return clock }
+	defer func() { g.now = nil }()
+
+	for i, e := range events {
+		if i >= len(g.events) {
+			if e.Kind == EventEmote {
+				clock = clock.Add(emoteInterval)
+			}
+
+			if err := g.redo(e); err != nil {
+				return g, err
+			}
+		}
+
+		if i >= len(g.events) || !sameEvent(g.events[i], e) {
+			return g, ErrJournalMismatch
+		}
+	}
+
+	return g, nil
+}
+
+// redo does again whatever caused e
+func (g *Game) redo(e Event) error {
+	pn := e.PlayerNum
+
+	switch e.Kind {
+	case EventHandStart, EventBurn, EventCommunityCards, EventPause, EventResume, EventTimeout:
+	default:
+		// Anything else is done by, or to, a player, who may not have been added yet
+		for uint(len(g.players)) <= pn {
+			g.AddPlayer()
+		}
+	}
+
+	switch e.Kind {
+	case EventBuyIn:
+		return BuyIn(g, pn, e.Amount)
+	case EventReady, EventNotReady:
+		return ToggleReady(g, pn, 0)
+	case EventLeave:
+		if e.Amount != 0 {
+			_, err := g.RemovePlayer(pn)
+			return err
+		}
+		return Leave(g, pn, 0)
+	case EventHandStart, EventBurn, EventCommunityCards, EventUpCard, EventDownCard:
+		return Deal(g, g.dealingNum(), 0)
+	case EventBet:
+		return Bet(g, pn, e.Amount)
+	case EventFold:
+		if g.getBetting() && g.actionNum == pn {
+			return Fold(g, pn, 0)
+		}
+		// Only a player who is removed folds out of turn
+		_, err := g.RemovePlayer(pn)
+		return err
+	case EventShow:
+		return Show(g, pn, 0)
+	case EventMuck:
+		return Muck(g, pn, 0)
+	case EventEmote:
+		return Emote(g, pn, uint(e.Emote))
+	case EventDeadChips:
+		return PostDead(g, pn, e.Amount)
+	case EventAway, EventBack:
+		return ToggleAway(g, pn, 0)
+	case EventDiscard:
+		return Discard(g, pn, g.discardIndex(pn, e.Cards))
+	case EventRematchAccept:
+		return Rematch(g, pn, 0)
+	case EventSitOut:
+		return SitOut(g, pn, 0)
+	case EventSitIn:
+		return SitIn(g, pn, 0)
+	case EventTimeout:
+		return g.timeOut(pn)
+	case EventSeat:
+		return ChangeSeat(g, pn, e.Amount)
+	case EventShowCards:
+		return ShowCards(g, pn, g.showCardsMask(pn, e.Cards))
+	case EventUndo:
+		return Undo(g, pn, 0)
+	case EventStraddle:
+		return Straddle(g, pn, e.Amount)
+	case EventPostMissed:
+		return PostMissedBlinds(g, pn, e.Amount)
+	case EventMisdeal:
+		return Misdeal(g, pn, 0)
+	case EventPause:
+		return g.Pause()
+	case EventResume:
+		return g.Resume()
+	}
+
+	// Everything else only ever follows from something else
+	return ErrJournalMismatch
+}
+
+// discardIndex returns the data Discard takes for player pn to discard the card in cards
+func (g *Game) discardIndex(pn uint, cards []eval.Card) uint {
+	p := g.getPlayer(pn)
+	for i, c := range p.Cards {
+		if len(cards) == 1 && cards[0] == c {
+			return uint(i)
+		}
+	}
+
+	return 2
+}
+
+// showCardsMask returns the data ShowCards takes for player pn to show the cards in cards
+func (g *Game) showCardsMask(pn uint, cards []eval.Card) uint {
+	var mask uint
+	for i, c := range g.getPlayer(pn).Cards {
+		for _, shown := range cards {
+			if c != 0 && c == shown {
+				mask |= 1 << uint(i)
+			}
+		}
+	}
+
+	return mask
+}
+
+// sameEvent reports whether a and b record the same thing, the same way
+func sameEvent(a, b Event) bool {
+	if len(a.Cards) != len(b.Cards) {
+		return false
+	}
+	for i := range a.Cards {
+		if a.Cards[i] != b.Cards[i] {
+			return false
+		}
+	}
+
+	a.Cards, b.Cards = nil, nil
+
+	return reflect.DeepEqual(a, b)
+}
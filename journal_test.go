@@ -0,0 +1,181 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReplayEvents(t *testing.T) {
+	config := GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7, Rules: DefaultRuleSet}
+	g := NewGame(&config)
+	for i := 0; i < 4; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	mustDo := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Test failed - error playing the session: %s", err)
+		}
+	}
+
+	// A hand with a raise, a player marked away mid-hand, and an emote
+	mustDo(Deal(g, g.dealingNum(), 0))
+	mustDo(Bet(g, g.actionNum, 75))
+	away := g.next(g.actionNum)
+	mustDo(ToggleAway(g, away, 0))
+	mustDo(Emote(g, 0, uint(EmoteNiceHand)))
+	for g.getStage() != PreDeal {
+		mustDo(Bet(g, g.actionNum, g.ToCall(g.actionNum)))
+	}
+
+	// A hand in which a player is removed out of turn, and another leaves at the end
+	mustDo(ToggleAway(g, away, 0))
+	mustDo(Deal(g, g.dealingNum(), 0))
+	mustDo(Fold(g, g.actionNum, 0))
+	_, err := g.RemovePlayer(g.next(g.actionNum))
+	mustDo(err)
+	for g.getStage() != PreDeal {
+		mustDo(Bet(g, g.actionNum, g.ToCall(g.actionNum)))
+	}
+	mustDo(Leave(g, 3, 0))
+	mustDo(g.Pause())
+
+	rebuilt, err := ReplayEvents(&config, g.Events())
+	if err != nil {
+		t.Fatalf("Test failed - ReplayEvents returned %s", err)
+	}
+
+	got, want := rebuilt.GenerateOmniView(), g.GenerateOmniView()
+	got.HandEnded = want.HandEnded
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Test failed - the rebuilt game is %+v\nwant %+v", got, want)
+	}
+
+	// The random source is where it was, so the next hand is dealt the same
+	for _, game := range []*Game{g, rebuilt} {
+		mustDo(game.Resume())
+		mustDo(Deal(game, game.dealingNum(), 0))
+	}
+	if rebuilt.players[0].Cards != g.players[0].Cards || rebuilt.players[1].Cards != g.players[1].Cards {
+		t.Errorf("Test failed - the rebuilt game dealt %v and %v, want %v and %v", rebuilt.players[0].Cards,
+			rebuilt.players[1].Cards, g.players[0].Cards, g.players[1].Cards)
+	}
+
+	// A log recorded with another seed deals other cards
+	other := config
+	other.Seed = 8
+	if _, err := ReplayEvents(&other, g.Events()); err != ErrJournalMismatch {
+		t.Errorf("Test failed - ReplayEvents with the wrong seed should return ErrJournalMismatch, got %v", err)
+	}
+
+	// And one that claims something followed that didn't
+	events := g.Events()
+	events = append(events[:1], append([]Event{{Seq: 2, Kind: EventPotAward, Amount: 100}}, events[1:]...)...)
+	if _, err := ReplayEvents(&config, events); err != ErrJournalMismatch {
+		t.Errorf("Test failed - ReplayEvents of a forged log should return ErrJournalMismatch, got %v", err)
+	}
+}
+
+func TestReplayEvents_NotReplayable(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *GameConfig
+	}{
+		{"no config", nil},
+		{"no seed", &GameConfig{BigBlind: 25, SmallBlind: 10}},
+		{"a committed shuffle", &GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7, CommitShuffle: true}},
+		{"hands discarded", &GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7, Retention: RetainShown}},
+	}
+
+	for _, tt := range tests {
+		// NewGame fills in a Seed it isn't given, so the log is replayed with the config as it was written
+		replayed := tt.config
+		if tt.config != nil {
+			config := *tt.config
+			replayed = &config
+		}
+
+		g := seatedGame(t, tt.config, 2, 1000)
+		if err := Deal(g, g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+
+		if rebuilt, err := ReplayEvents(replayed, g.Events()); err != ErrNotReplayable || rebuilt != nil {
+			t.Errorf("Test failed - expected ReplayEvents of a log with %s to return ErrNotReplayable, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestReplayEvents_PostMissedBlinds(t *testing.T) {
+	config := GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7, Rules: DefaultRuleSet}
+	config.Rules.MissedBlinds = true
+	g := NewGame(&config)
+
+	mustDo := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Test failed - error playing the session: %s", err)
+		}
+	}
+
+	for i := 0; i < 4; i++ {
+		pn := g.AddPlayer()
+		mustDo(BuyIn(g, pn, 1000))
+		mustDo(ToggleReady(g, pn, 0))
+	}
+	mustDo(Deal(g, g.dealingNum(), 0))
+	for g.getStage() != PreDeal {
+		mustDo(Fold(g, g.actionNum, 0))
+	}
+
+	// A player joining mid-orbit posts their missed big blind to be dealt straight in
+	late := g.AddPlayer()
+	mustDo(BuyIn(g, late, 1000))
+	mustDo(ToggleReady(g, late, 0))
+	mustDo(PostMissedBlinds(g, late, 1))
+	mustDo(Deal(g, g.dealingNum(), 0))
+	if !g.players[late].In {
+		t.Fatalf("Test failed - the player who posted their missed blinds should be dealt in")
+	}
+
+	rebuilt, err := ReplayEvents(&config, g.Events())
+	if err != nil {
+		t.Fatalf("Test failed - ReplayEvents returned %s", err)
+	}
+
+	got, want := rebuilt.GenerateOmniView(), g.GenerateOmniView()
+	got.HandEnded = want.HandEnded
+	got.ActionDeadline = want.ActionDeadline
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Test failed - the rebuilt game is %+v\nwant %+v", got, want)
+	}
+}
@@ -0,0 +1,86 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+)
+
+// ViewSchemaVersion is the version of the JSON encoding of GameView produced by MarshalJSON. It is increased
+// whenever the encoding changes in a way that older payloads need to be migrated to be read correctly.
+//
+// Version 0 is the encoding produced by encoding/json's defaults, before GameView had a versioned
+// encoding: it has no version field, Go field names as keys, cards as numbers, and pots without names.
+//
+// Version 1 has no seat numbers: players are seated in order of player number.
+const ViewSchemaVersion = 2
+
+// MarshalJSON encodes gv along with the current ViewSchemaVersion, using stable field names that
+// do not depend on the names of GameView's Go fields.
+func (gv GameView) MarshalJSON() ([]byte, error) {
+	type view GameView
+	return json.Marshal(struct {
+		Version uint `json:"version"`
+		view
+	}{ViewSchemaVersion, view(gv)})
+}
+
+// UnmarshalJSON decodes a GameView encoded by MarshalJSON, in the current or any earlier schema version,
+// and migrates it to the current version. Payloads from a newer schema version than this one are
+// decoded on a best-effort basis.
+func (gv *GameView) UnmarshalJSON(b []byte) error {
+	type view GameView
+	aux := struct {
+		Version uint `json:"version"`
+		*view
+	}{view: (*view)(gv)}
+
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	migrateView(gv, aux.Version)
+
+	return nil
+}
+
+// migrateView brings gv, decoded from a payload of the given schema version, up to date.
+func migrateView(gv *GameView, version uint) {
+	if version < 1 {
+		migratePots(gv.Pots)
+	}
+	if version < 2 {
+		migrateSeats(gv.Players)
+	}
+}
+
+// migratePots names any pots that have no name, as pots in version 0 payloads had none. It is safe to
+// apply to pots that are already current, so FillFromView can apply it to views of unknown origin.
+func migratePots(pots []Pot) {
+	for i := range pots {
+		if pots[i].Name == "" {
+			pots[i].Name, pots[i].Side = potName(i)
+		}
+	}
+}
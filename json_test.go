@@ -0,0 +1,103 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestGameView_JSONRoundTrip(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 100)
+		ToggleReady(g, pn, 0)
+	}
+	Deal(g, 0, 0)
+	Bet(g, 0, 100)
+
+	view := g.GenerateOmniView()
+
+	b, err := json.Marshal(view)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	if !strings.Contains(string(b), `"version":2`) || !strings.Contains(string(b), `"dealerNum"`) {
+		t.Errorf("json.Marshal() is missing the version or stable field names: %s", b)
+	}
+
+	got := &GameView{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, view) {
+		t.Errorf("json round trip = %+v\nwant %+v", got, view)
+	}
+}
+
+func TestGameView_UnmarshalJSONVersion0(t *testing.T) {
+	ace := eval.MustParseCardString("AS")
+	king := eval.MustParseCardString("KS")
+
+	// The default encoding/json output, from before GameView had a versioned encoding
+	legacy := `{"DealerNum":1,"ActionNum":2,"UTGNum":2,"SBNum":0,"BBNum":1,"CalledNum":0,` +
+		`"CommunityCards":[0,0,0,0,0],"Stage":2,"Betting":true,` +
+		`"Config":{"MaxBuy":0,"BigBlind":25,"SmallBlind":10,"Seed":0},` +
+		`"Players":[{"Ready":true,"In":true,"Stack":90,"Bet":10,"TotalBet":10,"Cards":[` +
+		string(mustMarshalNumber(ace)) + `,` + string(mustMarshalNumber(king)) + `]}],` +
+		`"Deck":null,"Pots":[{"TopShare":0,"Amt":35},{"TopShare":0,"Amt":0}],"MinRaise":25,"ReadyCount":3}`
+
+	gv := &GameView{}
+	if err := json.Unmarshal([]byte(legacy), gv); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if gv.UTGNum != 2 || gv.Stage != PreFlop || gv.Config.BigBlind != 25 || gv.Players[0].Stack != 90 {
+		t.Errorf("json.Unmarshal() did not decode version 0 fields: %+v", gv)
+	}
+
+	if gv.Players[0].Cards != [2]eval.Card{ace, king} {
+		t.Errorf("json.Unmarshal() did not decode version 0 cards: %v", gv.Players[0].Cards)
+	}
+
+	if gv.Pots[0].Name != "Main pot" || gv.Pots[1].Name != "Side pot 1" || !gv.Pots[1].Side {
+		t.Errorf("json.Unmarshal() did not migrate version 0 pots: %+v", gv.Pots)
+	}
+}
+
+func mustMarshalNumber(c eval.Card) []byte {
+	b, err := json.Marshal(int32(c))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,101 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// KillMode decides whether a limit game plays kill pots, and at what stakes (see GameConfig.Kill). A player who wins
+// the whole of the main pot in two hands running (or, in a hi-lo game, scoops both halves of it at showdown in one)
+// kills the next hand: they post a kill blind, which becomes the bet to call, and the hand is played at the raised
+// stakes. Like a straddler (see Straddle), the player who posted the kill blind acts last before the flop unless
+// somebody raises. They keep killing hands for as long as they keep winning them.
+type KillMode uint8
+
+const (
+	// NoKill plays no kill pots. This is the default.
+	NoKill KillMode = iota
+	// FullKill plays kill pots at double the stakes
+	FullKill
+	// HalfKill plays kill pots at one and a half times the stakes
+	HalfKill
+)
+
+// killStakes returns amt, at the stakes of the hand being played: raised if it is a kill pot
+func (g *Game) killStakes(amt uint) uint {
+	if !g.kill {
+		return amt
+	}
+
+	switch g.config.Kill {
+	case FullKill:
+		return 2 * amt
+	case HalfKill:
+		return amt + amt/2
+	}
+
+	return amt
+}
+
+// updateKill decides, as a hand ends, whether the next hand is a kill pot
+func (g *Game) updateKill() {
+	won := g.potWon && g.config.Kill != NoKill && g.limitBetting()
+
+	g.kill = won && (g.potScooped || (g.lastWon && g.lastWinner == g.potWinner))
+	g.killNum = g.potWinner
+	g.lastWinner, g.lastWon = g.potWinner, won
+}
+
+// postKill posts the kill blind, if the hand is a kill pot, and returns how much the player who killed it put in.
+// The kill blind is the big blind at the raised stakes, and tops up any blind the player has posted already. If the
+// player isn't dealt in, the hand isn't a kill pot after all. It should be called once the blinds are in.
+func (g *Game) postKill() uint {
+	for i := range g.players {
+		g.players[i].Kill = false
+	}
+
+	if !g.kill {
+		return 0
+	}
+	p := &g.players[g.killNum]
+	if !p.In {
+		g.kill = false
+		g.minRaise = g.config.BigBlind
+		return 0
+	}
+
+	before := p.Stack
+	if amt := g.killStakes(g.config.BigBlind); amt > p.Bet {
+		p.putInChips(amt-p.Bet, g.config.HandCap)
+	}
+	// Like the straddler, the player who killed the pot is passed over until everybody else has acted
+	p.Called = true
+	p.Kill = true
+
+	return before - p.Stack
+}
+
+// killOption reports whether the player who posted the kill blind is owed the option to act once everybody else has
+// called it. The kill is cleared once they act, so this is only the case if nobody has raised.
+func (g *Game) killOption() bool {
+	p := g.players[g.killNum]
+	return g.kill && p.Kill && p.In && !g.allIn(g.killNum)
+}
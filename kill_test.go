@@ -0,0 +1,206 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// winHand deals a hand in which everybody but player pn folds, and pn calls if they need to act
+func winHand(t *testing.T, g *Game, pn uint) {
+	t.Helper()
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	for g.getStage() != PreDeal {
+		var err error
+		if g.actionNum == pn {
+			err = Bet(g, pn, g.toCall()-g.players[pn].Bet)
+		} else {
+			err = Fold(g, g.actionNum, 0)
+		}
+		if err != nil {
+			t.Fatalf("Test failed - error playing the hand: %s", err)
+		}
+	}
+}
+
+func TestGame_Kill(t *testing.T) {
+	g := seatedGame(t, &GameConfig{BigBlind: 20, SmallBlind: 10, Betting: SpreadLimit, SpreadMin: 20, SpreadMax: 100,
+		Kill: FullKill}, 3, 1000)
+
+	winHand(t, g, 0)
+	if g.GenerateOmniView().Kill {
+		t.Fatalf("Test failed - expected one pot won not to kill the next hand")
+	}
+	winHand(t, g, 0)
+	if view := g.GenerateOmniView(); !view.Kill || view.KillNum != 0 {
+		t.Fatalf("Test failed - expected player 0 to kill the next hand, got %t for player %d", view.Kill, view.KillNum)
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if p := g.players[0]; p.Bet != 40 || !p.Kill {
+		t.Fatalf("Test failed - expected player 0 to post a kill blind of 40, got %+v", p)
+	}
+
+	// Everybody calls the kill blind, and the player who posted it gets the option
+	for g.actionNum != 0 {
+		pn := g.actionNum
+		if err := Bet(g, pn, 40-g.players[pn].Bet); err != nil {
+			t.Fatalf("Test failed - error calling the kill blind: %s", err)
+		}
+	}
+	if g.getStage() != PreFlop {
+		t.Fatalf("Test failed - expected player 0 to have the option before the flop")
+	}
+
+	// The spread is doubled too
+	if err := Bet(g, 0, 201); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected ErrIllegalAction raising more than the killed spread, got %v", err)
+	}
+	if err := Bet(g, 0, 200); err != nil {
+		t.Errorf("Test failed - error raising the killed spread: %s", err)
+	}
+}
+
+func TestGame_KillRestored(t *testing.T) {
+	g := seatedGame(t, &GameConfig{BigBlind: 20, SmallBlind: 10, Betting: SpreadLimit, SpreadMin: 20, SpreadMax: 100,
+		Kill: FullKill}, 3, 1000)
+
+	winHand(t, g, 0)
+
+	// The first pot won is kept through a save and restore, as a GameStore would
+	var view GameView
+	view.FromProto(g.GenerateOmniView().ToProto())
+	restored := NewGame(nil)
+	restored.FillFromView(&view)
+
+	winHand(t, restored, 0)
+	if view := restored.GenerateOmniView(); !view.Kill || view.KillNum != 0 {
+		t.Errorf("Test failed - expected player 0 to kill the next hand after a restore, got %t for player %d",
+			view.Kill, view.KillNum)
+	}
+}
+
+func TestGame_KillModes(t *testing.T) {
+	tests := []struct {
+		name string
+		// wins is how many hands running player 0 wins
+		wins   int
+		config GameConfig
+		want   uint
+	}{
+		{"a half kill", 2, GameConfig{Betting: SpreadLimit, SpreadMin: 20, SpreadMax: 100, Kill: HalfKill}, 30},
+		// Only a scoop at showdown kills a hi-lo pot in one hand (see TestGame_KillHiLoScoop)
+		{"an uncontested hi-lo pot", 1, GameConfig{Betting: SpreadLimit, SpreadMin: 20, SpreadMax: 100, Kill: FullKill,
+			Rules: RuleSet{HiLo: true}}, 0},
+		{"a fixed-limit game", 2, GameConfig{Betting: FixedLimit, Kill: FullKill}, 40},
+		{"a no-limit game", 2, GameConfig{Kill: FullKill}, 0},
+	}
+
+	for _, tt := range tests {
+		tt.config.BigBlind, tt.config.SmallBlind = 20, 10
+		g := seatedGame(t, &tt.config, 3, 1000)
+
+		for i := 0; i < tt.wins; i++ {
+			winHand(t, g, 0)
+		}
+		if err := Deal(g, g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+
+		var got uint
+		if g.kill && g.killNum == 0 {
+			got = g.players[0].Bet
+		}
+		if got != tt.want {
+			t.Errorf("Test failed - expected %s to have a kill blind of %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestGame_KillHiLoScoop(t *testing.T) {
+	g := seatedGame(t, &GameConfig{BigBlind: 20, SmallBlind: 10, Betting: SpreadLimit, SpreadMin: 20, SpreadMax: 100,
+		Kill: FullKill, Rules: RuleSet{HiLo: true}}, 3, 1000)
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Player 0 makes a wheel, the best high hand and the best low, and scoops the pot at showdown
+	rigHoleCards(g, 0, "Ah", "2h")
+	rigHoleCards(g, 1, "Kd", "Qd")
+	rigHoleCards(g, 2, "Jh", "Js")
+	for g.getStage() != PreDeal {
+		if g.getStage() == River {
+			copy(g.communityCards, []eval.Card{eval.MustParseCardString("3c"), eval.MustParseCardString("4d"),
+				eval.MustParseCardString("5s"), eval.MustParseCardString("Kc"), eval.MustParseCardString("9h")})
+		}
+
+		pn := g.actionNum
+		if err := Bet(g, pn, g.toCall()-g.players[pn].Bet); err != nil {
+			t.Fatalf("Test failed - error checking the hand down: %s", err)
+		}
+	}
+
+	if !g.kill || g.killNum != 0 {
+		t.Errorf("Test failed - expected player 0 to kill the next hand after scooping, got %t for player %d", g.kill,
+			g.killNum)
+	}
+}
+
+func TestGame_KillBlindEvents(t *testing.T) {
+	// Each player in turn kills the third hand, from the button and from either blind
+	for killer := uint(0); killer < 3; killer++ {
+		g := seatedGame(t, &GameConfig{BigBlind: 20, SmallBlind: 10, Betting: SpreadLimit, SpreadMin: 20, SpreadMax: 100,
+			Kill: FullKill}, 3, 1000)
+
+		winHand(t, g, killer)
+		winHand(t, g, killer)
+		if err := Deal(g, g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+
+		// The blinds recorded add up to what each player actually put in, kill blind included
+		posted := make([]uint, 3)
+		events := g.Events()
+		for _, e := range events[g.handStartIndex():] {
+			if e.Kind == EventBlind {
+				posted[e.PlayerNum] += e.Amount
+			}
+		}
+		for pn, p := range g.players {
+			if posted[pn] != p.Bet {
+				t.Errorf("Test failed - with player %d killing the pot, expected blinds of %d recorded for player %d, got %d",
+					killer, p.Bet, pn, posted[pn])
+			}
+		}
+	}
+}
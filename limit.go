@@ -0,0 +1,117 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "math"
+
+// BettingStructure decides how much a player can bet or raise (see GameConfig.Betting)
+type BettingStructure uint8
+
+const (
+	// NoLimit lets a player bet or raise as much as they have. This is the default.
+	NoLimit BettingStructure = iota
+	// SpreadLimit, as in many live low-stakes games, lets a player bet or raise by anywhere from GameConfig.SpreadMin
+	// to GameConfig.SpreadMax, e.g. $2 to $10. A raise must still be at least as big as the last bet or raise on the
+	// street, up to SpreadMax. A player can always go all-in for less than the smallest bet or raise, but not for
+	// more than the largest.
+	SpreadLimit
+	// FixedLimit lets a player bet or raise by exactly the small bet (the big blind) before the flop and on the flop,
+	// and by exactly the big bet (twice the big blind) on the turn and river. Betting on a street is capped at a bet
+	// and three raises, after which players can only call. A player can always go all-in for less than a bet.
+	FixedLimit
+	// PotLimit lets a player bet or raise by anything from the last bet or raise on the street (and at least the big
+	// blind) up to the size of the pot, counting their call, e.g. facing a bet of 10 into a pot of 30, a player can
+	// raise by as much as 50.
+	PotLimit
+)
+
+// fixedLimitBets is how many bets there can be on a street under FixedLimit: a bet and three raises
+const fixedLimitBets = 4
+
+// getLimit returns the most player pn can put in with a single bet or raise, on top of what they have in already
+func (g *Game) getLimit(pn uint) uint {
+	call := g.toCall() - g.players[pn].Bet
+
+	switch g.config.Betting {
+	case SpreadLimit:
+		return call + g.killStakes(g.config.SpreadMax)
+	case FixedLimit:
+		if g.bringInPending() {
+			return g.config.BigBlind - g.players[pn].Bet
+		}
+		if g.toCall() >= fixedLimitBets*g.fixedBet() {
+			return call
+		}
+		return call + g.fixedBet()
+	case PotLimit:
+		return call + g.PotSize() + call
+	}
+
+	return uint(math.MaxUint64)
+}
+
+// raiseMin returns the smallest amount a bet or raise can be by on top of the call. In stud, that is enough to
+// complete the bring-in to the BigBlind, until somebody has, and never less than the BigBlind after.
+func (g *Game) raiseMin() uint {
+	if g.bringInPending() {
+		return g.config.BigBlind - g.toCall()
+	}
+
+	min := g.minRaise
+	if g.variant().stud() && min < g.config.BigBlind {
+		min = g.config.BigBlind
+	}
+
+	switch g.config.Betting {
+	case SpreadLimit:
+		if spreadMin := g.killStakes(g.config.SpreadMin); min < spreadMin {
+			min = spreadMin
+		}
+		// The blinds or a straddle can be bigger than the spread, but a raise can't be
+		if spreadMax := g.killStakes(g.config.SpreadMax); min > spreadMax {
+			min = spreadMax
+		}
+	case FixedLimit:
+		min = g.fixedBet()
+	}
+
+	return min
+}
+
+// fixedBet returns the size of a bet or raise under FixedLimit on the current street: the small bet before the turn,
+// and the big bet from the turn on
+func (g *Game) fixedBet() uint {
+	bet := g.killStakes(g.config.BigBlind)
+	if g.getStage() >= Turn {
+		return 2 * bet
+	}
+
+	return bet
+}
+
+// limitBetting reports whether the game is played at one of the limit betting structures, where the size of bets is
+// fixed or spread, rather than set by the stacks or the pot
+func (g *Game) limitBetting() bool {
+	return g.config.Betting == SpreadLimit || g.config.Betting == FixedLimit
+}
@@ -0,0 +1,170 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestGame_SpreadLimit(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 20, SmallBlind: 10, Betting: SpreadLimit, SpreadMin: 20, SpreadMax: 100})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	utg := g.actionNum
+	tests := []struct {
+		bet  uint
+		want error
+	}{
+		// Going all-in is over the limit
+		{1000, ErrIllegalAction},
+		// Raising by more than SpreadMax
+		{121, ErrIllegalAction},
+		// Raising by less than the big blind
+		{30, ErrIllegalAction},
+		{60, nil},
+	}
+	for _, tt := range tests {
+		if err := Bet(g, utg, tt.bet); err != tt.want {
+			t.Errorf("Test failed - betting %d under the gun, expected %v, got %v", tt.bet, tt.want, err)
+		}
+	}
+
+	// The small blind must raise by at least the 40 just raised, and at most SpreadMax
+	sb := g.sbNum
+	for _, bet := range []uint{80, 151} {
+		if err := Bet(g, sb, bet); err != ErrIllegalAction {
+			t.Errorf("Test failed - expected ErrIllegalAction raising %d in the small blind, got %v", bet, err)
+		}
+	}
+	if err := Bet(g, sb, 150); err != nil {
+		t.Errorf("Test failed - error making the largest raise: %s", err)
+	}
+}
+
+func TestGame_SpreadLimitShortAllIn(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 20, SmallBlind: 10, Betting: SpreadLimit, SpreadMin: 20, SpreadMax: 100})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// The short stack can go all-in for less than a full raise
+	pn := g.actionNum
+	g.players[pn].Stack = 30
+	if err := Bet(g, pn, 30); err != nil {
+		t.Errorf("Test failed - error going all-in for less than a raise: %s", err)
+	}
+}
+
+func TestGame_FixedLimit(t *testing.T) {
+	g := seatedGame(t, &GameConfig{BigBlind: 20, SmallBlind: 10, Betting: FixedLimit}, 3, 1000)
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Before the flop, every raise is by exactly the big blind
+	utg := g.actionNum
+	for _, bet := range []uint{30, 50} {
+		if err := Bet(g, utg, bet); err != ErrIllegalAction {
+			t.Errorf("Test failed - expected ErrIllegalAction betting %d under the gun, got %v", bet, err)
+		}
+	}
+	if err := Bet(g, utg, 40); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+	if err := Bet(g, g.sbNum, 50); err != nil {
+		t.Fatalf("Test failed - error reraising from the small blind: %s", err)
+	}
+	if err := Bet(g, g.bbNum, 60); err != nil {
+		t.Fatalf("Test failed - error capping the betting: %s", err)
+	}
+
+	// The big blind, and three raises, cap the betting
+	if err := Bet(g, utg, 60); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected ErrIllegalAction raising once the betting is capped, got %v", err)
+	}
+	if err := Bet(g, utg, 40); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if err := Bet(g, g.sbNum, 20); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+
+	// Check the flop through
+	for i := 0; i < 3; i++ {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+	}
+
+	// From the turn on, every bet is the big bet
+	pn := g.actionNum
+	if err := Bet(g, pn, 20); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected ErrIllegalAction betting the small bet on the turn, got %v", err)
+	}
+	if err := Bet(g, pn, 40); err != nil {
+		t.Errorf("Test failed - error betting the big bet on the turn: %s", err)
+	}
+}
+
+func TestGame_PotLimit(t *testing.T) {
+	g := seatedGame(t, &GameConfig{BigBlind: 20, SmallBlind: 10, Betting: PotLimit}, 3, 1000)
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	tests := []struct {
+		pn   uint
+		bet  uint
+		want error
+	}{
+		// Calling 20 makes the pot 50, so under the gun can raise to 70
+		{g.utgNum, 71, ErrIllegalAction},
+		{g.utgNum, 30, ErrIllegalAction},
+		{g.utgNum, 70, nil},
+		// Calling 60 makes the pot 160, so the small blind can put in 220 more
+		{g.sbNum, 221, ErrIllegalAction},
+		{g.sbNum, 220, nil},
+	}
+	for _, tt := range tests {
+		if err := Bet(g, tt.pn, tt.bet); err != tt.want {
+			t.Errorf("Test failed - player %d betting %d, expected %v, got %v", tt.pn, tt.bet, tt.want, err)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"sort"
+	"sync"
+)
+
+// Lobby is a registry of the tables a server hosts: it owns one Game per table, keyed by a table ID of the
+// integrator's choosing, and routes each player's Actions to the right Game, by the player's own ID (like a user ID)
+// rather than their player number at the table. It is safe for concurrent use: each table has a lock of its own, held
+// while its Game is acted on, so tables don't hold each other up. Lobbies should not be initialized directly, only
+// through the NewLobby factory function.
+//
+// Each table has a waitlist (see JoinWaitlist). Whenever a seat is free between hands, the player at the front of the
+// waitlist is seated, and the Lobby's OnSeated callbacks are called to let them know.
+type Lobby struct {
+	mu       sync.Mutex
+	tables   map[string]*lobbyTable
+	onSeated []func(tableID string, playerID string, pn uint)
+}
+
+type lobbyTable struct {
+	mu       sync.Mutex
+	game     *Game
+	players  map[string]uint
+	waitlist []string
+}
+
+// seating is a player seated from a waitlist, who hasn't been told yet
+type seating struct {
+	tableID  string
+	playerID string
+	pn       uint
+}
+
+// TableInfo describes a table in a Lobby, for listing tables to players choosing one. Players is how many players
+// are seated at it (counting players sitting out, but not players who have left), out of Seats (0 if the table has
+// no limit), and Waiting how many are on its waitlist.
+type TableInfo struct {
+	ID         string  `json:"id"`
+	Variant    Variant `json:"variant"`
+	SmallBlind uint    `json:"smallBlind"`
+	BigBlind   uint    `json:"bigBlind"`
+	Seats      uint    `json:"seats"`
+	Players    uint    `json:"players"`
+	Waiting    uint    `json:"waiting"`
+}
+
+// NewLobby returns a Lobby with no tables.
+func NewLobby() *Lobby {
+	return &Lobby{tables: make(map[string]*lobbyTable)}
+}
+
+// OpenTable opens a new table, with a Game created with config (or NewGame's defaults, if config is nil). OpenTable
+// returns ErrTableExists if the Lobby already has a table with the ID.
+func (l *Lobby) OpenTable(tableID string, config *GameConfig) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.tables[tableID]; ok {
+		return ErrTableExists
+	}
+
+	l.tables[tableID] = &lobbyTable{game: NewGame(config), players: make(map[string]uint)}
+
+	return nil
+}
+
+// CloseTable closes a table, and returns what each player still seated there is owed: their stack, and anything
+// they have bet in a hand that is still being played, which is called off (see Game.RemovePlayer, which players
+// should be removed with first, if they are to forfeit their bets). CloseTable returns ErrUnknownTable if there is no
+// such table.
+func (l *Lobby) CloseTable(tableID string) (map[string]uint, error) {
+	l.mu.Lock()
+	t, ok := l.tables[tableID]
+	delete(l.tables, tableID)
+	l.mu.Unlock()
+
+	if !ok {
+		return nil, ErrUnknownTable
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	owed := make(map[string]uint, len(t.players))
+	for id, pn := range t.players {
+		p := t.game.getPlayer(pn)
+		owed[id] = p.Stack + p.TotalBet + p.DeadChips
+	}
+
+	return owed, nil
+}
+
+// Tables returns a TableInfo for each of the Lobby's tables, sorted by ID.
+func (l *Lobby) Tables() []TableInfo {
+	l.mu.Lock()
+	ids := make([]string, 0, len(l.tables))
+	for id := range l.tables {
+		ids = append(ids, id)
+	}
+	l.mu.Unlock()
+	sort.Strings(ids)
+
+	infos := make([]TableInfo, 0, len(ids))
+	for _, id := range ids {
+		if info, err := l.TableInfo(id); err == nil {
+			infos = append(infos, info)
+		}
+	}
+
+	return infos
+}
+
+// TableInfo returns the TableInfo of a table, or ErrUnknownTable if there is no such table.
+func (l *Lobby) TableInfo(tableID string) (TableInfo, error) {
+	t, err := l.table(tableID)
+	if err != nil {
+		return TableInfo{}, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info := TableInfo{
+		ID:         tableID,
+		Variant:    t.game.variant(),
+		SmallBlind: t.game.config.SmallBlind,
+		BigBlind:   t.game.config.BigBlind,
+		Seats:      t.game.config.Seats,
+		Waiting:    uint(len(t.waitlist)),
+	}
+	for _, p := range t.game.players {
+		if p.SeatNum != 0 && !p.Left {
+			info.Players++
+		}
+	}
+
+	return info, nil
+}
+
+// Join seats a player at a table, and returns their player number there. A player who has already joined the table
+// keeps the seat they have. Join returns ErrUnknownTable if there is no such table, or ErrTableFull if every seat is
+// taken, or if anybody is on the table's waitlist: players waiting are seated first (see JoinWaitlist).
+func (l *Lobby) Join(tableID string, playerID string) (uint, error) {
+	var pn uint
+	err := l.withTable(tableID, func(t *lobbyTable) error {
+		var ok bool
+		if pn, ok = t.players[playerID]; ok {
+			return nil
+		}
+
+		if len(t.waitlist) > 0 {
+			return ErrTableFull
+		}
+
+		var err error
+		if pn, err = t.game.SeatPlayer(); err != nil {
+			return err
+		}
+		t.players[playerID] = pn
+		t.unwait(playerID)
+
+		return nil
+	})
+
+	return pn, err
+}
+
+// Leave removes a player from a table (see Game.RemovePlayer), and returns the chips they should be credited with.
+// Leave returns ErrUnknownTable if there is no such table, or ErrUnknownPlayer if the player hasn't joined it.
+func (l *Lobby) Leave(tableID string, playerID string) (uint, error) {
+	var credit uint
+	err := l.withTable(tableID, func(t *lobbyTable) error {
+		pn, ok := t.players[playerID]
+		if !ok {
+			return ErrUnknownPlayer
+		}
+
+		var err error
+		if credit, err = t.game.RemovePlayer(pn); err != nil {
+			return err
+		}
+		delete(t.players, playerID)
+
+		return nil
+	})
+
+	return credit, err
+}
+
+// Act performs Action a at a table, for the player, through Game.Apply. Act returns ErrUnknownTable if there is no
+// such table, ErrUnknownPlayer if the player hasn't joined it, or else whatever Apply returns.
+func (l *Lobby) Act(tableID string, playerID string, a Action, data uint) error {
+	return l.withTable(tableID, func(t *lobbyTable) error {
+		pn, ok := t.players[playerID]
+		if !ok {
+			return ErrUnknownPlayer
+		}
+
+		return t.game.Apply(a, pn, data)
+	})
+}
+
+// PlayerNum returns the player number of the player at a table, and false if there is no such table, or the player
+// hasn't joined it.
+func (l *Lobby) PlayerNum(tableID string, playerID string) (uint, bool) {
+	t, err := l.table(tableID)
+	if err != nil {
+		return 0, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pn, ok := t.players[playerID]
+
+	return pn, ok
+}
+
+// Game calls fn with the Game being played at a table, while holding the table's lock, so fn may safely inspect or
+// modify it (like to generate a player's view, or to Advance it). fn must not add or remove players; Join and Leave
+// keep track of them. Game returns ErrUnknownTable if there is no such table.
+func (l *Lobby) Game(tableID string, fn func(g *Game)) error {
+	return l.withTable(tableID, func(t *lobbyTable) error {
+		fn(t.game)
+		return nil
+	})
+}
+
+// JoinWaitlist puts a player on the end of a table's waitlist, and returns their place on it, counting from 1. If a
+// seat is free between hands, the player at the front is seated right away, which may be this one. A player already
+// on the waitlist keeps their place. JoinWaitlist returns ErrUnknownTable if there is no such table, or
+// ErrAlreadySeated if the player is seated at it.
+func (l *Lobby) JoinWaitlist(tableID string, playerID string) (int, error) {
+	var place int
+	err := l.withTable(tableID, func(t *lobbyTable) error {
+		if _, ok := t.players[playerID]; ok {
+			return ErrAlreadySeated
+		}
+
+		for i, id := range t.waitlist {
+			if id == playerID {
+				place = i + 1
+				return nil
+			}
+		}
+
+		t.waitlist = append(t.waitlist, playerID)
+		place = len(t.waitlist)
+
+		return nil
+	})
+
+	return place, err
+}
+
+// LeaveWaitlist takes a player off a table's waitlist. It returns ErrUnknownTable if there is no such table, or
+// ErrNotWaitlisted if the player isn't on its waitlist.
+func (l *Lobby) LeaveWaitlist(tableID string, playerID string) error {
+	return l.withTable(tableID, func(t *lobbyTable) error {
+		if !t.unwait(playerID) {
+			return ErrNotWaitlisted
+		}
+
+		return nil
+	})
+}
+
+// Waitlist returns the players on a table's waitlist, in order, or ErrUnknownTable if there is no such table.
+func (l *Lobby) Waitlist(tableID string) ([]string, error) {
+	var waitlist []string
+	err := l.withTable(tableID, func(t *lobbyTable) error {
+		waitlist = append([]string{}, t.waitlist...)
+		return nil
+	})
+
+	return waitlist, err
+}
+
+// OnSeated registers fn to be called with the player ID and player number of every player seated from a waitlist,
+// so the server can let them know. fn is called once the table's lock has been released, so it may use the Lobby.
+func (l *Lobby) OnSeated(fn func(tableID string, playerID string, pn uint)) (cancel func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ndx := len(l.onSeated)
+	l.onSeated = append(l.onSeated, fn)
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.onSeated[ndx] = nil
+	}
+}
+
+// withTable calls fn with a table, while holding its lock, and returns what fn does, or ErrUnknownTable if there is
+// no such table. Once fn is done, any seats it has freed between hands are filled from the waitlist, and the players
+// seated are told of it, after the lock is released.
+func (l *Lobby) withTable(tableID string, fn func(t *lobbyTable) error) error {
+	t, err := l.table(tableID)
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	err = fn(t)
+	seated := t.seatWaiting(tableID)
+	t.mu.Unlock()
+
+	l.mu.Lock()
+	callbacks := append([]func(string, string, uint){}, l.onSeated...)
+	l.mu.Unlock()
+
+	for _, s := range seated {
+		for _, fn := range callbacks {
+			if fn != nil {
+				fn(s.tableID, s.playerID, s.pn)
+			}
+		}
+	}
+
+	return err
+}
+
+// seatWaiting seats players from the front of the waitlist while there are seats free and no hand is being played,
+// and returns who it seated. The table's lock must be held.
+func (t *lobbyTable) seatWaiting(tableID string) []seating {
+	var seated []seating
+	for len(t.waitlist) > 0 && t.game.getStage() == PreDeal {
+		pn, err := t.game.SeatPlayer()
+		if err != nil {
+			break
+		}
+
+		playerID := t.waitlist[0]
+		t.waitlist = t.waitlist[1:]
+		t.players[playerID] = pn
+		seated = append(seated, seating{tableID: tableID, playerID: playerID, pn: pn})
+	}
+
+	return seated
+}
+
+// unwait takes the player off the waitlist, and reports whether they were on it. The table's lock must be held.
+func (t *lobbyTable) unwait(playerID string) bool {
+	for i, id := range t.waitlist {
+		if id == playerID {
+			t.waitlist = append(t.waitlist[:i], t.waitlist[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *Lobby) table(tableID string) (*lobbyTable, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t, ok := l.tables[tableID]
+	if !ok {
+		return nil, ErrUnknownTable
+	}
+
+	return t, nil
+}
@@ -0,0 +1,200 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLobby(t *testing.T) {
+	l := NewLobby()
+
+	if err := l.OpenTable("b", &GameConfig{BigBlind: 50, SmallBlind: 25, Seats: 2}); err != nil {
+		t.Fatalf("Test failed - error opening a table: %s", err)
+	}
+	if err := l.OpenTable("a", nil); err != nil {
+		t.Fatalf("Test failed - error opening a table: %s", err)
+	}
+	if err := l.OpenTable("a", nil); err != ErrTableExists {
+		t.Errorf("Test failed - opening a table twice should return ErrTableExists, got %v", err)
+	}
+
+	for _, id := range []string{"alice", "bob"} {
+		if _, err := l.Join("b", id); err != nil {
+			t.Fatalf("Test failed - error joining: %s", err)
+		}
+	}
+	if pn, err := l.Join("b", "bob"); err != nil || pn != 1 {
+		t.Errorf("Test failed - joining again should keep bob's seat, got player %d, error %v", pn, err)
+	}
+	if _, err := l.Join("b", "carol"); err != ErrTableFull {
+		t.Errorf("Test failed - joining a full table should return ErrTableFull, got %v", err)
+	}
+	if _, err := l.Join("c", "carol"); err != ErrUnknownTable {
+		t.Errorf("Test failed - joining a table that isn't open should return ErrUnknownTable, got %v", err)
+	}
+
+	want := []TableInfo{
+		{ID: "a", BigBlind: 25, SmallBlind: 10},
+		{ID: "b", BigBlind: 50, SmallBlind: 25, Seats: 2, Players: 2},
+	}
+	if got := l.Tables(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Test failed - Tables returned %+v, want %+v", got, want)
+	}
+
+	// Actions are routed by the player's ID
+	if err := l.Act("b", "bob", BuyIn, 1000); err != nil {
+		t.Fatalf("Test failed - error buying in: %s", err)
+	}
+	if err := l.Act("a", "bob", BuyIn, 1000); err != ErrUnknownPlayer {
+		t.Errorf("Test failed - acting at a table the player hasn't joined should return ErrUnknownPlayer, got %v", err)
+	}
+	if pn, ok := l.PlayerNum("b", "bob"); !ok || pn != 1 {
+		t.Errorf("Test failed - expected bob to be player 1, got %d", pn)
+	}
+	l.Game("b", func(g *Game) {
+		if g.players[1].Stack != 1000 {
+			t.Errorf("Test failed - expected bob to have bought in, got %+v", g.players)
+		}
+	})
+
+	if credit, err := l.Leave("b", "bob"); err != nil || credit != 1000 {
+		t.Errorf("Test failed - bob should be credited 1000 for leaving, got %d, error %v", credit, err)
+	}
+	if _, ok := l.PlayerNum("b", "bob"); ok {
+		t.Errorf("Test failed - expected bob to be gone from the table")
+	}
+	if _, err := l.Join("b", "carol"); err != nil {
+		t.Errorf("Test failed - carol should be able to take bob's seat, got %v", err)
+	}
+
+	// Closing a table pays out what players still have at it
+	l.Act("b", "carol", BuyIn, 500)
+	owed, err := l.CloseTable("b")
+	if err != nil || !reflect.DeepEqual(owed, map[string]uint{"alice": 0, "carol": 500}) {
+		t.Errorf("Test failed - CloseTable returned %v, error %v", owed, err)
+	}
+	if _, err := l.CloseTable("b"); err != ErrUnknownTable {
+		t.Errorf("Test failed - closing a closed table should return ErrUnknownTable, got %v", err)
+	}
+	if got := l.Tables(); len(got) != 1 {
+		t.Errorf("Test failed - expected one table left, got %+v", got)
+	}
+}
+
+func TestLobby_Waitlist(t *testing.T) {
+	l := NewLobby()
+	l.OpenTable("t", &GameConfig{BigBlind: 25, SmallBlind: 10, Seats: 3})
+
+	type seated struct {
+		playerID string
+		pn       uint
+	}
+	var got []seated
+	l.OnSeated(func(tableID string, playerID string, pn uint) {
+		if tableID != "t" {
+			t.Errorf("Test failed - expected a seating at table t, got %s", tableID)
+		}
+		// The table's lock is released by now, so this mustn't deadlock
+		l.PlayerNum(tableID, playerID)
+		got = append(got, seated{playerID, pn})
+	})
+
+	for _, id := range []string{"alice", "bob", "erin"} {
+		if _, err := l.JoinWaitlist("t", id); err != nil {
+			t.Fatalf("Test failed - error joining the waitlist: %s", err)
+		}
+		l.Act("t", id, BuyIn, 1000)
+		l.Act("t", id, ToggleReady, 0)
+	}
+	if len(got) != 3 {
+		t.Fatalf("Test failed - players joining the waitlist of a table with free seats should be seated, got %+v", got)
+	}
+	if _, err := l.JoinWaitlist("t", "bob"); err != ErrAlreadySeated {
+		t.Errorf("Test failed - a seated player joining the waitlist should return ErrAlreadySeated, got %v", err)
+	}
+
+	for i, id := range []string{"carol", "dave"} {
+		if place, err := l.JoinWaitlist("t", id); err != nil || place != i+1 {
+			t.Errorf("Test failed - expected %s to be at place %d, got %d, error %v", id, i+1, place, err)
+		}
+	}
+	if place, _ := l.JoinWaitlist("t", "carol"); place != 1 {
+		t.Errorf("Test failed - joining the waitlist again should keep carol's place, got %d", place)
+	}
+	if info, _ := l.TableInfo("t"); info.Waiting != 2 {
+		t.Errorf("Test failed - expected 2 players waiting, got %d", info.Waiting)
+	}
+
+	l.Game("t", func(g *Game) {
+		if err := Deal(g, g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+	})
+
+	// A seat freed during a hand isn't filled until it ends, and nobody can jump the waitlist for it
+	got = nil
+	if _, err := l.Leave("t", "bob"); err != nil {
+		t.Fatalf("Test failed - error leaving: %s", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Test failed - nobody should be seated during a hand, got %+v", got)
+	}
+	if _, err := l.Join("t", "frank"); err != ErrTableFull {
+		t.Errorf("Test failed - joining ahead of the waitlist should return ErrTableFull, got %v", err)
+	}
+
+	for len(got) == 0 {
+		var actor string
+		l.Game("t", func(g *Game) {
+			for id, pn := range map[string]uint{"alice": 0, "erin": 2} {
+				if g.actionNum == pn {
+					actor = id
+				}
+			}
+		})
+		if err := l.Act("t", actor, Fold, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+	if !reflect.DeepEqual(got, []seated{{"carol", 3}}) {
+		t.Errorf("Test failed - expected carol to be seated once the hand ended, got %+v", got)
+	}
+	l.Game("t", func(g *Game) {
+		if g.players[3].SeatNum != g.players[1].SeatNum {
+			t.Errorf("Test failed - expected carol to take bob's seat, got seat %d", g.players[3].SeatNum)
+		}
+	})
+
+	if list, _ := l.Waitlist("t"); !reflect.DeepEqual(list, []string{"dave"}) {
+		t.Errorf("Test failed - expected dave to be left waiting, got %v", list)
+	}
+	if err := l.LeaveWaitlist("t", "dave"); err != nil {
+		t.Errorf("Test failed - error leaving the waitlist: %s", err)
+	}
+	if err := l.LeaveWaitlist("t", "dave"); err != ErrNotWaitlisted {
+		t.Errorf("Test failed - leaving the waitlist twice should return ErrNotWaitlisted, got %v", err)
+	}
+}
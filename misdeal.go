@@ -0,0 +1,206 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "github.com/alexclewontin/riverboat/eval"
+
+// dealtFrom is what a Game was like just before a hand was dealt that dealing changes, kept so a misdeal can put it
+// back (see Misdeal)
+type dealtFrom struct {
+	dealerNum uint
+	sbNum     uint
+	bbNum     uint
+	utgNum    uint
+	// players holds, for each player at the time, what dealing the hand changes other than their cards and chips
+	players []Player
+}
+
+// recordDealtFrom records what dealing the next hand will change
+func (g *Game) recordDealtFrom() *dealtFrom {
+	return &dealtFrom{
+		dealerNum: g.dealerNum,
+		sbNum:     g.sbNum,
+		bbNum:     g.bbNum,
+		utgNum:    g.utgNum,
+		players:   append([]Player{}, g.players...),
+	}
+}
+
+// Misdeal is the Action for calling off the hand being played, like when Deal reports that the cards can't be dealt
+// from. Every chip put in during the hand, blinds included, goes back to the player who put it in, the cards are
+// taken back, and the table is back where it was before the hand was dealt: the button and blinds don't move, and
+// players owe the missed blinds they did then, so the next Deal deals the hand again from a fresh shuffle. The
+// revealed shuffle, if the table commits to its shuffles, is that of the misdeal. An EventMisdeal is recorded.
+// Misdeal returns an error if pn is not the dealer (as for Deal), or if no hand is being played.
+// Misdeal ignores the value passed in as data.
+func Misdeal(g *Game, pn uint, data uint) error {
+	if pn != g.dealingNum() {
+		return ErrIllegalAction
+	}
+
+	stage := g.getStage()
+	if stage == PreDeal {
+		return ErrIllegalAction
+	}
+
+	for i := range g.players {
+		p := &g.players[i]
+		p.Stack += p.TotalBet + p.DeadChips
+		p.Bet = 0
+		p.TotalBet = 0
+		p.DeadChips = 0
+		p.Called = false
+		p.In = false
+		p.AllInStage = 0
+		p.Cards = [2]eval.Card{}
+		p.ThirdCard = 0
+		p.Discarded = 0
+		p.ExtraCards = [2]eval.Card{}
+		p.UpCards = [4]eval.Card{}
+		p.Shown = [2]bool{}
+	}
+
+	// A Game filled from a view doesn't know where it was dealt from, so it just leaves the button where it is
+	if from := g.dealtFrom; from != nil {
+		g.dealerNum, g.sbNum, g.bbNum, g.utgNum = from.dealerNum, from.sbNum, from.bbNum, from.utgNum
+		for i := range from.players {
+			p := &g.players[i]
+			p.MissedBlinds = from.players[i].MissedBlinds
+			p.PostMissed = from.players[i].PostMissed
+			p.PreviousBet = from.players[i].PreviousBet
+			p.PreviouslyIn = from.players[i].PreviouslyIn
+			p.PreviouslyAllIn = from.players[i].PreviouslyAllIn
+		}
+	}
+
+	for i := range g.communityCards {
+		g.communityCards[i] = 0
+	}
+
+	g.pots = []Pot{}
+	g.showdown = []ShowdownReveal{}
+	g.showdownOrder = nil
+	g.burns = []eval.Card{}
+	g.startStacks = nil
+	g.knockouts = nil
+	g.minRaise = g.config.BigBlind
+	g.dealtFrom = nil
+	g.undo = nil
+	g.revealShuffle()
+
+	g.handEnded = g.currentTime()
+	g.setStageAndBetting(PreDeal, false)
+
+	g.emit(Event{Kind: EventMisdeal, Stage: stage, PlayerNum: pn})
+
+	return nil
+}
+
+// checkDeck reports whether the rest of a hand at stage can be dealt: that no card has been dealt twice, or is
+// still in the deck after being dealt, that the community cards dealt are what the streets up to stage call for, and
+// that the deck has enough cards left for the streets after it. At PreDeal, the deck is shuffled up anew, so it only
+// checks that a full deck is enough to deal everybody in.
+func (g *Game) checkDeck(stage GameStage) error {
+	v := g.variant()
+
+	var need, board int
+	for i, s := range v.Schedule() {
+		if s.Stage <= stage {
+			board += s.CommunityCards
+			continue
+		}
+
+		need += s.CommunityCards
+		for pn, p := range g.players {
+			// Once the hand is dealt, only the players still in it are dealt any more
+			if (stage == PreDeal && p.Ready && !g.waiting(uint(pn))) || (stage != PreDeal && p.In) {
+				need += s.HoleCards + s.UpCards
+			}
+		}
+		if i != 0 && g.config.BurnCards {
+			need++
+		}
+	}
+
+	if stage == PreDeal {
+		if need > len(v.deck()) {
+			return ErrShortDeck
+		}
+
+		return nil
+	}
+
+	if need > len(g.deck) {
+		return ErrShortDeck
+	}
+
+	seen := make(map[eval.Card]bool, len(v.deck()))
+	add := func(c eval.Card) bool {
+		if c == 0 {
+			return true
+		}
+		if seen[c] {
+			return false
+		}
+		seen[c] = true
+		return true
+	}
+
+	dealt := 0
+	for _, c := range g.communityCards {
+		if c != 0 {
+			dealt++
+		}
+		if !add(c) {
+			return ErrDuplicateCard
+		}
+	}
+
+	if dealt != board {
+		return ErrBadBoard
+	}
+
+	for _, p := range g.players {
+		for _, c := range []eval.Card{p.Cards[0], p.Cards[1], p.ThirdCard, p.Discarded, p.ExtraCards[0], p.ExtraCards[1],
+			p.UpCards[0], p.UpCards[1], p.UpCards[2], p.UpCards[3]} {
+			if !add(c) {
+				return ErrDuplicateCard
+			}
+		}
+	}
+
+	for _, c := range g.burns {
+		if !add(c) {
+			return ErrDuplicateCard
+		}
+	}
+
+	for _, c := range g.deck {
+		if !add(c) {
+			return ErrDuplicateCard
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+)
+
+func TestMisdeal(t *testing.T) {
+	g := NewGame(&defaultConfig)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Misdeal(g, g.dealingNum(), 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected no misdeal to be called before a hand is dealt, got %v", err)
+	}
+
+	dealer, sb, bb := g.dealerNum, g.sbNum, g.bbNum
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	if err := Bet(g, g.actionNum, 100); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+
+	if err := Misdeal(g, g.next(g.dealingNum()), 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected only the dealer to be able to call a misdeal, got %v", err)
+	}
+
+	if err := Misdeal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error calling a misdeal: %s", err)
+	}
+
+	for i, p := range g.players {
+		if p.Stack != 1000 || p.TotalBet != 0 || p.In || p.Cards[0] != 0 {
+			t.Errorf("Test failed - expected player %d's chips and cards to be taken back, got %+v", i, p)
+		}
+	}
+
+	if g.getStage() != PreDeal || len(g.pots) != 0 || g.dealerNum != dealer || g.sbNum != sb || g.bbNum != bb {
+		t.Errorf("Test failed - expected the table to be back where it was before the deal")
+	}
+
+	if events := g.Events(); events[len(events)-1].Kind != EventMisdeal || events[len(events)-1].Stage != PreFlop {
+		t.Errorf("Test failed - expected an EventMisdeal")
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil || g.bbNum != bb {
+		t.Errorf("Test failed - expected the hand to be dealt again with the same blinds, got %v", err)
+	}
+}
+
+func TestDeal_checksDeck(t *testing.T) {
+	g := NewGame(&defaultConfig)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Close the betting by hand, so the flop would be dealt next
+	g.setBetting(false)
+
+	g.communityCards[0] = g.deck[0]
+	if err := Deal(g, g.dealingNum(), 0); err != ErrBadBoard {
+		t.Errorf("Test failed - expected a card on the board before the flop to be caught, got %v", err)
+	}
+	g.communityCards[0] = 0
+
+	g.deck = append(g.deck, g.players[0].Cards[0])
+	if err := Deal(g, g.dealingNum(), 0); err != ErrDuplicateCard {
+		t.Errorf("Test failed - expected a hole card left in the deck to be caught, got %v", err)
+	}
+
+	g.deck = g.deck[:4]
+	if err := Deal(g, g.dealingNum(), 0); err != ErrShortDeck {
+		t.Errorf("Test failed - expected dealing from a short deck to be caught, got %v", err)
+	}
+
+	if g.getStage() != PreFlop || g.communityCards[0] != 0 {
+		t.Errorf("Test failed - expected nothing to be dealt from a bad deck")
+	}
+
+	if err := Misdeal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error calling a misdeal: %s", err)
+	}
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Errorf("Test failed - expected the hand to be dealt again after the misdeal, got %v", err)
+	}
+
+	// 26 players need the whole deck for their hole cards, leaving none for the board
+	g = NewGame(&defaultConfig)
+	for i := 0; i < 26; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != ErrShortDeck {
+		t.Errorf("Test failed - expected too many players for the deck to be caught, got %v", err)
+	}
+
+	// A deck that runs short when the betting closes fails the action that closed it
+	g = seatedGame(t, &defaultConfig, 2, 1000)
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	g.deck = g.deck[:2]
+	if err := Bet(g, g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if err := Bet(g, g.actionNum, 0); err != ErrShortDeck {
+		t.Errorf("Test failed - expected the check that closed the betting to report the short deck, got %v", err)
+	}
+	if g.getBetting() || g.getStage() != PreFlop {
+		t.Errorf("Test failed - expected the hand to wait on a misdeal, got stage %d", g.getStage())
+	}
+	if err := Misdeal(g, g.dealingNum(), 0); err != nil {
+		t.Errorf("Test failed - error calling a misdeal: %s", err)
+	}
+}
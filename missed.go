@@ -0,0 +1,139 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// MissedBlinds is the set of blinds a player has missed, and so owes before they are dealt back in
+// (see RuleSet.MissedBlinds). Blinds are combined with bitwise or.
+type MissedBlinds uint8
+
+const (
+	MissedBigBlind MissedBlinds = 1 << iota
+	MissedSmallBlind
+)
+
+// PostMissedBlinds decides how a player who has missed blinds gets back into the game. For PostMissedBlinds, data
+// is 1 to post the missed blinds and be dealt in next hand (the big blind is posted live, and the small blind dead,
+// as in PostDead), or 0 to wait to be dealt in until the big blind reaches them, which clears what they owe.
+// PostMissedBlinds will return an error if the player doesn't owe any blinds, or is in the current hand. If
+// missed blinds are not tracked at the table, PostMissedBlinds returns ErrFeatureDisabled.
+func PostMissedBlinds(g *Game, pn uint, data uint) error {
+	if !g.config.Rules.MissedBlinds {
+		return ErrFeatureDisabled
+	}
+
+	p := g.getPlayer(pn)
+
+	if p.MissedBlinds == 0 || p.In || data > 1 {
+		return ErrIllegalAction
+	}
+
+	p.PostMissed = data == 1
+	g.emit(Event{Kind: EventPostMissed, PlayerNum: pn, Amount: data})
+
+	return nil
+}
+
+// trackMissedBlinds records the blinds missed by the players sitting out as a new hand is dealt. When the blinds
+// are carried over from the last hand, the small blind is on the seat that had the big blind, and the button on
+// the seat that had the small blind, so each blind passed over every seat between its old and new positions.
+func (g *Game) trackMissedBlinds() {
+	if !g.blindsCarried() {
+		return
+	}
+
+	g.missBlinds(g.sbNum, g.bbNum, MissedBigBlind)
+	g.missBlinds(g.dealerNum, g.sbNum, MissedSmallBlind)
+}
+
+// missBlinds records that blind passed over every player seated strictly between from and to who wasn't ready
+func (g *Game) missBlinds(from uint, to uint, blind MissedBlinds) {
+	if !g.config.Rules.MissedBlinds {
+		return
+	}
+
+	for pn := g.next(from); pn != to && pn != from; pn = g.next(pn) {
+		p := &g.players[pn]
+		if !p.Ready && !p.Left && p.TotalBuyIn > 0 {
+			p.MissedBlinds |= blind
+		}
+	}
+}
+
+// owes reports whether player pn would have to post or wait for the big blind to be dealt into the next hand. Stud
+// has no blinds, so nobody does, but what they owe carries over to the next hand that has them.
+func (g *Game) owes(pn uint) bool {
+	p := g.players[pn]
+	return p.MissedBlinds != 0 && pn != g.bbNum && !g.variant().stud()
+}
+
+// waiting reports whether player pn is ready, but sitting out the next hand until they post their missed blinds or
+// the big blind reaches them. If that would leave fewer than two players to deal in (like heads up), missed blinds
+// are let go, as there's no one to wait for.
+func (g *Game) waiting(pn uint) bool {
+	p := g.players[pn]
+	if !p.Ready || !g.owes(pn) || p.PostMissed {
+		return false
+	}
+
+	var playing uint
+	for i, q := range g.players {
+		if q.Ready && (!g.owes(uint(i)) || q.PostMissed) {
+			playing++
+		}
+	}
+
+	return playing >= 2
+}
+
+// postMissedBlinds has every player dealt into the new hand who chose to post their missed blinds post them, and
+// clears what everyone dealt in owes, whether they posted, have the big blind, or were let off.
+func (g *Game) postMissedBlinds(stage GameStage) {
+	for i := range g.players {
+		p := &g.players[i]
+		if !p.In || p.MissedBlinds == 0 {
+			continue
+		}
+
+		if g.owes(uint(i)) && p.PostMissed {
+			if p.MissedBlinds&MissedBigBlind != 0 {
+				before := p.Stack
+				p.putInChips(g.config.BigBlind, g.config.HandCap)
+				g.emit(Event{Kind: EventBlind, Stage: stage, PlayerNum: uint(i), Amount: before - p.Stack})
+			}
+
+			if p.MissedBlinds&MissedSmallBlind != 0 {
+				dead := g.config.SmallBlind
+				if dead > p.Stack {
+					dead = p.Stack
+				}
+				p.Stack -= dead
+				p.DeadChips += dead
+				g.emit(Event{Kind: EventDeadChips, Stage: stage, PlayerNum: uint(i), Amount: dead})
+			}
+		}
+
+		p.MissedBlinds = 0
+		p.PostMissed = false
+	}
+}
@@ -0,0 +1,122 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestGame_MissedBlinds(t *testing.T) {
+	config := defaultConfig
+	config.Rules.MissedBlinds = true
+	g := NewGame(&config)
+
+	seat := func() uint {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+		return pn
+	}
+
+	for i := 0; i < 5; i++ {
+		seat()
+	}
+
+	if err := PostMissedBlinds(g, 0, 1); err != ErrIllegalAction {
+		t.Errorf("Test failed - a player who owes nothing can't post missed blinds, got %v", err)
+	}
+
+	// Button 0, blinds 1 and 2
+	playFoldedHand(t, g)
+
+	// Player 4 sits out, and player 5 joins mid-orbit
+	if err := ToggleReady(g, 4, 0); err != nil {
+		t.Fatalf("Test failed - Error sitting out: %s", err)
+	}
+	late := seat()
+	if g.players[late].MissedBlinds != MissedBigBlind {
+		t.Fatalf("Test failed - a player joining a game in progress should owe the big blind")
+	}
+
+	// Button 1, blinds 2 and 3. Player 5 waits for the big blind
+	if err := Deal(g, g.dealerNum, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if g.players[late].In || g.actionNum != 0 {
+		t.Errorf("Test failed - player 5 shouldn't be dealt in until the big blind reaches them")
+	}
+	for g.getStage() != PreDeal {
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+
+	// Button 2, blinds 3 and 5, passing player 4 by
+	if err := Deal(g, g.dealerNum, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if !g.players[late].In || g.players[late].Bet != config.BigBlind || g.players[late].MissedBlinds != 0 {
+		t.Errorf("Test failed - player 5 should be dealt in on the big blind, owing nothing")
+	}
+	if g.players[4].MissedBlinds != MissedBigBlind {
+		t.Errorf("Test failed - player 4 should have missed the big blind, got %d", g.players[4].MissedBlinds)
+	}
+	for g.getStage() != PreDeal {
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+
+	// Button 3, blinds 5 and 0: the small blind passes player 4 by too
+	playFoldedHand(t, g)
+	if g.players[4].MissedBlinds != MissedBigBlind|MissedSmallBlind {
+		t.Fatalf("Test failed - player 4 should have missed both blinds, got %d", g.players[4].MissedBlinds)
+	}
+
+	// Player 4 comes back and posts what they missed
+	if err := ToggleReady(g, 4, 0); err != nil {
+		t.Fatalf("Test failed - Error marking ready: %s", err)
+	}
+	if err := PostMissedBlinds(g, 4, 1); err != nil {
+		t.Fatalf("Test failed - error posting missed blinds: %s", err)
+	}
+	if err := Deal(g, g.dealerNum, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	p := g.players[4]
+	if !p.In || p.Bet != config.BigBlind || p.DeadChips != config.SmallBlind || p.MissedBlinds != 0 || p.PostMissed {
+		t.Errorf("Test failed - player 4 should be dealt in having posted a live big blind and a dead small blind, got %+v", p)
+	}
+}
+
+func TestPostMissedBlinds_Disabled(t *testing.T) {
+	g := NewGame(nil)
+	g.AddPlayer()
+
+	if err := PostMissedBlinds(g, 0, 1); err != ErrFeatureDisabled {
+		t.Errorf("Test failed - expected ErrFeatureDisabled, got %v", err)
+	}
+}
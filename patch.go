@@ -0,0 +1,94 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// ViewPatch holds the changes between two GameViews of the same Game, for servers that would rather send those than
+// a whole new view every time something happens. From and To are the Seq of the older and newer view, and Fields
+// holds the JSON encoding of every field that changed, keyed by its name in GameView's JSON encoding.
+type ViewPatch struct {
+	From   uint64                     `json:"from"`
+	To     uint64                     `json:"to"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// DiffViews returns the ViewPatch that turns old into new. If nothing has changed, the patch's Fields are empty.
+func DiffViews(old *GameView, new *GameView) (*ViewPatch, error) {
+	p := &ViewPatch{From: old.Seq, To: new.Seq, Fields: map[string]json.RawMessage{}}
+
+	for _, vf := range viewFields {
+		val := vf.get(new)
+		if reflect.DeepEqual(val, vf.get(old)) {
+			continue
+		}
+
+		b, err := json.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+		p.Fields[vf.name] = b
+	}
+
+	return p, nil
+}
+
+// Patch applies p to gv, which must be the view p was made from (its Seq must be p.From), or Patch returns
+// ErrPatchMismatch. If one of p's fields can't be decoded, Patch returns the error and leaves gv as it was.
+func (gv *GameView) Patch(p *ViewPatch) error {
+	if gv.Seq != p.From {
+		return ErrPatchMismatch
+	}
+
+	b, err := json.Marshal(gv)
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	for name, val := range p.Fields {
+		fields[name] = val
+	}
+
+	if b, err = json.Marshal(fields); err != nil {
+		return err
+	}
+
+	// Decode into a fresh view, so fields that were left out of the encoding can't keep their old values
+	patched := &GameView{}
+	if err := json.Unmarshal(b, patched); err != nil {
+		return err
+	}
+
+	patched.Seq = p.To
+	*gv = *patched
+
+	return nil
+}
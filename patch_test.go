@@ -0,0 +1,103 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGameView_Patch(t *testing.T) {
+	g := NewGame(nil)
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	old := g.GeneratePlayerView(pn_a)
+
+	if p, err := DiffViews(old, g.GeneratePlayerView(pn_a)); err != nil || len(p.Fields) != 0 {
+		t.Errorf("Test failed - nothing has changed, but got %v, %v", p, err)
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := Bet(g, g.actionNum, 15); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+
+	new := g.GeneratePlayerView(pn_a)
+
+	p, err := DiffViews(old, new)
+	if err != nil {
+		t.Fatalf("Test failed - DiffViews returned %s", err)
+	}
+	if p.From != old.Seq || p.To != new.Seq || p.From >= p.To {
+		t.Errorf("Test failed - the patch should go from seq %d to %d, got %d to %d", old.Seq, new.Seq, p.From, p.To)
+	}
+	if _, ok := p.Fields["stage"]; !ok {
+		t.Errorf("Test failed - the patch should hold the new stage, got %v", p.Fields)
+	}
+	if _, ok := p.Fields["config"]; ok {
+		t.Errorf("Test failed - the patch should not hold fields that haven't changed, got %v", p.Fields)
+	}
+
+	// Patch a copy of the old view that has been through the wire, like a client's would have
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Test failed - error encoding the patch: %s", err)
+	}
+	received := &ViewPatch{}
+	if err := json.Unmarshal(b, received); err != nil {
+		t.Fatalf("Test failed - error decoding the patch: %s", err)
+	}
+
+	if b, err = json.Marshal(old); err != nil {
+		t.Fatalf("Test failed - error encoding the old view: %s", err)
+	}
+	patched := &GameView{}
+	if err := json.Unmarshal(b, patched); err != nil {
+		t.Fatalf("Test failed - error decoding the old view: %s", err)
+	}
+
+	if err := patched.Patch(received); err != nil {
+		t.Fatalf("Test failed - Patch returned %s", err)
+	}
+	if !reflect.DeepEqual(patched, new) {
+		t.Errorf("Test failed - the patched view is %+v\nwant %+v", patched, new)
+	}
+
+	if err := patched.Patch(received); err != ErrPatchMismatch {
+		t.Errorf("Test failed - a patch from another seq should be rejected, got %v", err)
+	}
+}
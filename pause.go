@@ -0,0 +1,57 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// Pause pauses the Game at the next hand boundary: a hand being played is played out, but no new hand is dealt
+// (Deal returns ErrGamePaused, and NextAdvance has nothing to schedule) until Resume is called. Players can still
+// buy in, sit out, and so on while the Game is paused, and views show it as Paused. Pause returns ErrGamePaused if
+// the Game is already paused.
+func (g *Game) Pause() error {
+	if g.paused {
+		return ErrGamePaused
+	}
+
+	g.paused = true
+	g.emit(Event{Kind: EventPause})
+
+	return nil
+}
+
+// Resume lets a paused Game deal again. With AutoDeal on, the next hand is dealt as soon as it would have been if
+// the Game had never been paused, which may be right away. Resume returns ErrGameRunning if the Game is not paused.
+func (g *Game) Resume() error {
+	if !g.paused {
+		return ErrGameRunning
+	}
+
+	g.paused = false
+	g.emit(Event{Kind: EventResume})
+
+	return nil
+}
+
+// Paused returns true if the Game is paused (see Pause).
+func (g *Game) Paused() bool {
+	return g.paused
+}
@@ -0,0 +1,99 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGame_Pause(t *testing.T) {
+	config := defaultConfig
+	config.AutoDeal = true
+	config.DealDelay = 5 * time.Second
+	g := NewGame(&config)
+
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	if err := g.Pause(); err != nil {
+		t.Fatalf("Test failed - error pausing: %s", err)
+	}
+	if err := g.Pause(); err != ErrGamePaused {
+		t.Errorf("Test failed - expected pausing twice to fail, got %v", err)
+	}
+	if !g.GenerateSpectatorView().Paused {
+		t.Errorf("Test failed - expected views to show the game paused")
+	}
+
+	// The hand being played is played out
+	if err := Bet(g, g.actionNum, 100); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+	for g.getStage() != PreDeal {
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+
+	now = now.Add(time.Hour)
+	if _, ok := g.NextAdvance(); ok {
+		t.Errorf("Test failed - expected nothing to advance while paused")
+	}
+	if err := Deal(g, g.dealingNum(), 0); err != ErrGamePaused {
+		t.Errorf("Test failed - expected no hand to be dealt while paused, got %v", err)
+	}
+
+	if err := g.Resume(); err != nil {
+		t.Fatalf("Test failed - error resuming: %s", err)
+	}
+	if err := g.Resume(); err != ErrGameRunning {
+		t.Errorf("Test failed - expected resuming a running game to fail, got %v", err)
+	}
+
+	if dealt, err := g.Advance(); !dealt || err != nil || g.GenerateSpectatorView().Paused {
+		t.Errorf("Test failed - expected the next hand to be dealt once resumed, got %t, %v", dealt, err)
+	}
+
+	events := g.Events()
+	var kinds []EventKind
+	for _, e := range events {
+		if e.Kind == EventPause || e.Kind == EventResume {
+			kinds = append(kinds, e.Kind)
+		}
+	}
+	if len(kinds) != 2 || kinds[0] != EventPause || kinds[1] != EventResume {
+		t.Errorf("Test failed - expected an EventPause and an EventResume, got %v", kinds)
+	}
+}
@@ -0,0 +1,131 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "math"
+
+// PayoutModel decides how a tournament's prize pool is paid out. Payouts returns the amount paid to each place,
+// first place first, for a field of entries entries and a prize pool of prizePool. The amounts must add up to
+// prizePool.
+type PayoutModel interface {
+	Payouts(entries uint, prizePool uint) ([]uint, error)
+}
+
+// PayoutFunc adapts an ordinary function to a PayoutModel, for custom payout structures.
+type PayoutFunc func(entries uint, prizePool uint) ([]uint, error)
+
+// Payouts calls f(entries, prizePool).
+func (f PayoutFunc) Payouts(entries uint, prizePool uint) ([]uint, error) {
+	return f(entries, prizePool)
+}
+
+// PayoutTier is one row of a PayoutTable. It covers fields of up to MaxEntries entries (0 means fields of any size),
+// and pays Percents of the prize pool to each place, first place first. If Percents don't add up to 100, they are
+// scaled so they do.
+type PayoutTier struct {
+	MaxEntries uint
+	Percents   []float64
+}
+
+// PayoutTable is a PayoutModel that pays a percentage of the prize pool to each place, from a table of percentages
+// by field size. The tiers must be in ascending order of MaxEntries; a field is paid by the first tier that covers
+// it. Chips that don't divide evenly go to the top places, one each, and a field smaller than the number of places
+// in its tier only pays as many places as it has entries.
+type PayoutTable []PayoutTier
+
+// DefaultPayouts is the PayoutTable Tournaments use if TournamentConfig.Payouts is nil. It pays roughly the top 15%
+// of the field, starting with winner-take-all for three or fewer entries.
+var DefaultPayouts = PayoutTable{
+	{MaxEntries: 3, Percents: []float64{100}},
+	{MaxEntries: 6, Percents: []float64{65, 35}},
+	{MaxEntries: 10, Percents: []float64{50, 30, 20}},
+	{MaxEntries: 20, Percents: []float64{40, 25, 16, 11, 8}},
+	{MaxEntries: 50, Percents: []float64{32, 20, 14, 11, 9, 8, 6}},
+	{MaxEntries: 100, Percents: []float64{27, 17, 12, 9.5, 8, 7, 6, 5, 4.5, 4}},
+	{MaxEntries: 0, Percents: []float64{25, 16, 11.5, 8.5, 7, 6, 5, 4.2, 3.5, 2.8, 2.5, 2.3, 2.1, 1.9, 1.7}},
+}
+
+// Payouts returns the amount paid to each place, first place first, for a field of entries entries and a prize pool
+// of prizePool. It returns ErrBadPayouts if no tier covers the field, or the tier that does has no places to pay.
+func (pt PayoutTable) Payouts(entries uint, prizePool uint) ([]uint, error) {
+	for _, tier := range pt {
+		if tier.MaxEntries == 0 || entries <= tier.MaxEntries {
+			percents := tier.Percents
+			if uint(len(percents)) > entries {
+				percents = percents[:entries]
+			}
+
+			return splitPrizePool(prizePool, percents)
+		}
+	}
+
+	return nil, ErrBadPayouts
+}
+
+// splitPrizePool pays each place its share of prizePool, in proportion to percents, which are rounded to the nearest
+// hundredth of a percent so the shares can be worked out exactly. The chips left over from rounding down go to the
+// top places, one each.
+func splitPrizePool(prizePool uint, percents []float64) ([]uint, error) {
+	weights := make([]uint64, len(percents))
+	var total uint64
+	for i, pct := range percents {
+		if pct < 0 || math.IsNaN(pct) {
+			return nil, ErrBadPayouts
+		}
+		weights[i] = uint64(math.Round(pct * 100))
+		total += weights[i]
+	}
+
+	if total == 0 {
+		return nil, ErrBadPayouts
+	}
+
+	payouts := make([]uint, len(weights))
+	paid := uint(0)
+	for i, w := range weights {
+		payouts[i] = uint(uint64(prizePool) * w / total)
+		paid += payouts[i]
+	}
+
+	for i := 0; paid < prizePool; i = (i + 1) % len(payouts) {
+		if weights[i] == 0 {
+			continue
+		}
+		payouts[i]++
+		paid++
+	}
+
+	return payouts, nil
+}
+
+// Payouts returns the amount the Tournament pays to each place, first place first, according to
+// TournamentConfig.Payouts (or DefaultPayouts), given the entries so far and the prize pool they have built.
+func (t *Tournament) Payouts() ([]uint, error) {
+	model := t.config.Payouts
+	if model == nil {
+		model = DefaultPayouts
+	}
+
+	return model.Payouts(t.Entries(), t.PrizePool())
+}
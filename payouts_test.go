@@ -0,0 +1,82 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPayoutTable(t *testing.T) {
+	tests := []struct {
+		entries   uint
+		prizePool uint
+		want      []uint
+	}{
+		{2, 200, []uint{200}},
+		{5, 1000, []uint{650, 350}},
+		// The odd chips from rounding go to the top places
+		{9, 901, []uint{451, 270, 180}},
+		{18, 1800, []uint{720, 450, 288, 198, 144}},
+		{1000, 100000, []uint{25000, 16000, 11500, 8500, 7000, 6000, 5000, 4200, 3500, 2800, 2500, 2300, 2100, 1900, 1700}},
+	}
+
+	for _, tt := range tests {
+		got, err := DefaultPayouts.Payouts(tt.entries, tt.prizePool)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Test failed - for %d entries and a prize pool of %d, expected %v, got %v (error %v)", tt.entries, tt.prizePool, tt.want, got, err)
+		}
+	}
+
+	// A field smaller than the tier's places only pays as many places as it has, scaled up to the whole prize pool
+	custom := PayoutTable{{Percents: []float64{50, 30, 20}}}
+	if got, _ := custom.Payouts(2, 80); !reflect.DeepEqual(got, []uint{50, 30}) {
+		t.Errorf("Test failed - expected [50 30], got %v", got)
+	}
+
+	if _, err := (PayoutTable{{MaxEntries: 10, Percents: []float64{100}}}).Payouts(11, 100); err != ErrBadPayouts {
+		t.Errorf("Test failed - a field no tier covers should return ErrBadPayouts, got %v", err)
+	}
+	if _, err := (PayoutTable{{Percents: []float64{-10}}}).Payouts(11, 100); err != ErrBadPayouts {
+		t.Errorf("Test failed - negative percentages should return ErrBadPayouts, got %v", err)
+	}
+}
+
+func TestTournament_Payouts(t *testing.T) {
+	tr, _ := NewTournament(&TournamentConfig{StartingStack: 100, BuyIn: 100, Game: defaultConfig})
+	for i := 0; i < 7; i++ {
+		tr.Register()
+	}
+
+	if got, err := tr.Payouts(); err != nil || !reflect.DeepEqual(got, []uint{350, 210, 140}) {
+		t.Errorf("Test failed - expected the default payouts of [350 210 140], got %v (error %v)", got, err)
+	}
+
+	tr.config.Payouts = PayoutFunc(func(entries uint, prizePool uint) ([]uint, error) {
+		return []uint{prizePool - entries, entries}, nil
+	})
+	if got, _ := tr.Payouts(); !reflect.DeepEqual(got, []uint{693, 7}) {
+		t.Errorf("Test failed - expected the custom payouts of [693 7], got %v", got)
+	}
+}
@@ -0,0 +1,2855 @@
+// Copyright (c) 2020, Alex Lewontin
+// All rights reserved.
+//
+// Use of this source code is governed by the BSD-2-Clause license found in the LICENSE file.
+
+// Protocol buffer definitions for riverboat's GameView, for servers that want to push compact binary state
+// to clients. The Go converters live in the riverboat package (GameView.ToProto and GameView.FromProto).
+//
+// To regenerate riverboat.pb.go:
+//   protoc --go_out=. --go_opt=paths=source_relative riverboat.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        (unknown)
+// source: riverboat.proto
+
+package pb
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type GameStage int32
+
+const (
+	GameStage_GAME_STAGE_UNSPECIFIED GameStage = 0
+	GameStage_PRE_DEAL               GameStage = 1
+	GameStage_PRE_FLOP               GameStage = 2
+	GameStage_FLOP                   GameStage = 3
+	GameStage_TURN                   GameStage = 4
+	GameStage_RIVER                  GameStage = 5
+	GameStage_SEVENTH_STREET         GameStage = 6
+)
+
+// Enum value maps for GameStage.
+var (
+	GameStage_name = map[int32]string{
+		0: "GAME_STAGE_UNSPECIFIED",
+		1: "PRE_DEAL",
+		2: "PRE_FLOP",
+		3: "FLOP",
+		4: "TURN",
+		5: "RIVER",
+		6: "SEVENTH_STREET",
+	}
+	GameStage_value = map[string]int32{
+		"GAME_STAGE_UNSPECIFIED": 0,
+		"PRE_DEAL":               1,
+		"PRE_FLOP":               2,
+		"FLOP":                   3,
+		"TURN":                   4,
+		"RIVER":                  5,
+		"SEVENTH_STREET":         6,
+	}
+)
+
+func (x GameStage) Enum() *GameStage {
+	p := new(GameStage)
+	*p = x
+	return p
+}
+
+func (x GameStage) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameStage) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[0].Descriptor()
+}
+
+func (GameStage) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[0]
+}
+
+func (x GameStage) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameStage.Descriptor instead.
+func (GameStage) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{0}
+}
+
+type Variant int32
+
+const (
+	Variant_HOLD_EM         Variant = 0
+	Variant_SHORT_DECK      Variant = 1
+	Variant_PINEAPPLE       Variant = 2
+	Variant_CRAZY_PINEAPPLE Variant = 3
+	Variant_OMAHA           Variant = 4
+	Variant_RAZZ            Variant = 5
+)
+
+// Enum value maps for Variant.
+var (
+	Variant_name = map[int32]string{
+		0: "HOLD_EM",
+		1: "SHORT_DECK",
+		2: "PINEAPPLE",
+		3: "CRAZY_PINEAPPLE",
+		4: "OMAHA",
+		5: "RAZZ",
+	}
+	Variant_value = map[string]int32{
+		"HOLD_EM":         0,
+		"SHORT_DECK":      1,
+		"PINEAPPLE":       2,
+		"CRAZY_PINEAPPLE": 3,
+		"OMAHA":           4,
+		"RAZZ":            5,
+	}
+)
+
+func (x Variant) Enum() *Variant {
+	p := new(Variant)
+	*p = x
+	return p
+}
+
+func (x Variant) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Variant) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[1].Descriptor()
+}
+
+func (Variant) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[1]
+}
+
+func (x Variant) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Variant.Descriptor instead.
+func (Variant) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{1}
+}
+
+type OddChipRule int32
+
+const (
+	OddChipRule_ODD_CHIP_LEFT_OF_BUTTON    OddChipRule = 0
+	OddChipRule_ODD_CHIP_LOWEST_PLAYER_NUM OddChipRule = 1
+	OddChipRule_ODD_CHIP_CARRY_OVER        OddChipRule = 2
+)
+
+// Enum value maps for OddChipRule.
+var (
+	OddChipRule_name = map[int32]string{
+		0: "ODD_CHIP_LEFT_OF_BUTTON",
+		1: "ODD_CHIP_LOWEST_PLAYER_NUM",
+		2: "ODD_CHIP_CARRY_OVER",
+	}
+	OddChipRule_value = map[string]int32{
+		"ODD_CHIP_LEFT_OF_BUTTON":    0,
+		"ODD_CHIP_LOWEST_PLAYER_NUM": 1,
+		"ODD_CHIP_CARRY_OVER":        2,
+	}
+)
+
+func (x OddChipRule) Enum() *OddChipRule {
+	p := new(OddChipRule)
+	*p = x
+	return p
+}
+
+func (x OddChipRule) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OddChipRule) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[2].Descriptor()
+}
+
+func (OddChipRule) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[2]
+}
+
+func (x OddChipRule) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OddChipRule.Descriptor instead.
+func (OddChipRule) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{2}
+}
+
+type AnteMode int32
+
+const (
+	AnteMode_ANTE_PER_PLAYER AnteMode = 0
+	AnteMode_ANTE_BIG_BLIND  AnteMode = 1
+	AnteMode_ANTE_BUTTON     AnteMode = 2
+)
+
+// Enum value maps for AnteMode.
+var (
+	AnteMode_name = map[int32]string{
+		0: "ANTE_PER_PLAYER",
+		1: "ANTE_BIG_BLIND",
+		2: "ANTE_BUTTON",
+	}
+	AnteMode_value = map[string]int32{
+		"ANTE_PER_PLAYER": 0,
+		"ANTE_BIG_BLIND":  1,
+		"ANTE_BUTTON":     2,
+	}
+)
+
+func (x AnteMode) Enum() *AnteMode {
+	p := new(AnteMode)
+	*p = x
+	return p
+}
+
+func (x AnteMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AnteMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[3].Descriptor()
+}
+
+func (AnteMode) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[3]
+}
+
+func (x AnteMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AnteMode.Descriptor instead.
+func (AnteMode) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{3}
+}
+
+type BettingStructure int32
+
+const (
+	BettingStructure_NO_LIMIT     BettingStructure = 0
+	BettingStructure_SPREAD_LIMIT BettingStructure = 1
+	BettingStructure_FIXED_LIMIT  BettingStructure = 2
+	BettingStructure_POT_LIMIT    BettingStructure = 3
+)
+
+// Enum value maps for BettingStructure.
+var (
+	BettingStructure_name = map[int32]string{
+		0: "NO_LIMIT",
+		1: "SPREAD_LIMIT",
+		2: "FIXED_LIMIT",
+		3: "POT_LIMIT",
+	}
+	BettingStructure_value = map[string]int32{
+		"NO_LIMIT":     0,
+		"SPREAD_LIMIT": 1,
+		"FIXED_LIMIT":  2,
+		"POT_LIMIT":    3,
+	}
+)
+
+func (x BettingStructure) Enum() *BettingStructure {
+	p := new(BettingStructure)
+	*p = x
+	return p
+}
+
+func (x BettingStructure) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BettingStructure) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[4].Descriptor()
+}
+
+func (BettingStructure) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[4]
+}
+
+func (x BettingStructure) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BettingStructure.Descriptor instead.
+func (BettingStructure) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{4}
+}
+
+type KillMode int32
+
+const (
+	KillMode_NO_KILL   KillMode = 0
+	KillMode_FULL_KILL KillMode = 1
+	KillMode_HALF_KILL KillMode = 2
+)
+
+// Enum value maps for KillMode.
+var (
+	KillMode_name = map[int32]string{
+		0: "NO_KILL",
+		1: "FULL_KILL",
+		2: "HALF_KILL",
+	}
+	KillMode_value = map[string]int32{
+		"NO_KILL":   0,
+		"FULL_KILL": 1,
+		"HALF_KILL": 2,
+	}
+)
+
+func (x KillMode) Enum() *KillMode {
+	p := new(KillMode)
+	*p = x
+	return p
+}
+
+func (x KillMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KillMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[5].Descriptor()
+}
+
+func (KillMode) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[5]
+}
+
+func (x KillMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KillMode.Descriptor instead.
+func (KillMode) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{5}
+}
+
+type HeadsUpRule int32
+
+const (
+	HeadsUpRule_HEADS_UP_BUTTON_SMALL_BLIND HeadsUpRule = 0
+	HeadsUpRule_HEADS_UP_BUTTON_BIG_BLIND   HeadsUpRule = 1
+)
+
+// Enum value maps for HeadsUpRule.
+var (
+	HeadsUpRule_name = map[int32]string{
+		0: "HEADS_UP_BUTTON_SMALL_BLIND",
+		1: "HEADS_UP_BUTTON_BIG_BLIND",
+	}
+	HeadsUpRule_value = map[string]int32{
+		"HEADS_UP_BUTTON_SMALL_BLIND": 0,
+		"HEADS_UP_BUTTON_BIG_BLIND":   1,
+	}
+)
+
+func (x HeadsUpRule) Enum() *HeadsUpRule {
+	p := new(HeadsUpRule)
+	*p = x
+	return p
+}
+
+func (x HeadsUpRule) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HeadsUpRule) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[6].Descriptor()
+}
+
+func (HeadsUpRule) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[6]
+}
+
+func (x HeadsUpRule) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HeadsUpRule.Descriptor instead.
+func (HeadsUpRule) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{6}
+}
+
+type TimeoutAction int32
+
+const (
+	TimeoutAction_TIMEOUT_AWAY       TimeoutAction = 0
+	TimeoutAction_TIMEOUT_CHECK_FOLD TimeoutAction = 1
+)
+
+// Enum value maps for TimeoutAction.
+var (
+	TimeoutAction_name = map[int32]string{
+		0: "TIMEOUT_AWAY",
+		1: "TIMEOUT_CHECK_FOLD",
+	}
+	TimeoutAction_value = map[string]int32{
+		"TIMEOUT_AWAY":       0,
+		"TIMEOUT_CHECK_FOLD": 1,
+	}
+)
+
+func (x TimeoutAction) Enum() *TimeoutAction {
+	p := new(TimeoutAction)
+	*p = x
+	return p
+}
+
+func (x TimeoutAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeoutAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[7].Descriptor()
+}
+
+func (TimeoutAction) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[7]
+}
+
+func (x TimeoutAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeoutAction.Descriptor instead.
+func (TimeoutAction) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{7}
+}
+
+type Retention int32
+
+const (
+	Retention_RETAIN_ALL      Retention = 0
+	Retention_RETAIN_SHOWDOWN Retention = 1
+	Retention_RETAIN_SHOWN    Retention = 2
+)
+
+// Enum value maps for Retention.
+var (
+	Retention_name = map[int32]string{
+		0: "RETAIN_ALL",
+		1: "RETAIN_SHOWDOWN",
+		2: "RETAIN_SHOWN",
+	}
+	Retention_value = map[string]int32{
+		"RETAIN_ALL":      0,
+		"RETAIN_SHOWDOWN": 1,
+		"RETAIN_SHOWN":    2,
+	}
+)
+
+func (x Retention) Enum() *Retention {
+	p := new(Retention)
+	*p = x
+	return p
+}
+
+func (x Retention) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Retention) Descriptor() protoreflect.EnumDescriptor {
+	return file_riverboat_proto_enumTypes[8].Descriptor()
+}
+
+func (Retention) Type() protoreflect.EnumType {
+	return &file_riverboat_proto_enumTypes[8]
+}
+
+func (x Retention) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Retention.Descriptor instead.
+func (Retention) EnumDescriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{8}
+}
+
+type ChipFormat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix    string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Scale     uint64 `protobuf:"varint,2,opt,name=scale,proto3" json:"scale,omitempty"`
+	Decimals  uint64 `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	Separator string `protobuf:"bytes,4,opt,name=separator,proto3" json:"separator,omitempty"`
+}
+
+func (x *ChipFormat) Reset() {
+	*x = ChipFormat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChipFormat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChipFormat) ProtoMessage() {}
+
+func (x *ChipFormat) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChipFormat.ProtoReflect.Descriptor instead.
+func (*ChipFormat) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ChipFormat) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ChipFormat) GetScale() uint64 {
+	if x != nil {
+		return x.Scale
+	}
+	return 0
+}
+
+func (x *ChipFormat) GetDecimals() uint64 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *ChipFormat) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+type RuleSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emotes       bool        `protobuf:"varint,1,opt,name=emotes,proto3" json:"emotes,omitempty"`
+	RabbitHunt   bool        `protobuf:"varint,2,opt,name=rabbit_hunt,json=rabbitHunt,proto3" json:"rabbit_hunt,omitempty"`
+	DeadChips    bool        `protobuf:"varint,3,opt,name=dead_chips,json=deadChips,proto3" json:"dead_chips,omitempty"`
+	OddChip      OddChipRule `protobuf:"varint,4,opt,name=odd_chip,json=oddChip,proto3,enum=riverboat.OddChipRule" json:"odd_chip,omitempty"`
+	HiLo         bool        `protobuf:"varint,5,opt,name=hi_lo,json=hiLo,proto3" json:"hi_lo,omitempty"`
+	MissedBlinds bool        `protobuf:"varint,6,opt,name=missed_blinds,json=missedBlinds,proto3" json:"missed_blinds,omitempty"`
+	ShowOrMuck   bool        `protobuf:"varint,7,opt,name=show_or_muck,json=showOrMuck,proto3" json:"show_or_muck,omitempty"`
+	Undo         bool        `protobuf:"varint,8,opt,name=undo,proto3" json:"undo,omitempty"`
+	TableStakes  bool        `protobuf:"varint,9,opt,name=table_stakes,json=tableStakes,proto3" json:"table_stakes,omitempty"`
+	HeadsUp      HeadsUpRule `protobuf:"varint,10,opt,name=heads_up,json=headsUp,proto3,enum=riverboat.HeadsUpRule" json:"heads_up,omitempty"`
+	Straddle     bool        `protobuf:"varint,11,opt,name=straddle,proto3" json:"straddle,omitempty"`
+}
+
+func (x *RuleSet) Reset() {
+	*x = RuleSet{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RuleSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleSet) ProtoMessage() {}
+
+func (x *RuleSet) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleSet.ProtoReflect.Descriptor instead.
+func (*RuleSet) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RuleSet) GetEmotes() bool {
+	if x != nil {
+		return x.Emotes
+	}
+	return false
+}
+
+func (x *RuleSet) GetRabbitHunt() bool {
+	if x != nil {
+		return x.RabbitHunt
+	}
+	return false
+}
+
+func (x *RuleSet) GetDeadChips() bool {
+	if x != nil {
+		return x.DeadChips
+	}
+	return false
+}
+
+func (x *RuleSet) GetOddChip() OddChipRule {
+	if x != nil {
+		return x.OddChip
+	}
+	return OddChipRule_ODD_CHIP_LEFT_OF_BUTTON
+}
+
+func (x *RuleSet) GetHiLo() bool {
+	if x != nil {
+		return x.HiLo
+	}
+	return false
+}
+
+func (x *RuleSet) GetMissedBlinds() bool {
+	if x != nil {
+		return x.MissedBlinds
+	}
+	return false
+}
+
+func (x *RuleSet) GetShowOrMuck() bool {
+	if x != nil {
+		return x.ShowOrMuck
+	}
+	return false
+}
+
+func (x *RuleSet) GetUndo() bool {
+	if x != nil {
+		return x.Undo
+	}
+	return false
+}
+
+func (x *RuleSet) GetTableStakes() bool {
+	if x != nil {
+		return x.TableStakes
+	}
+	return false
+}
+
+func (x *RuleSet) GetHeadsUp() HeadsUpRule {
+	if x != nil {
+		return x.HeadsUp
+	}
+	return HeadsUpRule_HEADS_UP_BUTTON_SMALL_BLIND
+}
+
+func (x *RuleSet) GetStraddle() bool {
+	if x != nil {
+		return x.Straddle
+	}
+	return false
+}
+
+type GameConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxBuy      uint64      `protobuf:"varint,1,opt,name=max_buy,json=maxBuy,proto3" json:"max_buy,omitempty"`
+	BigBlind    uint64      `protobuf:"varint,2,opt,name=big_blind,json=bigBlind,proto3" json:"big_blind,omitempty"`
+	SmallBlind  uint64      `protobuf:"varint,3,opt,name=small_blind,json=smallBlind,proto3" json:"small_blind,omitempty"`
+	Seed        int64       `protobuf:"varint,4,opt,name=seed,proto3" json:"seed,omitempty"`
+	ChipFormat  *ChipFormat `protobuf:"bytes,5,opt,name=chip_format,json=chipFormat,proto3" json:"chip_format,omitempty"`
+	HandCap     uint64      `protobuf:"varint,6,opt,name=hand_cap,json=handCap,proto3" json:"hand_cap,omitempty"`
+	Rules       *RuleSet    `protobuf:"bytes,7,opt,name=rules,proto3" json:"rules,omitempty"`
+	RejectLimit uint64      `protobuf:"varint,8,opt,name=reject_limit,json=rejectLimit,proto3" json:"reject_limit,omitempty"`
+	Variant     Variant     `protobuf:"varint,9,opt,name=variant,proto3,enum=riverboat.Variant" json:"variant,omitempty"`
+	AutoDeal    bool        `protobuf:"varint,10,opt,name=auto_deal,json=autoDeal,proto3" json:"auto_deal,omitempty"`
+	// In nanoseconds
+	DealDelay   int64     `protobuf:"varint,11,opt,name=deal_delay,json=dealDelay,proto3" json:"deal_delay,omitempty"`
+	Rotation    []Variant `protobuf:"varint,12,rep,packed,name=rotation,proto3,enum=riverboat.Variant" json:"rotation,omitempty"`
+	RotateEvery uint64    `protobuf:"varint,13,opt,name=rotate_every,json=rotateEvery,proto3" json:"rotate_every,omitempty"`
+	// In nanoseconds
+	RematchTimeout int64     `protobuf:"varint,14,opt,name=rematch_timeout,json=rematchTimeout,proto3" json:"rematch_timeout,omitempty"`
+	RematchStack   uint64    `protobuf:"varint,15,opt,name=rematch_stack,json=rematchStack,proto3" json:"rematch_stack,omitempty"`
+	MinBuy         uint64    `protobuf:"varint,16,opt,name=min_buy,json=minBuy,proto3" json:"min_buy,omitempty"`
+	Retention      Retention `protobuf:"varint,17,opt,name=retention,proto3,enum=riverboat.Retention" json:"retention,omitempty"`
+	// In nanoseconds
+	ActionTime        int64         `protobuf:"varint,18,opt,name=action_time,json=actionTime,proto3" json:"action_time,omitempty"`
+	TimeBank          int64         `protobuf:"varint,19,opt,name=time_bank,json=timeBank,proto3" json:"time_bank,omitempty"`
+	TimeoutAction     TimeoutAction `protobuf:"varint,20,opt,name=timeout_action,json=timeoutAction,proto3,enum=riverboat.TimeoutAction" json:"timeout_action,omitempty"`
+	TimeoutsToSitOut  uint64        `protobuf:"varint,21,opt,name=timeouts_to_sit_out,json=timeoutsToSitOut,proto3" json:"timeouts_to_sit_out,omitempty"`
+	Seats             uint64        `protobuf:"varint,22,opt,name=seats,proto3" json:"seats,omitempty"`
+	CommitShuffle     bool          `protobuf:"varint,23,opt,name=commit_shuffle,json=commitShuffle,proto3" json:"commit_shuffle,omitempty"`
+	BurnCards         bool          `protobuf:"varint,24,opt,name=burn_cards,json=burnCards,proto3" json:"burn_cards,omitempty"`
+	MinBigBlinds      uint64        `protobuf:"varint,25,opt,name=min_big_blinds,json=minBigBlinds,proto3" json:"min_big_blinds,omitempty"`
+	SevenDeuceBounty  uint64        `protobuf:"varint,26,opt,name=seven_deuce_bounty,json=sevenDeuceBounty,proto3" json:"seven_deuce_bounty,omitempty"`
+	HighHandQualifier int64         `protobuf:"varint,27,opt,name=high_hand_qualifier,json=highHandQualifier,proto3" json:"high_hand_qualifier,omitempty"`
+	// In nanoseconds
+	HighHandWindow int64            `protobuf:"varint,28,opt,name=high_hand_window,json=highHandWindow,proto3" json:"high_hand_window,omitempty"`
+	Ante           uint64           `protobuf:"varint,29,opt,name=ante,proto3" json:"ante,omitempty"`
+	AnteMode       AnteMode         `protobuf:"varint,30,opt,name=ante_mode,json=anteMode,proto3,enum=riverboat.AnteMode" json:"ante_mode,omitempty"`
+	WinTheButton   bool             `protobuf:"varint,31,opt,name=win_the_button,json=winTheButton,proto3" json:"win_the_button,omitempty"`
+	Betting        BettingStructure `protobuf:"varint,32,opt,name=betting,proto3,enum=riverboat.BettingStructure" json:"betting,omitempty"`
+	SpreadMin      uint64           `protobuf:"varint,33,opt,name=spread_min,json=spreadMin,proto3" json:"spread_min,omitempty"`
+	SpreadMax      uint64           `protobuf:"varint,34,opt,name=spread_max,json=spreadMax,proto3" json:"spread_max,omitempty"`
+	Kill           KillMode         `protobuf:"varint,35,opt,name=kill,proto3,enum=riverboat.KillMode" json:"kill,omitempty"`
+	EventHistory   uint64           `protobuf:"varint,36,opt,name=event_history,json=eventHistory,proto3" json:"event_history,omitempty"`
+}
+
+func (x *GameConfig) Reset() {
+	*x = GameConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameConfig) ProtoMessage() {}
+
+func (x *GameConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameConfig.ProtoReflect.Descriptor instead.
+func (*GameConfig) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GameConfig) GetMaxBuy() uint64 {
+	if x != nil {
+		return x.MaxBuy
+	}
+	return 0
+}
+
+func (x *GameConfig) GetBigBlind() uint64 {
+	if x != nil {
+		return x.BigBlind
+	}
+	return 0
+}
+
+func (x *GameConfig) GetSmallBlind() uint64 {
+	if x != nil {
+		return x.SmallBlind
+	}
+	return 0
+}
+
+func (x *GameConfig) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *GameConfig) GetChipFormat() *ChipFormat {
+	if x != nil {
+		return x.ChipFormat
+	}
+	return nil
+}
+
+func (x *GameConfig) GetHandCap() uint64 {
+	if x != nil {
+		return x.HandCap
+	}
+	return 0
+}
+
+func (x *GameConfig) GetRules() *RuleSet {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *GameConfig) GetRejectLimit() uint64 {
+	if x != nil {
+		return x.RejectLimit
+	}
+	return 0
+}
+
+func (x *GameConfig) GetVariant() Variant {
+	if x != nil {
+		return x.Variant
+	}
+	return Variant_HOLD_EM
+}
+
+func (x *GameConfig) GetAutoDeal() bool {
+	if x != nil {
+		return x.AutoDeal
+	}
+	return false
+}
+
+func (x *GameConfig) GetDealDelay() int64 {
+	if x != nil {
+		return x.DealDelay
+	}
+	return 0
+}
+
+func (x *GameConfig) GetRotation() []Variant {
+	if x != nil {
+		return x.Rotation
+	}
+	return nil
+}
+
+func (x *GameConfig) GetRotateEvery() uint64 {
+	if x != nil {
+		return x.RotateEvery
+	}
+	return 0
+}
+
+func (x *GameConfig) GetRematchTimeout() int64 {
+	if x != nil {
+		return x.RematchTimeout
+	}
+	return 0
+}
+
+func (x *GameConfig) GetRematchStack() uint64 {
+	if x != nil {
+		return x.RematchStack
+	}
+	return 0
+}
+
+func (x *GameConfig) GetMinBuy() uint64 {
+	if x != nil {
+		return x.MinBuy
+	}
+	return 0
+}
+
+func (x *GameConfig) GetRetention() Retention {
+	if x != nil {
+		return x.Retention
+	}
+	return Retention_RETAIN_ALL
+}
+
+func (x *GameConfig) GetActionTime() int64 {
+	if x != nil {
+		return x.ActionTime
+	}
+	return 0
+}
+
+func (x *GameConfig) GetTimeBank() int64 {
+	if x != nil {
+		return x.TimeBank
+	}
+	return 0
+}
+
+func (x *GameConfig) GetTimeoutAction() TimeoutAction {
+	if x != nil {
+		return x.TimeoutAction
+	}
+	return TimeoutAction_TIMEOUT_AWAY
+}
+
+func (x *GameConfig) GetTimeoutsToSitOut() uint64 {
+	if x != nil {
+		return x.TimeoutsToSitOut
+	}
+	return 0
+}
+
+func (x *GameConfig) GetSeats() uint64 {
+	if x != nil {
+		return x.Seats
+	}
+	return 0
+}
+
+func (x *GameConfig) GetCommitShuffle() bool {
+	if x != nil {
+		return x.CommitShuffle
+	}
+	return false
+}
+
+func (x *GameConfig) GetBurnCards() bool {
+	if x != nil {
+		return x.BurnCards
+	}
+	return false
+}
+
+func (x *GameConfig) GetMinBigBlinds() uint64 {
+	if x != nil {
+		return x.MinBigBlinds
+	}
+	return 0
+}
+
+func (x *GameConfig) GetSevenDeuceBounty() uint64 {
+	if x != nil {
+		return x.SevenDeuceBounty
+	}
+	return 0
+}
+
+func (x *GameConfig) GetHighHandQualifier() int64 {
+	if x != nil {
+		return x.HighHandQualifier
+	}
+	return 0
+}
+
+func (x *GameConfig) GetHighHandWindow() int64 {
+	if x != nil {
+		return x.HighHandWindow
+	}
+	return 0
+}
+
+func (x *GameConfig) GetAnte() uint64 {
+	if x != nil {
+		return x.Ante
+	}
+	return 0
+}
+
+func (x *GameConfig) GetAnteMode() AnteMode {
+	if x != nil {
+		return x.AnteMode
+	}
+	return AnteMode_ANTE_PER_PLAYER
+}
+
+func (x *GameConfig) GetWinTheButton() bool {
+	if x != nil {
+		return x.WinTheButton
+	}
+	return false
+}
+
+func (x *GameConfig) GetBetting() BettingStructure {
+	if x != nil {
+		return x.Betting
+	}
+	return BettingStructure_NO_LIMIT
+}
+
+func (x *GameConfig) GetSpreadMin() uint64 {
+	if x != nil {
+		return x.SpreadMin
+	}
+	return 0
+}
+
+func (x *GameConfig) GetSpreadMax() uint64 {
+	if x != nil {
+		return x.SpreadMax
+	}
+	return 0
+}
+
+func (x *GameConfig) GetKill() KillMode {
+	if x != nil {
+		return x.Kill
+	}
+	return KillMode_NO_KILL
+}
+
+func (x *GameConfig) GetEventHistory() uint64 {
+	if x != nil {
+		return x.EventHistory
+	}
+	return 0
+}
+
+type Player struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ready      bool   `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"`
+	In         bool   `protobuf:"varint,2,opt,name=in,proto3" json:"in,omitempty"`
+	Called     bool   `protobuf:"varint,3,opt,name=called,proto3" json:"called,omitempty"`
+	Left       bool   `protobuf:"varint,4,opt,name=left,proto3" json:"left,omitempty"`
+	TotalBuyIn uint64 `protobuf:"varint,5,opt,name=total_buy_in,json=totalBuyIn,proto3" json:"total_buy_in,omitempty"`
+	Stack      uint64 `protobuf:"varint,6,opt,name=stack,proto3" json:"stack,omitempty"`
+	Bet        uint64 `protobuf:"varint,7,opt,name=bet,proto3" json:"bet,omitempty"`
+	TotalBet   uint64 `protobuf:"varint,8,opt,name=total_bet,json=totalBet,proto3" json:"total_bet,omitempty"`
+	// Two hole cards, three in Razz, or four in Omaha
+	Cards           []uint32  `protobuf:"varint,9,rep,packed,name=cards,proto3" json:"cards,omitempty"`
+	PreviouslyIn    bool      `protobuf:"varint,10,opt,name=previously_in,json=previouslyIn,proto3" json:"previously_in,omitempty"`
+	PreviouslyAllIn bool      `protobuf:"varint,11,opt,name=previously_all_in,json=previouslyAllIn,proto3" json:"previously_all_in,omitempty"`
+	PreviousBet     uint64    `protobuf:"varint,12,opt,name=previous_bet,json=previousBet,proto3" json:"previous_bet,omitempty"`
+	AllInStage      GameStage `protobuf:"varint,13,opt,name=all_in_stage,json=allInStage,proto3,enum=riverboat.GameStage" json:"all_in_stage,omitempty"`
+	DeadChips       uint64    `protobuf:"varint,14,opt,name=dead_chips,json=deadChips,proto3" json:"dead_chips,omitempty"`
+	Away            bool      `protobuf:"varint,15,opt,name=away,proto3" json:"away,omitempty"`
+	ThirdCard       uint32    `protobuf:"varint,16,opt,name=third_card,json=thirdCard,proto3" json:"third_card,omitempty"`
+	Discarded       uint32    `protobuf:"varint,17,opt,name=discarded,proto3" json:"discarded,omitempty"`
+	TotalCashOut    uint64    `protobuf:"varint,18,opt,name=total_cash_out,json=totalCashOut,proto3" json:"total_cash_out,omitempty"`
+	// A bit set of the missed blinds: 1 is the big blind, 2 the small blind
+	MissedBlinds uint32 `protobuf:"varint,19,opt,name=missed_blinds,json=missedBlinds,proto3" json:"missed_blinds,omitempty"`
+	PostMissed   bool   `protobuf:"varint,20,opt,name=post_missed,json=postMissed,proto3" json:"post_missed,omitempty"`
+	SittingOut   bool   `protobuf:"varint,21,opt,name=sitting_out,json=sittingOut,proto3" json:"sitting_out,omitempty"`
+	// In nanoseconds
+	TimeBank     int64  `protobuf:"varint,22,opt,name=time_bank,json=timeBank,proto3" json:"time_bank,omitempty"`
+	Timeouts     uint64 `protobuf:"varint,23,opt,name=timeouts,proto3" json:"timeouts,omitempty"`
+	SeatNum      uint64 `protobuf:"varint,24,opt,name=seat_num,json=seatNum,proto3" json:"seat_num,omitempty"`
+	Shown        []bool `protobuf:"varint,25,rep,packed,name=shown,proto3" json:"shown,omitempty"`
+	CashedOut    uint64 `protobuf:"varint,26,opt,name=cashed_out,json=cashedOut,proto3" json:"cashed_out,omitempty"`
+	Bounty       uint64 `protobuf:"varint,27,opt,name=bounty,proto3" json:"bounty,omitempty"`
+	Straddle     bool   `protobuf:"varint,28,opt,name=straddle,proto3" json:"straddle,omitempty"`
+	Kill         bool   `protobuf:"varint,29,opt,name=kill,proto3" json:"kill,omitempty"`
+	HandsPlayed  uint64 `protobuf:"varint,30,opt,name=hands_played,json=handsPlayed,proto3" json:"hands_played,omitempty"`
+	Rebuys       uint64 `protobuf:"varint,31,opt,name=rebuys,proto3" json:"rebuys,omitempty"`
+	BiggestPot   uint64 `protobuf:"varint,32,opt,name=biggest_pot,json=biggestPot,proto3" json:"biggest_pot,omitempty"`
+	ShowdownsWon uint64 `protobuf:"varint,33,opt,name=showdowns_won,json=showdownsWon,proto3" json:"showdowns_won,omitempty"`
+	// The cards dealt face up, in Razz
+	UpCards []uint32 `protobuf:"varint,34,rep,packed,name=up_cards,json=upCards,proto3" json:"up_cards,omitempty"`
+}
+
+func (x *Player) Reset() {
+	*x = Player{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Player) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Player) ProtoMessage() {}
+
+func (x *Player) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Player.ProtoReflect.Descriptor instead.
+func (*Player) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Player) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *Player) GetIn() bool {
+	if x != nil {
+		return x.In
+	}
+	return false
+}
+
+func (x *Player) GetCalled() bool {
+	if x != nil {
+		return x.Called
+	}
+	return false
+}
+
+func (x *Player) GetLeft() bool {
+	if x != nil {
+		return x.Left
+	}
+	return false
+}
+
+func (x *Player) GetTotalBuyIn() uint64 {
+	if x != nil {
+		return x.TotalBuyIn
+	}
+	return 0
+}
+
+func (x *Player) GetStack() uint64 {
+	if x != nil {
+		return x.Stack
+	}
+	return 0
+}
+
+func (x *Player) GetBet() uint64 {
+	if x != nil {
+		return x.Bet
+	}
+	return 0
+}
+
+func (x *Player) GetTotalBet() uint64 {
+	if x != nil {
+		return x.TotalBet
+	}
+	return 0
+}
+
+func (x *Player) GetCards() []uint32 {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *Player) GetPreviouslyIn() bool {
+	if x != nil {
+		return x.PreviouslyIn
+	}
+	return false
+}
+
+func (x *Player) GetPreviouslyAllIn() bool {
+	if x != nil {
+		return x.PreviouslyAllIn
+	}
+	return false
+}
+
+func (x *Player) GetPreviousBet() uint64 {
+	if x != nil {
+		return x.PreviousBet
+	}
+	return 0
+}
+
+func (x *Player) GetAllInStage() GameStage {
+	if x != nil {
+		return x.AllInStage
+	}
+	return GameStage_GAME_STAGE_UNSPECIFIED
+}
+
+func (x *Player) GetDeadChips() uint64 {
+	if x != nil {
+		return x.DeadChips
+	}
+	return 0
+}
+
+func (x *Player) GetAway() bool {
+	if x != nil {
+		return x.Away
+	}
+	return false
+}
+
+func (x *Player) GetThirdCard() uint32 {
+	if x != nil {
+		return x.ThirdCard
+	}
+	return 0
+}
+
+func (x *Player) GetDiscarded() uint32 {
+	if x != nil {
+		return x.Discarded
+	}
+	return 0
+}
+
+func (x *Player) GetTotalCashOut() uint64 {
+	if x != nil {
+		return x.TotalCashOut
+	}
+	return 0
+}
+
+func (x *Player) GetMissedBlinds() uint32 {
+	if x != nil {
+		return x.MissedBlinds
+	}
+	return 0
+}
+
+func (x *Player) GetPostMissed() bool {
+	if x != nil {
+		return x.PostMissed
+	}
+	return false
+}
+
+func (x *Player) GetSittingOut() bool {
+	if x != nil {
+		return x.SittingOut
+	}
+	return false
+}
+
+func (x *Player) GetTimeBank() int64 {
+	if x != nil {
+		return x.TimeBank
+	}
+	return 0
+}
+
+func (x *Player) GetTimeouts() uint64 {
+	if x != nil {
+		return x.Timeouts
+	}
+	return 0
+}
+
+func (x *Player) GetSeatNum() uint64 {
+	if x != nil {
+		return x.SeatNum
+	}
+	return 0
+}
+
+func (x *Player) GetShown() []bool {
+	if x != nil {
+		return x.Shown
+	}
+	return nil
+}
+
+func (x *Player) GetCashedOut() uint64 {
+	if x != nil {
+		return x.CashedOut
+	}
+	return 0
+}
+
+func (x *Player) GetBounty() uint64 {
+	if x != nil {
+		return x.Bounty
+	}
+	return 0
+}
+
+func (x *Player) GetStraddle() bool {
+	if x != nil {
+		return x.Straddle
+	}
+	return false
+}
+
+func (x *Player) GetKill() bool {
+	if x != nil {
+		return x.Kill
+	}
+	return false
+}
+
+func (x *Player) GetHandsPlayed() uint64 {
+	if x != nil {
+		return x.HandsPlayed
+	}
+	return 0
+}
+
+func (x *Player) GetRebuys() uint64 {
+	if x != nil {
+		return x.Rebuys
+	}
+	return 0
+}
+
+func (x *Player) GetBiggestPot() uint64 {
+	if x != nil {
+		return x.BiggestPot
+	}
+	return 0
+}
+
+func (x *Player) GetShowdownsWon() uint64 {
+	if x != nil {
+		return x.ShowdownsWon
+	}
+	return 0
+}
+
+func (x *Player) GetUpCards() []uint32 {
+	if x != nil {
+		return x.UpCards
+	}
+	return nil
+}
+
+type Pot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TopShare             uint64    `protobuf:"varint,1,opt,name=top_share,json=topShare,proto3" json:"top_share,omitempty"`
+	Amt                  uint64    `protobuf:"varint,2,opt,name=amt,proto3" json:"amt,omitempty"`
+	EligiblePlayerNums   []uint32  `protobuf:"varint,3,rep,packed,name=eligible_player_nums,json=eligiblePlayerNums,proto3" json:"eligible_player_nums,omitempty"`
+	WinningPlayerNums    []uint32  `protobuf:"varint,4,rep,packed,name=winning_player_nums,json=winningPlayerNums,proto3" json:"winning_player_nums,omitempty"`
+	WinningHand          []uint32  `protobuf:"varint,5,rep,packed,name=winning_hand,json=winningHand,proto3" json:"winning_hand,omitempty"`
+	WinningScore         int32     `protobuf:"varint,6,opt,name=winning_score,json=winningScore,proto3" json:"winning_score,omitempty"`
+	Name                 string    `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	Side                 bool      `protobuf:"varint,8,opt,name=side,proto3" json:"side,omitempty"`
+	Capped               bool      `protobuf:"varint,9,opt,name=capped,proto3" json:"capped,omitempty"`
+	CreatedStage         GameStage `protobuf:"varint,10,opt,name=created_stage,json=createdStage,proto3,enum=riverboat.GameStage" json:"created_stage,omitempty"`
+	CreatedByPlayerNum   uint32    `protobuf:"varint,11,opt,name=created_by_player_num,json=createdByPlayerNum,proto3" json:"created_by_player_num,omitempty"`
+	Contributions        []uint64  `protobuf:"varint,12,rep,packed,name=contributions,proto3" json:"contributions,omitempty"`
+	LowWinningPlayerNums []uint32  `protobuf:"varint,13,rep,packed,name=low_winning_player_nums,json=lowWinningPlayerNums,proto3" json:"low_winning_player_nums,omitempty"`
+	LowWinningHand       []uint32  `protobuf:"varint,14,rep,packed,name=low_winning_hand,json=lowWinningHand,proto3" json:"low_winning_hand,omitempty"`
+	LowWinningScore      int32     `protobuf:"varint,15,opt,name=low_winning_score,json=lowWinningScore,proto3" json:"low_winning_score,omitempty"`
+}
+
+func (x *Pot) Reset() {
+	*x = Pot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pot) ProtoMessage() {}
+
+func (x *Pot) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pot.ProtoReflect.Descriptor instead.
+func (*Pot) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Pot) GetTopShare() uint64 {
+	if x != nil {
+		return x.TopShare
+	}
+	return 0
+}
+
+func (x *Pot) GetAmt() uint64 {
+	if x != nil {
+		return x.Amt
+	}
+	return 0
+}
+
+func (x *Pot) GetEligiblePlayerNums() []uint32 {
+	if x != nil {
+		return x.EligiblePlayerNums
+	}
+	return nil
+}
+
+func (x *Pot) GetWinningPlayerNums() []uint32 {
+	if x != nil {
+		return x.WinningPlayerNums
+	}
+	return nil
+}
+
+func (x *Pot) GetWinningHand() []uint32 {
+	if x != nil {
+		return x.WinningHand
+	}
+	return nil
+}
+
+func (x *Pot) GetWinningScore() int32 {
+	if x != nil {
+		return x.WinningScore
+	}
+	return 0
+}
+
+func (x *Pot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pot) GetSide() bool {
+	if x != nil {
+		return x.Side
+	}
+	return false
+}
+
+func (x *Pot) GetCapped() bool {
+	if x != nil {
+		return x.Capped
+	}
+	return false
+}
+
+func (x *Pot) GetCreatedStage() GameStage {
+	if x != nil {
+		return x.CreatedStage
+	}
+	return GameStage_GAME_STAGE_UNSPECIFIED
+}
+
+func (x *Pot) GetCreatedByPlayerNum() uint32 {
+	if x != nil {
+		return x.CreatedByPlayerNum
+	}
+	return 0
+}
+
+func (x *Pot) GetContributions() []uint64 {
+	if x != nil {
+		return x.Contributions
+	}
+	return nil
+}
+
+func (x *Pot) GetLowWinningPlayerNums() []uint32 {
+	if x != nil {
+		return x.LowWinningPlayerNums
+	}
+	return nil
+}
+
+func (x *Pot) GetLowWinningHand() []uint32 {
+	if x != nil {
+		return x.LowWinningHand
+	}
+	return nil
+}
+
+func (x *Pot) GetLowWinningScore() int32 {
+	if x != nil {
+		return x.LowWinningScore
+	}
+	return 0
+}
+
+type ShowdownReveal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerNum uint32 `protobuf:"varint,1,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"`
+	Mucked    bool   `protobuf:"varint,2,opt,name=mucked,proto3" json:"mucked,omitempty"`
+	// Two hole cards, three in Razz, or four in Omaha
+	Cards []uint32 `protobuf:"varint,3,rep,packed,name=cards,proto3" json:"cards,omitempty"`
+	Score int32    `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (x *ShowdownReveal) Reset() {
+	*x = ShowdownReveal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShowdownReveal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShowdownReveal) ProtoMessage() {}
+
+func (x *ShowdownReveal) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShowdownReveal.ProtoReflect.Descriptor instead.
+func (*ShowdownReveal) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ShowdownReveal) GetPlayerNum() uint32 {
+	if x != nil {
+		return x.PlayerNum
+	}
+	return 0
+}
+
+func (x *ShowdownReveal) GetMucked() bool {
+	if x != nil {
+		return x.Mucked
+	}
+	return false
+}
+
+func (x *ShowdownReveal) GetCards() []uint32 {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *ShowdownReveal) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type WeightedCombo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cards  []uint32 `protobuf:"varint,1,rep,packed,name=cards,proto3" json:"cards,omitempty"`
+	Weight float64  `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+}
+
+func (x *WeightedCombo) Reset() {
+	*x = WeightedCombo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WeightedCombo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeightedCombo) ProtoMessage() {}
+
+func (x *WeightedCombo) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeightedCombo.ProtoReflect.Descriptor instead.
+func (*WeightedCombo) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WeightedCombo) GetCards() []uint32 {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *WeightedCombo) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type Range struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Combos []*WeightedCombo `protobuf:"bytes,1,rep,name=combos,proto3" json:"combos,omitempty"`
+}
+
+func (x *Range) Reset() {
+	*x = Range{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Range) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Range) ProtoMessage() {}
+
+func (x *Range) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Range.ProtoReflect.Descriptor instead.
+func (*Range) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Range) GetCombos() []*WeightedCombo {
+	if x != nil {
+		return x.Combos
+	}
+	return nil
+}
+
+type GameView struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The JSON schema version (riverboat.ViewSchemaVersion) this message corresponds to
+	SchemaVersion  uint32            `protobuf:"varint,1,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	DealerNum      uint32            `protobuf:"varint,2,opt,name=dealer_num,json=dealerNum,proto3" json:"dealer_num,omitempty"`
+	ActionNum      uint32            `protobuf:"varint,3,opt,name=action_num,json=actionNum,proto3" json:"action_num,omitempty"`
+	UtgNum         uint32            `protobuf:"varint,4,opt,name=utg_num,json=utgNum,proto3" json:"utg_num,omitempty"`
+	SbNum          uint32            `protobuf:"varint,5,opt,name=sb_num,json=sbNum,proto3" json:"sb_num,omitempty"`
+	BbNum          uint32            `protobuf:"varint,6,opt,name=bb_num,json=bbNum,proto3" json:"bb_num,omitempty"`
+	CalledNum      uint32            `protobuf:"varint,7,opt,name=called_num,json=calledNum,proto3" json:"called_num,omitempty"`
+	CommunityCards []uint32          `protobuf:"varint,8,rep,packed,name=community_cards,json=communityCards,proto3" json:"community_cards,omitempty"`
+	Stage          GameStage         `protobuf:"varint,9,opt,name=stage,proto3,enum=riverboat.GameStage" json:"stage,omitempty"`
+	Betting        bool              `protobuf:"varint,10,opt,name=betting,proto3" json:"betting,omitempty"`
+	Config         *GameConfig       `protobuf:"bytes,11,opt,name=config,proto3" json:"config,omitempty"`
+	Players        []*Player         `protobuf:"bytes,12,rep,name=players,proto3" json:"players,omitempty"`
+	Deck           []uint32          `protobuf:"varint,13,rep,packed,name=deck,proto3" json:"deck,omitempty"`
+	Pots           []*Pot            `protobuf:"bytes,14,rep,name=pots,proto3" json:"pots,omitempty"`
+	MinRaise       uint64            `protobuf:"varint,15,opt,name=min_raise,json=minRaise,proto3" json:"min_raise,omitempty"`
+	ReadyCount     uint64            `protobuf:"varint,16,opt,name=ready_count,json=readyCount,proto3" json:"ready_count,omitempty"`
+	Showdown       []*ShowdownReveal `protobuf:"bytes,17,rep,name=showdown,proto3" json:"showdown,omitempty"`
+	// Only present in omniscient views, and only when range tracking is on
+	Ranges        []*Range      `protobuf:"bytes,18,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	Carryover     uint64        `protobuf:"varint,19,opt,name=carryover,proto3" json:"carryover,omitempty"`
+	Variant       Variant       `protobuf:"varint,20,opt,name=variant,proto3,enum=riverboat.Variant" json:"variant,omitempty"`
+	RotationNum   uint64        `protobuf:"varint,21,opt,name=rotation_num,json=rotationNum,proto3" json:"rotation_num,omitempty"`
+	RotationHands uint64        `protobuf:"varint,22,opt,name=rotation_hands,json=rotationHands,proto3" json:"rotation_hands,omitempty"`
+	Rematch       *RematchOffer `protobuf:"bytes,23,opt,name=rematch,proto3" json:"rematch,omitempty"`
+	// In nanoseconds since the Unix epoch, or 0 if no hand has ended
+	HandEnded int64 `protobuf:"varint,24,opt,name=hand_ended,json=handEnded,proto3" json:"hand_ended,omitempty"`
+	// In nanoseconds since the Unix epoch, or 0 if there is no deadline
+	ActionDeadline int64              `protobuf:"varint,25,opt,name=action_deadline,json=actionDeadline,proto3" json:"action_deadline,omitempty"`
+	Seq            uint64             `protobuf:"varint,26,opt,name=seq,proto3" json:"seq,omitempty"`
+	Shuffle        *ShuffleCommitment `protobuf:"bytes,27,opt,name=shuffle,proto3" json:"shuffle,omitempty"`
+	LastShuffle    *ShuffleCommitment `protobuf:"bytes,28,opt,name=last_shuffle,json=lastShuffle,proto3" json:"last_shuffle,omitempty"`
+	Burns          []uint32           `protobuf:"varint,29,rep,packed,name=burns,proto3" json:"burns,omitempty"`
+	ShowdownOrder  []uint32           `protobuf:"varint,30,rep,packed,name=showdown_order,json=showdownOrder,proto3" json:"showdown_order,omitempty"`
+	Paused         bool               `protobuf:"varint,31,opt,name=paused,proto3" json:"paused,omitempty"`
+	Kill           bool               `protobuf:"varint,32,opt,name=kill,proto3" json:"kill,omitempty"`
+	KillNum        uint32             `protobuf:"varint,33,opt,name=kill_num,json=killNum,proto3" json:"kill_num,omitempty"`
+	LastPotWinner  uint32             `protobuf:"varint,34,opt,name=last_pot_winner,json=lastPotWinner,proto3" json:"last_pot_winner,omitempty"`
+	LastPotWon     bool               `protobuf:"varint,35,opt,name=last_pot_won,json=lastPotWon,proto3" json:"last_pot_won,omitempty"`
+	// The hand leading the high-hand promotion, if the table runs one and a hand has qualified
+	HighHand *HighHand `protobuf:"bytes,36,opt,name=high_hand,json=highHand,proto3" json:"high_hand,omitempty"`
+}
+
+func (x *GameView) Reset() {
+	*x = GameView{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameView) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameView) ProtoMessage() {}
+
+func (x *GameView) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameView.ProtoReflect.Descriptor instead.
+func (*GameView) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GameView) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *GameView) GetDealerNum() uint32 {
+	if x != nil {
+		return x.DealerNum
+	}
+	return 0
+}
+
+func (x *GameView) GetActionNum() uint32 {
+	if x != nil {
+		return x.ActionNum
+	}
+	return 0
+}
+
+func (x *GameView) GetUtgNum() uint32 {
+	if x != nil {
+		return x.UtgNum
+	}
+	return 0
+}
+
+func (x *GameView) GetSbNum() uint32 {
+	if x != nil {
+		return x.SbNum
+	}
+	return 0
+}
+
+func (x *GameView) GetBbNum() uint32 {
+	if x != nil {
+		return x.BbNum
+	}
+	return 0
+}
+
+func (x *GameView) GetCalledNum() uint32 {
+	if x != nil {
+		return x.CalledNum
+	}
+	return 0
+}
+
+func (x *GameView) GetCommunityCards() []uint32 {
+	if x != nil {
+		return x.CommunityCards
+	}
+	return nil
+}
+
+func (x *GameView) GetStage() GameStage {
+	if x != nil {
+		return x.Stage
+	}
+	return GameStage_GAME_STAGE_UNSPECIFIED
+}
+
+func (x *GameView) GetBetting() bool {
+	if x != nil {
+		return x.Betting
+	}
+	return false
+}
+
+func (x *GameView) GetConfig() *GameConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *GameView) GetPlayers() []*Player {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *GameView) GetDeck() []uint32 {
+	if x != nil {
+		return x.Deck
+	}
+	return nil
+}
+
+func (x *GameView) GetPots() []*Pot {
+	if x != nil {
+		return x.Pots
+	}
+	return nil
+}
+
+func (x *GameView) GetMinRaise() uint64 {
+	if x != nil {
+		return x.MinRaise
+	}
+	return 0
+}
+
+func (x *GameView) GetReadyCount() uint64 {
+	if x != nil {
+		return x.ReadyCount
+	}
+	return 0
+}
+
+func (x *GameView) GetShowdown() []*ShowdownReveal {
+	if x != nil {
+		return x.Showdown
+	}
+	return nil
+}
+
+func (x *GameView) GetRanges() []*Range {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *GameView) GetCarryover() uint64 {
+	if x != nil {
+		return x.Carryover
+	}
+	return 0
+}
+
+func (x *GameView) GetVariant() Variant {
+	if x != nil {
+		return x.Variant
+	}
+	return Variant_HOLD_EM
+}
+
+func (x *GameView) GetRotationNum() uint64 {
+	if x != nil {
+		return x.RotationNum
+	}
+	return 0
+}
+
+func (x *GameView) GetRotationHands() uint64 {
+	if x != nil {
+		return x.RotationHands
+	}
+	return 0
+}
+
+func (x *GameView) GetRematch() *RematchOffer {
+	if x != nil {
+		return x.Rematch
+	}
+	return nil
+}
+
+func (x *GameView) GetHandEnded() int64 {
+	if x != nil {
+		return x.HandEnded
+	}
+	return 0
+}
+
+func (x *GameView) GetActionDeadline() int64 {
+	if x != nil {
+		return x.ActionDeadline
+	}
+	return 0
+}
+
+func (x *GameView) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *GameView) GetShuffle() *ShuffleCommitment {
+	if x != nil {
+		return x.Shuffle
+	}
+	return nil
+}
+
+func (x *GameView) GetLastShuffle() *ShuffleCommitment {
+	if x != nil {
+		return x.LastShuffle
+	}
+	return nil
+}
+
+func (x *GameView) GetBurns() []uint32 {
+	if x != nil {
+		return x.Burns
+	}
+	return nil
+}
+
+func (x *GameView) GetShowdownOrder() []uint32 {
+	if x != nil {
+		return x.ShowdownOrder
+	}
+	return nil
+}
+
+func (x *GameView) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *GameView) GetKill() bool {
+	if x != nil {
+		return x.Kill
+	}
+	return false
+}
+
+func (x *GameView) GetKillNum() uint32 {
+	if x != nil {
+		return x.KillNum
+	}
+	return 0
+}
+
+func (x *GameView) GetLastPotWinner() uint32 {
+	if x != nil {
+		return x.LastPotWinner
+	}
+	return 0
+}
+
+func (x *GameView) GetLastPotWon() bool {
+	if x != nil {
+		return x.LastPotWon
+	}
+	return false
+}
+
+func (x *GameView) GetHighHand() *HighHand {
+	if x != nil {
+		return x.HighHand
+	}
+	return nil
+}
+
+type HighHand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerNum uint32 `protobuf:"varint,1,opt,name=player_num,json=playerNum,proto3" json:"player_num,omitempty"`
+	// The five cards played
+	Hand  []uint32 `protobuf:"varint,2,rep,packed,name=hand,proto3" json:"hand,omitempty"`
+	Score int32    `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	// In nanoseconds since the Unix epoch
+	At int64 `protobuf:"varint,4,opt,name=at,proto3" json:"at,omitempty"`
+}
+
+func (x *HighHand) Reset() {
+	*x = HighHand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HighHand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HighHand) ProtoMessage() {}
+
+func (x *HighHand) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HighHand.ProtoReflect.Descriptor instead.
+func (*HighHand) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *HighHand) GetPlayerNum() uint32 {
+	if x != nil {
+		return x.PlayerNum
+	}
+	return 0
+}
+
+func (x *HighHand) GetHand() []uint32 {
+	if x != nil {
+		return x.Hand
+	}
+	return nil
+}
+
+func (x *HighHand) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *HighHand) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+type ShuffleCommitment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Commitment string   `protobuf:"bytes,1,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Seed       int64    `protobuf:"varint,2,opt,name=seed,proto3" json:"seed,omitempty"`
+	Salt       string   `protobuf:"bytes,3,opt,name=salt,proto3" json:"salt,omitempty"`
+	Variant    Variant  `protobuf:"varint,4,opt,name=variant,proto3,enum=riverboat.Variant" json:"variant,omitempty"`
+	Dealt      []uint32 `protobuf:"varint,5,rep,packed,name=dealt,proto3" json:"dealt,omitempty"`
+}
+
+func (x *ShuffleCommitment) Reset() {
+	*x = ShuffleCommitment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShuffleCommitment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShuffleCommitment) ProtoMessage() {}
+
+func (x *ShuffleCommitment) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShuffleCommitment.ProtoReflect.Descriptor instead.
+func (*ShuffleCommitment) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ShuffleCommitment) GetCommitment() string {
+	if x != nil {
+		return x.Commitment
+	}
+	return ""
+}
+
+func (x *ShuffleCommitment) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *ShuffleCommitment) GetSalt() string {
+	if x != nil {
+		return x.Salt
+	}
+	return ""
+}
+
+func (x *ShuffleCommitment) GetVariant() Variant {
+	if x != nil {
+		return x.Variant
+	}
+	return Variant_HOLD_EM
+}
+
+func (x *ShuffleCommitment) GetDealt() []uint32 {
+	if x != nil {
+		return x.Dealt
+	}
+	return nil
+}
+
+type RematchOffer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PlayerNums []uint32 `protobuf:"varint,1,rep,packed,name=player_nums,json=playerNums,proto3" json:"player_nums,omitempty"`
+	Accepted   []bool   `protobuf:"varint,2,rep,packed,name=accepted,proto3" json:"accepted,omitempty"`
+	// In nanoseconds since the Unix epoch
+	Deadline int64 `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+}
+
+func (x *RematchOffer) Reset() {
+	*x = RematchOffer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_riverboat_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RematchOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RematchOffer) ProtoMessage() {}
+
+func (x *RematchOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_riverboat_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RematchOffer.ProtoReflect.Descriptor instead.
+func (*RematchOffer) Descriptor() ([]byte, []int) {
+	return file_riverboat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RematchOffer) GetPlayerNums() []uint32 {
+	if x != nil {
+		return x.PlayerNums
+	}
+	return nil
+}
+
+func (x *RematchOffer) GetAccepted() []bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return nil
+}
+
+func (x *RematchOffer) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+var File_riverboat_proto protoreflect.FileDescriptor
+
+var file_riverboat_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x22, 0x74, 0x0a, 0x0a,
+	0x43, 0x68, 0x69, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x22, 0xf6, 0x02, 0x0a, 0x07, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x61, 0x62, 0x62, 0x69, 0x74,
+	0x5f, 0x68, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x72, 0x61, 0x62,
+	0x62, 0x69, 0x74, 0x48, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x61, 0x64, 0x5f,
+	0x63, 0x68, 0x69, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x64, 0x65, 0x61,
+	0x64, 0x43, 0x68, 0x69, 0x70, 0x73, 0x12, 0x31, 0x0a, 0x08, 0x6f, 0x64, 0x64, 0x5f, 0x63, 0x68,
+	0x69, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x74, 0x2e, 0x4f, 0x64, 0x64, 0x43, 0x68, 0x69, 0x70, 0x52, 0x75, 0x6c, 0x65,
+	0x52, 0x07, 0x6f, 0x64, 0x64, 0x43, 0x68, 0x69, 0x70, 0x12, 0x13, 0x0a, 0x05, 0x68, 0x69, 0x5f,
+	0x6c, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x68, 0x69, 0x4c, 0x6f, 0x12, 0x23,
+	0x0a, 0x0d, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x42, 0x6c, 0x69,
+	0x6e, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x68, 0x6f, 0x77, 0x5f, 0x6f, 0x72, 0x5f, 0x6d,
+	0x75, 0x63, 0x6b, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x68, 0x6f, 0x77, 0x4f,
+	0x72, 0x4d, 0x75, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x64, 0x6f, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x75, 0x6e, 0x64, 0x6f, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x08,
+	0x68, 0x65, 0x61, 0x64, 0x73, 0x5f, 0x75, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
+	0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x73,
+	0x55, 0x70, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x73, 0x55, 0x70, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61, 0x64, 0x64, 0x6c, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61, 0x64, 0x64, 0x6c, 0x65, 0x22, 0xd6, 0x0a, 0x0a, 0x0a,
+	0x47, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x61,
+	0x78, 0x5f, 0x62, 0x75, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x61, 0x78,
+	0x42, 0x75, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x67, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x62, 0x69, 0x67, 0x42, 0x6c, 0x69, 0x6e, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x6d, 0x61, 0x6c, 0x6c, 0x42, 0x6c, 0x69, 0x6e,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x0b, 0x63, 0x68, 0x69, 0x70, 0x5f, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72, 0x69, 0x76,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x43, 0x68, 0x69, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x52, 0x0a, 0x63, 0x68, 0x69, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x68, 0x61, 0x6e, 0x64, 0x5f, 0x63, 0x61, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x68, 0x61, 0x6e, 0x64, 0x43, 0x61, 0x70, 0x12, 0x28, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62,
+	0x6f, 0x61, 0x74, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x05, 0x72, 0x75, 0x6c,
+	0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x74, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x52, 0x07, 0x76, 0x61, 0x72, 0x69,
+	0x61, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x64, 0x65, 0x61, 0x6c,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x75, 0x74, 0x6f, 0x44, 0x65, 0x61, 0x6c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12,
+	0x2e, 0x0a, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x12, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x56, 0x61,
+	0x72, 0x69, 0x61, 0x6e, 0x74, 0x52, 0x08, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x21, 0x0a, 0x0c, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x65, 0x76, 0x65, 0x72, 0x79, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65,
+	0x72, 0x79, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72,
+	0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x72, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x63, 0x6b,
+	0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x75, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x42, 0x75, 0x79, 0x12, 0x32, 0x0a, 0x09, 0x72, 0x65, 0x74,
+	0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x42, 0x61, 0x6e, 0x6b, 0x12, 0x3f, 0x0a, 0x0e, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x13,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73, 0x5f, 0x74, 0x6f, 0x5f, 0x73, 0x69, 0x74, 0x5f,
+	0x6f, 0x75, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x73, 0x54, 0x6f, 0x53, 0x69, 0x74, 0x4f, 0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x65, 0x61, 0x74, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x61, 0x74,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x68, 0x75, 0x66,
+	0x66, 0x6c, 0x65, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x53, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x75, 0x72, 0x6e,
+	0x5f, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x62, 0x75,
+	0x72, 0x6e, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x62,
+	0x69, 0x67, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64, 0x73, 0x18, 0x19, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x6d, 0x69, 0x6e, 0x42, 0x69, 0x67, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x73, 0x12, 0x2c, 0x0a,
+	0x12, 0x73, 0x65, 0x76, 0x65, 0x6e, 0x5f, 0x64, 0x65, 0x75, 0x63, 0x65, 0x5f, 0x62, 0x6f, 0x75,
+	0x6e, 0x74, 0x79, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x73, 0x65, 0x76, 0x65, 0x6e,
+	0x44, 0x65, 0x75, 0x63, 0x65, 0x42, 0x6f, 0x75, 0x6e, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x13, 0x68,
+	0x69, 0x67, 0x68, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x68, 0x69, 0x67, 0x68, 0x48, 0x61,
+	0x6e, 0x64, 0x51, 0x75, 0x61, 0x6c, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x10, 0x68,
+	0x69, 0x67, 0x68, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18,
+	0x1c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x68, 0x69, 0x67, 0x68, 0x48, 0x61, 0x6e, 0x64, 0x57,
+	0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x6e, 0x74, 0x65, 0x18, 0x1d, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x6e, 0x74, 0x65, 0x12, 0x30, 0x0a, 0x09, 0x61, 0x6e, 0x74,
+	0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x41, 0x6e, 0x74, 0x65, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x08, 0x61, 0x6e, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x77,
+	0x69, 0x6e, 0x5f, 0x74, 0x68, 0x65, 0x5f, 0x62, 0x75, 0x74, 0x74, 0x6f, 0x6e, 0x18, 0x1f, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x54, 0x68, 0x65, 0x42, 0x75, 0x74, 0x74, 0x6f,
+	0x6e, 0x12, 0x35, 0x0a, 0x07, 0x62, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x20, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x42,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x52,
+	0x07, 0x62, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x70, 0x72, 0x65,
+	0x61, 0x64, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x21, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x70,
+	0x72, 0x65, 0x61, 0x64, 0x4d, 0x69, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x70, 0x72, 0x65, 0x61,
+	0x64, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x22, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x73, 0x70, 0x72,
+	0x65, 0x61, 0x64, 0x4d, 0x61, 0x78, 0x12, 0x27, 0x0a, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x18, 0x23,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74,
+	0x2e, 0x4b, 0x69, 0x6c, 0x6c, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x12,
+	0x23, 0x0a, 0x0d, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x18, 0x24, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x22, 0xed, 0x07, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x65, 0x66,
+	0x74, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x75, 0x79, 0x5f, 0x69,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x75,
+	0x79, 0x49, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x65, 0x74,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x62, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x62, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x6c, 0x79, 0x5f, 0x69, 0x6e, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x6c,
+	0x79, 0x49, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x6c,
+	0x79, 0x5f, 0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x6c, 0x79, 0x41, 0x6c, 0x6c, 0x49, 0x6e, 0x12,
+	0x21, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x62, 0x65, 0x74, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x42,
+	0x65, 0x74, 0x12, 0x36, 0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x5f, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72,
+	0x62, 0x6f, 0x61, 0x74, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x0a,
+	0x61, 0x6c, 0x6c, 0x49, 0x6e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65,
+	0x61, 0x64, 0x5f, 0x63, 0x68, 0x69, 0x70, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x64, 0x65, 0x61, 0x64, 0x43, 0x68, 0x69, 0x70, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x77, 0x61,
+	0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x77, 0x61, 0x79, 0x12, 0x1d, 0x0a,
+	0x0a, 0x74, 0x68, 0x69, 0x72, 0x64, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x74, 0x68, 0x69, 0x72, 0x64, 0x43, 0x61, 0x72, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x65, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x09, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x63, 0x61, 0x73, 0x68, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x61, 0x73, 0x68, 0x4f, 0x75, 0x74,
+	0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x5f, 0x62, 0x6c, 0x69, 0x6e, 0x64,
+	0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x42,
+	0x6c, 0x69, 0x6e, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x6d, 0x69,
+	0x73, 0x73, 0x65, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x6f, 0x73, 0x74,
+	0x4d, 0x69, 0x73, 0x73, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x69, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x5f, 0x6f, 0x75, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x69, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x4f, 0x75, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x62, 0x61, 0x6e, 0x6b, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65,
+	0x42, 0x61, 0x6e, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73,
+	0x18, 0x17, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x73,
+	0x12, 0x19, 0x0a, 0x08, 0x73, 0x65, 0x61, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x73, 0x65, 0x61, 0x74, 0x4e, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x68, 0x6f, 0x77, 0x6e, 0x18, 0x19, 0x20, 0x03, 0x28, 0x08, 0x52, 0x05, 0x73, 0x68, 0x6f, 0x77,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x61, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x6f, 0x75, 0x74, 0x18,
+	0x1a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x61, 0x73, 0x68, 0x65, 0x64, 0x4f, 0x75, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x74, 0x79, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x74, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x72, 0x61,
+	0x64, 0x64, 0x6c, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x74, 0x72, 0x61,
+	0x64, 0x64, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x18, 0x1d, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x61, 0x6e, 0x64,
+	0x73, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x68, 0x61, 0x6e, 0x64, 0x73, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x62, 0x75, 0x79, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x72, 0x65, 0x62,
+	0x75, 0x79, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x67, 0x65, 0x73, 0x74, 0x5f, 0x70,
+	0x6f, 0x74, 0x18, 0x20, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x62, 0x69, 0x67, 0x67, 0x65, 0x73,
+	0x74, 0x50, 0x6f, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x68, 0x6f, 0x77, 0x64, 0x6f, 0x77, 0x6e,
+	0x73, 0x5f, 0x77, 0x6f, 0x6e, 0x18, 0x21, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x73, 0x68, 0x6f,
+	0x77, 0x64, 0x6f, 0x77, 0x6e, 0x73, 0x57, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x70, 0x5f,
+	0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x22, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x75, 0x70, 0x43,
+	0x61, 0x72, 0x64, 0x73, 0x22, 0xbf, 0x04, 0x0a, 0x03, 0x50, 0x6f, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x74, 0x6f, 0x70, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x74, 0x6f, 0x70, 0x53, 0x68, 0x61, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6d, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x61, 0x6d, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x65,
+	0x6c, 0x69, 0x67, 0x69, 0x62, 0x6c, 0x65, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6e,
+	0x75, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x12, 0x65, 0x6c, 0x69, 0x67, 0x69,
+	0x62, 0x6c, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x73, 0x12, 0x2e, 0x0a,
+	0x13, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f,
+	0x6e, 0x75, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x11, 0x77, 0x69, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x0b, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x61, 0x6e, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x64,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x61, 0x70, 0x70, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63,
+	0x61, 0x70, 0x70, 0x65, 0x64, 0x12, 0x39, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x53, 0x74, 0x61, 0x67, 0x65,
+	0x12, 0x31, 0x0a, 0x15, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x5f, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x12, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x4e, 0x75, 0x6d, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x35, 0x0a, 0x17, 0x6c, 0x6f, 0x77,
+	0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f,
+	0x6e, 0x75, 0x6d, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x14, 0x6c, 0x6f, 0x77, 0x57,
+	0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x73,
+	0x12, 0x28, 0x0a, 0x10, 0x6c, 0x6f, 0x77, 0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f,
+	0x68, 0x61, 0x6e, 0x64, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0e, 0x6c, 0x6f, 0x77, 0x57,
+	0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x48, 0x61, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f,
+	0x77, 0x5f, 0x77, 0x69, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6c, 0x6f, 0x77, 0x57, 0x69, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x73, 0x0a, 0x0e, 0x53, 0x68, 0x6f, 0x77, 0x64, 0x6f,
+	0x77, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x75, 0x63, 0x6b, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6d, 0x75, 0x63, 0x6b, 0x65, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05,
+	0x63, 0x61, 0x72, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0x3d, 0x0a, 0x0d, 0x57,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x62, 0x6f, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x61, 0x72,
+	0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x39, 0x0a, 0x05, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x62, 0x6f, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e,
+	0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x62, 0x6f, 0x52, 0x06, 0x63,
+	0x6f, 0x6d, 0x62, 0x6f, 0x73, 0x22, 0x93, 0x0a, 0x0a, 0x08, 0x47, 0x61, 0x6d, 0x65, 0x56, 0x69,
+	0x65, 0x77, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x65, 0x61,
+	0x6c, 0x65, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64,
+	0x65, 0x61, 0x6c, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x74, 0x67, 0x5f, 0x6e,
+	0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x75, 0x74, 0x67, 0x4e, 0x75, 0x6d,
+	0x12, 0x15, 0x0a, 0x06, 0x73, 0x62, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x73, 0x62, 0x4e, 0x75, 0x6d, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x62, 0x5f, 0x6e, 0x75,
+	0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x62, 0x62, 0x4e, 0x75, 0x6d, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x64, 0x4e, 0x75, 0x6d, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74,
+	0x79, 0x43, 0x61, 0x72, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x74, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x67, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2d, 0x0a, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x47, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x2b, 0x0a, 0x07, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52,
+	0x07, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x63, 0x6b,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x64, 0x65, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x04,
+	0x70, 0x6f, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x69, 0x76,
+	0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x50, 0x6f, 0x74, 0x52, 0x04, 0x70, 0x6f, 0x74, 0x73,
+	0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x69, 0x73, 0x65, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x52, 0x61, 0x69, 0x73, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x72, 0x65, 0x61, 0x64, 0x79, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x35,
+	0x0a, 0x08, 0x73, 0x68, 0x6f, 0x77, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x53, 0x68, 0x6f,
+	0x77, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x52, 0x08, 0x73, 0x68, 0x6f,
+	0x77, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x28, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18,
+	0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61,
+	0x74, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x61, 0x72, 0x72, 0x79, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x63, 0x61, 0x72, 0x72, 0x79, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x2c, 0x0a,
+	0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x56, 0x61, 0x72, 0x69, 0x61,
+	0x6e, 0x74, 0x52, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x72,
+	0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0b, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x73,
+	0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x18, 0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f,
+	0x61, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52,
+	0x07, 0x72, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x61, 0x6e, 0x64,
+	0x5f, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x18, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x68, 0x61,
+	0x6e, 0x64, 0x45, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x73,
+	0x65, 0x71, 0x12, 0x36, 0x0a, 0x07, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x18, 0x1b, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e,
+	0x53, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x07, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x6c, 0x61,
+	0x73, 0x74, 0x5f, 0x73, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x53, 0x68, 0x75,
+	0x66, 0x66, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0b,
+	0x6c, 0x61, 0x73, 0x74, 0x53, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x75, 0x72, 0x6e, 0x73, 0x18, 0x1d, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x62, 0x75, 0x72, 0x6e,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x68, 0x6f, 0x77, 0x64, 0x6f, 0x77, 0x6e, 0x5f, 0x6f, 0x72,
+	0x64, 0x65, 0x72, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0d, 0x73, 0x68, 0x6f, 0x77, 0x64,
+	0x6f, 0x77, 0x6e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x75, 0x73,
+	0x65, 0x64, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x61, 0x75, 0x73, 0x65, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6c, 0x6c, 0x18, 0x20, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04,
+	0x6b, 0x69, 0x6c, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x69, 0x6c, 0x6c, 0x5f, 0x6e, 0x75, 0x6d,
+	0x18, 0x21, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6b, 0x69, 0x6c, 0x6c, 0x4e, 0x75, 0x6d, 0x12,
+	0x26, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x74, 0x5f, 0x77, 0x69, 0x6e, 0x6e,
+	0x65, 0x72, 0x18, 0x22, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x6f,
+	0x74, 0x57, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f,
+	0x70, 0x6f, 0x74, 0x5f, 0x77, 0x6f, 0x6e, 0x18, 0x23, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x50, 0x6f, 0x74, 0x57, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x09, 0x68, 0x69, 0x67,
+	0x68, 0x5f, 0x68, 0x61, 0x6e, 0x64, 0x18, 0x24, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72,
+	0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x48, 0x69, 0x67, 0x68, 0x48, 0x61, 0x6e,
+	0x64, 0x52, 0x08, 0x68, 0x69, 0x67, 0x68, 0x48, 0x61, 0x6e, 0x64, 0x22, 0x63, 0x0a, 0x08, 0x48,
+	0x69, 0x67, 0x68, 0x48, 0x61, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x6c, 0x61,
+	0x79, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x68, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63,
+	0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x61, 0x74,
+	0x22, 0x9f, 0x01, 0x0a, 0x11, 0x53, 0x68, 0x75, 0x66, 0x66, 0x6c, 0x65, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61,
+	0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x12, 0x2c,
+	0x0a, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x12, 0x2e, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2e, 0x56, 0x61, 0x72, 0x69,
+	0x61, 0x6e, 0x74, 0x52, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x64, 0x65, 0x61, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x05, 0x64, 0x65, 0x61,
+	0x6c, 0x74, 0x22, 0x67, 0x0a, 0x0c, 0x52, 0x65, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6e, 0x75, 0x6d,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4e,
+	0x75, 0x6d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x2a, 0x76, 0x0a, 0x09, 0x47,
+	0x61, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x47, 0x41, 0x4d, 0x45,
+	0x5f, 0x53, 0x54, 0x41, 0x47, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x45, 0x5f, 0x44, 0x45, 0x41, 0x4c,
+	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x52, 0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x50, 0x10, 0x02,
+	0x12, 0x08, 0x0a, 0x04, 0x46, 0x4c, 0x4f, 0x50, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x55,
+	0x52, 0x4e, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x49, 0x56, 0x45, 0x52, 0x10, 0x05, 0x12,
+	0x12, 0x0a, 0x0e, 0x53, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x48, 0x5f, 0x53, 0x54, 0x52, 0x45, 0x45,
+	0x54, 0x10, 0x06, 0x2a, 0x5f, 0x0a, 0x07, 0x56, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x12, 0x0b,
+	0x0a, 0x07, 0x48, 0x4f, 0x4c, 0x44, 0x5f, 0x45, 0x4d, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x53,
+	0x48, 0x4f, 0x52, 0x54, 0x5f, 0x44, 0x45, 0x43, 0x4b, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x50,
+	0x49, 0x4e, 0x45, 0x41, 0x50, 0x50, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x43, 0x52,
+	0x41, 0x5a, 0x59, 0x5f, 0x50, 0x49, 0x4e, 0x45, 0x41, 0x50, 0x50, 0x4c, 0x45, 0x10, 0x03, 0x12,
+	0x09, 0x0a, 0x05, 0x4f, 0x4d, 0x41, 0x48, 0x41, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x41,
+	0x5a, 0x5a, 0x10, 0x05, 0x2a, 0x63, 0x0a, 0x0b, 0x4f, 0x64, 0x64, 0x43, 0x68, 0x69, 0x70, 0x52,
+	0x75, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x4f, 0x44, 0x44, 0x5f, 0x43, 0x48, 0x49, 0x50, 0x5f,
+	0x4c, 0x45, 0x46, 0x54, 0x5f, 0x4f, 0x46, 0x5f, 0x42, 0x55, 0x54, 0x54, 0x4f, 0x4e, 0x10, 0x00,
+	0x12, 0x1e, 0x0a, 0x1a, 0x4f, 0x44, 0x44, 0x5f, 0x43, 0x48, 0x49, 0x50, 0x5f, 0x4c, 0x4f, 0x57,
+	0x45, 0x53, 0x54, 0x5f, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x5f, 0x4e, 0x55, 0x4d, 0x10, 0x01,
+	0x12, 0x17, 0x0a, 0x13, 0x4f, 0x44, 0x44, 0x5f, 0x43, 0x48, 0x49, 0x50, 0x5f, 0x43, 0x41, 0x52,
+	0x52, 0x59, 0x5f, 0x4f, 0x56, 0x45, 0x52, 0x10, 0x02, 0x2a, 0x44, 0x0a, 0x08, 0x41, 0x6e, 0x74,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x4e, 0x54, 0x45, 0x5f, 0x50, 0x45,
+	0x52, 0x5f, 0x50, 0x4c, 0x41, 0x59, 0x45, 0x52, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4e,
+	0x54, 0x45, 0x5f, 0x42, 0x49, 0x47, 0x5f, 0x42, 0x4c, 0x49, 0x4e, 0x44, 0x10, 0x01, 0x12, 0x0f,
+	0x0a, 0x0b, 0x41, 0x4e, 0x54, 0x45, 0x5f, 0x42, 0x55, 0x54, 0x54, 0x4f, 0x4e, 0x10, 0x02, 0x2a,
+	0x52, 0x0a, 0x10, 0x42, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x4f, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x10,
+	0x00, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x50, 0x52, 0x45, 0x41, 0x44, 0x5f, 0x4c, 0x49, 0x4d, 0x49,
+	0x54, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x49, 0x58, 0x45, 0x44, 0x5f, 0x4c, 0x49, 0x4d,
+	0x49, 0x54, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x4f, 0x54, 0x5f, 0x4c, 0x49, 0x4d, 0x49,
+	0x54, 0x10, 0x03, 0x2a, 0x35, 0x0a, 0x08, 0x4b, 0x69, 0x6c, 0x6c, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x5f, 0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
+	0x46, 0x55, 0x4c, 0x4c, 0x5f, 0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x48,
+	0x41, 0x4c, 0x46, 0x5f, 0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x02, 0x2a, 0x4d, 0x0a, 0x0b, 0x48, 0x65,
+	0x61, 0x64, 0x73, 0x55, 0x70, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x48, 0x45, 0x41,
+	0x44, 0x53, 0x5f, 0x55, 0x50, 0x5f, 0x42, 0x55, 0x54, 0x54, 0x4f, 0x4e, 0x5f, 0x53, 0x4d, 0x41,
+	0x4c, 0x4c, 0x5f, 0x42, 0x4c, 0x49, 0x4e, 0x44, 0x10, 0x00, 0x12, 0x1d, 0x0a, 0x19, 0x48, 0x45,
+	0x41, 0x44, 0x53, 0x5f, 0x55, 0x50, 0x5f, 0x42, 0x55, 0x54, 0x54, 0x4f, 0x4e, 0x5f, 0x42, 0x49,
+	0x47, 0x5f, 0x42, 0x4c, 0x49, 0x4e, 0x44, 0x10, 0x01, 0x2a, 0x39, 0x0a, 0x0d, 0x54, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x49,
+	0x4d, 0x45, 0x4f, 0x55, 0x54, 0x5f, 0x41, 0x57, 0x41, 0x59, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12,
+	0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x5f, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x5f, 0x46, 0x4f,
+	0x4c, 0x44, 0x10, 0x01, 0x2a, 0x42, 0x0a, 0x09, 0x52, 0x65, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x45, 0x54, 0x41, 0x49, 0x4e, 0x5f, 0x41, 0x4c, 0x4c, 0x10,
+	0x00, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x54, 0x41, 0x49, 0x4e, 0x5f, 0x53, 0x48, 0x4f, 0x57,
+	0x44, 0x4f, 0x57, 0x4e, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x52, 0x45, 0x54, 0x41, 0x49, 0x4e,
+	0x5f, 0x53, 0x48, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6c, 0x65, 0x78, 0x63, 0x6c, 0x65, 0x77, 0x6f,
+	0x6e, 0x74, 0x69, 0x6e, 0x2f, 0x72, 0x69, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x61, 0x74, 0x2f, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_riverboat_proto_rawDescOnce sync.Once
+	file_riverboat_proto_rawDescData = file_riverboat_proto_rawDesc
+)
+
+func file_riverboat_proto_rawDescGZIP() []byte {
+	file_riverboat_proto_rawDescOnce.Do(func() {
+		file_riverboat_proto_rawDescData = protoimpl.X.CompressGZIP(file_riverboat_proto_rawDescData)
+	})
+	return file_riverboat_proto_rawDescData
+}
+
+var file_riverboat_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_riverboat_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_riverboat_proto_goTypes = []interface{}{
+	(GameStage)(0),            // 0: riverboat.GameStage
+	(Variant)(0),              // 1: riverboat.Variant
+	(OddChipRule)(0),          // 2: riverboat.OddChipRule
+	(AnteMode)(0),             // 3: riverboat.AnteMode
+	(BettingStructure)(0),     // 4: riverboat.BettingStructure
+	(KillMode)(0),             // 5: riverboat.KillMode
+	(HeadsUpRule)(0),          // 6: riverboat.HeadsUpRule
+	(TimeoutAction)(0),        // 7: riverboat.TimeoutAction
+	(Retention)(0),            // 8: riverboat.Retention
+	(*ChipFormat)(nil),        // 9: riverboat.ChipFormat
+	(*RuleSet)(nil),           // 10: riverboat.RuleSet
+	(*GameConfig)(nil),        // 11: riverboat.GameConfig
+	(*Player)(nil),            // 12: riverboat.Player
+	(*Pot)(nil),               // 13: riverboat.Pot
+	(*ShowdownReveal)(nil),    // 14: riverboat.ShowdownReveal
+	(*WeightedCombo)(nil),     // 15: riverboat.WeightedCombo
+	(*Range)(nil),             // 16: riverboat.Range
+	(*GameView)(nil),          // 17: riverboat.GameView
+	(*HighHand)(nil),          // 18: riverboat.HighHand
+	(*ShuffleCommitment)(nil), // 19: riverboat.ShuffleCommitment
+	(*RematchOffer)(nil),      // 20: riverboat.RematchOffer
+}
+var file_riverboat_proto_depIdxs = []int32{
+	2,  // 0: riverboat.RuleSet.odd_chip:type_name -> riverboat.OddChipRule
+	6,  // 1: riverboat.RuleSet.heads_up:type_name -> riverboat.HeadsUpRule
+	9,  // 2: riverboat.GameConfig.chip_format:type_name -> riverboat.ChipFormat
+	10, // 3: riverboat.GameConfig.rules:type_name -> riverboat.RuleSet
+	1,  // 4: riverboat.GameConfig.variant:type_name -> riverboat.Variant
+	1,  // 5: riverboat.GameConfig.rotation:type_name -> riverboat.Variant
+	8,  // 6: riverboat.GameConfig.retention:type_name -> riverboat.Retention
+	7,  // 7: riverboat.GameConfig.timeout_action:type_name -> riverboat.TimeoutAction
+	3,  // 8: riverboat.GameConfig.ante_mode:type_name -> riverboat.AnteMode
+	4,  // 9: riverboat.GameConfig.betting:type_name -> riverboat.BettingStructure
+	5,  // 10: riverboat.GameConfig.kill:type_name -> riverboat.KillMode
+	0,  // 11: riverboat.Player.all_in_stage:type_name -> riverboat.GameStage
+	0,  // 12: riverboat.Pot.created_stage:type_name -> riverboat.GameStage
+	15, // 13: riverboat.Range.combos:type_name -> riverboat.WeightedCombo
+	0,  // 14: riverboat.GameView.stage:type_name -> riverboat.GameStage
+	11, // 15: riverboat.GameView.config:type_name -> riverboat.GameConfig
+	12, // 16: riverboat.GameView.players:type_name -> riverboat.Player
+	13, // 17: riverboat.GameView.pots:type_name -> riverboat.Pot
+	14, // 18: riverboat.GameView.showdown:type_name -> riverboat.ShowdownReveal
+	16, // 19: riverboat.GameView.ranges:type_name -> riverboat.Range
+	1,  // 20: riverboat.GameView.variant:type_name -> riverboat.Variant
+	20, // 21: riverboat.GameView.rematch:type_name -> riverboat.RematchOffer
+	19, // 22: riverboat.GameView.shuffle:type_name -> riverboat.ShuffleCommitment
+	19, // 23: riverboat.GameView.last_shuffle:type_name -> riverboat.ShuffleCommitment
+	18, // 24: riverboat.GameView.high_hand:type_name -> riverboat.HighHand
+	1,  // 25: riverboat.ShuffleCommitment.variant:type_name -> riverboat.Variant
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
+}
+
+func init() { file_riverboat_proto_init() }
+func file_riverboat_proto_init() {
+	if File_riverboat_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_riverboat_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChipFormat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RuleSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Player); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShowdownReveal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WeightedCombo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Range); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameView); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HighHand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShuffleCommitment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_riverboat_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RematchOffer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_riverboat_proto_rawDesc,
+			NumEnums:      9,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_riverboat_proto_goTypes,
+		DependencyIndexes: file_riverboat_proto_depIdxs,
+		EnumInfos:         file_riverboat_proto_enumTypes,
+		MessageInfos:      file_riverboat_proto_msgTypes,
+	}.Build()
+	File_riverboat_proto = out.File
+	file_riverboat_proto_rawDesc = nil
+	file_riverboat_proto_goTypes = nil
+	file_riverboat_proto_depIdxs = nil
+}
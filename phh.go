@@ -0,0 +1,688 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// PHH is the Poker Hand History format (https://phh.readthedocs.io): a TOML file per hand, with the stakes, the
+// starting stacks, and every card dealt and action taken, in a notation like "d dh p1 AsKd" or "p2 cbr 300". The
+// players are p1 to pN, in the order they are seated starting to the left of the button, so the button is last. As
+// in the rest of the PHH tooling, blinds_or_straddles lists the small blind first even heads up, where p1 (the
+// player to the button's left) posts the big blind. PHH has no way to record the button posting the big blind heads
+// up (see HeadsUpButtonBigBlind).
+
+// phhVariants maps the variants riverboat and PHH share to their PHH codes
+var phhVariants = map[Variant]string{
+	HoldEm:    "NT",
+	ShortDeck: "NS",
+}
+
+// MarshalPHH replays r (see Replay), and writes the hand it deals as a PHH hand history. Only the first hand dealt is
+// written, whether or not it was played to the end. MarshalPHH returns ErrBadPHH if the hand can't be written to PHH:
+// if it wasn't no-limit hold'em or short deck (including spread limit), if it was played hi-lo or with a HandCap, if
+// it was played heads up with the button posting the big blind, if the button straddled, or if it was called off as a
+// misdeal. If one of r's Actions fails, MarshalPHH returns its error.
+func (r *HandRecord) MarshalPHH() ([]byte, error) {
+	w := &phhWriter{}
+	var unsupported bool
+
+	var g *Game
+	_, err := replay(r, func(rg *Game) {
+		if g == nil {
+			g = rg
+			g.Subscribe(func(e Event) {
+				if w.write(g, e) != nil {
+					unsupported = true
+				}
+			})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	if unsupported || w.players == nil {
+		return nil, ErrBadPHH
+	}
+
+	return w.bytes(), nil
+}
+
+// phhWriter builds a PHH hand history from the Events of a hand
+type phhWriter struct {
+	code    string
+	minBet  uint
+	players []uint       // the players dealt in, in PHH order
+	index   map[uint]int // each player's place in players
+	antes   []uint
+	blinds  []uint
+	stacks  []uint
+	actions []string
+	// undo holds the length of actions before each Bet or Fold, for taking them back
+	undo []int
+	done bool
+}
+
+func (w *phhWriter) write(g *Game, e Event) error {
+	if w.done {
+		return nil
+	}
+
+	if w.players == nil {
+		if e.Kind != EventHandStart {
+			return nil
+		}
+		return w.start(g)
+	}
+
+	switch e.Kind {
+	case EventBlind:
+		w.blinds[w.index[e.PlayerNum]] += e.Amount
+	case EventDeadChips:
+		w.antes[w.index[e.PlayerNum]] += e.Amount
+	case EventBet:
+		w.undo = append(w.undo, len(w.actions))
+
+		// The bet has been put in, but the betting round isn't over yet, so the bets on the table are this street's
+		var high uint
+		for i, p := range g.players {
+			if uint(i) != e.PlayerNum && p.Bet > high {
+				high = p.Bet
+			}
+		}
+		if bet := g.players[e.PlayerNum].Bet; bet > high {
+			w.act(w.name(e.PlayerNum), "cbr", strconv.FormatUint(uint64(bet), 10))
+		} else {
+			w.act(w.name(e.PlayerNum), "cc")
+		}
+	case EventFold:
+		w.undo = append(w.undo, len(w.actions))
+		w.act(w.name(e.PlayerNum), "f")
+	case EventUndo:
+		if n := len(w.undo); n > 0 {
+			w.actions = w.actions[:w.undo[n-1]]
+			w.undo = w.undo[:n-1]
+		}
+	case EventCommunityCards:
+		w.act("d", "db", phhCards(e.Cards))
+	case EventShow:
+		w.act(w.name(e.PlayerNum), "sm", phhCards(e.Cards))
+	case EventMuck:
+		w.act(w.name(e.PlayerNum), "sm", "-")
+	case EventHandEnd:
+		w.done = true
+	case EventMisdeal:
+		return ErrBadPHH
+	}
+
+	return nil
+}
+
+// start sets w up for the hand g has just started dealing
+func (w *phhWriter) start(g *Game) error {
+	code, ok := phhVariants[g.variant()]
+	// PHH has no betting cap, so a capped player would look like they could have bet the rest of their stack
+	if !ok || g.config.Rules.HiLo || g.config.HandCap != 0 || g.config.Betting != NoLimit {
+		return ErrBadPHH
+	}
+	w.code = code
+	w.minBet = g.config.BigBlind
+
+	w.index = map[uint]int{}
+	for _, pn := range g.seatOrder(g.next(g.dealerNum)) {
+		if g.players[pn].In {
+			w.index[pn] = len(w.players)
+			w.players = append(w.players, pn)
+			w.stacks = append(w.stacks, g.startStacks[pn])
+			// Dead chips posted before the hand are taken as antes
+			w.antes = append(w.antes, g.players[pn].DeadChips)
+		}
+	}
+	w.blinds = make([]uint, len(w.players))
+
+	// PHH straddles are posted left of the big blind, not on the button
+	if (len(w.players) == 2 && g.config.Rules.HeadsUp != HeadsUpButtonSmallBlind) || g.players[g.dealerNum].Straddle {
+		return ErrBadPHH
+	}
+
+	for _, pn := range w.players {
+		p := g.players[pn]
+		w.act("d", "dh", w.name(pn), phhCards([]eval.Card{p.Cards[0], p.Cards[1]}))
+	}
+
+	return nil
+}
+
+func (w *phhWriter) name(pn uint) string {
+	return fmt.Sprintf("p%d", w.index[pn]+1)
+}
+
+func (w *phhWriter) act(fields ...string) {
+	w.actions = append(w.actions, strings.Join(fields, " "))
+}
+
+func (w *phhWriter) bytes() []byte {
+	blinds := w.blinds
+	if len(blinds) == 2 {
+		blinds = []uint{blinds[1], blinds[0]}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "variant = %q\n", w.code)
+	fmt.Fprintf(&b, "antes = %s\n", phhList(w.antes))
+	fmt.Fprintf(&b, "blinds_or_straddles = %s\n", phhList(blinds))
+	fmt.Fprintf(&b, "min_bet = %d\n", w.minBet)
+	fmt.Fprintf(&b, "starting_stacks = %s\n", phhList(w.stacks))
+	b.WriteString("actions = [\n")
+	for _, a := range w.actions {
+		fmt.Fprintf(&b, "  %q,\n", a)
+	}
+	b.WriteString("]\n")
+
+	return b.Bytes()
+}
+
+func phhList(vals []uint) string {
+	s := make([]string, len(vals))
+	for i, v := range vals {
+		s[i] = strconv.FormatUint(uint64(v), 10)
+	}
+
+	return "[" + strings.Join(s, ", ") + "]"
+}
+
+// phhCards writes cards the way PHH does, with lowercase suits, like "AsKd"
+func phhCards(cards []eval.Card) string {
+	var s string
+	for _, c := range cards {
+		cs := c.String()
+		s += cs[:1] + strings.ToLower(cs[1:])
+	}
+
+	return s
+}
+
+// ParsePHH reads a PHH hand history, and returns a HandRecord of it, which can be replayed like one recorded by
+// RecordHand. The table it starts from seats p1 to pN in seats 1 to N, as player numbers 0 to N-1, with pN on the
+// button. Hole cards that weren't shown, written "??", are filled in with cards that weren't dealt, unless the
+// player shows them later in the hand. ParsePHH can read no-limit hold'em ("NT") and short deck ("NS") hands without
+// antes or straddles. It returns ErrBadPHH if data isn't a PHH hand history it can read, or if the hand it records
+// can't be played out (like a bet out of turn).
+func ParsePHH(data []byte) (*HandRecord, error) {
+	fields, err := parsePHHFields(data)
+	if err != nil {
+		return nil, err
+	}
+
+	config := defaultConfig
+	code, _ := fields["variant"].(string)
+	var ok bool
+	if config.Variant, ok = phhVariant(code); !ok {
+		return nil, ErrBadPHH
+	}
+
+	stacks, ok := phhUints(fields["starting_stacks"])
+	if !ok || len(stacks) < 2 {
+		return nil, ErrBadPHH
+	}
+	n := len(stacks)
+
+	blinds, ok := phhUints(fields["blinds_or_straddles"])
+	if !ok || len(blinds) != n {
+		return nil, ErrBadPHH
+	}
+	for _, b := range blinds[2:] {
+		if b != 0 {
+			return nil, ErrBadPHH
+		}
+	}
+	config.SmallBlind, config.BigBlind = blinds[0], blinds[1]
+	if config.BigBlind == 0 || config.SmallBlind > config.BigBlind {
+		return nil, ErrBadPHH
+	}
+
+	if v, ok := fields["antes"]; ok {
+		antes, ok := phhUints(v)
+		if !ok {
+			return nil, ErrBadPHH
+		}
+		for _, a := range antes {
+			if a != 0 {
+				return nil, ErrBadPHH
+			}
+		}
+	}
+
+	list, _ := fields["actions"].([]interface{})
+	actions := make([][]string, len(list))
+	for i, v := range list {
+		a, ok := v.(string)
+		if !ok {
+			return nil, ErrBadPHH
+		}
+		// Actions can end with a comment
+		if c := strings.Index(a, "#"); c >= 0 {
+			a = a[:c]
+		}
+		actions[i] = strings.Fields(a)
+	}
+
+	g := NewGame(&config)
+	for _, stack := range stacks {
+		pn := g.AddPlayer()
+		if BuyIn(g, pn, stack) != nil || ToggleReady(g, pn, 0) != nil {
+			return nil, ErrBadPHH
+		}
+	}
+	g.dealerNum = uint(n - 1)
+
+	r := RecordHand(g)
+	if r.Deck, err = phhDeck(g, actions); err != nil {
+		return nil, err
+	}
+	g.SetRandSource(dealtDeck{full: g.variant().deck(), dealt: r.Deck})
+
+	if r.Perform(g, "deal", g.dealingNum(), 0) != nil {
+		return nil, ErrBadPHH
+	}
+
+	for _, a := range actions {
+		if len(a) < 2 || a[0] == "d" {
+			// The community cards are dealt as soon as the betting before them is over
+			continue
+		}
+
+		pn, ok := phhPlayer(a[0], n)
+		if !ok {
+			return nil, ErrBadPHH
+		}
+
+		switch {
+		case a[1] == "f":
+			err = r.Perform(g, "fold", pn, 0)
+		case a[1] == "cc":
+			err = r.Perform(g, "bet", pn, g.ToCall(pn))
+		case a[1] == "cbr" && len(a) == 3:
+			var to uint64
+			if to, err = strconv.ParseUint(a[2], 10, 0); err == nil && uint(to) > g.players[pn].Bet {
+				err = r.Perform(g, "bet", pn, uint(to)-g.players[pn].Bet)
+			} else {
+				err = ErrBadPHH
+			}
+		case a[1] == "sm":
+			// The engine decides who shows at showdown
+		default:
+			err = ErrBadPHH
+		}
+
+		if err != nil {
+			return nil, ErrBadPHH
+		}
+	}
+
+	return r, nil
+}
+
+func phhVariant(code string) (Variant, bool) {
+	for v, c := range phhVariants {
+		if c == code {
+			return v, true
+		}
+	}
+
+	return 0, false
+}
+
+// phhPlayer returns the player number of the player a PHH action names, like "p1"
+func phhPlayer(name string, n int) (uint, bool) {
+	if !strings.HasPrefix(name, "p") {
+		return 0, false
+	}
+
+	k, err := strconv.Atoi(name[1:])
+	if err != nil || k < 1 || k > n {
+		return 0, false
+	}
+
+	return uint(k - 1), true
+}
+
+// phhDeck returns the cards g deals the hand in actions in, in the order it deals them: each player's hole cards, in
+// order of player number, then the board
+func phhDeck(g *Game, actions [][]string) (eval.Deck, error) {
+	hole := make([][]eval.Card, len(g.players))
+	var board []eval.Card
+
+	for _, a := range actions {
+		switch {
+		case len(a) == 4 && a[0] == "d" && a[1] == "dh":
+			pn, ok := phhPlayer(a[2], len(g.players))
+			cards, err := parsePHHCards(a[3])
+			if !ok || err != nil || len(cards) != 2 || hole[pn] != nil {
+				return nil, ErrBadPHH
+			}
+			hole[pn] = cards
+		case len(a) == 3 && a[0] == "d" && a[1] == "db":
+			cards, err := parsePHHCards(a[2])
+			if err != nil {
+				return nil, ErrBadPHH
+			}
+			board = append(board, cards...)
+		case len(a) == 3 && a[1] == "sm" && a[2] != "-":
+			pn, ok := phhPlayer(a[0], len(g.players))
+			cards, err := parsePHHCards(a[2])
+			if !ok || err != nil || len(cards) != 2 {
+				return nil, ErrBadPHH
+			}
+			// Shown cards stand in for the ones that were dealt face down
+			for i := range hole[pn] {
+				if hole[pn][i] == 0 {
+					hole[pn][i] = cards[i]
+				}
+			}
+			if hole[pn] == nil {
+				hole[pn] = cards
+			}
+		}
+	}
+
+	var deck eval.Deck
+	for _, cards := range hole {
+		if cards == nil {
+			cards = make([]eval.Card, 2)
+		}
+		deck = append(deck, cards...)
+	}
+	deck = append(deck, board...)
+
+	// Fill in the cards nobody saw with ones that weren't dealt
+	dealt := map[eval.Card]bool{}
+	for _, c := range deck {
+		if c != 0 {
+			if dealt[c] {
+				return nil, ErrBadPHH
+			}
+			dealt[c] = true
+		}
+	}
+	unused := eval.Deck{}
+	for _, c := range g.variant().deck() {
+		if !dealt[c] {
+			unused = append(unused, c)
+		}
+	}
+	for i := range deck {
+		if deck[i] == 0 {
+			if len(unused) == 0 {
+				return nil, ErrBadPHH
+			}
+			deck[i] = unused.Pop()
+		}
+	}
+
+	return deck, nil
+}
+
+// parsePHHCards reads cards written the way PHH writes them, like "AsKd", with "??" for a card that wasn't seen,
+// which is returned as the zero Card
+func parsePHHCards(s string) ([]eval.Card, error) {
+	if len(s)%2 != 0 {
+		return nil, ErrBadPHH
+	}
+
+	var cards []eval.Card
+	for i := 0; i < len(s); i += 2 {
+		if s[i:i+2] == "??" {
+			cards = append(cards, 0)
+			continue
+		}
+
+		c, err := eval.ParseCardBytes([]byte(s[i : i+2]))
+		if err != nil {
+			return nil, ErrBadPHH
+		}
+		cards = append(cards, c)
+	}
+
+	return cards, nil
+}
+
+// phhUints reads a PHH array of amounts
+func phhUints(v interface{}) ([]uint, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	vals := make([]uint, len(list))
+	for i, e := range list {
+		switch n := e.(type) {
+		case int64:
+			if n < 0 {
+				return nil, false
+			}
+			vals[i] = uint(n)
+		case float64:
+			if n < 0 || n != float64(uint(n)) {
+				return nil, false
+			}
+			vals[i] = uint(n)
+		default:
+			return nil, false
+		}
+	}
+
+	return vals, true
+}
+
+// parsePHHFields reads the top level keys of a PHH file. PHH files are TOML, and parsePHHFields reads as much TOML as
+// they use: keys set to strings, numbers, booleans, and arrays of them. Tables, which only hold fields that aren't
+// needed to play the hand back, are skipped.
+func parsePHHFields(data []byte) (map[string]interface{}, error) {
+	s := &phhScanner{data: data}
+	fields := map[string]interface{}{}
+	inTable := false
+
+	for {
+		s.skipSpace(true)
+		if s.pos >= len(s.data) {
+			return fields, nil
+		}
+
+		if s.peek() == '[' {
+			// A table header, like [_]
+			inTable = true
+			s.skipLine()
+			continue
+		}
+
+		key := s.key()
+		s.skipSpace(false)
+		if key == "" || s.peek() != '=' {
+			return nil, ErrBadPHH
+		}
+		s.pos++
+		s.skipSpace(false)
+
+		v, err := s.value()
+		if err != nil {
+			return nil, err
+		}
+		if !inTable {
+			fields[key] = v
+		}
+
+		s.skipSpace(false)
+		if s.pos < len(s.data) && s.peek() != '\n' && s.peek() != '\r' {
+			return nil, ErrBadPHH
+		}
+	}
+}
+
+type phhScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *phhScanner) peek() byte {
+	if s.pos >= len(s.data) {
+		return 0
+	}
+
+	return s.data[s.pos]
+}
+
+// skipSpace skips whitespace and comments, and newlines too if newlines is true
+func (s *phhScanner) skipSpace(newlines bool) {
+	for s.pos < len(s.data) {
+		switch c := s.data[s.pos]; {
+		case c == ' ' || c == '\t':
+			s.pos++
+		case newlines && (c == '\n' || c == '\r'):
+			s.pos++
+		case c == '#':
+			for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+				s.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (s *phhScanner) skipLine() {
+	for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+		s.pos++
+	}
+}
+
+func (s *phhScanner) key() string {
+	start := s.pos
+	for s.pos < len(s.data) && isPHHBare(s.data[s.pos]) {
+		s.pos++
+	}
+
+	return string(s.data[start:s.pos])
+}
+
+func isPHHBare(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+func (s *phhScanner) value() (interface{}, error) {
+	switch c := s.peek(); {
+	case c == '"' || c == '\'':
+		return s.str()
+	case c == '[':
+		return s.array()
+	default:
+		start := s.pos
+		for s.pos < len(s.data) && (isPHHBare(s.data[s.pos]) || s.data[s.pos] == '.' || s.data[s.pos] == '+') {
+			s.pos++
+		}
+		word := strings.ReplaceAll(string(s.data[start:s.pos]), "_", "")
+
+		switch word {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		if i, err := strconv.ParseInt(word, 10, 64); err == nil {
+			return i, nil
+		}
+		if f, err := strconv.ParseFloat(word, 64); err == nil {
+			return f, nil
+		}
+
+		return nil, ErrBadPHH
+	}
+}
+
+func (s *phhScanner) str() (string, error) {
+	quote := s.data[s.pos]
+	s.pos++
+
+	var b strings.Builder
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		s.pos++
+
+		switch {
+		case c == quote:
+			return b.String(), nil
+		case c == '\n':
+			return "", ErrBadPHH
+		case c == '\\' && quote == '"' && s.pos < len(s.data):
+			// Basic strings have escapes, literal strings don't
+			switch e := s.data[s.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			default:
+				b.WriteByte(e)
+			}
+			s.pos++
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", ErrBadPHH
+}
+
+func (s *phhScanner) array() ([]interface{}, error) {
+	s.pos++
+	list := []interface{}{}
+
+	for {
+		s.skipSpace(true)
+		if s.peek() == ']' {
+			s.pos++
+			return list, nil
+		}
+
+		v, err := s.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+
+		s.skipSpace(true)
+		switch s.peek() {
+		case ',':
+			s.pos++
+		case ']':
+		default:
+			return nil, ErrBadPHH
+		}
+	}
+}
@@ -0,0 +1,220 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestPHH_RoundTrip(t *testing.T) {
+	for _, players := range []int{2, 3} {
+		g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7})
+		for i := 0; i < players; i++ {
+			pn := g.AddPlayer()
+			BuyIn(g, pn, 1000)
+			ToggleReady(g, pn, 0)
+		}
+
+		r := RecordHand(g)
+		if err := r.Perform(g, "deal", g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+		// The parsed hand seats the players starting to the left of the button
+		order := g.seatOrder(g.next(g.dealerNum))
+
+		// A raise, then calls down to showdown
+		if err := r.Perform(g, "bet", g.actionNum, 75-g.players[g.actionNum].Bet); err != nil {
+			t.Fatalf("Test failed - error raising: %s", err)
+		}
+		for g.getStage() != PreDeal {
+			if err := r.Perform(g, "bet", g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+				t.Fatalf("Test failed - error calling: %s", err)
+			}
+		}
+
+		phh, err := r.MarshalPHH()
+		if err != nil {
+			t.Fatalf("Test failed - MarshalPHH returned %s", err)
+		}
+		for _, want := range []string{`variant = "NT"`, "blinds_or_straddles = [10, 25", "min_bet = 25", `"p1 cc"`, `"d db `} {
+			if !strings.Contains(string(phh), want) {
+				t.Errorf("Test failed - %d handed PHH has no %s:\n%s", players, want, phh)
+			}
+		}
+
+		parsed, err := ParsePHH(phh)
+		if err != nil {
+			t.Fatalf("Test failed - ParsePHH returned %s reading:\n%s", err, phh)
+		}
+		replayed, err := Replay(parsed)
+		if err != nil {
+			t.Fatalf("Test failed - Replay of the parsed hand returned %s", err)
+		}
+
+		for i, pn := range order {
+			if got, want := replayed.players[i].Stack, g.players[pn].Stack; got != want {
+				t.Errorf("Test failed - %d handed p%d ended with %d, want %d", players, i+1, got, want)
+			}
+			if got, want := replayed.players[i].Cards, g.players[pn].Cards; got != want {
+				t.Errorf("Test failed - %d handed p%d was dealt %v, want %v", players, i+1, got, want)
+			}
+		}
+		if !reflect.DeepEqual(replayed.communityCards, g.communityCards) {
+			t.Errorf("Test failed - %d handed board is %v, want %v", players, replayed.communityCards, g.communityCards)
+		}
+
+		again, err := parsed.MarshalPHH()
+		if err != nil || string(again) != string(phh) {
+			t.Errorf("Test failed - %d handed PHH changed writing it back:\n%s\nwant\n%s", players, again, phh)
+		}
+	}
+}
+
+// samplePHH is a three handed hand in PHH, in which p1's hole cards weren't seen
+const samplePHH = `
+variant = 'NT'
+antes = [0, 0, 0]
+blinds_or_straddles = [1, 2, 0]
+min_bet = 2
+starting_stacks = [100, 100, 100.0]
+actions = [
+  'd dh p1 ????',
+  'd dh p2 7h2c',
+  'd dh p3 AsAd',
+  'p3 cbr 6', # a raise to 6
+  'p1 f',
+  'p2 cc',
+  'd db Ac8h3d',
+  'p2 cc',
+  'p3 cbr 10',
+  'p2 f',
+]
+
+[_]
+venue = 'nowhere'
+`
+
+func TestParsePHH(t *testing.T) {
+
+	r, err := ParsePHH([]byte(samplePHH))
+	if err != nil {
+		t.Fatalf("Test failed - ParsePHH returned %s", err)
+	}
+
+	g, err := Replay(r)
+	if err != nil {
+		t.Fatalf("Test failed - Replay returned %s", err)
+	}
+
+	if r.Start.DealerNum != 2 {
+		t.Errorf("Test failed - the button is on player %d, want p3 (player 2)", r.Start.DealerNum)
+	}
+	if got := g.players[2].Cards; got != [2]eval.Card{eval.MustParseCardString("as"), eval.MustParseCardString("ad")} {
+		t.Errorf("Test failed - p3 was dealt %v", got)
+	}
+	if c := g.communityCards[0]; c != eval.MustParseCardString("ac") {
+		t.Errorf("Test failed - the flop starts with %v, want Ac", c)
+	}
+	for pn, want := range []uint{99, 94, 107} {
+		if got := g.players[pn].Stack; got != want {
+			t.Errorf("Test failed - p%d ended with %d, want %d", pn+1, got, want)
+		}
+	}
+
+	for _, bad := range []string{
+		"variant = 'FT'\nstarting_stacks = [100, 100]\nblinds_or_straddles = [1, 2]\nactions = []",
+		"variant = 'NT'\nstarting_stacks = [100, 100, 100]\nblinds_or_straddles = [1, 2, 4]\nactions = []",
+		"variant = 'NT'\nstarting_stacks = [100, 100]\nantes = [1, 1]\nblinds_or_straddles = [1, 2]\nactions = []",
+		"variant = 'NT'\nstarting_stacks = [100, 100]\nblinds_or_straddles = [1, 2]\nactions = ['p1 f', 'p1 f']",
+		"variant = 'NT'\nstarting_stacks = [100, 100]\nblinds_or_straddles = [1, 2]\nactions = ['d dh p1 AsAs']",
+		"variant = 'NT'\nstarting_stacks = [100, 100\n",
+	} {
+		if _, err := ParsePHH([]byte(bad)); err != ErrBadPHH {
+			t.Errorf("Test failed - ParsePHH should reject %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestMarshalPHH_Unsupported(t *testing.T) {
+	configs := map[string]GameConfig{
+		"a Pineapple hand": {BigBlind: 25, SmallBlind: 10, Seed: 7, Variant: Pineapple},
+		"a heads-up hand with the button on the big blind": {BigBlind: 25, SmallBlind: 10, Seed: 7,
+			Rules: RuleSet{HeadsUp: HeadsUpButtonBigBlind}},
+		"a capped hand": {BigBlind: 25, SmallBlind: 10, Seed: 7, HandCap: 500},
+		"a spread-limit hand": {BigBlind: 25, SmallBlind: 10, Seed: 7, Betting: SpreadLimit, SpreadMin: 25,
+			SpreadMax: 100},
+	}
+
+	for name, config := range configs {
+		config := config
+		g := NewGame(&config)
+		for i := 0; i < 2; i++ {
+			pn := g.AddPlayer()
+			BuyIn(g, pn, 1000)
+			ToggleReady(g, pn, 0)
+		}
+
+		r := RecordHand(g)
+		if err := r.Perform(g, "deal", g.dealingNum(), 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+
+		if _, err := r.MarshalPHH(); err != ErrBadPHH {
+			t.Errorf("Test failed - MarshalPHH should reject %s, got %v", name, err)
+		}
+	}
+}
+
+func TestHandRecord_GameAt(t *testing.T) {
+	r, err := ParsePHH([]byte(samplePHH))
+	if err != nil {
+		t.Fatalf("Test failed - ParsePHH returned %s", err)
+	}
+
+	if g, err := r.GameAt(0); err != nil || g.getStage() != PreDeal {
+		t.Errorf("Test failed - GameAt(0) should be the table before the deal, got error %v", err)
+	}
+	if _, err := r.GameAt(len(r.Actions) + 1); err != ErrBadActionIndex {
+		t.Errorf("Test failed - GameAt past the last action should return ErrBadActionIndex, got %v", err)
+	}
+
+	// Just before p2 folds to the bet on the flop, p2 calls instead
+	g, err := r.GameAt(len(r.Actions) - 1)
+	if err != nil {
+		t.Fatalf("Test failed - GameAt returned %s", err)
+	}
+	if g.getStage() != Flop || g.actionNum != 1 || g.ToCall(1) != 10 {
+		t.Fatalf("Test failed - expected p2 facing a bet of 10 on the flop, got player %d to call %d", g.actionNum, g.ToCall(1))
+	}
+	if err := Bet(g, 1, 10); err != nil {
+		t.Fatalf("Test failed - p2 couldn't call: %s", err)
+	}
+	if g.getStage() != Turn || g.players[1].Stack != 84 {
+		t.Errorf("Test failed - expected the call to take the hand to the turn, leaving p2 with 84, got %d", g.players[1].Stack)
+	}
+}
@@ -24,22 +24,90 @@
 package riverboat
 
 import (
+	"time"
+
 	"github.com/alexclewontin/riverboat/eval"
 )
 
 type Player struct {
-	Ready           bool
-	In              bool
-	Called          bool
-	Left            bool
-	TotalBuyIn      uint
-	Stack           uint
-	Bet             uint
-	TotalBet        uint
-	Cards           [2]eval.Card
-	PreviouslyIn    bool
-	PreviouslyAllIn bool
-	PreviousBet     uint
+	// SeatNum is the seat the player is sitting in, numbered from 1, or 0 if they are waiting for one (see ChangeSeat)
+	SeatNum         uint         `json:"seatNum"`
+	Ready           bool         `json:"ready"`
+	In              bool         `json:"in"`
+	Called          bool         `json:"called"`
+	Left            bool         `json:"left"`
+	TotalBuyIn      uint         `json:"totalBuyIn"`
+	Stack           uint         `json:"stack"`
+	Bet             uint         `json:"bet"`
+	TotalBet        uint         `json:"totalBet"`
+	Cards           [2]eval.Card `json:"cards"`
+	PreviouslyIn    bool         `json:"previouslyIn"`
+	PreviouslyAllIn bool         `json:"previouslyAllIn"`
+	PreviousBet     uint         `json:"previousBet"`
+	AllInStage      GameStage    `json:"allInStage"`
+	DeadChips       uint         `json:"deadChips"`
+	Away            bool         `json:"away"`
+	// TotalCashOut is the total taken off the player's stack when stacks were reset for a rematch (see Rematch), or
+	// credited back to them when they were removed (see RemovePlayer)
+	TotalCashOut uint `json:"totalCashOut"`
+	// CashedOut is the stack the player was credited back when they were last removed, which they must buy back in
+	// for at least at tables that play RuleSet.TableStakes. It is cleared once they have.
+	CashedOut uint `json:"cashedOut"`
+	// MissedBlinds is what the player owes for the blinds they have missed, and PostMissed is true if they have
+	// chosen to post them, rather than wait for the big blind (see PostMissedBlinds)
+	MissedBlinds MissedBlinds `json:"missedBlinds"`
+	PostMissed   bool         `json:"postMissed"`
+	// SittingOut is true if the player is sitting out (see SitOut)
+	SittingOut bool `json:"sittingOut"`
+	// TimeBank is the extra time the player has left to act, once their ActionTime is up (see ActionDeadline)
+	TimeBank time.Duration `json:"timeBank"`
+	// Timeouts is how many times in a row the player has run out of time to act (see Timeout)
+	Timeouts uint `json:"timeouts"`
+	// In variants dealt three hole cards (see Discard), ThirdCard is the third card until the player discards, and
+	// Discarded is the card they discarded afterwards. Both are 0 otherwise.
+	ThirdCard eval.Card `json:"thirdCard"`
+	Discarded eval.Card `json:"discarded"`
+	// In Omaha, ExtraCards are the player's third and fourth hole cards, and in Razz, the first is the card they are
+	// dealt face down on seventh street. They are 0 in every other variant.
+	ExtraCards [2]eval.Card `json:"extraCards"`
+	// UpCards are the cards the player has been dealt face up in a stud variant like Razz, which everybody can see.
+	// They are 0 in every other variant.
+	UpCards [4]eval.Card `json:"upCards"`
+	// Shown says which of the player's Cards they have turned face up since the last hand ended (see ShowCards)
+	Shown [2]bool `json:"shown"`
+	// Bounty is the bounty on the player's head, at a Tournament table that plays bounties (see
+	// TournamentConfig.Bounty), so that clients can show it. It is 0 otherwise.
+	Bounty uint `json:"bounty"`
+	// Straddle is true if the player has announced a straddle for the next hand, or has posted one in this hand and
+	// hasn't acted on it yet (see Straddle)
+	Straddle bool `json:"straddle"`
+	// Kill is true if the player has posted a kill blind in this hand and hasn't acted on it yet (see
+	// GameConfig.Kill)
+	Kill bool `json:"kill"`
+	// HandsPlayed, Rebuys, BiggestPot and ShowdownsWon are the player's session so far (see SessionSummary)
+	HandsPlayed  uint `json:"handsPlayed"`
+	Rebuys       uint `json:"rebuys"`
+	BiggestPot   uint `json:"biggestPot"`
+	ShowdownsWon uint `json:"showdownsWon"`
+}
+
+// holeCards returns the hole cards the player makes their hand from: their Cards, and in Omaha and Razz, their
+// ExtraCards
+func (p *Player) holeCards() []eval.Card {
+	return holeCards(p.Cards, p.ExtraCards)
+}
+
+// holeCards returns cards, followed by extra if there are any
+func holeCards(cards [2]eval.Card, extra [2]eval.Card) []eval.Card {
+	if extra[0] == 0 {
+		return []eval.Card{cards[0], cards[1]}
+	}
+
+	if extra[1] == 0 {
+		return []eval.Card{cards[0], cards[1], extra[0]}
+	}
+
+	return []eval.Card{cards[0], cards[1], extra[0], extra[1]}
 }
 
 func (p *Player) in(stage GameStage) bool {
@@ -50,12 +118,32 @@ func (p *Player) in(stage GameStage) bool {
 	return p.In
 }
 
-func (p *Player) allIn(stage GameStage) bool {
+// allIn reports whether the player has committed everything they can this hand: either their whole stack,
+// or (if cap is not 0) cap chips in total
+func (p *Player) allIn(stage GameStage, cap uint) bool {
 	if stage == PreDeal {
 		return p.PreviouslyAllIn
 	}
 
-	return p.in(stage) && (p.Stack == 0)
+	return p.in(stage) && (p.Stack == 0 || (cap != 0 && p.TotalBet >= cap))
+}
+
+// maxCommit returns the most the player can put in right now: the smaller of their stack and, if cap is not 0,
+// what's left before their total commitment this hand reaches cap.
+func (p *Player) maxCommit(cap uint) uint {
+	if cap == 0 {
+		return p.Stack
+	}
+
+	if p.TotalBet >= cap {
+		return 0
+	}
+
+	if cap-p.TotalBet < p.Stack {
+		return cap - p.TotalBet
+	}
+
+	return p.Stack
 }
 
 func (p *Player) bet(stage GameStage) uint {
@@ -75,8 +163,13 @@ func (p *Player) initialize() {
 	p.Called = false
 }
 
-//putInChips is simply a helper function that transfers the amounts between fields
-func (p *Player) putInChips(amt uint) {
+//putInChips is simply a helper function that transfers the amounts between fields. If cap is not 0,
+// it never lets the player's total commitment for the hand go over cap.
+func (p *Player) putInChips(amt uint, cap uint) {
+	if cap != 0 && amt > p.maxCommit(cap) {
+		amt = p.maxCommit(cap)
+	}
+
 	if p.Stack > amt {
 		p.Bet += amt
 		p.TotalBet += amt
@@ -0,0 +1,633 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+
+	"github.com/alexclewontin/riverboat/eval"
+	"github.com/alexclewontin/riverboat/pb"
+)
+
+// protoCards maps the compact card encoding used by the protobuf messages (1 + 13*suit + rank) back to Cards
+var protoCards [53]eval.Card
+
+func init() {
+	for _, c := range eval.DefaultDeck {
+		protoCards[cardToProto(c)] = c
+	}
+}
+
+func cardToProto(c eval.Card) uint32 {
+	if c == 0 {
+		return 0
+	}
+
+	rank := (uint32(c) >> 8) & 0x0F
+
+	var suit uint32
+	switch {
+	case c&0x8000 != 0:
+		suit = 0
+	case c&0x4000 != 0:
+		suit = 1
+	case c&0x2000 != 0:
+		suit = 2
+	default:
+		suit = 3
+	}
+
+	return 1 + 13*suit + rank
+}
+
+func cardFromProto(n uint32) eval.Card {
+	if n >= uint32(len(protoCards)) {
+		return 0
+	}
+
+	return protoCards[n]
+}
+
+func cardsToProto(src []eval.Card) []uint32 {
+	ret := make([]uint32, len(src))
+	for i, c := range src {
+		ret[i] = cardToProto(c)
+	}
+
+	return ret
+}
+
+func cardsFromProto(src []uint32) []eval.Card {
+	ret := make([]eval.Card, len(src))
+	for i, n := range src {
+		ret[i] = cardFromProto(n)
+	}
+
+	return ret
+}
+
+func holeCardsFromProto(src []uint32) [2]eval.Card {
+	var ret [2]eval.Card
+	for i := 0; i < len(src) && i < 2; i++ {
+		ret[i] = cardFromProto(src[i])
+	}
+
+	return ret
+}
+
+// extraCardsFromProto returns the Omaha hole cards that follow the first two in src, if there are any
+func extraCardsFromProto(src []uint32) [2]eval.Card {
+	if len(src) < 2 {
+		return [2]eval.Card{}
+	}
+
+	return holeCardsFromProto(src[2:])
+}
+
+// upCardsFromProto returns the stud up cards in src
+func upCardsFromProto(src []uint32) [4]eval.Card {
+	var ret [4]eval.Card
+	for i := 0; i < len(src) && i < len(ret); i++ {
+		ret[i] = cardFromProto(src[i])
+	}
+
+	return ret
+}
+
+func numsToProto(src []uint) []uint32 {
+	ret := make([]uint32, len(src))
+	for i, n := range src {
+		ret[i] = uint32(n)
+	}
+
+	return ret
+}
+
+func numsFromProto(src []uint32) []uint {
+	ret := make([]uint, len(src))
+	for i, n := range src {
+		ret[i] = uint(n)
+	}
+
+	return ret
+}
+
+// timeToProto converts t to nanoseconds since the Unix epoch, with the zero time as 0
+func timeToProto(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.UnixNano()
+}
+
+func timeFromProto(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, ns)
+}
+
+func variantsToProto(src []Variant) []pb.Variant {
+	if len(src) == 0 {
+		return nil
+	}
+
+	ret := make([]pb.Variant, len(src))
+	for i, v := range src {
+		ret[i] = pb.Variant(v)
+	}
+
+	return ret
+}
+
+func variantsFromProto(src []pb.Variant) []Variant {
+	if len(src) == 0 {
+		return nil
+	}
+
+	ret := make([]Variant, len(src))
+	for i, v := range src {
+		ret[i] = Variant(v)
+	}
+
+	return ret
+}
+
+// ToProto converts the view to its protobuf message, for compact binary serialization.
+func (gv *GameView) ToProto() *pb.GameView {
+	m := &pb.GameView{
+		SchemaVersion:  ViewSchemaVersion,
+		DealerNum:      uint32(gv.DealerNum),
+		ActionNum:      uint32(gv.ActionNum),
+		UtgNum:         uint32(gv.UTGNum),
+		SbNum:          uint32(gv.SBNum),
+		BbNum:          uint32(gv.BBNum),
+		CalledNum:      uint32(gv.CalledNum),
+		CommunityCards: cardsToProto(gv.CommunityCards),
+		Stage:          pb.GameStage(gv.Stage),
+		Betting:        gv.Betting,
+		Config:         gv.Config.ToProto(),
+		Deck:           cardsToProto(gv.Deck),
+		MinRaise:       uint64(gv.MinRaise),
+		ReadyCount:     uint64(gv.ReadyCount),
+		Carryover:      uint64(gv.Carryover),
+		Variant:        pb.Variant(gv.Variant),
+		RotationNum:    uint64(gv.RotationNum),
+		RotationHands:  uint64(gv.RotationHands),
+		HandEnded:      timeToProto(gv.HandEnded),
+		ActionDeadline: timeToProto(gv.ActionDeadline),
+		Seq:            gv.Seq,
+		Shuffle:        gv.Shuffle.ToProto(),
+		LastShuffle:    gv.LastShuffle.ToProto(),
+		Burns:          cardsToProto(gv.Burns),
+		ShowdownOrder:  numsToProto(gv.ShowdownOrder),
+		Paused:         gv.Paused,
+		Kill:           gv.Kill,
+		KillNum:        uint32(gv.KillNum),
+		LastPotWinner:  uint32(gv.LastPotWinner),
+		LastPotWon:     gv.LastPotWon,
+		HighHand:       gv.HighHand.ToProto(),
+	}
+
+	if gv.Rematch != nil {
+		m.Rematch = &pb.RematchOffer{
+			PlayerNums: numsToProto(gv.Rematch.PlayerNums[:]),
+			Accepted:   gv.Rematch.Accepted[:],
+			Deadline:   timeToProto(gv.Rematch.Deadline),
+		}
+	}
+
+	for _, p := range gv.Players {
+		m.Players = append(m.Players, p.ToProto())
+	}
+
+	for _, pot := range gv.Pots {
+		m.Pots = append(m.Pots, pot.ToProto())
+	}
+
+	for _, r := range gv.Showdown {
+		m.Showdown = append(m.Showdown, &pb.ShowdownReveal{
+			PlayerNum: uint32(r.PlayerNum),
+			Mucked:    r.Mucked,
+			Cards:     cardsToProto(r.holeCards()),
+			Score:     int32(r.Score),
+		})
+	}
+
+	for _, r := range gv.Ranges {
+		pr := &pb.Range{}
+		for _, wc := range r {
+			pr.Combos = append(pr.Combos, &pb.WeightedCombo{Cards: cardsToProto(wc.Cards[:]), Weight: wc.Weight})
+		}
+		m.Ranges = append(m.Ranges, pr)
+	}
+
+	return m
+}
+
+// FromProto overwrites the view with the contents of m.
+func (gv *GameView) FromProto(m *pb.GameView) {
+	*gv = GameView{
+		DealerNum:      uint(m.GetDealerNum()),
+		ActionNum:      uint(m.GetActionNum()),
+		UTGNum:         uint(m.GetUtgNum()),
+		SBNum:          uint(m.GetSbNum()),
+		BBNum:          uint(m.GetBbNum()),
+		CalledNum:      uint(m.GetCalledNum()),
+		CommunityCards: cardsFromProto(m.GetCommunityCards()),
+		Stage:          GameStage(m.GetStage()),
+		Betting:        m.GetBetting(),
+		Players:        make([]Player, len(m.GetPlayers())),
+		Deck:           cardsFromProto(m.GetDeck()),
+		Pots:           make([]Pot, len(m.GetPots())),
+		MinRaise:       uint(m.GetMinRaise()),
+		ReadyCount:     uint(m.GetReadyCount()),
+		Carryover:      uint(m.GetCarryover()),
+		Variant:        Variant(m.GetVariant()),
+		RotationNum:    uint(m.GetRotationNum()),
+		RotationHands:  uint(m.GetRotationHands()),
+		HandEnded:      timeFromProto(m.GetHandEnded()),
+		ActionDeadline: timeFromProto(m.GetActionDeadline()),
+		Seq:            m.GetSeq(),
+		Showdown:       make([]ShowdownReveal, len(m.GetShowdown())),
+		Shuffle:        shuffleCommitmentFromProto(m.GetShuffle()),
+		LastShuffle:    shuffleCommitmentFromProto(m.GetLastShuffle()),
+		Burns:          cardsFromProto(m.GetBurns()),
+		ShowdownOrder:  numsFromProto(m.GetShowdownOrder()),
+		Paused:         m.GetPaused(),
+		Kill:           m.GetKill(),
+		KillNum:        uint(m.GetKillNum()),
+		LastPotWinner:  uint(m.GetLastPotWinner()),
+		LastPotWon:     m.GetLastPotWon(),
+		HighHand:       highHandFromProto(m.GetHighHand()),
+	}
+
+	gv.Config.FromProto(m.GetConfig())
+
+	if r := m.GetRematch(); r != nil {
+		gv.Rematch = &RematchOffer{Deadline: timeFromProto(r.GetDeadline())}
+		copy(gv.Rematch.PlayerNums[:], numsFromProto(r.GetPlayerNums()))
+		copy(gv.Rematch.Accepted[:], r.GetAccepted())
+	}
+
+	for i, p := range m.GetPlayers() {
+		gv.Players[i].FromProto(p)
+	}
+
+	for i, pot := range m.GetPots() {
+		gv.Pots[i].FromProto(pot)
+	}
+
+	for i, r := range m.GetShowdown() {
+		gv.Showdown[i] = ShowdownReveal{
+			PlayerNum:  uint(r.GetPlayerNum()),
+			Mucked:     r.GetMucked(),
+			Cards:      holeCardsFromProto(r.GetCards()),
+			ExtraCards: extraCardsFromProto(r.GetCards()),
+			Score:      int(r.GetScore()),
+		}
+	}
+
+	if len(m.GetRanges()) > 0 {
+		gv.Ranges = make([]Range, len(m.GetRanges()))
+		for i, pr := range m.GetRanges() {
+			gv.Ranges[i] = make(Range, len(pr.GetCombos()))
+			for j, wc := range pr.GetCombos() {
+				gv.Ranges[i][j] = WeightedCombo{Cards: holeCardsFromProto(wc.GetCards()), Weight: wc.GetWeight()}
+			}
+		}
+	}
+
+	migrateView(gv, uint(m.GetSchemaVersion()))
+}
+
+// ToProto converts the commitment to its protobuf message. A nil commitment converts to nil.
+func (s *ShuffleCommitment) ToProto() *pb.ShuffleCommitment {
+	if s == nil {
+		return nil
+	}
+
+	return &pb.ShuffleCommitment{
+		Commitment: s.Commitment,
+		Seed:       s.Seed,
+		Salt:       s.Salt,
+		Variant:    pb.Variant(s.Variant),
+		Dealt:      cardsToProto(s.Dealt),
+	}
+}
+
+func shuffleCommitmentFromProto(m *pb.ShuffleCommitment) *ShuffleCommitment {
+	if m == nil {
+		return nil
+	}
+
+	return &ShuffleCommitment{
+		Commitment: m.GetCommitment(),
+		Seed:       m.GetSeed(),
+		Salt:       m.GetSalt(),
+		Variant:    Variant(m.GetVariant()),
+		Dealt:      cardsFromProto(m.GetDealt()),
+	}
+}
+
+// ToProto converts the high hand to its protobuf message. A nil high hand converts to nil.
+func (h *HighHand) ToProto() *pb.HighHand {
+	if h == nil {
+		return nil
+	}
+
+	return &pb.HighHand{
+		PlayerNum: uint32(h.PlayerNum),
+		Hand:      cardsToProto(h.Hand),
+		Score:     int32(h.Score),
+		At:        timeToProto(h.At),
+	}
+}
+
+func highHandFromProto(m *pb.HighHand) *HighHand {
+	if m == nil {
+		return nil
+	}
+
+	return &HighHand{
+		PlayerNum: uint(m.GetPlayerNum()),
+		Hand:      cardsFromProto(m.GetHand()),
+		Score:     int(m.GetScore()),
+		At:        timeFromProto(m.GetAt()),
+	}
+}
+
+// ToProto converts the config to its protobuf message.
+func (c *GameConfig) ToProto() *pb.GameConfig {
+	return &pb.GameConfig{
+		MaxBuy:     uint64(c.MaxBuy),
+		MinBuy:     uint64(c.MinBuy),
+		BigBlind:   uint64(c.BigBlind),
+		SmallBlind: uint64(c.SmallBlind),
+		Seed:       c.Seed,
+		ChipFormat: &pb.ChipFormat{
+			Prefix:    c.ChipFormat.Prefix,
+			Scale:     uint64(c.ChipFormat.Scale),
+			Decimals:  uint64(c.ChipFormat.Decimals),
+			Separator: c.ChipFormat.Separator,
+		},
+		HandCap: uint64(c.HandCap),
+		Rules: &pb.RuleSet{
+			Emotes:       c.Rules.Emotes,
+			RabbitHunt:   c.Rules.RabbitHunt,
+			DeadChips:    c.Rules.DeadChips,
+			OddChip:      pb.OddChipRule(c.Rules.OddChip),
+			HiLo:         c.Rules.HiLo,
+			MissedBlinds: c.Rules.MissedBlinds,
+			ShowOrMuck:   c.Rules.ShowOrMuck,
+			Undo:         c.Rules.Undo,
+			TableStakes:  c.Rules.TableStakes,
+			HeadsUp:      pb.HeadsUpRule(c.Rules.HeadsUp),
+			Straddle:     c.Rules.Straddle,
+		},
+		RejectLimit:       uint64(c.RejectLimit),
+		Variant:           pb.Variant(c.Variant),
+		AutoDeal:          c.AutoDeal,
+		DealDelay:         int64(c.DealDelay),
+		Rotation:          variantsToProto(c.Rotation),
+		RotateEvery:       uint64(c.RotateEvery),
+		RematchTimeout:    int64(c.RematchTimeout),
+		RematchStack:      uint64(c.RematchStack),
+		Retention:         pb.Retention(c.Retention),
+		EventHistory:      uint64(c.EventHistory),
+		ActionTime:        int64(c.ActionTime),
+		TimeBank:          int64(c.TimeBank),
+		TimeoutAction:     pb.TimeoutAction(c.TimeoutAction),
+		TimeoutsToSitOut:  uint64(c.TimeoutsToSitOut),
+		Seats:             uint64(c.Seats),
+		CommitShuffle:     c.CommitShuffle,
+		BurnCards:         c.BurnCards,
+		MinBigBlinds:      uint64(c.MinBigBlinds),
+		SevenDeuceBounty:  uint64(c.SevenDeuceBounty),
+		HighHandQualifier: int64(c.HighHandQualifier),
+		HighHandWindow:    int64(c.HighHandWindow),
+		Ante:              uint64(c.Ante),
+		AnteMode:          pb.AnteMode(c.AnteMode),
+		WinTheButton:      c.WinTheButton,
+		Betting:           pb.BettingStructure(c.Betting),
+		SpreadMin:         uint64(c.SpreadMin),
+		SpreadMax:         uint64(c.SpreadMax),
+		Kill:              pb.KillMode(c.Kill),
+	}
+}
+
+// FromProto overwrites the config with the contents of m.
+func (c *GameConfig) FromProto(m *pb.GameConfig) {
+	*c = GameConfig{
+		MaxBuy:     uint(m.GetMaxBuy()),
+		MinBuy:     uint(m.GetMinBuy()),
+		BigBlind:   uint(m.GetBigBlind()),
+		SmallBlind: uint(m.GetSmallBlind()),
+		Seed:       m.GetSeed(),
+		ChipFormat: ChipFormat{
+			Prefix:    m.GetChipFormat().GetPrefix(),
+			Scale:     uint(m.GetChipFormat().GetScale()),
+			Decimals:  uint(m.GetChipFormat().GetDecimals()),
+			Separator: m.GetChipFormat().GetSeparator(),
+		},
+		HandCap: uint(m.GetHandCap()),
+		Rules: RuleSet{
+			Emotes:       m.GetRules().GetEmotes(),
+			RabbitHunt:   m.GetRules().GetRabbitHunt(),
+			DeadChips:    m.GetRules().GetDeadChips(),
+			OddChip:      OddChipRule(m.GetRules().GetOddChip()),
+			HiLo:         m.GetRules().GetHiLo(),
+			MissedBlinds: m.GetRules().GetMissedBlinds(),
+			ShowOrMuck:   m.GetRules().GetShowOrMuck(),
+			Undo:         m.GetRules().GetUndo(),
+			TableStakes:  m.GetRules().GetTableStakes(),
+			HeadsUp:      HeadsUpRule(m.GetRules().GetHeadsUp()),
+			Straddle:     m.GetRules().GetStraddle(),
+		},
+		RejectLimit:       uint(m.GetRejectLimit()),
+		Variant:           Variant(m.GetVariant()),
+		AutoDeal:          m.GetAutoDeal(),
+		DealDelay:         time.Duration(m.GetDealDelay()),
+		Rotation:          variantsFromProto(m.GetRotation()),
+		RotateEvery:       uint(m.GetRotateEvery()),
+		RematchTimeout:    time.Duration(m.GetRematchTimeout()),
+		RematchStack:      uint(m.GetRematchStack()),
+		Retention:         Retention(m.GetRetention()),
+		EventHistory:      uint(m.GetEventHistory()),
+		ActionTime:        time.Duration(m.GetActionTime()),
+		TimeBank:          time.Duration(m.GetTimeBank()),
+		TimeoutAction:     TimeoutAction(m.GetTimeoutAction()),
+		TimeoutsToSitOut:  uint(m.GetTimeoutsToSitOut()),
+		Seats:             uint(m.GetSeats()),
+		CommitShuffle:     m.GetCommitShuffle(),
+		BurnCards:         m.GetBurnCards(),
+		MinBigBlinds:      uint(m.GetMinBigBlinds()),
+		SevenDeuceBounty:  uint(m.GetSevenDeuceBounty()),
+		HighHandQualifier: int(m.GetHighHandQualifier()),
+		HighHandWindow:    time.Duration(m.GetHighHandWindow()),
+		Ante:              uint(m.GetAnte()),
+		AnteMode:          AnteMode(m.GetAnteMode()),
+		WinTheButton:      m.GetWinTheButton(),
+		Betting:           BettingStructure(m.GetBetting()),
+		SpreadMin:         uint(m.GetSpreadMin()),
+		SpreadMax:         uint(m.GetSpreadMax()),
+		Kill:              KillMode(m.GetKill()),
+	}
+}
+
+// ToProto converts the player to its protobuf message.
+func (p *Player) ToProto() *pb.Player {
+	return &pb.Player{
+		Ready:           p.Ready,
+		In:              p.In,
+		Called:          p.Called,
+		Left:            p.Left,
+		TotalBuyIn:      uint64(p.TotalBuyIn),
+		Stack:           uint64(p.Stack),
+		Bet:             uint64(p.Bet),
+		TotalBet:        uint64(p.TotalBet),
+		Cards:           cardsToProto(p.holeCards()),
+		PreviouslyIn:    p.PreviouslyIn,
+		PreviouslyAllIn: p.PreviouslyAllIn,
+		PreviousBet:     uint64(p.PreviousBet),
+		AllInStage:      pb.GameStage(p.AllInStage),
+		DeadChips:       uint64(p.DeadChips),
+		Away:            p.Away,
+		TotalCashOut:    uint64(p.TotalCashOut),
+		CashedOut:       uint64(p.CashedOut),
+		Bounty:          uint64(p.Bounty),
+		Straddle:        p.Straddle,
+		Kill:            p.Kill,
+		HandsPlayed:     uint64(p.HandsPlayed),
+		Rebuys:          uint64(p.Rebuys),
+		BiggestPot:      uint64(p.BiggestPot),
+		ShowdownsWon:    uint64(p.ShowdownsWon),
+		UpCards:         cardsToProto(p.UpCards[:]),
+		MissedBlinds:    uint32(p.MissedBlinds),
+		PostMissed:      p.PostMissed,
+		SittingOut:      p.SittingOut,
+		TimeBank:        int64(p.TimeBank),
+		Timeouts:        uint64(p.Timeouts),
+		SeatNum:         uint64(p.SeatNum),
+		Shown:           p.Shown[:],
+		ThirdCard:       cardToProto(p.ThirdCard),
+		Discarded:       cardToProto(p.Discarded),
+	}
+}
+
+// FromProto overwrites the player with the contents of m.
+func (p *Player) FromProto(m *pb.Player) {
+	*p = Player{
+		Ready:           m.GetReady(),
+		In:              m.GetIn(),
+		Called:          m.GetCalled(),
+		Left:            m.GetLeft(),
+		TotalBuyIn:      uint(m.GetTotalBuyIn()),
+		Stack:           uint(m.GetStack()),
+		Bet:             uint(m.GetBet()),
+		TotalBet:        uint(m.GetTotalBet()),
+		Cards:           holeCardsFromProto(m.GetCards()),
+		ExtraCards:      extraCardsFromProto(m.GetCards()),
+		PreviouslyIn:    m.GetPreviouslyIn(),
+		PreviouslyAllIn: m.GetPreviouslyAllIn(),
+		PreviousBet:     uint(m.GetPreviousBet()),
+		AllInStage:      GameStage(m.GetAllInStage()),
+		DeadChips:       uint(m.GetDeadChips()),
+		Away:            m.GetAway(),
+		TotalCashOut:    uint(m.GetTotalCashOut()),
+		CashedOut:       uint(m.GetCashedOut()),
+		Bounty:          uint(m.GetBounty()),
+		Straddle:        m.GetStraddle(),
+		Kill:            m.GetKill(),
+		HandsPlayed:     uint(m.GetHandsPlayed()),
+		Rebuys:          uint(m.GetRebuys()),
+		BiggestPot:      uint(m.GetBiggestPot()),
+		ShowdownsWon:    uint(m.GetShowdownsWon()),
+		UpCards:         upCardsFromProto(m.GetUpCards()),
+		MissedBlinds:    MissedBlinds(m.GetMissedBlinds()),
+		PostMissed:      m.GetPostMissed(),
+		SittingOut:      m.GetSittingOut(),
+		TimeBank:        time.Duration(m.GetTimeBank()),
+		Timeouts:        uint(m.GetTimeouts()),
+		SeatNum:         uint(m.GetSeatNum()),
+		ThirdCard:       cardFromProto(m.GetThirdCard()),
+		Discarded:       cardFromProto(m.GetDiscarded()),
+	}
+
+	copy(p.Shown[:], m.GetShown())
+}
+
+// ToProto converts the pot to its protobuf message.
+func (pot *Pot) ToProto() *pb.Pot {
+	m := &pb.Pot{
+		TopShare:             uint64(pot.TopShare),
+		Amt:                  uint64(pot.Amt),
+		EligiblePlayerNums:   numsToProto(pot.EligiblePlayerNums),
+		WinningPlayerNums:    numsToProto(pot.WinningPlayerNums),
+		WinningHand:          cardsToProto(pot.WinningHand),
+		WinningScore:         int32(pot.WinningScore),
+		Name:                 pot.Name,
+		Side:                 pot.Side,
+		Capped:               pot.Capped,
+		CreatedStage:         pb.GameStage(pot.CreatedStage),
+		CreatedByPlayerNum:   uint32(pot.CreatedByPlayerNum),
+		Contributions:        make([]uint64, len(pot.Contributions)),
+		LowWinningPlayerNums: numsToProto(pot.LowWinningPlayerNums),
+		LowWinningHand:       cardsToProto(pot.LowWinningHand),
+		LowWinningScore:      int32(pot.LowWinningScore),
+	}
+
+	for i, amt := range pot.Contributions {
+		m.Contributions[i] = uint64(amt)
+	}
+
+	return m
+}
+
+// FromProto overwrites the pot with the contents of m.
+func (pot *Pot) FromProto(m *pb.Pot) {
+	*pot = Pot{
+		TopShare:             uint(m.GetTopShare()),
+		Amt:                  uint(m.GetAmt()),
+		EligiblePlayerNums:   numsFromProto(m.GetEligiblePlayerNums()),
+		WinningPlayerNums:    numsFromProto(m.GetWinningPlayerNums()),
+		WinningHand:          cardsFromProto(m.GetWinningHand()),
+		WinningScore:         int(m.GetWinningScore()),
+		Name:                 m.GetName(),
+		Side:                 m.GetSide(),
+		Capped:               m.GetCapped(),
+		CreatedStage:         GameStage(m.GetCreatedStage()),
+		CreatedByPlayerNum:   uint(m.GetCreatedByPlayerNum()),
+		Contributions:        make([]uint, len(m.GetContributions())),
+		LowWinningPlayerNums: numsFromProto(m.GetLowWinningPlayerNums()),
+		LowWinningHand:       cardsFromProto(m.GetLowWinningHand()),
+		LowWinningScore:      int(m.GetLowWinningScore()),
+	}
+
+	for i, amt := range m.GetContributions() {
+		pot.Contributions[i] = uint(amt)
+	}
+}
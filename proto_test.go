@@ -0,0 +1,88 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+	"github.com/alexclewontin/riverboat/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGameView_ProtoRoundTrip(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 11, ChipFormat: TournamentChipFormat})
+
+	for i, amt := range []uint{100, 50, 100} {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, amt)
+		ToggleReady(g, uint(i), 0)
+	}
+	Deal(g, 0, 0)
+	Bet(g, 0, 25)
+	Bet(g, 1, 40)
+
+	view := g.GenerateOmniView()
+
+	b, err := proto.Marshal(view.ToProto())
+	if err != nil {
+		t.Fatalf("proto.Marshal() error = %v", err)
+	}
+
+	m := &pb.GameView{}
+	if err := proto.Unmarshal(b, m); err != nil {
+		t.Fatalf("proto.Unmarshal() error = %v", err)
+	}
+
+	got := &GameView{}
+	got.FromProto(m)
+
+	if !reflect.DeepEqual(got, view) {
+		t.Errorf("proto round trip = %+v\nwant %+v", got, view)
+	}
+}
+
+func TestCardToProto(t *testing.T) {
+	seen := make(map[uint32]bool)
+	for _, c := range eval.DefaultDeck {
+		n := cardToProto(c)
+		if n < 1 || n > 52 || seen[n] {
+			t.Errorf("cardToProto(%v) = %d, which is out of range or a duplicate", c, n)
+		}
+		seen[n] = true
+
+		if cardFromProto(n) != c {
+			t.Errorf("cardFromProto(cardToProto(%v)) = %v", c, cardFromProto(n))
+		}
+	}
+
+	if cardToProto(eval.MustParseCardString("2C")) != 1 || cardToProto(eval.MustParseCardString("AS")) != 52 {
+		t.Error("cardToProto does not match the documented encoding")
+	}
+
+	if cardToProto(0) != 0 || cardFromProto(0) != 0 {
+		t.Error("the zero Card must encode as 0")
+	}
+}
@@ -0,0 +1,253 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"strings"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+const rangeRanks = "23456789TJQKA"
+const rangeSuits = "CDHS"
+
+// handClass is a set of starting hands written like "AKs": two ranks (hi >= lo), and whether the hand must be suited
+// ('s'), offsuit ('o'), or either (0). Pairs are never suited.
+type handClass struct {
+	hi, lo int
+	suit   byte
+}
+
+// ParseRange parses a Range written in the usual comma-separated notation, equally weighting every combo it
+// contains. Each part of the notation is one of:
+//
+//   - a pair, like "TT", or a class of unpaired hands, like "AK" (all 16 combos), "AKs" (suited), or "AKo" (offsuit)
+//   - any of those followed by "+", which adds the better hands with the same top card: "22+" is every pair,
+//     and "ATs+" is ATs, AJs, AQs and AKs
+//   - two of those joined by "-", which includes everything between them: "TT-77" is four pairs, and "A5s-A2s" is
+//     four suited aces
+//   - a specific combo, like "AsKs"
+//
+// Combos listed more than once are only included once.
+func ParseRange(s string) (Range, error) {
+	seen := make(map[[2]eval.Card]bool)
+	r := Range{}
+
+	add := func(a, b eval.Card) {
+		if a > b {
+			a, b = b, a
+		}
+		if k := [2]eval.Card{a, b}; !seen[k] {
+			seen[k] = true
+			r = append(r, WeightedCombo{Cards: k, Weight: 1})
+		}
+	}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if len(part) == 4 {
+			a, errA := eval.ParseCardBytes([]byte(part[:2]))
+			b, errB := eval.ParseCardBytes([]byte(part[2:]))
+			if errA == nil && errB == nil {
+				if a == b {
+					return nil, ErrBadRange
+				}
+				add(a, b)
+				continue
+			}
+		}
+
+		classes, err := parseRangePart(part)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, hc := range classes {
+			hc.combos(add)
+		}
+	}
+
+	return r, nil
+}
+
+// parseRangePart expands one part of range notation (other than a specific combo) into the handClasses it names
+func parseRangePart(part string) ([]handClass, error) {
+	if strings.HasSuffix(part, "+") {
+		hc, err := parseHandClass(strings.TrimSuffix(part, "+"))
+		if err != nil {
+			return nil, err
+		}
+
+		ret := []handClass{}
+		if hc.hi == hc.lo {
+			for r := hc.hi; r < len(rangeRanks); r++ {
+				ret = append(ret, handClass{hi: r, lo: r})
+			}
+		} else {
+			for lo := hc.lo; lo < hc.hi; lo++ {
+				ret = append(ret, handClass{hi: hc.hi, lo: lo, suit: hc.suit})
+			}
+		}
+
+		return ret, nil
+	}
+
+	if ends := strings.Split(part, "-"); len(ends) == 2 {
+		a, errA := parseHandClass(strings.TrimSpace(ends[0]))
+		b, errB := parseHandClass(strings.TrimSpace(ends[1]))
+		if errA != nil || errB != nil || a.suit != b.suit {
+			return nil, ErrBadRange
+		}
+
+		ret := []handClass{}
+		switch {
+		case a.hi == a.lo && b.hi == b.lo:
+			lo, hi := a.hi, b.hi
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			for r := lo; r <= hi; r++ {
+				ret = append(ret, handClass{hi: r, lo: r})
+			}
+		case a.hi != a.lo && b.hi != b.lo && a.hi == b.hi:
+			lo, hi := a.lo, b.lo
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			for k := lo; k <= hi; k++ {
+				ret = append(ret, handClass{hi: a.hi, lo: k, suit: a.suit})
+			}
+		default:
+			return nil, ErrBadRange
+		}
+
+		return ret, nil
+	}
+
+	hc, err := parseHandClass(part)
+	if err != nil {
+		return nil, err
+	}
+
+	return []handClass{hc}, nil
+}
+
+func parseHandClass(s string) (handClass, error) {
+	if len(s) != 2 && len(s) != 3 {
+		return handClass{}, ErrBadRange
+	}
+
+	hi := strings.IndexByte(rangeRanks, upper(s[0]))
+	lo := strings.IndexByte(rangeRanks, upper(s[1]))
+	if hi < 0 || lo < 0 {
+		return handClass{}, ErrBadRange
+	}
+	if lo > hi {
+		hi, lo = lo, hi
+	}
+
+	hc := handClass{hi: hi, lo: lo}
+	if len(s) == 3 {
+		hc.suit = s[2] | 0x20
+		if (hc.suit != 's' && hc.suit != 'o') || hi == lo {
+			return handClass{}, ErrBadRange
+		}
+	}
+
+	return hc, nil
+}
+
+// combos calls add with every pair of cards in hc
+func (hc handClass) combos(add func(a, b eval.Card)) {
+	card := func(rank, suit int) eval.Card {
+		return eval.MustParseCardBytes([]byte{rangeRanks[rank], rangeSuits[suit]})
+	}
+
+	for i := range rangeSuits {
+		for j := range rangeSuits {
+			switch {
+			case hc.hi == hc.lo && j <= i:
+			case hc.suit == 's' && i != j:
+			case hc.suit == 'o' && i == j:
+			default:
+				add(card(hc.hi, i), card(hc.lo, j))
+			}
+		}
+	}
+}
+
+func upper(b byte) byte {
+	if b >= 'a' && b <= 'z' {
+		return b - 0x20
+	}
+	return b
+}
+
+// RangeEquity calculates the all-in equity of hand against an opponent holding a combo from r on the given board,
+// weighting each combo's result by its Weight. Combos that share a card with hand or the board can't be dealt, and
+// are left out; if none are left, RangeEquity returns ErrEmptyRange.
+//
+// The iterations are split evenly among the combos, and each combo's equity is calculated as by eval.Equity, so
+// iterations of zero or less makes the result exact, but can be very slow before the flop.
+func RangeEquity(hand [2]eval.Card, r Range, board []eval.Card, iterations int) (eval.HandEquity, error) {
+	live := r.Without(append([]eval.Card{hand[0], hand[1]}, board...)...)
+
+	var total float64
+	for _, wc := range live {
+		total += wc.Weight
+	}
+	if total <= 0 {
+		return eval.HandEquity{}, ErrEmptyRange
+	}
+
+	each := 0
+	if iterations > 0 {
+		each = iterations / len(live)
+		if each < 1 {
+			each = 1
+		}
+	}
+
+	var ret eval.HandEquity
+	for _, wc := range live {
+		if wc.Weight <= 0 {
+			continue
+		}
+
+		res, err := eval.Equity([][]eval.Card{hand[:], wc.Cards[:]}, board, each)
+		if err != nil {
+			return eval.HandEquity{}, err
+		}
+
+		ret.Win += res[0].Win * wc.Weight / total
+		ret.Tie += res[0].Tie * wc.Weight / total
+		ret.Equity += res[0].Equity * wc.Weight / total
+	}
+
+	return ret, nil
+}
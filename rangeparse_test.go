@@ -0,0 +1,111 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		notation string
+		combos   int
+	}{
+		{"22", 6},
+		{"22+", 78},
+		{"TT-77", 24},
+		{"77-TT", 24},
+		{"AKs", 4},
+		{"AKo", 12},
+		{"AK", 16},
+		{"ka", 16},
+		{"ATs+", 16},
+		{"KQo+", 12},
+		{"A5s-A2s", 16},
+		{"AsKs", 1},
+		{"22+, ATs+, KQo, A5s-A2s", 122},
+		{"AK, AKs, AsKs", 16},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseRange(tt.notation)
+		if err != nil {
+			t.Errorf("Test failed - unexpected error parsing %q: %v", tt.notation, err)
+			continue
+		}
+		if len(r) != tt.combos {
+			t.Errorf("Test failed - expected %q to have %d combos, got %d", tt.notation, tt.combos, len(r))
+		}
+	}
+
+	r, _ := ParseRange("AKs")
+	for _, wc := range r {
+		if wc.Weight != 1 || wc.Cards[0]&wc.Cards[1]&0xF000 == 0 {
+			t.Errorf("Test failed - expected an equally weighted suited combo, got %v", wc)
+		}
+	}
+
+	for _, bad := range []string{"AKx", "AAs", "A", "22-AK", "AKs-AQo", "KQs-A2s", "1K", "AsAs", "AK+-AQ"} {
+		if _, err := ParseRange(bad); err != ErrBadRange {
+			t.Errorf("Test failed - expected ErrBadRange parsing %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestRangeEquity(t *testing.T) {
+	hand := [2]eval.Card{eval.MustParseCardString("As"), eval.MustParseCardString("Ad")}
+
+	// On this board, the aces beat every pair but the kings, who have a set
+	r, _ := ParseRange("KK, QQ")
+	board := []eval.Card{eval.MustParseCardString("Kh"), eval.MustParseCardString("7c"), eval.MustParseCardString("2d"),
+		eval.MustParseCardString("3s"), eval.MustParseCardString("9h")}
+
+	// Three combos of kings and six of queens are live
+	got, err := RangeEquity(hand, r, board, 0)
+	if err != nil {
+		t.Fatalf("Test failed - unexpected error %v", err)
+	}
+	if math.Abs(got.Equity-6.0/9) > 1e-9 || got.Tie != 0 {
+		t.Errorf("Test failed - expected the aces to win 6/9 of the time, got %+v", got)
+	}
+
+	// Weighting the kings more heavily makes the aces worse off
+	for i := range r {
+		if (r[i].Cards[0]>>8)&0xF == 11 {
+			r[i].Weight = 3
+		}
+	}
+	if got, _ = RangeEquity(hand, r, board, 0); math.Abs(got.Equity-6.0/15) > 1e-9 {
+		t.Errorf("Test failed - expected the aces to win 6/15 of the time, got %+v", got)
+	}
+
+	r, _ = ParseRange("AsAh, AhAd")
+	if _, err := RangeEquity(hand, r, nil, 1000); err != ErrEmptyRange {
+		t.Errorf("Test failed - expected ErrEmptyRange when every combo is blocked, got %v", err)
+	}
+}
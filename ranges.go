@@ -0,0 +1,148 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// WeightedCombo is a specific pair of hole cards, and how likely a player is to hold it, relative to the other
+// combos in their Range.
+type WeightedCombo struct {
+	Cards  [2]eval.Card `json:"cards"`
+	Weight float64      `json:"weight"`
+}
+
+// Range is the set of hole cards a player might plausibly hold, each with a relative weight.
+type Range []WeightedCombo
+
+// RangeModel narrows players' ranges as a hand progresses. Narrow is called whenever a player bets (including checks
+// and calls) or folds, with the player's range before the action, the Event recording the action, and a view of the
+// Game (after the action) in which no hole cards are visible unless every player could see them. It returns the
+// player's new range. Narrow must not modify r in place.
+type RangeModel interface {
+	Narrow(r Range, e Event, gv *GameView) Range
+}
+
+// RangeModelFunc adapts an ordinary function to the RangeModel interface.
+type RangeModelFunc func(r Range, e Event, gv *GameView) Range
+
+// Narrow calls f(r, e, gv).
+func (f RangeModelFunc) Narrow(r Range, e Event, gv *GameView) Range {
+	return f(r, e, gv)
+}
+
+// FullRange returns a Range containing all 1326 possible pairs of hole cards, equally weighted.
+func FullRange() Range {
+	return deckRange(eval.DefaultDeck)
+}
+
+// deckRange returns a Range containing every pair of cards in deck, equally weighted
+func deckRange(deck eval.Deck) Range {
+	r := make(Range, 0, len(deck)*(len(deck)-1)/2)
+	for i := range deck {
+		for j := i + 1; j < len(deck); j++ {
+			r = append(r, WeightedCombo{Cards: [2]eval.Card{deck[i], deck[j]}, Weight: 1})
+		}
+	}
+
+	return r
+}
+
+// Without returns a copy of r without any combos that contain any of cards. Zero Cards are ignored.
+func (r Range) Without(cards ...eval.Card) Range {
+	dead := make(map[eval.Card]bool)
+	for _, c := range cards {
+		if c != 0 {
+			dead[c] = true
+		}
+	}
+
+	ret := make(Range, 0, len(r))
+	for _, wc := range r {
+		if !dead[wc.Cards[0]] && !dead[wc.Cards[1]] {
+			ret = append(ret, wc)
+		}
+	}
+
+	return ret
+}
+
+// SetRangeModel turns on range tracking, using m to narrow each player's range as they act. Every player dealt into
+// a hand starts it with every combo that can be dealt in the Game's Variant (a FullRange, for HoldEm), which is
+// narrowed by m as they act, has combos that conflict with the board removed as community cards are dealt, and
+// becomes empty when they fold. The ranges appear in GenerateOmniView. Passing nil turns range tracking off.
+func (g *Game) SetRangeModel(m RangeModel) {
+	if g.cancelRanges != nil {
+		g.cancelRanges()
+		g.cancelRanges = nil
+	}
+
+	g.rangeModel = m
+	g.ranges = nil
+
+	if m != nil {
+		g.cancelRanges = g.Subscribe(g.trackRanges)
+	}
+}
+
+func (g *Game) trackRanges(e Event) {
+	switch e.Kind {
+	case EventHandStart:
+		g.ranges = make([]Range, len(g.players))
+	case EventHoleCards:
+		if g.ranges != nil {
+			g.ranges[e.PlayerNum] = deckRange(g.variant().deck())
+		}
+	case EventCommunityCards:
+		for i := range g.ranges {
+			if g.ranges[i] != nil {
+				g.ranges[i] = g.ranges[i].Without(e.Cards...)
+			}
+		}
+	case EventBet:
+		if g.ranges != nil && g.ranges[e.PlayerNum] != nil {
+			g.ranges[e.PlayerNum] = g.rangeModel.Narrow(g.ranges[e.PlayerNum], e, g.GenerateSpectatorView())
+		}
+	case EventFold:
+		if g.ranges != nil && g.ranges[e.PlayerNum] != nil {
+			g.ranges[e.PlayerNum] = Range{}
+		}
+	}
+}
+
+func copyRanges(src []Range) []Range {
+	if src == nil {
+		return nil
+	}
+
+	ret := make([]Range, len(src))
+	for i := range src {
+		if src[i] != nil {
+			ret[i] = append(Range{}, src[i]...)
+		}
+	}
+
+	return ret
+}
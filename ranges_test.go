@@ -0,0 +1,98 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+)
+
+// pairsWhenRaising narrows a player's range to pocket pairs whenever they raise
+var pairsWhenRaising = RangeModelFunc(func(r Range, e Event, gv *GameView) Range {
+	for i, p := range gv.Players {
+		if uint(i) != e.PlayerNum && p.Bet >= gv.Players[e.PlayerNum].Bet {
+			return r
+		}
+	}
+
+	ret := Range{}
+	for _, wc := range r {
+		if (wc.Cards[0]>>8)&0x0F == (wc.Cards[1]>>8)&0x0F {
+			ret = append(ret, wc)
+		}
+	}
+	return ret
+})
+
+func TestGame_SetRangeModel(t *testing.T) {
+	g := NewGame(nil)
+	g.SetRangeModel(pairsWhenRaising)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	Deal(g, 0, 0)
+
+	view := g.GenerateOmniView()
+	for i, r := range view.Ranges {
+		if len(r) != 1326 {
+			t.Errorf("Test failed - player %d should start with 1326 combos, got %d", i, len(r))
+		}
+	}
+
+	// Player 0 raises, player 1 folds, player 2 calls
+	Bet(g, 0, 100)
+	Fold(g, 1, 0)
+	Bet(g, 2, 75)
+
+	view = g.GenerateOmniView()
+	if view.Stage != Flop {
+		t.Fatalf("Test failed - expected to be on the flop, got stage %d", view.Stage)
+	}
+
+	// 13 ranks * 6 combos each, minus the pairs that conflict with the flop
+	if n := len(view.Ranges[0]); n > 78 || n < 78-9 {
+		t.Errorf("Test failed - player 0's range should be pocket pairs, got %d combos", n)
+	}
+
+	if n := len(view.Ranges[1]); n != 0 {
+		t.Errorf("Test failed - player 1 folded, so should have an empty range, got %d combos", n)
+	}
+
+	// C(49, 2) combos don't conflict with the flop
+	if n := len(view.Ranges[2]); n != 1176 {
+		t.Errorf("Test failed - player 2's range should only have card removal applied, got %d combos", n)
+	}
+
+	if g.GeneratePlayerView(0).Ranges != nil {
+		t.Error("Test failed - ranges should only be in the omni view")
+	}
+
+	g.SetRangeModel(nil)
+	if g.GenerateOmniView().Ranges != nil {
+		t.Error("Test failed - turning range tracking off should clear the ranges")
+	}
+}
@@ -0,0 +1,205 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// stackedCards parses cards, for stacking the deck
+func stackedCards(s ...string) eval.Deck {
+	ret := eval.Deck{}
+	for _, c := range s {
+		ret = append(ret, eval.MustParseCardString(c))
+	}
+
+	return ret
+}
+
+// razzGame seats three players at a fixed limit Razz table with an ante, and deals them a stacked deck: player 0
+// makes a wheel, player 1 a seven low, and player 2 a ten low. Kings of diamonds and hearts tie for the bring-in.
+func razzGame(t *testing.T) *Game {
+	t.Helper()
+
+	config := defaultConfig
+	config.Variant = Razz
+	config.Betting = FixedLimit
+	config.Ante = 5
+	g := seatedGame(t, &config, 3, 1000)
+	g.SetRandSource(dealtDeck{full: eval.DefaultDeck, dealt: stackedCards(
+		"As", "2s", "Kd", "3h", "4h", "5c", "6d", "7d", "Kh", // third street
+		"8c", "5d", "2c", // fourth street
+		"3c", "6c", "9c", // fifth street
+		"4d", "7c", "Tc", // sixth street
+		"5s", "8d", "Jc", // seventh street
+	)})
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	return g
+}
+
+func TestRazz_BringIn(t *testing.T) {
+	g := razzGame(t)
+
+	for i, p := range g.players {
+		if p.Cards[0] == 0 || p.Cards[1] == 0 || p.UpCards[0] == 0 || p.UpCards[1] != 0 {
+			t.Fatalf("Test failed - player %d should have been dealt two cards down and one up, got %v and %v", i, p.Cards, p.UpCards)
+		}
+		if p.DeadChips != 5 {
+			t.Errorf("Test failed - player %d should have anted 5, got %d", i, p.DeadChips)
+		}
+	}
+
+	// The king of hearts outranks the king of diamonds
+	if g.players[2].Bet != 10 {
+		t.Fatalf("Test failed - player 2 should have brought it in for 10, got %d", g.players[2].Bet)
+	}
+	if g.actionNum != 0 {
+		t.Fatalf("Test failed - expected the action on player 0, after the bring-in, got player %d", g.actionNum)
+	}
+
+	// Everybody just calls, and the bring-in isn't owed the option
+	for _, pn := range []uint{0, 1} {
+		if err := Bet(g, pn, 10); err != nil {
+			t.Fatalf("Test failed - player %d couldn't call the bring-in: %s", pn, err)
+		}
+	}
+
+	if g.getStage() != FourthStreet {
+		t.Fatalf("Test failed - expected fourth street once the bring-in was called, got stage %d", g.getStage())
+	}
+}
+
+func TestRazz_Hand(t *testing.T) {
+	g := razzGame(t)
+
+	// Player 0 can complete to the small bet, but no more, as the completion is the first bet
+	if err := Bet(g, 0, 35); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected raising the bring-in past the small bet to fail, got %v", err)
+	}
+	if err := Bet(g, 0, 25); err != nil {
+		t.Fatalf("Test failed - error completing the bring-in: %s", err)
+	}
+	if min := g.raiseMin(); min != 25 {
+		t.Errorf("Test failed - expected raises after the completion to be by the small bet, got %d", min)
+	}
+	if err := Bet(g, 1, 25); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if err := Bet(g, 2, 15); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+
+	// Player 2 shows K-2, better than player 0's K-8, and player 1 has paired fives
+	if g.getStage() != FourthStreet || g.actionNum != 2 {
+		t.Fatalf("Test failed - expected player 2 to act first on fourth street, got player %d at stage %d", g.actionNum, g.getStage())
+	}
+	for _, pn := range []uint{2, 0, 1} {
+		if err := Bet(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - player %d couldn't check: %s", pn, err)
+		}
+	}
+
+	// K-8-3 beats K-9-2
+	if g.getStage() != FifthStreet || g.actionNum != 0 {
+		t.Fatalf("Test failed - expected player 0 to act first on fifth street, got player %d at stage %d", g.actionNum, g.getStage())
+	}
+	if err := Bet(g, 0, 25); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected a small bet on fifth street to fail, got %v", err)
+	}
+	for _, pn := range []uint{0, 1, 2} {
+		if err := Bet(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - player %d couldn't check: %s", pn, err)
+		}
+	}
+	for g.getStage() == SixthStreet {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - player %d couldn't check: %s", g.actionNum, err)
+		}
+	}
+
+	if g.getStage() != SeventhStreet {
+		t.Fatalf("Test failed - expected seventh street, got stage %d", g.getStage())
+	}
+
+	// The last card is dealt face down: the other players see only the up cards
+	view := g.GeneratePlayerView(1)
+	if got := view.Players[0].UpCards; got != [4]eval.Card{eval.MustParseCardString("Kd"), eval.MustParseCardString("8c"), eval.MustParseCardString("3c"), eval.MustParseCardString("4d")} {
+		t.Errorf("Test failed - expected player 1 to see player 0's up cards, got %v", got)
+	}
+	if got := view.Players[0].holeCards(); got[0] != 0 || got[1] != 0 {
+		t.Errorf("Test failed - expected player 1 not to see player 0's hole cards, got %v", got)
+	}
+	if got := view.Players[1].holeCards(); len(got) != 3 || got[2] != eval.MustParseCardString("8d") {
+		t.Errorf("Test failed - expected player 1 to see their seventh street card, got %v", got)
+	}
+	for _, e := range g.PlayerEventsSince(1, 0) {
+		if e.Kind == EventDownCard && e.PlayerNum != 1 && len(e.Cards) != 0 {
+			t.Errorf("Test failed - expected player 1 not to see player %d's down card, got %v", e.PlayerNum, e.Cards)
+		}
+	}
+
+	for g.getBetting() {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - player %d couldn't check: %s", g.actionNum, err)
+		}
+	}
+
+	// The wheel wins the antes and three small bets
+	if g.getStage() != PreDeal {
+		t.Fatalf("Test failed - expected the hand to be over, got stage %d", g.getStage())
+	}
+	if got := g.pots[0].WinningPlayerNums; len(got) != 1 || got[0] != 0 {
+		t.Fatalf("Test failed - expected player 0's wheel to win, got %v", got)
+	}
+	if g.players[0].Stack != 1000-5-25+90 {
+		t.Errorf("Test failed - expected player 0 to win 90, got a stack of %d", g.players[0].Stack)
+	}
+}
+
+func TestRazz_ShortDeck(t *testing.T) {
+	config := defaultConfig
+	config.Variant = Razz
+	g := seatedGame(t, &config, 8, 1000)
+
+	// Eight players would need 56 cards
+	if err := Deal(g, g.dealingNum(), 0); err != ErrShortDeck {
+		t.Errorf("Test failed - expected dealing eight players Razz to fail with ErrShortDeck, got %v", err)
+	}
+}
+
+func TestRazz_Proto(t *testing.T) {
+	g := razzGame(t)
+
+	p := &Player{}
+	p.FromProto(g.players[0].ToProto())
+	if p.UpCards != g.players[0].UpCards {
+		t.Errorf("Test failed - expected the up cards to survive a proto round trip, got %v, want %v", p.UpCards, g.players[0].UpCards)
+	}
+}
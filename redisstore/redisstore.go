@@ -0,0 +1,170 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package redisstore is a reference implementation of riverboat.GameStore backed by Redis, so a Game can outlive the
+// server process holding it, and be picked up by another.
+//
+// Each Game is kept under its own key, a hash holding the Game's view as JSON and its version. Saves go through a Lua
+// script that checks the version and writes the new one in a single step, so concurrent writers can't both save over
+// the same version. Every save resets the key's time to live, if the Store has one, so Games nobody has touched in
+// that long are dropped by Redis on its own.
+package redisstore
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexclewontin/riverboat"
+	"github.com/gomodule/redigo/redis"
+)
+
+// saveScript sets the view (ARGV[1]) of the Game at KEYS[1], if it is at version ARGV[2], and expires it after ARGV[3]
+// milliseconds (or never, if that is 0). It returns the new version, or an error reply of "stale" if the Game is at a
+// different version.
+var saveScript = redis.NewScript(1, `
+local version = tonumber(redis.call('HGET', KEYS[1], 'version') or '0')
+if version ~= tonumber(ARGV[2]) then
+	return redis.error_reply('stale')
+end
+redis.call('HSET', KEYS[1], 'view', ARGV[1], 'version', version + 1)
+if tonumber(ARGV[3]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+else
+	redis.call('PERSIST', KEYS[1])
+end
+return version + 1
+`)
+
+// Store is a riverboat.GameStore that keeps Games in Redis. It is safe for concurrent use.
+// Stores should not be initialized directly, only through the New factory function.
+type Store struct {
+	pool   *redis.Pool
+	prefix string
+	ttl    time.Duration
+}
+
+// New returns a Store that keeps Games in the Redis server pool connects to, under keys made of prefix followed by
+// the Game's ID, so several Stores (or other applications) can share a server. Games that go ttl without being saved
+// expire; if ttl is 0, they are kept until deleted.
+func New(pool *redis.Pool, prefix string, ttl time.Duration) *Store {
+	return &Store{pool: pool, prefix: prefix, ttl: ttl}
+}
+
+// Save implements riverboat.GameStore.
+func (s *Store) Save(id string, view *riverboat.GameView, version uint64) (uint64, error) {
+	b, err := json.Marshal(view)
+	if err != nil {
+		return 0, err
+	}
+
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	newVersion, err := redis.Uint64(saveScript.Do(conn, s.prefix+id, b, version, s.ttl.Milliseconds()))
+	var reply redis.Error
+	if errors.As(err, &reply) && reply.Error() == "stale" {
+		return 0, riverboat.ErrStaleVersion
+	}
+
+	return newVersion, err
+}
+
+// Load implements riverboat.GameStore.
+func (s *Store) Load(id string) (*riverboat.GameView, uint64, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	values, err := redis.Values(conn.Do("HMGET", s.prefix+id, "view", "version"))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var b []byte
+	var version uint64
+	if _, err := redis.Scan(values, &b, &version); err != nil {
+		return nil, 0, err
+	}
+	if b == nil {
+		return nil, 0, riverboat.ErrGameNotFound
+	}
+
+	view := &riverboat.GameView{}
+	if err := json.Unmarshal(b, view); err != nil {
+		return nil, 0, err
+	}
+
+	return view, version, nil
+}
+
+// List implements riverboat.GameStore. The IDs are sorted. List walks the keys with SCAN, so it doesn't block the
+// server, but a Game saved or expiring while it runs may or may not be listed.
+func (s *Store) List() ([]string, error) {
+	conn := s.pool.Get()
+	defer conn.Close()
+
+	ids := []string{}
+	seen := map[string]bool{}
+	cursor := "0"
+	for {
+		values, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", escapePattern(s.prefix)+"*"))
+		if err != nil {
+			return nil, err
+		}
+
+		var keys []string
+		if _, err := redis.Scan(values, &cursor, &keys); err != nil {
+			return nil, err
+		}
+
+		// SCAN can return a key more than once
+		for _, k := range keys {
+			if id := strings.TrimPrefix(k, s.prefix); !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		if cursor == "0" {
+			break
+		}
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// escapePattern escapes the characters that are special in the glob-style patterns SCAN matches keys with
+func escapePattern(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if strings.ContainsRune(`*?[]^\`, c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
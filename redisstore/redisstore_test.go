@@ -0,0 +1,110 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package redisstore
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alexclewontin/riverboat"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gomodule/redigo/redis"
+)
+
+// newStore returns a Store backed by an in-process Redis server, which the caller must Close
+func newStore(t *testing.T, ttl time.Duration) (*Store, *miniredis.Miniredis) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("miniredis.Run() error = %v", err)
+	}
+
+	pool := &redis.Pool{Dial: func() (redis.Conn, error) { return redis.Dial("tcp", mr.Addr()) }}
+
+	return New(pool, "rb:[1]:", ttl), mr
+}
+
+func TestStore(t *testing.T) {
+	s, mr := newStore(t, time.Hour)
+	defer mr.Close()
+	mr.Set("rb:other", "not a game")
+
+	g := riverboat.NewGame(nil)
+	pn := g.AddPlayer()
+	riverboat.BuyIn(g, pn, 1000)
+	view := g.GenerateOmniView()
+
+	if _, _, err := s.Load("a"); err != riverboat.ErrGameNotFound {
+		t.Errorf("Load() of a missing game error = %v, want ErrGameNotFound", err)
+	}
+
+	v, err := s.Save("b", view, 0)
+	if err != nil || v != 1 {
+		t.Fatalf("Save() = %d, %v, want 1, nil", v, err)
+	}
+	if _, err := s.Save("b", view, 0); err != riverboat.ErrStaleVersion {
+		t.Errorf("Save() over a newer version error = %v, want ErrStaleVersion", err)
+	}
+	if v, err := s.Save("b", view, 1); err != nil || v != 2 {
+		t.Errorf("Save() = %d, %v, want 2, nil", v, err)
+	}
+	if _, err := s.Save("a", view, 0); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, v, err := s.Load("b")
+	if err != nil || v != 2 || !reflect.DeepEqual(loaded, view) {
+		t.Errorf("Load() = %+v, %d, %v, want %+v, 2, nil", loaded, v, err, view)
+	}
+
+	if ids, err := s.List(); err != nil || !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("List() = %v, %v, want [a b]", ids, err)
+	}
+
+	// Games nobody saves expire
+	mr.FastForward(2 * time.Hour)
+	if _, _, err := s.Load("b"); err != riverboat.ErrGameNotFound {
+		t.Errorf("Load() of an expired game error = %v, want ErrGameNotFound", err)
+	}
+}
+
+func TestStore_Game(t *testing.T) {
+	s, mr := newStore(t, 0)
+	defer mr.Close()
+
+	g := riverboat.NewGame(nil)
+	g.SetStore(s, "table", 0)
+	pn := g.AddPlayer()
+	if err := g.Apply(riverboat.BuyIn, pn, 1000); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	loaded, err := riverboat.LoadGame(s, "table")
+	if err != nil {
+		t.Fatalf("LoadGame() error = %v", err)
+	}
+	if got, want := loaded.GenerateOmniView(), g.GenerateOmniView(); !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadGame() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// ReEnter buys entrant en, who has busted, back into the Tournament while late registration is open, and returns
+// the entrant number of the new entry. Like a late entry, it is seated straight away with the starting stack, and
+// adds to the prize pool. Each entrant may re-enter up to TournamentConfig.ReEntries times, counting the re-entries
+// of the entries they re-entered from. ReEnter returns ErrTournamentStarted once late registration has closed, or
+// ErrCannotReEnter if en hasn't busted, or has no re-entries left.
+func (t *Tournament) ReEnter(en uint) (uint, error) {
+	if en >= uint(len(t.seats)) {
+		return 0, ErrUnknownEntrant
+	}
+
+	if !t.LateRegOpen() {
+		return 0, ErrTournamentStarted
+	}
+
+	first := t.firstEntry[en]
+	if !t.busted[en] || t.reEntries[first] >= t.config.ReEntries || t.hasLaterEntry(en) {
+		return 0, ErrCannotReEnter
+	}
+
+	reEntry := uint(len(t.seats))
+	if err := t.addEntry(first); err != nil {
+		return 0, err
+	}
+	t.reEntries[first]++
+
+	return reEntry, nil
+}
+
+// hasLaterEntry reports whether the entrant who made entry en has already entered again since
+func (t *Tournament) hasLaterEntry(en uint) bool {
+	for later := en + 1; later < uint(len(t.seats)); later++ {
+		if t.firstEntry[later] == t.firstEntry[en] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FirstEntry returns the entrant number of the first entry made by whoever made entry en, which is en itself unless
+// en is a re-entry.
+func (t *Tournament) FirstEntry(en uint) (uint, error) {
+	if en >= uint(len(t.seats)) {
+		return 0, ErrUnknownEntrant
+	}
+
+	return t.firstEntry[en], nil
+}
+
+// ReEntries returns how many of the Tournament's entries are re-entries.
+func (t *Tournament) ReEntries() uint {
+	var total uint
+	for _, n := range t.reEntries {
+		total += n
+	}
+
+	return total
+}
+
+// AddOn buys entrant en an add-on: TournamentConfig.AddOnStack more chips, at the cost of AddOnCost to the prize
+// pool. Every entrant still in may buy one add-on, between hands, while the clock is on the break (the level numbered
+// TournamentConfig.AddOnLevel). Otherwise, AddOn returns ErrAddOnUnavailable.
+func (t *Tournament) AddOn(en uint) error {
+	if !t.started {
+		return ErrTournamentNotStarted
+	}
+
+	if t.finished {
+		return ErrAddOnUnavailable
+	}
+
+	if en >= uint(len(t.seats)) || t.busted[en] {
+		return ErrUnknownEntrant
+	}
+
+	s := t.seats[en]
+	if levelNum, _ := t.Level(); t.config.AddOnStack == 0 || levelNum != t.config.AddOnLevel || t.addedOn[en] ||
+		!t.betweenHands(s.TableNum) {
+		return ErrAddOnUnavailable
+	}
+
+	// An add-on isn't a rebuy
+	if err := t.tables[s.TableNum].buyIn(s.PlayerNum, t.config.AddOnStack, false); err != nil {
+		return err
+	}
+	t.addedOn[en] = true
+
+	return nil
+}
+
+// AddOns returns how many add-ons have been bought.
+func (t *Tournament) AddOns() uint {
+	var total uint
+	for _, added := range t.addedOn {
+		if added {
+			total++
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,131 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+)
+
+// RematchOffer is the offer of a rematch made to the two players of a heads-up match when one of them busts (see
+// GameConfig.RematchTimeout). PlayerNums are the two players, and Accepted records which of them have accepted. The
+// offer lapses if both haven't accepted by Deadline.
+type RematchOffer struct {
+	PlayerNums [2]uint   `json:"playerNums"`
+	Accepted   [2]bool   `json:"accepted"`
+	Deadline   time.Time `json:"deadline"`
+}
+
+func copyRematch(src *RematchOffer) *RematchOffer {
+	if src == nil {
+		return nil
+	}
+
+	ret := *src
+	return &ret
+}
+
+// offerRematch offers the players of the hand that just ended a rematch, if rematches are on, the hand was heads up,
+// and one of the two busted. It must be called before busted players are marked not ready.
+func (g *Game) offerRematch() {
+	g.rematch = nil
+
+	if g.config.RematchTimeout == 0 {
+		return
+	}
+
+	var nums []uint
+	busted := false
+	for i, s := range g.startStacks {
+		if s != 0 {
+			nums = append(nums, uint(i))
+			busted = busted || g.players[i].Stack == 0
+		}
+	}
+
+	if len(nums) != 2 || !busted {
+		return
+	}
+
+	g.rematch = &RematchOffer{
+		PlayerNums: [2]uint{nums[0], nums[1]},
+		Deadline:   g.currentTime().Add(g.config.RematchTimeout),
+	}
+
+	g.emit(Event{Kind: EventRematchOffer})
+}
+
+// Rematch accepts the open rematch offer for the player. Once both players of the match have accepted, their stacks
+// are reset to the configured RematchStack (the difference is recorded as a buy in, or a cash out, so the table's
+// chips stay accounted for), both are marked ready, and the rematch is played on at the same table, so the players
+// keep their player numbers and the event log carries on. Rematch will return an error if there is no open offer,
+// the player isn't one of the two it was made to, or it has lapsed (ErrRematchExpired).
+// Rematch ignores the value passed in as data.
+func Rematch(g *Game, pn uint, data uint) error {
+	offer := g.rematch
+	if offer == nil {
+		return ErrIllegalAction
+	}
+
+	seat := 0
+	switch pn {
+	case offer.PlayerNums[0]:
+	case offer.PlayerNums[1]:
+		seat = 1
+	default:
+		return ErrIllegalAction
+	}
+
+	if g.currentTime().After(offer.Deadline) {
+		return ErrRematchExpired
+	}
+
+	offer.Accepted[seat] = true
+	g.emit(Event{Kind: EventRematchAccept, PlayerNum: pn})
+
+	if !offer.Accepted[0] || !offer.Accepted[1] {
+		return nil
+	}
+
+	stack := g.config.RematchStack
+	if stack == 0 {
+		stack = g.config.MaxBuy
+	}
+
+	for _, n := range offer.PlayerNums {
+		p := &g.players[n]
+		if p.Stack < stack {
+			p.TotalBuyIn += stack - p.Stack
+		} else {
+			p.TotalCashOut += p.Stack - stack
+		}
+		p.Stack = stack
+		p.Ready = true
+		p.Left = false
+	}
+
+	g.rematch = nil
+	g.emit(Event{Kind: EventRematch, Amount: stack})
+
+	return nil
+}
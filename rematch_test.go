@@ -0,0 +1,136 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestRematch(t *testing.T) {
+	config := defaultConfig
+	config.RematchTimeout = 30 * time.Second
+	config.RematchStack = 100
+	g := NewGame(&config)
+
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Rematch(g, 0, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a rematch can't be accepted before the match is over, got %v", err)
+	}
+
+	if err := Deal(g, g.dealerNum, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Player 0 stacks player 1 with aces
+	g.deck = eval.Deck{}
+	for _, s := range []string{"2s", "7d", "9h", "Jc", "Kd"} {
+		g.deck.Push(eval.MustParseCardString(s))
+	}
+	g.players[0].Cards = [2]eval.Card{eval.MustParseCardString("As"), eval.MustParseCardString("Ah")}
+	g.players[1].Cards = [2]eval.Card{eval.MustParseCardString("3c"), eval.MustParseCardString("4h")}
+
+	for g.getStage() != PreDeal {
+		var err error
+		if g.getBetting() {
+			err = Bet(g, g.actionNum, g.players[g.actionNum].Stack)
+		} else {
+			err = Deal(g, g.dealerNum, 0)
+		}
+		if err != nil {
+			t.Fatalf("Test failed - error playing the hand out: %s", err)
+		}
+	}
+
+	offer := g.GenerateOmniView().Rematch
+	if offer == nil || offer.PlayerNums != [2]uint{0, 1} || !offer.Deadline.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("Test failed - expected a rematch to be offered, got %+v", offer)
+	}
+
+	if err := Rematch(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error accepting the rematch: %s", err)
+	}
+	if g.players[1].Stack != 0 || g.players[1].Ready {
+		t.Errorf("Test failed - the rematch shouldn't start until both players accept")
+	}
+
+	if err := Rematch(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error accepting the rematch: %s", err)
+	}
+
+	if g.rematch != nil {
+		t.Errorf("Test failed - the offer should be closed once the rematch starts")
+	}
+	for _, pn := range []uint{0, 1} {
+		if p := g.players[pn]; p.Stack != 100 || !p.Ready {
+			t.Errorf("Test failed - player %d should start the rematch ready with 100, got %+v", pn, p)
+		}
+	}
+	if g.players[0].TotalCashOut != 100 || g.players[1].TotalBuyIn != 200 {
+		t.Errorf("Test failed - the stack resets should be recorded as a cash out and a buy in")
+	}
+	if g.ChipsInPlay() != 200 {
+		t.Errorf("Test failed - expected 200 chips in play, got %d", g.ChipsInPlay())
+	}
+
+	if err := Deal(g, g.dealerNum, 0); err != nil {
+		t.Errorf("Test failed - the rematch should be dealt at the same table: %s", err)
+	}
+}
+
+func TestRematch_Expired(t *testing.T) {
+	config := defaultConfig
+	config.RematchTimeout = 30 * time.Second
+	g := NewGame(&config)
+
+	now := time.Unix(1000, 0)
+	g.now = func() time.Time { return now }
+
+	g.AddPlayer()
+	g.AddPlayer()
+	g.rematch = &RematchOffer{PlayerNums: [2]uint{0, 1}, Deadline: now.Add(config.RematchTimeout)}
+
+	now = now.Add(time.Minute)
+
+	if err := Rematch(g, 0, 0); err != ErrRematchExpired {
+		t.Errorf("Test failed - expected a lapsed offer to be refused, got %v", err)
+	}
+	if g.rematch.Accepted[0] {
+		t.Errorf("Test failed - a refused acceptance shouldn't be recorded")
+	}
+}
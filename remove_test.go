@@ -0,0 +1,197 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestGame_RemovePlayer(t *testing.T) {
+	g := NewGame(nil)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if _, err := g.RemovePlayer(3); err != ErrIllegalAction {
+		t.Errorf("Test failed - removing a player that doesn't exist should fail, got %v", err)
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// The big blind leaves while player 0 is to act, and forfeits their blind
+	credit, err := g.RemovePlayer(2)
+	if err != nil {
+		t.Fatalf("Test failed - error removing player: %s", err)
+	}
+	if credit != 975 {
+		t.Errorf("Test failed - expected 975 chips to be credited back, got %d", credit)
+	}
+	if p := g.players[2]; p.In || p.Ready || !p.Left || p.Stack != 0 || p.TotalCashOut != 975 {
+		t.Errorf("Test failed - the removed player should be out of the hand and the game, got %+v", p)
+	}
+	if g.actionNum != 0 || !g.getBetting() {
+		t.Errorf("Test failed - the hand should carry on with player 0 to act")
+	}
+	if g.ChipsInPlay() != 2025 {
+		t.Errorf("Test failed - expected the forfeited blind to stay in play, got %d chips", g.ChipsInPlay())
+	}
+
+	// Then the small blind leaves on their turn, conceding the hand to player 0
+	if err := Bet(g, 0, 25); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if _, err := g.RemovePlayer(1); err != nil {
+		t.Fatalf("Test failed - error removing player: %s", err)
+	}
+	if g.getStage() != PreDeal || g.players[0].Stack != 1035 {
+		t.Errorf("Test failed - player 0 should have won the blinds, got a stack of %d", g.players[0].Stack)
+	}
+
+	// Seats are kept, and not reused
+	if pn := g.AddPlayer(); pn != 3 {
+		t.Errorf("Test failed - expected the next player to be seated as player 3, got %d", pn)
+	}
+}
+
+// checkChipsConserved fails the test unless every chip bought in is either still in play or has been cashed out
+func checkChipsConserved(t *testing.T, g *Game) {
+	t.Helper()
+
+	var in, out uint
+	for _, p := range g.players {
+		in += p.TotalBuyIn
+		out += p.TotalCashOut
+	}
+
+	if g.ChipsInPlay() != in-out {
+		t.Errorf("Test failed - expected %d chips in play, got %d", in-out, g.ChipsInPlay())
+	}
+}
+
+func TestGame_RemovePlayer_DeadChips(t *testing.T) {
+	g := seatedGame(t, nil, 3, 1000)
+
+	// Dead chips posted before the deal are handed back along with the rest of the stack
+	if err := PostDead(g, 2, 40); err != nil {
+		t.Fatalf("Test failed - error posting dead: %s", err)
+	}
+	credit, err := g.RemovePlayer(2)
+	if err != nil {
+		t.Fatalf("Test failed - error removing player: %s", err)
+	}
+	if credit != 1000 || g.players[2].TotalCashOut != 1000 || g.players[2].DeadChips != 0 {
+		t.Errorf("Test failed - expected 1000 chips to be credited back, got %d", credit)
+	}
+	checkChipsConserved(t, g)
+
+	var journaled uint
+	for _, e := range g.Events() {
+		if e.Kind == EventLeave {
+			journaled = e.Amount
+		}
+	}
+	if journaled != 1000 {
+		t.Errorf("Test failed - expected the 1000 chips credited back to be journaled, got %d", journaled)
+	}
+}
+
+func TestGame_RemovePlayer_Ante(t *testing.T) {
+	for _, mode := range []AnteMode{AntePerPlayer, AnteBigBlind, AnteButton} {
+		config := defaultConfig
+		config.Ante = 5
+		config.AnteMode = mode
+		g := seatedGame(t, &config, 3, 1000)
+
+		if err := Deal(g, 0, 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+
+		// Whoever posted the ante forfeits it, along with their blind, if they are removed mid-hand
+		for pn := uint(2); pn > 0; pn-- {
+			p := g.players[pn]
+			credit, err := g.RemovePlayer(pn)
+			if err != nil {
+				t.Fatalf("Test failed - error removing player: %s", err)
+			}
+			if credit != p.Stack {
+				t.Errorf("Test failed - ante mode %d: expected %d chips to be credited back, got %d", mode, p.Stack, credit)
+			}
+			checkChipsConserved(t, g)
+		}
+	}
+}
+
+func TestGame_RemovePlayer_AllIn(t *testing.T) {
+	g := NewGame(nil)
+
+	for _, stack := range []uint{500, 500, 1000, 613, 1000} {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, stack); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Player 3 is all in for 613, and player 4 calls, over the top of the two all in for 500
+	for _, bet := range []struct{ pn, amt uint }{{3, 613}, {4, 613}, {0, 500}, {1, 490}} {
+		if err := Bet(g, bet.pn, bet.amt); err != nil {
+			t.Fatalf("Test failed - error betting: %s", err)
+		}
+	}
+
+	// Both of them are removed before the big blind acts, so nobody still in can win the 226 chips they bet over 500
+	for _, pn := range []uint{3, 4} {
+		if _, err := g.RemovePlayer(pn); err != nil {
+			t.Fatalf("Test failed - error removing player: %s", err)
+		}
+	}
+	if err := Fold(g, 2, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	// The two left check it down
+	for g.getStage() != PreDeal {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+	}
+
+	if won := g.players[0].Stack + g.players[1].Stack + g.carryover; won != 2251 {
+		t.Errorf("Test failed - expected the players all in for 500 to win 2251 chips between them, got %d", won)
+	}
+	checkChipsConserved(t, g)
+}
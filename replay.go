@@ -0,0 +1,217 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// HandRecord holds everything needed to replay a hand exactly: Start, an omniscient view of the table from before
+// the hand was dealt (its Config.Seed decides how the deck is shuffled), and every Action successfully performed
+// from then on, in order. Records of hands that weren't dealt by riverboat, like those read by ParsePHH, have no
+// Seed to shuffle with, and hold the cards in the order they are dealt in Deck instead.
+type HandRecord struct {
+	Start   *GameView        `json:"start"`
+	Actions []RecordedAction `json:"actions"`
+	Deck    eval.Deck        `json:"deck,omitempty"`
+}
+
+// RecordedAction is a single Action in a HandRecord. Action is one of the keys of ActionsByName.
+type RecordedAction struct {
+	Action    string `json:"action"`
+	PlayerNum uint   `json:"playerNum"`
+	Data      uint   `json:"data"`
+}
+
+// RecordHand starts a HandRecord of g as it is now. The Actions performed on g from now on should be performed
+// through the record's Perform, so they are recorded. g must not have an RNG of its own (see SetRandSource).
+func RecordHand(g *Game) *HandRecord {
+	return &HandRecord{Start: g.GenerateOmniView()}
+}
+
+// Perform performs the Action named action for player pn on g, and adds it to r if it succeeds. Perform returns
+// ErrUnknownAction if there is no such Action, or whatever error the Action returns.
+func (r *HandRecord) Perform(g *Game, action string, pn uint, data uint) error {
+	a, ok := ActionsByName[action]
+	if !ok {
+		return ErrUnknownAction
+	}
+
+	if err := a(g, pn, data); err != nil {
+		return err
+	}
+
+	r.Actions = append(r.Actions, RecordedAction{Action: action, PlayerNum: pn, Data: data})
+
+	return nil
+}
+
+// Replay rebuilds the table r started from, and performs every Action in r on it, returning the Game as it was
+// after the last of them: the same cards dealt, the same bets made, and the same pots awarded. If one of the
+// Actions fails, which means r was not recorded faithfully, Replay returns the Game as it was before that Action,
+// along with its error (or ErrUnknownAction if there is no Action by that name).
+func Replay(r *HandRecord) (*Game, error) {
+	return replay(r, nil)
+}
+
+// GameAt rebuilds the table r started from, and performs the first n of r's Actions on it, returning the Game as it
+// was right after them, mid-hand, ready to be played on from there, like for a trainer that asks what to do next or
+// for going over a disputed hand. GameAt(0) is the table before the hand was dealt. In a record read from a hand
+// history (see ParsePHH), the first Action is the deal. GameAt returns ErrBadActionIndex if r doesn't have n Actions,
+// or, like Replay, the Game before the Action that failed, along with its error.
+func (r *HandRecord) GameAt(n int) (*Game, error) {
+	if n < 0 || n > len(r.Actions) {
+		return nil, ErrBadActionIndex
+	}
+
+	return replay(&HandRecord{Start: r.Start, Actions: r.Actions[:n], Deck: r.Deck}, nil)
+}
+
+// replay is Replay, calling after (if it isn't nil) with the Game before the first Action and after each one
+func replay(r *HandRecord, after func(g *Game)) (*Game, error) {
+	g := NewGame(&r.Start.Config)
+	g.FillFromView(r.Start)
+	if len(r.Deck) > 0 {
+		g.SetRandSource(dealtDeck{full: g.variant().deck(), dealt: r.Deck})
+	}
+
+	if after != nil {
+		after(g)
+	}
+
+	for _, ra := range r.Actions {
+		a, ok := ActionsByName[ra.Action]
+		if !ok {
+			return g, ErrUnknownAction
+		}
+
+		if err := a(g, ra.PlayerNum, ra.Data); err != nil {
+			return g, err
+		}
+
+		if after != nil {
+			after(g)
+		}
+	}
+
+	return g, nil
+}
+
+// dealtDeck is an RNG that stacks the deck, rather than shuffling it, so that the cards in dealt are dealt in order,
+// followed by the rest of full. full must be the deck being shuffled.
+type dealtDeck struct {
+	full  eval.Deck
+	dealt eval.Deck
+}
+
+func (d dealtDeck) Shuffle(n int, swap func(i, j int)) {
+	// Cards are dealt off the end of the deck, so dealt goes on the end, last card first
+	want := make(eval.Deck, 0, n)
+	inDealt := map[eval.Card]bool{}
+	for _, c := range d.dealt {
+		inDealt[c] = true
+	}
+	for _, c := range d.full {
+		if !inDealt[c] {
+			want = append(want, c)
+		}
+	}
+	for i := len(d.dealt) - 1; i >= 0; i-- {
+		want = append(want, d.dealt[i])
+	}
+
+	deck := append(eval.Deck{}, d.full...)
+	for i := 0; i < n && i < len(want); i++ {
+		for j := i; j < n; j++ {
+			if deck[j] == want[i] {
+				swap(i, j)
+				deck[i], deck[j] = deck[j], deck[i]
+				break
+			}
+		}
+	}
+}
+
+// HandReplay steps through a HandRecord one Action at a time, for building hand replayers. Its position is the
+// number of the record's Actions performed so far, starting at 0, before the first. The views it returns are
+// omniscient views of the table at a position, and are shared by every call that returns them, so they must not be
+// modified.
+type HandReplay struct {
+	views []*GameView
+	pos   int
+}
+
+// NewHandReplay replays r (see Replay), keeping a view of the table from before each Action and after the last,
+// and returns a HandReplay at position 0. If one of r's Actions fails, NewHandReplay returns its error.
+func NewHandReplay(r *HandRecord) (*HandReplay, error) {
+	h := &HandReplay{}
+
+	_, err := replay(r, func(g *Game) {
+		h.views = append(h.views, g.GenerateOmniView())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// Len returns how many Actions the replay steps through.
+func (h *HandReplay) Len() int {
+	return len(h.views) - 1
+}
+
+// Pos returns the replay's position: how many Actions have been performed.
+func (h *HandReplay) Pos() int {
+	return h.pos
+}
+
+// View returns the view of the table at the replay's position.
+func (h *HandReplay) View() *GameView {
+	return h.views[h.pos]
+}
+
+// Next performs the next Action, and returns the view of the table after it. If every Action has been performed,
+// Next returns nil and false.
+func (h *HandReplay) Next() (*GameView, bool) {
+	return h.Seek(h.pos + 1)
+}
+
+// Prev takes back the last Action performed, and returns the view of the table before it. At position 0, Prev
+// returns nil and false.
+func (h *HandReplay) Prev() (*GameView, bool) {
+	return h.Seek(h.pos - 1)
+}
+
+// Seek moves the replay to position pos, and returns the view of the table there. If there is no such position,
+// Seek returns nil and false, and the replay stays where it is.
+func (h *HandReplay) Seek(pos int) (*GameView, bool) {
+	if pos < 0 || pos > h.Len() {
+		return nil, false
+	}
+
+	h.pos = pos
+
+	return h.views[pos], true
+}
@@ -0,0 +1,131 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestReplay(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7})
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 100)
+		ToggleReady(g, pn, 0)
+	}
+
+	r := RecordHand(g)
+
+	if err := r.Perform(g, "deal", 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := r.Perform(g, "nope", 0, 0); err != ErrUnknownAction {
+		t.Errorf("Test failed - Perform should reject unknown actions, got %v", err)
+	}
+	for g.getStage() != PreDeal {
+		if err := r.Perform(g, "bet", g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+			t.Fatalf("Test failed - error calling: %s", err)
+		}
+	}
+
+	replayed, err := Replay(r)
+	if err != nil {
+		t.Fatalf("Test failed - Replay returned %s", err)
+	}
+
+	// Only the clock can differ, as the hand ended again when it was replayed
+	got, want := replayed.GenerateOmniView(), g.GenerateOmniView()
+	got.HandEnded = want.HandEnded
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Test failed - the replayed game is %+v\nwant %+v", got, want)
+	}
+
+	r.Actions = append(r.Actions, RecordedAction{Action: "fold", PlayerNum: 1})
+	if _, err := Replay(r); err != ErrIllegalAction {
+		t.Errorf("Test failed - Replay should return the error of an Action that fails, got %v", err)
+	}
+}
+
+func TestHandReplay(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7})
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 100)
+		ToggleReady(g, pn, 0)
+	}
+
+	r := RecordHand(g)
+	if err := r.Perform(g, "deal", 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	for g.getStage() != PreDeal {
+		if err := r.Perform(g, "bet", g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+			t.Fatalf("Test failed - error calling: %s", err)
+		}
+	}
+
+	h, err := NewHandReplay(r)
+	if err != nil {
+		t.Fatalf("Test failed - NewHandReplay returned %s", err)
+	}
+
+	if h.Len() != len(r.Actions) || h.Pos() != 0 || h.View().Stage != PreDeal {
+		t.Fatalf("Test failed - expected a replay of %d actions starting before the deal", len(r.Actions))
+	}
+	if _, ok := h.Prev(); ok {
+		t.Errorf("Test failed - expected no step back from the start")
+	}
+
+	dealt, ok := h.Next()
+	if !ok || dealt.Stage != PreFlop || dealt.Players[0].Cards == [2]eval.Card{} {
+		t.Errorf("Test failed - expected the first step to deal the hand, got %+v", dealt)
+	}
+
+	for i := 1; i < h.Len(); i++ {
+		if _, ok := h.Next(); !ok {
+			t.Fatalf("Test failed - expected step %d to succeed", i+1)
+		}
+	}
+	if _, ok := h.Next(); ok || h.Pos() != h.Len() {
+		t.Errorf("Test failed - expected no step past the end")
+	}
+
+	// The last view is the table as it was after the hand
+	want := g.GenerateOmniView()
+	got := *h.View()
+	got.HandEnded = want.HandEnded
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("Test failed - the replay ended at %+v\nwant %+v", got, want)
+	}
+
+	if back, ok := h.Prev(); !ok || h.Pos() != h.Len()-1 || back.Stage == PreDeal {
+		t.Errorf("Test failed - expected stepping back to undo the last action")
+	}
+	if start, ok := h.Seek(0); !ok || start.Stage != PreDeal {
+		t.Errorf("Test failed - expected to be able to seek back to the start")
+	}
+}
@@ -0,0 +1,89 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// Retention decides how much of the hidden information from a hand a Game keeps once the hand is over, in its
+// event log (see Events) and its omni views. Hands that aren't kept are discarded from both as soon as the hand
+// ends, before its EventHandEnd is recorded: their hole cards, third cards and discards are zeroed in the players'
+// records, and in the Cards of the hand's EventHoleCards, EventDownCard and EventDiscard records. Subscribers still receive those
+// Events while the hand is being played, but anything that reads the log or an omni view once it sees EventHandEnd
+// only sees the hands that were kept.
+type Retention uint8
+
+const (
+	// RetainAll keeps every hand dealt, including those that were folded or mucked. This is the default.
+	RetainAll Retention = iota
+	// RetainShowdown keeps the hands of every player who reached showdown, whether they showed or mucked, and
+	// discards the rest
+	RetainShowdown
+	// RetainShown keeps only the hands that were shown at showdown
+	RetainShown
+)
+
+// retains reports whether g keeps player pn's hand from the hand that just ended
+func (g *Game) retains(pn uint) bool {
+	if g.config.Retention == RetainAll {
+		return true
+	}
+
+	for _, r := range g.showdown {
+		if r.PlayerNum == pn {
+			return !r.Mucked || g.config.Retention == RetainShowdown
+		}
+	}
+
+	return false
+}
+
+// discardHands discards the hands that g doesn't retain from the hand that just ended
+func (g *Game) discardHands() {
+	if g.config.Retention == RetainAll {
+		return
+	}
+
+	start := g.handStartIndex()
+
+	for i := range g.players {
+		pn := uint(i)
+		if g.retains(pn) {
+			continue
+		}
+
+		g.players[pn].Cards = [2]eval.Card{0, 0}
+		g.players[pn].ThirdCard = 0
+		g.players[pn].ExtraCards = [2]eval.Card{}
+		g.players[pn].Discarded = 0
+
+		for j := start; j < len(g.events); j++ {
+			e := &g.events[j]
+			if e.PlayerNum == pn && (e.Kind == EventHoleCards || e.Kind == EventDownCard || e.Kind == EventDiscard) {
+				e.Cards = make([]eval.Card, len(e.Cards))
+			}
+		}
+	}
+}
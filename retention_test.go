@@ -0,0 +1,120 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestGame_Retention(t *testing.T) {
+	cards := func(s ...string) []eval.Card {
+		ret := []eval.Card{}
+		for _, c := range s {
+			ret = append(ret, eval.MustParseCardString(c))
+		}
+		return ret
+	}
+
+	tests := []struct {
+		retention Retention
+		// whether each player's hand is kept. Player 0 folds, player 1 shows, and player 2 mucks.
+		want [3]bool
+	}{
+		{RetainAll, [3]bool{true, true, true}},
+		{RetainShowdown, [3]bool{false, true, true}},
+		{RetainShown, [3]bool{false, true, false}},
+	}
+
+	for _, tt := range tests {
+		g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Retention: tt.retention})
+
+		for i := 0; i < 3; i++ {
+			pn := g.AddPlayer()
+			if err := BuyIn(g, pn, 1000); err != nil {
+				t.Fatalf("Test failed - Error buying in: %s", err)
+			}
+			if err := ToggleReady(g, pn, 0); err != nil {
+				t.Fatalf("Test failed - Error marking ready: %s", err)
+			}
+		}
+
+		if err := Deal(g, 0, 0); err != nil {
+			t.Fatalf("Test failed - error dealing: %s", err)
+		}
+
+		copy(g.players[0].Cards[:], cards("8s", "4h"))
+		copy(g.players[1].Cards[:], cards("Kh", "Kd"))
+		copy(g.players[2].Cards[:], cards("7c", "2d"))
+
+		// The rigged hole cards can't be dealt again
+		deck := eval.Deck{}
+		for _, c := range g.variant().deck() {
+			held := false
+			for _, p := range g.players {
+				held = held || c == p.Cards[0] || c == p.Cards[1]
+			}
+			if !held {
+				deck = append(deck, c)
+			}
+		}
+		g.deck = deck
+
+		if err := Fold(g, 0, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+		if err := Bet(g, 1, 15); err != nil {
+			t.Fatalf("Test failed - error calling: %s", err)
+		}
+		if err := Bet(g, 2, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+
+		// Each street is dealt as soon as the last one is checked through
+		for g.getStage() != PreDeal {
+			if g.getStage() == River {
+				copy(g.communityCards, cards("As", "9h", "5c", "Jd", "3s"))
+			}
+			for _, pn := range []uint{1, 2} {
+				if err := Bet(g, pn, 0); err != nil {
+					t.Fatalf("Test failed - error checking: %s", err)
+				}
+			}
+		}
+
+		view := g.GenerateOmniView()
+		for pn, kept := range tt.want {
+			if got := view.Players[pn].Cards != [2]eval.Card{0, 0}; got != kept {
+				t.Errorf("Test failed - with retention %d, expected player %d's hand kept to be %t", tt.retention, pn, kept)
+			}
+		}
+
+		for _, e := range g.Events() {
+			if e.Kind == EventHoleCards && (e.Cards[0] != 0) != tt.want[e.PlayerNum] {
+				t.Errorf("Test failed - with retention %d, expected player %d's hole cards kept in the log to be %t", tt.retention, e.PlayerNum, tt.want[e.PlayerNum])
+			}
+		}
+	}
+}
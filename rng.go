@@ -0,0 +1,113 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// RNG is what a Game shuffles its deck with. A *rand.Rand from math/rand satisfies it, as does NewCryptoRNG.
+type RNG interface {
+	// Shuffle pseudo-randomizes the order of n elements, by calling swap to swap the elements with indexes i and j
+	// (see math/rand's Shuffle).
+	Shuffle(n int, swap func(i, j int))
+}
+
+// SetRandSource makes g shuffle with rng from now on, instead of the math/rand generator seeded from g's
+// GameConfig.Seed. While g has an RNG of its own, its Seed no longer decides (or records) how the deck is shuffled,
+// so hands dealt with one can't be replayed. Passing nil goes back to the seeded generator.
+//
+// Tests can stack the deck with an RNG that leaves the order alone, and production servers can shuffle with
+// NewCryptoRNG.
+func (g *Game) SetRandSource(rng RNG) {
+	g.rng = rng
+}
+
+// shuffle resets g's deck to the full deck of the variant being dealt, in a random order
+func (g *Game) shuffle() {
+	if g.rng != nil {
+		// There's no seed to commit to
+		g.shuffleCommit = nil
+		g.deck = shuffledDeck(g.variant().deck(), g.rng)
+		return
+	}
+
+	if !g.config.CommitShuffle {
+		g.deck = shuffledDeck(g.variant().deck(), g.rand)
+		g.advanceRand()
+		return
+	}
+
+	if g.shuffleCommit == nil {
+		g.commitShuffle()
+	}
+	g.shuffleCommit.Variant = g.variant()
+	g.deck = shuffledDeck(g.variant().deck(), g.rand)
+}
+
+// shuffledDeck returns a copy of full, shuffled with rng
+func shuffledDeck(full eval.Deck, rng RNG) eval.Deck {
+	var deck eval.Deck
+	for i := 0; i < 3; i++ {
+		deck = append(eval.Deck{}, full...)
+		rng.Shuffle(len(deck), func(i, j int) { deck[i], deck[j] = deck[j], deck[i] })
+	}
+
+	return deck
+}
+
+type cryptoRNG struct{}
+
+// NewCryptoRNG returns an RNG that draws from the operating system's cryptographically secure random number
+// generator (see crypto/rand), for servers where nobody should be able to predict the deck. It panics if the
+// operating system's generator fails.
+func NewCryptoRNG() RNG {
+	return cryptoRNG{}
+}
+
+func (cryptoRNG) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		swap(i, int(cryptoUint64n(uint64(i+1))))
+	}
+}
+
+// cryptoUint64n returns a uniformly distributed number in [0, n), rejecting draws from the uneven tail of the
+// range so every number is equally likely
+func cryptoUint64n(n uint64) uint64 {
+	max := ^uint64(0) - ^uint64(0)%n
+	b := make([]byte, 8)
+
+	for {
+		if _, err := rand.Read(b); err != nil {
+			panic(err)
+		}
+
+		if v := binary.LittleEndian.Uint64(b); v < max {
+			return v % n
+		}
+	}
+}
@@ -0,0 +1,75 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// unshuffled is an RNG that leaves the deck as it is, for stacking it in tests
+type unshuffled struct{}
+
+func (unshuffled) Shuffle(n int, swap func(i, j int)) {}
+
+func dealtGame(t *testing.T, config *GameConfig, rng RNG) *Game {
+	g := seatedGame(t, config, 3, 100)
+	if rng != nil {
+		g.SetRandSource(rng)
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	return g
+}
+
+func TestGame_SetRandSource(t *testing.T) {
+	a := dealtGame(t, &GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 1}, unshuffled{})
+	b := dealtGame(t, &GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 2}, unshuffled{})
+
+	if !reflect.DeepEqual(a.players, b.players) || !reflect.DeepEqual(a.deck, b.deck) {
+		t.Errorf("Test failed - games that don't shuffle should deal the same cards, whatever their seeds")
+	}
+	if a.config.Seed != 1 {
+		t.Errorf("Test failed - the seed should be left alone while the game has its own RNG, got %d", a.config.Seed)
+	}
+
+	g := dealtGame(t, nil, NewCryptoRNG())
+
+	seen := map[eval.Card]bool{}
+	for _, c := range g.deck {
+		seen[c] = true
+	}
+	for _, p := range g.players {
+		seen[p.Cards[0]] = true
+		seen[p.Cards[1]] = true
+	}
+	if len(seen) != len(eval.DefaultDeck) {
+		t.Errorf("Test failed - the crypto RNG should deal every card once, got %d distinct cards", len(seen))
+	}
+}
@@ -0,0 +1,64 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// variant returns the Variant being dealt at the table: the current game of the config's Rotation if it has one,
+// and otherwise the config's Variant
+func (g *Game) variant() Variant {
+	if len(g.config.Rotation) == 0 {
+		return g.config.Variant
+	}
+
+	return g.config.Rotation[g.rotationNum%uint(len(g.config.Rotation))]
+}
+
+// rotate counts a finished hand, dealt to dealt players, toward the current game of the rotation, and moves the
+// table on to the next game once the current one has been played for RotateEvery hands (or for an orbit, if
+// RotateEvery is 0). Stacks carry across, since it is the same table.
+func (g *Game) rotate(dealt uint) {
+	if len(g.config.Rotation) == 0 {
+		return
+	}
+
+	g.rotationHands++
+
+	hands := g.config.RotateEvery
+	if hands == 0 {
+		hands = dealt
+	}
+
+	if g.rotationHands < hands {
+		return
+	}
+
+	g.rotationNum = (g.rotationNum + 1) % uint(len(g.config.Rotation))
+	g.rotationHands = 0
+	g.emit(Event{Kind: EventVariant, Variant: g.variant()})
+}
+
+// copyConfig returns a copy of c that shares no memory with it
+func copyConfig(c GameConfig) GameConfig {
+	c.Rotation = append([]Variant(nil), c.Rotation...)
+	return c
+}
@@ -0,0 +1,102 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+// playFoldedHand deals a hand of g and folds everybody around to the big blind
+func playFoldedHand(t *testing.T, g *Game) {
+	t.Helper()
+
+	if err := Deal(g, g.dealerNum, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	for g.getStage() != PreDeal {
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+}
+
+func TestGame_Rotation(t *testing.T) {
+	for _, every := range []uint{0, 2} {
+		config := defaultConfig
+		config.Rotation = []Variant{HoldEm, ShortDeck}
+		config.RotateEvery = every
+		g := NewGame(&config)
+
+		for i := 0; i < 3; i++ {
+			pn := g.AddPlayer()
+			if err := BuyIn(g, pn, 100); err != nil {
+				t.Fatalf("Test failed - Error buying in: %s", err)
+			}
+			if err := ToggleReady(g, pn, 0); err != nil {
+				t.Fatalf("Test failed - Error marking ready: %s", err)
+			}
+		}
+
+		// An orbit of three players is three hands
+		hands := int(every)
+		if hands == 0 {
+			hands = 3
+		}
+
+		for i := 0; i < hands; i++ {
+			if v := g.GenerateOmniView().Variant; v != HoldEm {
+				t.Fatalf("Test failed - hand %d should be hold'em, got variant %d", i, v)
+			}
+			playFoldedHand(t, g)
+		}
+
+		view := g.GenerateOmniView()
+		if view.Variant != ShortDeck || view.RotationNum != 1 || view.RotationHands != 0 {
+			t.Errorf("Test failed - expected to have rotated to short deck, got variant %d", view.Variant)
+		}
+
+		events := g.Events()
+		if e := events[len(events)-1]; e.Kind != EventVariant || e.Variant != ShortDeck {
+			t.Errorf("Test failed - expected the rotation to be recorded")
+		}
+
+		var chips uint
+		for _, p := range g.players {
+			chips += p.Stack
+		}
+		if chips != 300 {
+			t.Errorf("Test failed - stacks should carry across the rotation, got %d chips in total", chips)
+		}
+
+		playFoldedHand(t, g)
+		if want := len(ShortDeck.deck()) - 6; len(g.deck) != want {
+			t.Errorf("Test failed - expected a short deck hand to leave %d cards, got %d", want, len(g.deck))
+		}
+
+		restored := NewGame(nil)
+		restored.FillFromView(g.GenerateOmniView())
+		if restored.variant() != ShortDeck || restored.rotationHands != 1 {
+			t.Errorf("Test failed - the rotation should be restored from a view")
+		}
+	}
+}
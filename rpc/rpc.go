@@ -0,0 +1,300 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package rpc is a reference implementation of the Riverboat gRPC service defined in pb/service.proto, so that
+// clients written in any language can create and drive riverboat Games.
+//
+// Player numbers in requests are trusted: any caller may act for, or watch the censored view of, any player in any
+// Game. The service is meant to sit behind something that authenticates clients and maps them to their player
+// numbers, not to be exposed directly.
+//
+// Servers should install UnaryRecovery and StreamRecovery, so that a request that trips a bug in a Game fails on its
+// own rather than taking the whole process down with it:
+//
+//	srv := grpc.NewServer(grpc.UnaryInterceptor(rpc.UnaryRecovery), grpc.StreamInterceptor(rpc.StreamRecovery))
+//	pb.RegisterRiverboatServer(srv, rpc.New())
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/alexclewontin/riverboat"
+	"github.com/alexclewontin/riverboat/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Service implements pb.RiverboatServer. It is safe for concurrent use.
+// Services should not be initialized directly, only through the New factory function.
+type Service struct {
+	pb.UnimplementedRiverboatServer
+
+	mu     sync.Mutex
+	nextID uint64
+	games  map[string]*game
+}
+
+type game struct {
+	mu      sync.Mutex
+	game    *riverboat.Game
+	players uint
+	timer   *time.Timer
+	// changed is closed (and replaced) every time the game changes, waking any StreamViews calls watching it
+	changed chan struct{}
+}
+
+// New returns an empty Service. Register it with a grpc.Server using pb.RegisterRiverboatServer.
+func New() *Service {
+	return &Service{games: make(map[string]*game)}
+}
+
+// Game calls fn with the Game identified by gameID, while holding that Game's lock, so fn may safely inspect or
+// modify it. Every stream watching the Game is sent a fresh view afterwards. Game returns false if there is no such
+// Game.
+//
+// fn must not add players to the Game; use the AddPlayer RPC instead, so that the Service can validate their player
+// numbers.
+func (s *Service) Game(gameID string, fn func(g *riverboat.Game)) bool {
+	gm, err := s.game(gameID)
+	if err != nil {
+		return false
+	}
+
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	fn(gm.game)
+	gm.notify()
+
+	return true
+}
+
+// CreateGame starts a new Game, configured by req.Config if it is set, or with NewGame's defaults if not.
+func (s *Service) CreateGame(ctx context.Context, req *pb.CreateGameRequest) (*pb.CreateGameResponse, error) {
+	var config *riverboat.GameConfig
+	if req.GetConfig() != nil {
+		config = &riverboat.GameConfig{}
+		config.FromProto(req.GetConfig())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := strconv.FormatUint(s.nextID, 10)
+	s.games[id] = &game{game: riverboat.NewGame(config), changed: make(chan struct{})}
+
+	return &pb.CreateGameResponse{GameId: id}, nil
+}
+
+// AddPlayer seats a new player at the Game, and returns their player number. It fails with
+// codes.ResourceExhausted if every seat is taken (see riverboat.Game.SeatPlayer).
+func (s *Service) AddPlayer(ctx context.Context, req *pb.AddPlayerRequest) (*pb.AddPlayerResponse, error) {
+	gm, err := s.game(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	pn, err := gm.game.SeatPlayer()
+	if err != nil {
+		return nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+	gm.players++
+	gm.notify()
+
+	return &pb.AddPlayerResponse{PlayerNum: uint32(pn)}, nil
+}
+
+// Act performs the action named by req.Action (one of the keys of riverboat.ActionsByName) for the player, and
+// returns their view of the Game afterwards. Illegal actions fail with codes.FailedPrecondition, and leave the Game
+// unchanged. If req.DryRun is set, the action is only checked (see riverboat.ValidateAction), and the Game is
+// always left unchanged.
+func (s *Service) Act(ctx context.Context, req *pb.ActRequest) (*pb.ActResponse, error) {
+	action, ok := riverboat.ActionsByName[req.GetAction()]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown action %q", req.GetAction())
+	}
+
+	gm, err := s.game(req.GetGameId())
+	if err != nil {
+		return nil, err
+	}
+
+	gm.mu.Lock()
+	defer gm.mu.Unlock()
+
+	pn := uint(req.GetPlayerNum())
+	if err := gm.checkPlayer(pn); err != nil {
+		return nil, err
+	}
+
+	if req.GetDryRun() {
+		if err := riverboat.ValidateAction(gm.game, action, pn, uint(req.GetData())); err != nil {
+			return nil, actionError(err)
+		}
+
+		return &pb.ActResponse{View: gm.game.GeneratePlayerView(pn).ToProto()}, nil
+	}
+
+	if err := gm.game.Apply(action, pn, uint(req.GetData())); err != nil {
+		return nil, actionError(err)
+	}
+
+	gm.notify()
+
+	return &pb.ActResponse{View: gm.game.GeneratePlayerView(pn).ToProto()}, nil
+}
+
+// StreamViews sends the player's view of the Game right away, and again every time the Game changes, until the
+// client cancels the call. Views are not queued: a client that falls behind skips straight to the latest one.
+func (s *Service) StreamViews(req *pb.StreamViewsRequest, stream pb.Riverboat_StreamViewsServer) error {
+	gm, err := s.game(req.GetGameId())
+	if err != nil {
+		return err
+	}
+
+	pn := uint(req.GetPlayerNum())
+
+	for {
+		gm.mu.Lock()
+		if err := gm.checkPlayer(pn); err != nil {
+			gm.mu.Unlock()
+			return err
+		}
+		view := gm.game.GeneratePlayerView(pn).ToProto()
+		changed := gm.changed
+		gm.mu.Unlock()
+
+		if err := stream.Send(view); err != nil {
+			return err
+		}
+
+		select {
+		case <-changed:
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+func (s *Service) game(gameID string) (*game, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	gm, ok := s.games[gameID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown game %q", gameID)
+	}
+
+	return gm, nil
+}
+
+// checkPlayer returns an error if pn has not been assigned in the game. The game's lock must be held.
+func (gm *game) checkPlayer(pn uint) error {
+	if pn >= gm.players {
+		return status.Errorf(codes.InvalidArgument, "unknown player %d", pn)
+	}
+
+	return nil
+}
+
+// notify wakes every stream watching the game, and reschedules its next dealer duty or timeout, as it is called every
+// time the game changes. The game's lock must be held.
+func (gm *game) notify() {
+	close(gm.changed)
+	gm.changed = make(chan struct{})
+
+	gm.schedule()
+}
+
+// schedule arranges for the game to Advance when its next dealer duty is due, if it has AutoDeal turned on, or to
+// Timeout when the player it is waiting on runs out of time, if it has an ActionTime, whichever comes first. The
+// game's lock must be held.
+func (gm *game) schedule() {
+	if gm.timer != nil {
+		gm.timer.Stop()
+		gm.timer = nil
+	}
+
+	at, ok := gm.game.NextAdvance()
+	if deadline, timed := gm.game.ActionDeadline(); timed && (!ok || deadline.Before(at)) {
+		at, ok = deadline, true
+	}
+	if !ok {
+		return
+	}
+
+	gm.timer = time.AfterFunc(time.Until(at), func() {
+		gm.mu.Lock()
+		defer gm.mu.Unlock()
+
+		dealt, _ := gm.game.Advance()
+		timedOut, _ := gm.game.Timeout()
+		if dealt || timedOut {
+			gm.notify()
+		} else {
+			gm.schedule()
+		}
+	})
+}
+
+// UnaryRecovery is a grpc.UnaryServerInterceptor that turns a panic in the handler into a codes.Internal error
+func UnaryRecovery(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = status.Errorf(codes.Internal, "panic in %s: %v", info.FullMethod, r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// StreamRecovery is a grpc.StreamServerInterceptor that turns a panic in the handler into a codes.Internal error
+func StreamRecovery(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = status.Errorf(codes.Internal, "panic in %s: %v", info.FullMethod, r)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+// actionError maps an error returned by a riverboat Action to a gRPC status
+func actionError(err error) error {
+	switch err {
+	case riverboat.ErrRateLimited:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+}
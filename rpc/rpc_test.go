@@ -0,0 +1,219 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alexclewontin/riverboat/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func dial(t *testing.T) (pb.RiverboatClient, func()) {
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(UnaryRecovery), grpc.StreamInterceptor(StreamRecovery))
+	pb.RegisterRiverboatServer(srv, New())
+	go srv.Serve(lis)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithInsecure(),
+	)
+	if err != nil {
+		t.Fatalf("Test failed - could not dial: %v", err)
+	}
+
+	return pb.NewRiverboatClient(conn), func() {
+		conn.Close()
+		srv.Stop()
+	}
+}
+
+func TestService(t *testing.T) {
+	client, stop := dial(t)
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	created, err := client.CreateGame(ctx, &pb.CreateGameRequest{})
+	if err != nil {
+		t.Fatalf("Test failed - CreateGame: %v", err)
+	}
+	id := created.GetGameId()
+
+	for want := uint32(0); want < 2; want++ {
+		added, err := client.AddPlayer(ctx, &pb.AddPlayerRequest{GameId: id})
+		if err != nil || added.GetPlayerNum() != want {
+			t.Fatalf("Test failed - AddPlayer returned %v, %v; expected player %d", added, err, want)
+		}
+		for _, act := range []*pb.ActRequest{{Action: "buyIn", Data: 100}, {Action: "toggleReady"}} {
+			act.GameId, act.PlayerNum = id, want
+			if _, err := client.Act(ctx, act); err != nil {
+				t.Fatalf("Test failed - %s: %v", act.GetAction(), err)
+			}
+		}
+	}
+
+	stream, err := client.StreamViews(ctx, &pb.StreamViewsRequest{GameId: id, PlayerNum: 1})
+	if err != nil {
+		t.Fatalf("Test failed - StreamViews: %v", err)
+	}
+	if view, err := stream.Recv(); err != nil || view.GetStage() != pb.GameStage_PRE_DEAL {
+		t.Fatalf("Test failed - expected an initial PRE_DEAL view, got %v, %v", view, err)
+	}
+
+	codeTests := []struct {
+		req  *pb.ActRequest
+		code codes.Code
+	}{
+		{&pb.ActRequest{GameId: "nope", Action: "deal"}, codes.NotFound},
+		{&pb.ActRequest{GameId: id, Action: "shuffleUpAndDeal"}, codes.InvalidArgument},
+		{&pb.ActRequest{GameId: id, PlayerNum: 2, Action: "deal"}, codes.InvalidArgument},
+		{&pb.ActRequest{GameId: id, PlayerNum: 1, Action: "deal"}, codes.FailedPrecondition},
+		{&pb.ActRequest{GameId: id, PlayerNum: 1, Action: "deal", DryRun: true}, codes.FailedPrecondition},
+	}
+
+	for _, tt := range codeTests {
+		if _, err := client.Act(ctx, tt.req); status.Code(err) != tt.code {
+			t.Errorf("Test failed - %v returned %v; expected code %v", tt.req, err, tt.code)
+		}
+	}
+
+	checked, err := client.Act(ctx, &pb.ActRequest{GameId: id, PlayerNum: 0, Action: "deal", DryRun: true})
+	if err != nil {
+		t.Fatalf("Test failed - dry run deal: %v", err)
+	}
+	if checked.GetView().GetStage() != pb.GameStage_PRE_DEAL {
+		t.Errorf("Test failed - a dry run should not deal, got a %v view", checked.GetView().GetStage())
+	}
+
+	dealt, err := client.Act(ctx, &pb.ActRequest{GameId: id, PlayerNum: 0, Action: "deal"})
+	if err != nil {
+		t.Fatalf("Test failed - deal: %v", err)
+	}
+	if dealt.GetView().GetStage() != pb.GameStage_PRE_FLOP {
+		t.Errorf("Test failed - expected a PRE_FLOP view after dealing, got %v", dealt.GetView().GetStage())
+	}
+
+	// Player 1 sees the deal, but only their own cards
+	view, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Test failed - Recv: %v", err)
+	}
+	if view.GetStage() != pb.GameStage_PRE_FLOP {
+		t.Fatalf("Test failed - expected a PRE_FLOP view after dealing, got %v", view.GetStage())
+	}
+	if players := view.GetPlayers(); players[1].GetCards()[0] == 0 || players[0].GetCards()[0] != 0 {
+		t.Errorf("Test failed - player 1 should see only their own cards, got %v", players)
+	}
+}
+
+func TestService_FoldBeforeDeal(t *testing.T) {
+	client, stop := dial(t)
+	defer stop()
+
+	ctx := context.Background()
+
+	created, err := client.CreateGame(ctx, &pb.CreateGameRequest{})
+	if err != nil {
+		t.Fatalf("Test failed - CreateGame: %v", err)
+	}
+	id := created.GetGameId()
+	if _, err := client.AddPlayer(ctx, &pb.AddPlayerRequest{GameId: id}); err != nil {
+		t.Fatalf("Test failed - AddPlayer: %v", err)
+	}
+
+	// Folding before anything is dealt is just illegal, and the server keeps serving
+	if _, err := client.Act(ctx, &pb.ActRequest{GameId: id, Action: "fold"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("Test failed - expected a fold before the deal to fail with FailedPrecondition, got %v", err)
+	}
+	if _, err := client.CreateGame(ctx, &pb.CreateGameRequest{}); err != nil {
+		t.Errorf("Test failed - CreateGame after the fold: %v", err)
+	}
+}
+
+func TestService_Timeout(t *testing.T) {
+	client, stop := dial(t)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	config := &pb.GameConfig{BigBlind: 25, SmallBlind: 10, ActionTime: int64(20 * time.Millisecond)}
+	created, err := client.CreateGame(ctx, &pb.CreateGameRequest{Config: config})
+	if err != nil {
+		t.Fatalf("Test failed - CreateGame: %v", err)
+	}
+	id := created.GetGameId()
+
+	for pn := uint32(0); pn < 2; pn++ {
+		if _, err := client.AddPlayer(ctx, &pb.AddPlayerRequest{GameId: id}); err != nil {
+			t.Fatalf("Test failed - AddPlayer: %v", err)
+		}
+		for _, act := range []*pb.ActRequest{{Action: "buyIn", Data: 100}, {Action: "toggleReady"}} {
+			act.GameId, act.PlayerNum = id, pn
+			if _, err := client.Act(ctx, act); err != nil {
+				t.Fatalf("Test failed - %s: %v", act.GetAction(), err)
+			}
+		}
+	}
+
+	stream, err := client.StreamViews(ctx, &pb.StreamViewsRequest{GameId: id, PlayerNum: 1})
+	if err != nil {
+		t.Fatalf("Test failed - StreamViews: %v", err)
+	}
+	if _, err := client.Act(ctx, &pb.ActRequest{GameId: id, PlayerNum: 0, Action: "deal"}); err != nil {
+		t.Fatalf("Test failed - deal: %v", err)
+	}
+
+	// Nobody acts, so the server times out whoever the action is on, without being asked
+	for {
+		view, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Test failed - expected a player to time out, got %v", err)
+		}
+		for _, p := range view.GetPlayers() {
+			if p.GetAway() {
+				return
+			}
+		}
+	}
+}
+
+func TestUnaryRecovery(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/riverboat.Riverboat/Act"}
+	_, err := UnaryRecovery(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("Test failed - expected a panic to become codes.Internal, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// RuleSet holds the optional rules and features enabled at a table. It is part of GameConfig, so it is carried in
+// every view, and clients can render only the controls that are actually available at a table, instead of
+// hardcoding assumptions. Actions that depend on a disabled feature return ErrFeatureDisabled.
+type RuleSet struct {
+	// Emotes allows the Emote Action
+	Emotes bool `json:"emotes"`
+	// RabbitHunt allows players to request a rabbit hunt with Emote
+	RabbitHunt bool `json:"rabbitHunt"`
+	// DeadChips allows the PostDead Action
+	DeadChips bool `json:"deadChips"`
+	// OddChip decides who gets the leftover chips from a split pot
+	OddChip OddChipRule `json:"oddChip"`
+	// HiLo splits every pot at showdown between the best high hand and the best ace-to-five low, if any hand
+	// qualifies for low with eight or better. In Omaha, the low is made like the high hand, from exactly two hole
+	// cards and three community cards.
+	HiLo bool `json:"hiLo"`
+	// MissedBlinds tracks the blinds players miss while sitting out, or by joining a game in progress, and makes
+	// them post the blinds or wait for the big blind before they are dealt back in (see PostMissedBlinds)
+	MissedBlinds bool `json:"missedBlinds"`
+	// ShowOrMuck has players show or muck their hands at showdown for themselves, in turn (see Show), instead of the
+	// engine showing every hand that could win and mucking the rest
+	ShowOrMuck bool `json:"showOrMuck"`
+	// Undo lets players take back their last Bet or Fold with the Undo Action, until anything else happens at the
+	// table
+	Undo bool `json:"undo"`
+	// TableStakes stops players taking chips off the table mid-session ("ratholing"): a player who is removed with
+	// chips and comes back has to buy back in for at least the stack they left with (see Player.CashedOut)
+	TableStakes bool `json:"tableStakes"`
+	// HeadsUp decides which blind the button posts when only two players are dealt in
+	HeadsUp HeadsUpRule `json:"headsUp"`
+	// Straddle allows the Straddle Action, with which the button posts a Mississippi straddle
+	Straddle bool `json:"straddle"`
+}
+
+// HeadsUpRule decides how the blinds are placed when only two players are dealt in. Whatever the rule, the
+// button acts last after the flop (the first to act on every street after the first is the first player in the hand
+// to the button's left).
+type HeadsUpRule uint8
+
+const (
+	// HeadsUpButtonSmallBlind has the button post the small blind and act first before the flop, and the other
+	// player post the big blind and act first after it. This is the usual rule, and the default.
+	HeadsUpButtonSmallBlind HeadsUpRule = iota
+	// HeadsUpButtonBigBlind has the button post the big blind, so it acts last on every street, and the other player
+	// post the small blind and act first on every street (an older rule still played in some home games)
+	HeadsUpButtonBigBlind
+)
+
+// DefaultRuleSet is the RuleSet used by NewGame when it is not passed a config
+var DefaultRuleSet = RuleSet{
+	Emotes:     true,
+	RabbitHunt: true,
+	DeadChips:  true,
+	OddChip:    OddChipLeftOfButton,
+}
@@ -0,0 +1,139 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// ChangeSeat moves a player to another seat. For ChangeSeat, data is the number of the seat to move to. Seats are
+// numbered from 1, up to the configured number of Seats, if there is one. Play goes around the table in seat
+// order, so moving seats moves the player's place at the table, but not their player number. A player who has left
+// gives up their seat, and has to change seats to come back if somebody else has taken it since. ChangeSeat will
+// return an error if the player is ready (players can only change seats while they are not being dealt in), if the
+// seat doesn't exist (ErrBadSeat), or if somebody else is sitting in it (ErrSeatTaken).
+func ChangeSeat(g *Game, pn uint, data uint) error {
+	p := g.getPlayer(pn)
+
+	if p.Ready {
+		return ErrIllegalAction
+	}
+
+	if data == 0 || (g.config.Seats != 0 && data > g.config.Seats) {
+		return ErrBadSeat
+	}
+
+	if g.seatTaken(data, pn) {
+		return ErrSeatTaken
+	}
+
+	p.SeatNum = data
+
+	g.emit(Event{Kind: EventSeat, PlayerNum: pn, Amount: data})
+
+	return nil
+}
+
+// SeatPlayer adds a new player to the Game, like AddPlayer, but only if there is a free seat for them: if every one
+// of the table's Seats is taken, SeatPlayer returns ErrTableFull, and the player isn't added.
+func (g *Game) SeatPlayer() (uint, error) {
+	if g.freeSeat() == 0 {
+		return 0, ErrTableFull
+	}
+
+	return g.AddPlayer(), nil
+}
+
+// seatTaken reports whether seat is taken by a player other than pn. Players who have left don't hold a seat.
+func (g *Game) seatTaken(seat uint, pn uint) bool {
+	for i, p := range g.players {
+		if uint(i) != pn && p.SeatNum == seat && !p.Left {
+			return true
+		}
+	}
+
+	return false
+}
+
+// freeSeat returns the lowest numbered seat nobody is sitting in, or 0 if every seat is taken
+func (g *Game) freeSeat() uint {
+	for seat := uint(1); g.config.Seats == 0 || seat <= g.config.Seats; seat++ {
+		if !g.seatTaken(seat, uint(len(g.players))) {
+			return seat
+		}
+	}
+
+	return 0
+}
+
+// seatedBefore reports whether player a comes before player b in seat order. Players in the same seat (only ever
+// possible when one of them has left) are ordered by player number.
+func (g *Game) seatedBefore(a uint, b uint) bool {
+	if sa, sb := g.players[a].SeatNum, g.players[b].SeatNum; sa != sb {
+		return sa < sb
+	}
+
+	return a < b
+}
+
+// next returns the player seated after pn, going around the table
+func (g *Game) next(pn uint) uint {
+	next, first := pn, pn
+
+	for i := range g.players {
+		q := uint(i)
+		if g.seatedBefore(q, first) {
+			first = q
+		}
+		if g.seatedBefore(pn, q) && (next == pn || g.seatedBefore(q, next)) {
+			next = q
+		}
+	}
+
+	if next == pn {
+		return first
+	}
+
+	return next
+}
+
+// seatOrder returns every player, in the order they are seated around the table, starting with pn
+func (g *Game) seatOrder(pn uint) []uint {
+	order := []uint{pn}
+	for q := g.next(pn); q != pn; q = g.next(q) {
+		order = append(order, q)
+	}
+
+	return order
+}
+
+// migrateSeats seats players in order of player number if none of them has a seat, as players in views from before
+// seats were numbered had none. It is safe to apply to players that are already seated.
+func migrateSeats(players []Player) {
+	for _, p := range players {
+		if p.SeatNum != 0 {
+			return
+		}
+	}
+
+	for i := range players {
+		players[i].SeatNum = uint(i) + 1
+	}
+}
@@ -0,0 +1,142 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestGame_Seats(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seats: 4})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+	}
+
+	// Player 0 moves to the empty seat 4, putting them to the left of player 2
+	if err := ChangeSeat(g, 0, 5); err != ErrBadSeat {
+		t.Errorf("Test failed - moving to a seat the table doesn't have should fail, got %v", err)
+	}
+	if err := ChangeSeat(g, 0, 2); err != ErrSeatTaken {
+		t.Errorf("Test failed - moving to a taken seat should fail, got %v", err)
+	}
+	if err := ChangeSeat(g, 0, 4); err != nil {
+		t.Fatalf("Test failed - error changing seats: %s", err)
+	}
+
+	if got := g.seatOrder(1); !reflect.DeepEqual(got, []uint{1, 2, 0}) {
+		t.Errorf("Test failed - expected the players to be seated in the order [1 2 0], got %v", got)
+	}
+
+	// The new player takes the seat player 0 left
+	if pn := g.AddPlayer(); g.players[pn].SeatNum != 1 {
+		t.Errorf("Test failed - expected the new player to take seat 1, got %d", g.players[pn].SeatNum)
+	}
+
+	for pn := uint(0); pn < 3; pn++ {
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := ChangeSeat(g, 0, 1); err != ErrIllegalAction {
+		t.Errorf("Test failed - changing seats while ready should fail, got %v", err)
+	}
+
+	// Player 1 deals, so player 2 posts the small blind, and player 0 the big blind
+	g.dealerNum = 1
+	g.updateBlindNums()
+	if err := Deal(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if g.sbNum != 2 || g.bbNum != 0 || g.actionNum != 1 {
+		t.Errorf("Test failed - expected blinds on players 2 and 0, and the action on 1, got %d, %d and %d", g.sbNum, g.bbNum, g.actionNum)
+	}
+
+	// Once every seat is taken, new players wait for one, and can't play until they have it
+	pn := g.AddPlayer()
+	if g.players[pn].SeatNum != 0 {
+		t.Errorf("Test failed - expected the new player not to have a seat, got %d", g.players[pn].SeatNum)
+	}
+	if err := BuyIn(g, pn, 1000); err != nil {
+		t.Fatalf("Test failed - Error buying in: %s", err)
+	}
+	if err := ToggleReady(g, pn, 0); err != ErrSeatTaken {
+		t.Errorf("Test failed - readying without a seat should fail, got %v", err)
+	}
+
+	if _, err := g.SeatPlayer(); err != ErrTableFull || len(g.players) != 5 {
+		t.Errorf("Test failed - expected a full table to turn new players away, got %v", err)
+	}
+}
+
+func TestGame_SeatPlayerHeadsUp(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seats: 2})
+
+	for i := 0; i < 2; i++ {
+		pn, err := g.SeatPlayer()
+		if err != nil {
+			t.Fatalf("Test failed - error seating player %d: %s", i, err)
+		}
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if _, err := g.SeatPlayer(); err != ErrTableFull {
+		t.Errorf("Test failed - expected a third player to be turned away from a heads-up table, got %v", err)
+	}
+
+	// Heads up, the dealer posts the small blind
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if g.sbNum != g.dealerNum || g.actionNum != g.dealerNum {
+		t.Errorf("Test failed - expected the dealer to post the small blind and act first, got %d, %d and %d", g.dealerNum, g.sbNum, g.actionNum)
+	}
+
+	// Once a player leaves, their seat can be taken
+	if _, err := g.RemovePlayer(1); err != nil {
+		t.Fatalf("Test failed - error removing player: %s", err)
+	}
+	if pn, err := g.SeatPlayer(); err != nil || g.players[pn].SeatNum != 2 {
+		t.Errorf("Test failed - expected the new player to take the free seat, got %v", err)
+	}
+}
+
+func TestGameView_MigrateSeats(t *testing.T) {
+	g := NewGame(nil)
+	g.FillFromView(&GameView{Players: make([]Player, 3), CommunityCards: make([]eval.Card, 5)})
+
+	for pn, p := range g.players {
+		if p.SeatNum != uint(pn)+1 {
+			t.Errorf("Test failed - expected player %d to be seated in seat %d, got %d", pn, pn+1, p.SeatNum)
+		}
+	}
+}
@@ -0,0 +1,501 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package server is a reference implementation of a WebSocket table server for riverboat. Clients connect to a
+// table, receive their own view of the Game (see riverboat.Game.GeneratePlayerView) every time it changes, and submit
+// Actions as JSON messages.
+//
+// A client connects with a WebSocket request to the Server's URL, naming the table in the "table" query parameter. The
+// table is created the first time anyone connects to it, and closed, along with its Game, once everybody has
+// disconnected from it. The new client is added to the table's Game as a player, and
+// is sent a "joined" message with its player number, followed by a "view" message. From then on, the client may send
+// ClientMessages, like
+//
+//	{"action": "buyIn", "data": 1000}
+//
+// If the Action succeeds, every client at the table is sent a "view" message with its updated view. If it fails, only
+// the client that sent it is sent an "error" message. When a client disconnects, it Leaves the Game.
+//
+// A client that connects with the "spectate" query parameter set (like "spectate=1") watches the table instead: it
+// is not added to the Game, is sent spectator views (see riverboat.Game.GenerateSpectatorView), and can't send
+// Actions.
+//
+// Clients that only need part of the table state can name the GameView fields they want in the "fields" query
+// parameter (like "fields=pots,actionNum"). They are sent "delta" messages in place of "view" messages, holding
+// only those fields, and only when they have changed. Clients that want the whole table state, but not a whole view
+// every time it changes, can set the "patches" query parameter (like "patches=1") instead. They are sent one "view"
+// message, and then "patch" messages that turn each view into the next (see riverboat.GameView.Patch).
+//
+// Clients that set the "events" query parameter (like "events=1") are also sent an "events" message after each
+// update, holding every Event since the last one, as the client should see it (see riverboat.Event.For): players see
+// their own hole cards, but not anybody else's.
+//
+// A Server can host tables for several tenants (see Tenant), which name theirs in the "tenant" query parameter.
+//
+// If the Server's GameConfig has AutoDeal turned on, the Server deals each hand itself (see riverboat.Game.Advance),
+// and nobody needs to send "deal". If it has an ActionTime, the Server also times out players who take too long to
+// act (see riverboat.Game.Timeout).
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/alexclewontin/riverboat"
+	"github.com/gorilla/websocket"
+)
+
+// Actions maps the action names accepted in a ClientMessage to the riverboat Actions they perform
+var Actions = riverboat.ActionsByName
+
+// The types of ServerMessage
+const (
+	TypeJoined = "joined"
+	TypeView   = "view"
+	TypeDelta  = "delta"
+	TypeError  = "error"
+	TypeValid  = "valid"
+	TypePatch  = "patch"
+	TypeEvents = "events"
+)
+
+// The player number spectators see Events as (see riverboat.Event.For), which is nobody's
+const spectatorNum = ^uint(0)
+
+// How many outgoing messages may be queued for a client before it is considered too slow, and disconnected
+const sendBuffer = 32
+
+// ClientMessage is a message from a client, asking to perform an Action. Action is one of the keys of Actions,
+// and Data is passed to the Action as data. If DryRun is set, the Action is only checked (see
+// riverboat.ValidateAction), not performed, and the server replies with TypeValid or TypeError.
+type ClientMessage struct {
+	Action string `json:"action"`
+	Data   uint   `json:"data"`
+	DryRun bool   `json:"dryRun"`
+}
+
+// ServerMessage is a message to a client. Type is one of TypeJoined (PlayerNum is the client's player number),
+// TypeView (View is the client's current view of the Game), TypeDelta (Delta holds the fields the client asked for
+// that have changed), TypeError (Error describes why the client's last message failed), TypeValid (the client's
+// last message was a dry run of an Action that would have succeeded), TypePatch (Patch turns the client's last
+// view into its current one), or TypeEvents (Events holds what has happened since the client's last update, as the
+// client should see it).
+type ServerMessage struct {
+	Type      string               `json:"type"`
+	PlayerNum uint                 `json:"playerNum"`
+	View      *riverboat.GameView  `json:"view,omitempty"`
+	Delta     riverboat.ViewDelta  `json:"delta,omitempty"`
+	Patch     *riverboat.ViewPatch `json:"patch,omitempty"`
+	Events    []riverboat.Event    `json:"events,omitempty"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// Server hosts riverboat Games over WebSocket. It is an http.Handler, and is safe for concurrent use.
+// Servers should not be initialized directly, only through the New factory function.
+type Server struct {
+	mu       sync.Mutex
+	config   *riverboat.GameConfig
+	tables   map[tableKey]*table
+	tenants  map[string]Tenant
+	upgrader websocket.Upgrader
+}
+
+type table struct {
+	mu      sync.Mutex
+	game    *riverboat.Game
+	clients map[*client]bool
+	timer   *time.Timer
+	// closed is set once the table has been closed, so its Game is no longer scheduled
+	closed bool
+	// conns is how many clients are connected to the table, counting those still joining it. Unlike the rest of the
+	// table, it is guarded by the Server's lock.
+	conns int
+}
+
+type client struct {
+	conn      *websocket.Conn
+	pn        uint
+	spectator bool
+	send      chan ServerMessage
+	filter    *riverboat.ViewFilter
+	patches   bool
+	last      *riverboat.GameView
+	events    bool
+	eventSeq  uint64
+}
+
+// New returns a Server whose tables are created with config (or NewGame's defaults, if config is nil).
+// checkOrigin is passed through to the underlying websocket.Upgrader; if it is nil, only same-origin requests
+// are accepted.
+func New(config *riverboat.GameConfig, checkOrigin func(r *http.Request) bool) *Server {
+	return &Server{
+		config:   config,
+		tables:   make(map[tableKey]*table),
+		tenants:  make(map[string]Tenant),
+		upgrader: websocket.Upgrader{CheckOrigin: checkOrigin},
+	}
+}
+
+// Game calls fn with the Game being played at the named table of the default tenant, while holding that table's
+// lock, so fn may safely inspect or modify it. Every client at the table is sent a fresh view afterwards. Game
+// returns false if there is no such table.
+func (s *Server) Game(tableID string, fn func(g *riverboat.Game)) bool {
+	return s.TenantGame("", tableID, fn)
+}
+
+// TenantGame is Game for the named table of the named tenant.
+func (s *Server) TenantGame(tenant string, tableID string, fn func(g *riverboat.Game)) bool {
+	s.mu.Lock()
+	t, ok := s.tables[tableKey{tenant, tableID}]
+	s.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	fn(t.game)
+	t.broadcast()
+
+	return true
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection, and seats the client at the table named by the
+// "table" query parameter, of the tenant named by the "tenant" query parameter.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := tableKey{tenant: r.URL.Query().Get("tenant"), table: r.URL.Query().Get("table")}
+	if key.table == "" {
+		http.Error(w, "missing table", http.StatusBadRequest)
+		return
+	}
+
+	var filter *riverboat.ViewFilter
+	if fields := r.URL.Query().Get("fields"); fields != "" {
+		f, err := riverboat.ParseViewFields(fields)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter = riverboat.NewViewFilter(f)
+	}
+
+	s.mu.Lock()
+	_, err := s.checkTable(key)
+	s.mu.Unlock()
+	switch err {
+	case nil:
+	case ErrUnknownTenant:
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied to the client
+		return
+	}
+
+	// The table is only opened once the client is connected, so a failed handshake doesn't use up the tenant's
+	// tables. Another client may have opened the last one in the meantime, though.
+	t, err := s.table(key)
+	if err != nil {
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, err.Error()))
+		conn.Close()
+		return
+	}
+	defer s.release(key, t)
+	c := &client{
+		conn:      conn,
+		spectator: r.URL.Query().Get("spectate") != "",
+		send:      make(chan ServerMessage, sendBuffer),
+		filter:    filter,
+		patches:   r.URL.Query().Get("patches") != "",
+		events:    r.URL.Query().Get("events") != "",
+	}
+
+	go c.writeLoop()
+
+	t.mu.Lock()
+	t.clients[c] = true
+	c.eventSeq = t.game.LastEventSeq()
+	if c.spectator {
+		c.update(t)
+	} else {
+		c.pn = t.game.AddPlayer()
+		c.queue(ServerMessage{Type: TypeJoined, PlayerNum: c.pn})
+		t.broadcast()
+	}
+	t.mu.Unlock()
+
+	c.readLoop(t)
+
+	t.mu.Lock()
+	delete(t.clients, c)
+	close(c.send)
+	if !c.spectator {
+		if err := riverboat.Leave(t.game, c.pn, 0); err == nil {
+			t.broadcast()
+		}
+	}
+	t.mu.Unlock()
+}
+
+// table returns the table identified by key, opening it if it isn't open yet, for a client to connect to. It returns
+// an error if the tenant doesn't exist, or can't open any more tables. Every table returned must be released.
+func (s *Server) table(key tableKey) (*table, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenant, err := s.checkTable(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if t, ok := s.tables[key]; ok {
+		t.conns++
+		return t, nil
+	}
+
+	var config *riverboat.GameConfig
+	if tenant.Config != nil {
+		c := *tenant.Config
+		config = &c
+	}
+
+	t := &table{game: riverboat.NewGame(config), clients: make(map[*client]bool), conns: 1}
+	s.tables[key] = t
+
+	return t, nil
+}
+
+// checkTable returns the settings of the tenant the table identified by key belongs to, or an error if the table
+// isn't open, and can't be opened: if the tenant doesn't exist, or can't open any more tables. The Server's lock must
+// be held.
+func (s *Server) checkTable(key tableKey) (Tenant, error) {
+	tenant, err := s.tenant(key.tenant)
+	if err != nil {
+		return Tenant{}, err
+	}
+
+	if _, ok := s.tables[key]; ok {
+		return tenant, nil
+	}
+
+	if tenant.MaxTables != 0 && s.tableCount(key.tenant) >= tenant.MaxTables {
+		return Tenant{}, ErrTableLimit
+	}
+
+	return tenant, nil
+}
+
+// release is called when a client disconnects from t, the table identified by key. Once nobody is connected, the
+// table is closed.
+func (s *Server) release(key tableKey, t *table) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.conns--; t.conns > 0 {
+		return
+	}
+	delete(s.tables, key)
+
+	t.mu.Lock()
+	t.closed = true
+	t.schedule()
+	t.mu.Unlock()
+}
+
+// broadcast sends every client at the table its current view, and reschedules the Game's next dealer duty, as
+// it is called every time the Game changes. The table's lock must be held.
+func (t *table) broadcast() {
+	t.schedule()
+
+	for c := range t.clients {
+		c.update(t)
+	}
+}
+
+// update queues the client's current view of the table's Game, or, if it has a filter, whatever has changed in it,
+// followed by the Events it hasn't been sent yet if it asked for them. The table's lock must be held.
+func (c *client) update(t *table) {
+	c.updateView(t)
+
+	if c.events {
+		pn := c.pn
+		if c.spectator {
+			pn = spectatorNum
+		}
+
+		if events := t.game.PlayerEventsSince(pn, c.eventSeq); len(events) > 0 {
+			c.queue(ServerMessage{Type: TypeEvents, PlayerNum: c.pn, Events: events})
+			c.eventSeq = events[len(events)-1].Seq
+		}
+	}
+}
+
+func (c *client) updateView(t *table) {
+	var view *riverboat.GameView
+	if c.spectator {
+		view = t.game.GenerateSpectatorView()
+	} else {
+		view = t.game.GeneratePlayerView(c.pn)
+	}
+
+	if c.filter != nil {
+		if delta := c.filter.Delta(view); len(delta) > 0 {
+			c.queue(ServerMessage{Type: TypeDelta, PlayerNum: c.pn, Delta: delta})
+		}
+		return
+	}
+
+	if c.patches && c.last != nil {
+		if patch, err := riverboat.DiffViews(c.last, view); err == nil {
+			if len(patch.Fields) > 0 {
+				c.queue(ServerMessage{Type: TypePatch, PlayerNum: c.pn, Patch: patch})
+			}
+			c.last = view
+			return
+		}
+	}
+
+	c.queue(ServerMessage{Type: TypeView, PlayerNum: c.pn, View: view})
+	if c.patches {
+		c.last = view
+	}
+}
+
+// schedule arranges for the table's Game to Advance when its next dealer duty is due, if it has AutoDeal turned on,
+// and to Timeout when the player the action is on runs out of time, if it has action timers. The table's lock must
+// be held.
+func (t *table) schedule() {
+	if t.timer != nil {
+		t.timer.Stop()
+		t.timer = nil
+	}
+	if t.closed {
+		return
+	}
+
+	at, ok := t.game.NextAdvance()
+	if deadline, timed := t.game.ActionDeadline(); timed && (!ok || deadline.Before(at)) {
+		at, ok = deadline, true
+	}
+	if !ok {
+		return
+	}
+
+	t.timer = time.AfterFunc(time.Until(at), func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+
+		dealt, _ := t.game.Advance()
+		timedOut, _ := t.game.Timeout()
+		if dealt || timedOut {
+			t.broadcast()
+		} else {
+			t.schedule()
+		}
+	})
+}
+
+// readLoop handles the client's messages until the connection is closed
+func (c *client) readLoop(t *table) {
+	for {
+		_, b, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(b, &msg); err != nil {
+			c.queueLocked(t, ServerMessage{Type: TypeError, PlayerNum: c.pn, Error: "malformed message"})
+			continue
+		}
+
+		if c.spectator {
+			c.queueLocked(t, ServerMessage{Type: TypeError, Error: "spectators can't act"})
+			continue
+		}
+
+		action, ok := Actions[msg.Action]
+		if !ok {
+			c.queueLocked(t, ServerMessage{Type: TypeError, PlayerNum: c.pn, Error: "unknown action"})
+			continue
+		}
+
+		c.act(t, action, msg)
+	}
+}
+
+// act performs, or with msg.DryRun only checks, the client's action, while holding the table's lock
+func (c *client) act(t *table, action riverboat.Action, msg ClientMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if msg.DryRun {
+		if err := riverboat.ValidateAction(t.game, action, c.pn, msg.Data); err != nil {
+			c.queue(ServerMessage{Type: TypeError, PlayerNum: c.pn, Error: err.Error()})
+		} else {
+			c.queue(ServerMessage{Type: TypeValid, PlayerNum: c.pn})
+		}
+	} else if err := t.game.Apply(action, c.pn, msg.Data); err != nil {
+		c.queue(ServerMessage{Type: TypeError, PlayerNum: c.pn, Error: err.Error()})
+	} else {
+		t.broadcast()
+	}
+}
+
+// writeLoop sends queued messages to the client until its send channel is closed
+func (c *client) writeLoop() {
+	for msg := range c.send {
+		if err := c.conn.WriteJSON(msg); err != nil {
+			break
+		}
+	}
+
+	c.conn.Close()
+}
+
+// queue queues msg to be sent to the client. If the client has fallen too far behind, its connection is closed,
+// which ends its readLoop. The table's lock must be held.
+func (c *client) queue(msg ServerMessage) {
+	select {
+	case c.send <- msg:
+	default:
+		c.conn.Close()
+	}
+}
+
+func (c *client) queueLocked(t *table, msg ServerMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c.queue(msg)
+}
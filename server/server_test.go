@@ -0,0 +1,339 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexclewontin/riverboat"
+	"github.com/gorilla/websocket"
+)
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+// readUntil reads messages until one of the given type arrives
+func readUntil(t *testing.T, conn *websocket.Conn, msgType string) ServerMessage {
+	for {
+		var msg ServerMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("ReadJSON() error = %v", err)
+		}
+		if msg.Type == msgType {
+			return msg
+		}
+	}
+}
+
+func TestServer(t *testing.T) {
+	s := New(nil, nil)
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/?table=t1"
+
+	a := dial(t, url)
+	defer a.Close()
+	if msg := readUntil(t, a, TypeJoined); msg.PlayerNum != 0 {
+		t.Errorf("first client joined as player %d", msg.PlayerNum)
+	}
+
+	b := dial(t, url)
+	defer b.Close()
+	if msg := readUntil(t, b, TypeJoined); msg.PlayerNum != 1 {
+		t.Errorf("second client joined as player %d", msg.PlayerNum)
+	}
+
+	for _, conn := range []*websocket.Conn{a, b} {
+		conn.WriteJSON(ClientMessage{Action: "buyIn", Data: 100})
+		readUntil(t, conn, TypeView)
+		conn.WriteJSON(ClientMessage{Action: "toggleReady"})
+		readUntil(t, conn, TypeView)
+	}
+
+	// Player 1 isn't the dealer
+	b.WriteJSON(ClientMessage{Action: "deal"})
+	if msg := readUntil(t, b, TypeError); msg.Error != riverboat.ErrIllegalAction.Error() {
+		t.Errorf("expected an illegal action error, got %+v", msg)
+	}
+
+	// Folding before the deal is illegal, and leaves the table free for the next message
+	b.WriteJSON(ClientMessage{Action: "fold"})
+	if msg := readUntil(t, b, TypeError); msg.Error != riverboat.ErrIllegalAction.Error() {
+		t.Errorf("expected an illegal action error folding before the deal, got %+v", msg)
+	}
+
+	b.WriteJSON(ClientMessage{Action: "shuffleUpAndDeal"})
+	if msg := readUntil(t, b, TypeError); msg.Error != "unknown action" {
+		t.Errorf("expected an unknown action error, got %+v", msg)
+	}
+
+	a.WriteJSON(ClientMessage{Action: "deal"})
+
+	// Player 1 sees the deal, but only their own cards
+	var view *riverboat.GameView
+	for view == nil || view.Stage != riverboat.PreFlop {
+		view = readUntil(t, b, TypeView).View
+	}
+
+	if view.Players[1].Cards[0] == 0 || view.Players[0].Cards[0] != 0 {
+		t.Errorf("player 1 should see only their own cards, got %+v", view.Players)
+	}
+
+	// A spectator isn't seated, sees nobody's cards, and can't act
+	c := dial(t, url+"&spectate=1")
+	defer c.Close()
+	view = readUntil(t, c, TypeView).View
+	if len(view.Players) != 2 || view.Players[0].Cards[0] != 0 || view.Players[1].Cards[0] != 0 {
+		t.Errorf("a spectator should see two players, and none of their cards, got %+v", view.Players)
+	}
+
+	c.WriteJSON(ClientMessage{Action: "buyIn", Data: 100})
+	if msg := readUntil(t, c, TypeError); msg.Error != "spectators can't act" {
+		t.Errorf("expected a spectator error, got %+v", msg)
+	}
+
+	if !s.Game("t1", func(g *riverboat.Game) {}) || s.Game("t2", func(g *riverboat.Game) {}) {
+		t.Error("Game() should only find tables that exist")
+	}
+}
+
+func TestServer_Fields(t *testing.T) {
+	ts := httptest.NewServer(New(nil, nil))
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/?table=t1"
+
+	if _, _, err := websocket.DefaultDialer.Dial(url+"&fields=nope", nil); err == nil {
+		t.Error("connecting with an unknown field should fail")
+	}
+
+	a := dial(t, url+"&fields=stage,readyCount")
+	defer a.Close()
+
+	if msg := readUntil(t, a, TypeDelta); len(msg.Delta) != 2 {
+		t.Errorf("the first delta should hold both fields, got %+v", msg.Delta)
+	}
+
+	a.WriteJSON(ClientMessage{Action: "buyIn", Data: 100})
+	a.WriteJSON(ClientMessage{Action: "toggleReady"})
+
+	// Buying in changes nothing asked for, so the next delta is from readying up
+	msg := readUntil(t, a, TypeDelta)
+	if _, ok := msg.Delta["stage"]; ok || msg.Delta["readyCount"] != float64(1) {
+		t.Errorf("expected only the new ready count, got %+v", msg.Delta)
+	}
+}
+
+func TestServer_Patches(t *testing.T) {
+	ts := httptest.NewServer(New(nil, nil))
+	defer ts.Close()
+
+	a := dial(t, "ws"+strings.TrimPrefix(ts.URL, "http")+"/?table=t1&patches=1")
+	defer a.Close()
+
+	view := readUntil(t, a, TypeView).View
+
+	a.WriteJSON(ClientMessage{Action: "buyIn", Data: 100})
+
+	msg := readUntil(t, a, TypePatch)
+	if err := view.Patch(msg.Patch); err != nil {
+		t.Fatalf("Patch() error = %v", err)
+	}
+	if view.Players[msg.PlayerNum].Stack != 100 {
+		t.Errorf("the patched view should hold the new stack, got %+v", view.Players)
+	}
+}
+
+func TestServer_Events(t *testing.T) {
+	ts := httptest.NewServer(New(nil, nil))
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/?table=t1&events=1"
+
+	a := dial(t, url)
+	defer a.Close()
+	b := dial(t, url)
+	defer b.Close()
+
+	pn := readUntil(t, a, TypeJoined).PlayerNum
+
+	for _, c := range []*websocket.Conn{a, b} {
+		c.WriteJSON(ClientMessage{Action: "buyIn", Data: 100})
+		c.WriteJSON(ClientMessage{Action: "toggleReady"})
+	}
+	a.WriteJSON(ClientMessage{Action: "deal"})
+
+	for {
+		msg := readUntil(t, a, TypeEvents)
+
+		dealt := false
+		for _, e := range msg.Events {
+			if e.Kind != riverboat.EventHoleCards {
+				continue
+			}
+			dealt = true
+
+			if (e.PlayerNum == pn) != (len(e.Cards) == 2) {
+				t.Errorf("player %d should only be sent their own hole cards, got %+v", pn, e)
+			}
+		}
+
+		if dealt {
+			break
+		}
+	}
+}
+
+func TestServer_AutoDeal(t *testing.T) {
+	config := riverboat.GameConfig{BigBlind: 25, SmallBlind: 10, AutoDeal: true}
+	ts := httptest.NewServer(New(&config, nil))
+	defer ts.Close()
+
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/?table=t1"
+
+	a := dial(t, url)
+	defer a.Close()
+	b := dial(t, url)
+	defer b.Close()
+
+	for _, conn := range []*websocket.Conn{a, b} {
+		conn.WriteJSON(ClientMessage{Action: "buyIn", Data: 100})
+		conn.WriteJSON(ClientMessage{Action: "toggleReady"})
+	}
+
+	// Nobody deals, but the hand starts anyway
+	var view *riverboat.GameView
+	for view == nil || view.Stage != riverboat.PreFlop {
+		view = readUntil(t, a, TypeView).View
+	}
+}
+
+func TestServer_Tenants(t *testing.T) {
+	s := New(nil, nil)
+	s.AddTenant("club", Tenant{Config: &riverboat.GameConfig{BigBlind: 50, SmallBlind: 25}, MaxTables: 1})
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	base := "ws" + strings.TrimPrefix(ts.URL, "http") + "/?table=t1"
+
+	if _, _, err := websocket.DefaultDialer.Dial(base+"&tenant=nope", nil); err == nil {
+		t.Error("connecting to an unknown tenant should fail")
+	}
+
+	a := dial(t, base+"&tenant=club")
+	defer a.Close()
+	readUntil(t, a, TypeJoined)
+
+	// The default tenant's t1 is a different table
+	b := dial(t, base)
+	defer b.Close()
+	if msg := readUntil(t, b, TypeJoined); msg.PlayerNum != 0 {
+		t.Errorf("the default tenant's table should be empty, but the client joined as player %d", msg.PlayerNum)
+	}
+
+	if view := readUntil(t, a, TypeView).View; view.Config.BigBlind != 50 || len(view.Players) != 1 {
+		t.Errorf("the club's table should use its own config, and only hold its own player, got %+v", view)
+	}
+
+	if _, _, err := websocket.DefaultDialer.Dial(strings.Replace(base, "t1", "t2", 1)+"&tenant=club", nil); err == nil {
+		t.Error("the club shouldn't be able to open more than one table")
+	}
+
+	if s.TableCount("club") != 1 || s.TableCount("") != 1 {
+		t.Errorf("expected one table for each tenant, got %d and %d", s.TableCount("club"), s.TableCount(""))
+	}
+
+	if !s.TenantGame("club", "t1", func(g *riverboat.Game) {}) || s.TenantGame("club", "t2", func(g *riverboat.Game) {}) {
+		t.Error("TenantGame() should only find the tenant's tables")
+	}
+}
+
+func TestServer_TenantDefaultConfig(t *testing.T) {
+	s := New(&riverboat.GameConfig{BigBlind: 50, SmallBlind: 25}, nil)
+	s.AddTenant("club", Tenant{MaxTables: 3})
+	s.AddTenant("", Tenant{MaxTables: 2})
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	base := "ws" + strings.TrimPrefix(ts.URL, "http") + "/?table=t1"
+
+	for _, url := range []string{base + "&tenant=club", base} {
+		conn := dial(t, url)
+		defer conn.Close()
+
+		if view := readUntil(t, conn, TypeView).View; view.Config.BigBlind != 50 {
+			t.Errorf("a tenant without a config should use the Server's, got a big blind of %d at %s",
+				view.Config.BigBlind, url)
+		}
+	}
+}
+
+func TestServer_TableLimit(t *testing.T) {
+	s := New(nil, nil)
+	s.AddTenant("club", Tenant{MaxTables: 1})
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	// A request that isn't a WebSocket handshake doesn't open a table
+	resp, err := http.Get(ts.URL + "/?table=t1&tenant=club")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if s.TableCount("club") != 0 {
+		t.Errorf("a failed handshake shouldn't open a table, got %d open", s.TableCount("club"))
+	}
+
+	base := "ws" + strings.TrimPrefix(ts.URL, "http") + "/?tenant=club&table="
+
+	a := dial(t, base+"t1")
+	readUntil(t, a, TypeJoined)
+	if _, _, err := websocket.DefaultDialer.Dial(base+"t2", nil); err == nil {
+		t.Error("the club shouldn't be able to open a second table while the first is open")
+	}
+
+	// Once everybody has left t1, it is closed, and the club can open another table
+	a.Close()
+	for deadline := time.Now().Add(5 * time.Second); s.TableCount("club") != 0; {
+		if time.Now().After(deadline) {
+			t.Fatalf("TableCount() = %d after the last client disconnected, want 0", s.TableCount("club"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	b := dial(t, base+"t2")
+	defer b.Close()
+	readUntil(t, b, TypeJoined)
+}
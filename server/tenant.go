@@ -0,0 +1,101 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package server
+
+import (
+	"errors"
+
+	"github.com/alexclewontin/riverboat"
+)
+
+// ErrUnknownTenant is returned when a tenant is named that hasn't been added to the Server.
+var ErrUnknownTenant = errors.New("no such tenant")
+
+// ErrTableLimit is returned when a tenant that already has as many tables as its MaxTables allows tries to open
+// another one.
+var ErrTableLimit = errors.New("the tenant has reached its table limit")
+
+// Tenant is a group of tables hosted by a Server for one club, skin, or other customer, kept apart from every
+// other tenant's: tables are named within the tenant, so two tenants can each have a table of the same name, and
+// the clients at a table only ever see that table. Config is used in place of the Server's config for the tenant's
+// tables (nil is the Server's), and MaxTables, if not 0, is the most tables the tenant may have open at once. A table
+// is open from the time the first client connects to it until the last one disconnects.
+//
+// Requests name their tenant in the "tenant" query parameter. Requests that don't name one are for the default
+// tenant, which uses the Server's config and has no table limit unless it is set with AddTenant("", ...).
+type Tenant struct {
+	Config    *riverboat.GameConfig
+	MaxTables int
+}
+
+// tableKey identifies a table on a Server
+type tableKey struct {
+	tenant string
+	table  string
+}
+
+// AddTenant adds the named tenant to the Server, or updates its settings if it has already been added. Changes to
+// Config only apply to tables opened afterwards, and lowering MaxTables never closes any.
+func (s *Server) AddTenant(name string, tenant Tenant) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tenants[name] = tenant
+}
+
+// TableCount returns how many tables the named tenant has open.
+func (s *Server) TableCount(tenant string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tableCount(tenant)
+}
+
+// tableCount is TableCount for callers holding the Server's lock.
+func (s *Server) tableCount(tenant string) int {
+	count := 0
+	for k := range s.tables {
+		if k.tenant == tenant {
+			count++
+		}
+	}
+
+	return count
+}
+
+// tenant returns the named tenant's settings. The Server's lock must be held.
+func (s *Server) tenant(name string) (Tenant, error) {
+	if t, ok := s.tenants[name]; ok {
+		if t.Config == nil {
+			t.Config = s.config
+		}
+		return t, nil
+	}
+
+	if name == "" {
+		return Tenant{Config: s.config}, nil
+	}
+
+	return Tenant{}, ErrUnknownTenant
+}
@@ -0,0 +1,84 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// SessionSummary sums up how a player's session at the table has gone, for hosts to settle up with at the end of
+// the night (see Game.SessionSummary).
+type SessionSummary struct {
+	PlayerNum uint `json:"playerNum"`
+	// HandsPlayed is how many hands the player has been dealt into that are over (a hand that is misdealt never is)
+	HandsPlayed uint `json:"handsPlayed"`
+	// Net is how many chips the player is up (or down, if it is negative): what they have at the table, including
+	// anything committed to a hand in progress, plus what they have cashed out, less what they have bought in for
+	Net int `json:"net"`
+	// BiggestPot is the most the player has won in a single hand
+	BiggestPot uint `json:"biggestPot"`
+	// ShowdownsWon is how many hands the player has won at least part of a pot in at showdown
+	ShowdownsWon uint `json:"showdownsWon"`
+	// TotalBuyIn is everything the player has bought in for, and Rebuys how many times they have bought in after
+	// the first. A tournament add-on isn't a rebuy (see Tournament.AddOn).
+	TotalBuyIn uint `json:"totalBuyIn"`
+	Rebuys     uint `json:"rebuys"`
+}
+
+// SessionSummary returns a summary of the session so far for every player who has joined the Game, including those
+// who have left, by player number. It is kept on the players themselves (see Player.HandsPlayed), so it survives the
+// Game being saved and restored from a view.
+func (g *Game) SessionSummary() []SessionSummary {
+	summaries := make([]SessionSummary, len(g.players))
+	for i, p := range g.players {
+		summaries[i] = SessionSummary{
+			PlayerNum:    uint(i),
+			HandsPlayed:  p.HandsPlayed,
+			Net:          int(p.Stack+p.TotalBet+p.DeadChips+p.TotalCashOut) - int(p.TotalBuyIn),
+			BiggestPot:   p.BiggestPot,
+			ShowdownsWon: p.ShowdownsWon,
+			TotalBuyIn:   p.TotalBuyIn,
+			Rebuys:       p.Rebuys,
+		}
+	}
+
+	return summaries
+}
+
+// tallyHand counts the hand that is ending in the session of every player dealt into it: won is what each player won,
+// and showdown is true if the hand went to showdown. It must be called before the hands g doesn't retain are
+// discarded, as the players dealt in are the ones who still hold cards.
+func (g *Game) tallyHand(won map[uint]uint, showdown bool) {
+	for i := range g.players {
+		if g.players[i].Cards[0] != 0 {
+			g.players[i].HandsPlayed++
+		}
+	}
+
+	for pn, amt := range won {
+		p := g.getPlayer(pn)
+		if amt > p.BiggestPot {
+			p.BiggestPot = amt
+		}
+		if showdown {
+			p.ShowdownsWon++
+		}
+	}
+}
@@ -0,0 +1,112 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGame_SessionSummary(t *testing.T) {
+	g := NewGame(&defaultConfig)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	act := func(a Action, pn uint, data uint) {
+		t.Helper()
+		if err := a(g, pn, data); err != nil {
+			t.Fatalf("Test failed - error acting for player %d: %s", pn, err)
+		}
+	}
+
+	// Player 0 raises, and takes down the blinds
+	act(Deal, 0, 0)
+	act(Bet, 0, 75)
+	act(Fold, 1, 0)
+	act(Fold, 2, 0)
+
+	// Player 1 rebuys, and the next hand is checked down to a showdown
+	act(BuyIn, 1, 100)
+	act(Deal, 1, 0)
+	act(Bet, 1, 25)
+	act(Bet, 2, 15)
+	act(Bet, 0, 0)
+	for g.getStage() != PreDeal {
+		act(Bet, g.actionNum, 0)
+	}
+
+	// A misdealt hand isn't played
+	act(Deal, 2, 0)
+	act(Misdeal, 2, 0)
+
+	summaries := g.SessionSummary()
+	if len(summaries) != 3 {
+		t.Fatalf("Test failed - expected a summary for each of 3 players, got %d", len(summaries))
+	}
+
+	var net, showdowns int
+	for i, s := range summaries {
+		if s.PlayerNum != uint(i) || s.HandsPlayed != 2 {
+			t.Errorf("Test failed - expected player %d to have played 2 hands, got %+v", i, s)
+		}
+		net += s.Net
+		showdowns += int(s.ShowdownsWon)
+	}
+
+	if net != 0 {
+		t.Errorf("Test failed - expected the players' net results to add up to 0, got %d", net)
+	}
+	if showdowns == 0 {
+		t.Errorf("Test failed - expected somebody to have won the showdown")
+	}
+	if s := summaries[0]; s.BiggestPot < 110 {
+		t.Errorf("Test failed - expected player 0's biggest pot to be at least 110, got %d", s.BiggestPot)
+	}
+	if s := summaries[1]; s.Rebuys != 1 || s.TotalBuyIn != 1100 || summaries[0].Rebuys != 0 {
+		t.Errorf("Test failed - expected player 1 to have rebought once for a total of 1100, got %+v", s)
+	}
+
+	// The summary survives the Game being saved and restored, as JSON and as protobuf
+	b, err := json.Marshal(g.GenerateOmniView())
+	if err != nil {
+		t.Fatalf("Test failed - error marshalling the view: %s", err)
+	}
+	var view GameView
+	if err := json.Unmarshal(b, &view); err != nil {
+		t.Fatalf("Test failed - error unmarshalling the view: %s", err)
+	}
+	var fromProto GameView
+	fromProto.FromProto(view.ToProto())
+
+	restored := &Game{}
+	restored.FillFromView(&fromProto)
+	if got := restored.SessionSummary(); !reflect.DeepEqual(got, summaries) {
+		t.Errorf("Test failed - expected the restored summary to be %+v, got %+v", summaries, got)
+	}
+}
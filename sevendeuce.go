@@ -0,0 +1,79 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "github.com/alexclewontin/riverboat/eval"
+
+// The ranks of the seven and the deuce, as encoded in an eval.Card
+const (
+	rankDeuce = 0
+	rankSeven = 5
+)
+
+// holdsSevenDeuce reports whether player pn's hole cards are a seven and a deuce, suited or not
+func (g *Game) holdsSevenDeuce(pn uint) bool {
+	p := g.players[pn]
+	r0, r1 := (p.Cards[0]>>8)&0xF, (p.Cards[1]>>8)&0xF
+
+	return p.Cards[0] != 0 && p.Cards[1] != 0 &&
+		((r0 == rankSeven && r1 == rankDeuce) || (r0 == rankDeuce && r1 == rankSeven))
+}
+
+// paySevenDeuce pays the seven-deuce bounty, if the table plays it, to each of the players who won a pot this hand
+// (who may be listed more than once) holding seven-deuce. Everybody else dealt into the hand pays, as much of the
+// bounty as they have left.
+func (g *Game) paySevenDeuce(winners []uint) {
+	bounty := g.config.SevenDeuceBounty
+	if bounty == 0 || g.variant().stud() {
+		return
+	}
+
+	paid := map[uint]bool{}
+	for _, pn := range winners {
+		if paid[pn] || !g.holdsSevenDeuce(pn) {
+			continue
+		}
+		paid[pn] = true
+
+		var collected uint
+		for i := range g.players {
+			// startStacks is only set for the players dealt in
+			if uint(i) == pn || i >= len(g.startStacks) || g.startStacks[i] == 0 {
+				continue
+			}
+
+			amt := bounty
+			if amt > g.players[i].Stack {
+				amt = g.players[i].Stack
+			}
+			g.players[i].Stack -= amt
+			collected += amt
+		}
+
+		g.players[pn].Stack += collected
+
+		cards := []eval.Card{g.players[pn].Cards[0], g.players[pn].Cards[1]}
+		g.emit(Event{Kind: EventSevenDeuce, PlayerNum: pn, Amount: collected, Cards: cards})
+	}
+}
@@ -0,0 +1,108 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// rigHoleCards gives player pn the cards, swapping them with wherever they are now (the deck, or another player's
+// hand), so no card is dealt twice
+func rigHoleCards(g *Game, pn uint, cards ...string) {
+	for i, s := range cards {
+		c := eval.MustParseCardString(s)
+		old := g.players[pn].Cards[i]
+
+		for j := range g.deck {
+			if g.deck[j] == c {
+				g.deck[j] = old
+			}
+		}
+		for q := range g.players {
+			for k := range g.players[q].Cards {
+				if g.players[q].Cards[k] == c {
+					g.players[q].Cards[k] = old
+				}
+			}
+		}
+
+		g.players[pn].Cards[i] = c
+	}
+}
+
+func TestGame_SevenDeuce(t *testing.T) {
+	config := defaultConfig
+	config.SevenDeuceBounty = 50
+	g := NewGame(&config)
+
+	for _, stack := range []uint{1000, 1000, 60} {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, stack)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	rigHoleCards(g, 0, "7c", "2d")
+
+	// Player 0 raises, and both blinds fold to them
+	if err := Bet(g, 0, 100); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+	for _, pn := range []uint{1, 2} {
+		if err := Fold(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+
+	// Player 2 only has 35 left after posting the big blind
+	if got := []uint{g.players[0].Stack, g.players[1].Stack, g.players[2].Stack}; got[0] != 1120 || got[1] != 940 || got[2] != 0 {
+		t.Errorf("Test failed - expected stacks of [1120 940 0], got %v", got)
+	}
+
+	events := g.Events()
+	if e := events[len(events)-2]; e.Kind != EventSevenDeuce || e.PlayerNum != 0 || e.Amount != 85 || len(e.Cards) != 2 {
+		t.Errorf("Test failed - expected an EventSevenDeuce for 85, got %+v", e)
+	}
+
+	// Winning with anything else collects nothing. Heads up, the dealer posts the small blind and wins the big one.
+	before := []uint{g.players[0].Stack, g.players[1].Stack}
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	winner := g.actionNum
+	rigHoleCards(g, winner, "7c", "3d")
+	if err := Bet(g, winner, g.toCall()-g.players[winner].Bet+25); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+	if err := Fold(g, g.actionNum, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+	if got := g.players[winner].Stack; got != before[winner]+25 {
+		t.Errorf("Test failed - expected only the big blind to be won, got %d from %d", got, before[winner])
+	}
+}
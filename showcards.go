@@ -0,0 +1,63 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// ShowCards is the Action for turning hole cards face up between hands, like a player who won without a showdown,
+// or who folded, showing what they had. For ShowCards, data says which of the player's Cards to show: 1 for the
+// first, 2 for the second, or 3 for both (in Omaha, only the first two of the four can be shown this way). The cards stay face up in everybody's view until the next hand is dealt.
+// ShowCards returns an error if a hand is being played, the player has no such cards from the last hand (they
+// weren't dealt in, or the Game didn't retain them, see Retention), or data isn't 1, 2 or 3.
+func ShowCards(g *Game, pn uint, data uint) error {
+	p := g.getPlayer(pn)
+
+	if g.getStage() != PreDeal || data == 0 || data > 3 {
+		return ErrIllegalAction
+	}
+
+	shown := []eval.Card{}
+	for i := range p.Cards {
+		if data&(1<<uint(i)) == 0 {
+			continue
+		}
+
+		if p.Cards[i] == 0 {
+			return ErrIllegalAction
+		}
+		shown = append(shown, p.Cards[i])
+	}
+
+	for i := range p.Cards {
+		if data&(1<<uint(i)) != 0 {
+			p.Shown[i] = true
+		}
+	}
+
+	g.emit(Event{Kind: EventShowCards, PlayerNum: pn, Cards: shown})
+
+	return nil
+}
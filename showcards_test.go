@@ -0,0 +1,91 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+)
+
+func TestShowCards(t *testing.T) {
+	g := NewGame(nil)
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	if err := ShowCards(g, pn_a, 3); err != ErrIllegalAction {
+		t.Errorf("Test failed - cards should not be shown during a hand, got %v", err)
+	}
+
+	folder := g.actionNum
+	winner := g.next(folder)
+	if err := Fold(g, folder, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	if view := g.GeneratePlayerView(folder); view.Players[winner].Cards[0] != 0 {
+		t.Fatalf("Test failed - a hand won without a showdown should be hidden, got %v", view.Players[winner].Cards)
+	}
+
+	for _, data := range []uint{0, 4} {
+		if err := ShowCards(g, winner, data); err != ErrIllegalAction {
+			t.Errorf("Test failed - ShowCards should reject %d, got %v", data, err)
+		}
+	}
+
+	if err := ShowCards(g, winner, 2); err != nil {
+		t.Fatalf("Test failed - error showing: %s", err)
+	}
+
+	cards := g.players[winner].Cards
+	if got := g.GeneratePlayerView(folder).Players[winner].Cards; got[0] != 0 || got[1] != cards[1] {
+		t.Errorf("Test failed - only the second card should be face up, got %v", got)
+	}
+
+	// The folded hand can be shown too
+	if err := ShowCards(g, folder, 3); err != nil {
+		t.Fatalf("Test failed - error showing: %s", err)
+	}
+	if got := g.GenerateSpectatorView().Players[folder].Cards; got != g.players[folder].Cards {
+		t.Errorf("Test failed - both folded cards should be face up, got %v", got)
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if got := g.GeneratePlayerView(folder).Players[winner].Cards; got[1] != 0 {
+		t.Errorf("Test failed - shown cards should be hidden again once the next hand is dealt, got %v", got)
+	}
+}
@@ -0,0 +1,336 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// ShowdownReveal is a single step of a showdown: one player either showing or mucking their hand.
+// The engine determines the complete, ordered sequence of reveals when a hand goes to showdown,
+// so every audience sees the same narrative in the same order. Cards and Score are only meaningful
+// if Mucked is false (and Cards are hidden from mucked reveals in player views).
+type ShowdownReveal struct {
+	PlayerNum uint         `json:"playerNum"`
+	Mucked    bool         `json:"mucked"`
+	Cards     [2]eval.Card `json:"cards"`
+	// ExtraCards are the third and fourth hole cards shown in Omaha, or the third in Razz (see Player.ExtraCards)
+	ExtraCards [2]eval.Card `json:"extraCards"`
+	Score      int          `json:"score"`
+}
+
+// holeCards returns every hole card shown
+func (r ShowdownReveal) holeCards() []eval.Card {
+	return holeCards(r.Cards, r.ExtraCards)
+}
+
+// Show is the Action for showing a hand at showdown, at tables that play RuleSet.ShowOrMuck, and Muck the Action for
+// giving it up instead. Once the river betting is over, the player who made the last bet or raise on the river (or the
+// first player to act, if it was checked through) shows first. Then each of the other players still in the hand, in
+// order around the table, either shows or mucks. A player who mucks gives up any claim to the pots, unless everybody
+// who could have won a pot mucked, in which case the best of their hands wins it. Players who are all in, or away, show
+// when their turn comes without being asked. Once everybody has shown or mucked, the pots are awarded to the best hands
+// shown. Show and Muck return ErrFeatureDisabled if the table doesn't play ShowOrMuck, and ErrIllegalAction if the
+// hand isn't waiting on pn to show or muck. They ignore data.
+func Show(g *Game, pn uint, data uint) error {
+	return g.decideShowdown(pn, false)
+}
+
+// Muck is the Action for giving up a hand at showdown, rather than showing it (see Show). A mucked hand is never
+// turned face up: it stays hidden in every other player's view (see GeneratePlayerView), and in their Events (see
+// PlayerEventsSince), whose EventMuck holds no cards.
+func Muck(g *Game, pn uint, data uint) error {
+	return g.decideShowdown(pn, true)
+}
+
+func (g *Game) decideShowdown(pn uint, muck bool) error {
+	if !g.config.Rules.ShowOrMuck {
+		return ErrFeatureDisabled
+	}
+
+	if !g.showdownPending() || g.showdownOrder[0] != pn {
+		return ErrIllegalAction
+	}
+
+	g.recordDecision(pn)
+	g.reveal(pn, muck)
+
+	return g.continueShowdown()
+}
+
+// showdownPending returns true if the hand is waiting on players to show or muck
+func (g *Game) showdownPending() bool {
+	return len(g.showdownOrder) > 0
+}
+
+// startShowdown puts the players still in the hand in the order they show or muck, and has the first of them show
+func (g *Game) startShowdown() error {
+	g.setBetting(false)
+	g.showdown = []ShowdownReveal{}
+	g.showdownOrder = []uint{}
+
+	for _, pn := range g.seatOrder(g.calledNum) {
+		if g.players[pn].In {
+			g.showdownOrder = append(g.showdownOrder, pn)
+		}
+	}
+
+	// Somebody has to show first, so they can't muck
+	g.reveal(g.showdownOrder[0], false)
+
+	return g.continueShowdown()
+}
+
+// continueShowdown shows the hands of the players who show without being asked, until it is the turn of one who
+// decides for themselves, or the hand is over, in which case it awards the pots
+func (g *Game) continueShowdown() error {
+	for g.showdownPending() {
+		pn := g.showdownOrder[0]
+		if !g.allIn(pn) && !g.players[pn].Away {
+			g.actionNum = pn
+			g.markActionAvailable()
+			return nil
+		}
+
+		g.reveal(pn, false)
+	}
+
+	return g.finishShowdown()
+}
+
+// reveal shows or mucks player pn's hand, and takes them out of the showdown order
+func (g *Game) reveal(pn uint, muck bool) {
+	g.leaveShowdown(pn)
+
+	r := ShowdownReveal{PlayerNum: pn, Mucked: muck}
+	if muck {
+		g.emit(Event{Kind: EventMuck, PlayerNum: pn})
+	} else {
+		r.Cards, r.ExtraCards = g.players[pn].Cards, g.players[pn].ExtraCards
+		r.Score = g.showdownScore(pn)
+		g.emit(Event{Kind: EventShow, PlayerNum: pn, Cards: r.holeCards()})
+	}
+
+	g.showdown = append(g.showdown, r)
+}
+
+// leaveShowdown takes pn out of the showdown order, if they are in it. It returns true if they were.
+func (g *Game) leaveShowdown(pn uint) bool {
+	for i, num := range g.showdownOrder {
+		if num == pn {
+			g.showdownOrder = append(g.showdownOrder[:i:i], g.showdownOrder[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// mucked returns true if pn has mucked at this hand's showdown
+func (g *Game) mucked(pn uint) bool {
+	for _, r := range g.showdown {
+		if r.PlayerNum == pn {
+			return r.Mucked
+		}
+	}
+
+	return false
+}
+
+// claimants returns the players who can win pot: those eligible for it who are still in, and haven't mucked, or if
+// every one of them has mucked, all of them
+func (g *Game) claimants(pot *Pot) []uint {
+	in := []uint{}
+	shown := []uint{}
+	for _, pn := range pot.EligiblePlayerNums {
+		if !g.players[pn].In {
+			continue
+		}
+
+		in = append(in, pn)
+		if !g.mucked(pn) {
+			shown = append(shown, pn)
+		}
+	}
+
+	if len(shown) == 0 {
+		return in
+	}
+
+	return shown
+}
+
+// finishShowdown awards the pots to the best hands, and ends the hand
+func (g *Game) finishShowdown() error {
+	// The chips carried over from the last hand are in the main pot now, and any odd chips from this one replace them
+	var carryover uint
+
+	for i := range g.pots {
+		claimants := g.claimants(&g.pots[i])
+
+		for j, num := range claimants {
+			hand, score := g.variant().bestHand(g.players[num].holeCards(), g.board(num))
+			// lower is better for the score
+			if j == 0 || score < g.pots[i].WinningScore {
+				g.pots[i].WinningScore = score
+				g.pots[i].WinningPlayerNums = []uint{num}
+				g.pots[i].WinningHand = hand
+			} else if score == g.pots[i].WinningScore {
+				g.pots[i].WinningPlayerNums = append(g.pots[i].WinningPlayerNums, num)
+			}
+		}
+
+		if g.hiLo() {
+			g.findLowWinners(&g.pots[i], claimants)
+		}
+
+		awards, carry := g.potAwards(&g.pots[i])
+		for _, award := range awards {
+			g.players[award.playerNum].Stack += award.amt
+		}
+		carryover += carry
+	}
+
+	g.carryover = carryover
+	g.potWinner, g.potWon = g.mainPotWinner()
+	g.potScooped = g.potWon && g.hiLo()
+
+	// Players who chose for themselves have already shown or mucked
+	if !g.config.Rules.ShowOrMuck {
+		g.computeShowdown()
+
+		for _, r := range g.showdown {
+			if r.Mucked {
+				g.emit(Event{Kind: EventMuck, PlayerNum: r.PlayerNum})
+			} else {
+				g.emit(Event{Kind: EventShow, PlayerNum: r.PlayerNum, Cards: r.holeCards()})
+			}
+		}
+	}
+
+	g.trackHighHand()
+
+	winners := []uint{}
+	won := map[uint]uint{}
+	for i := range g.pots {
+		awards, _ := g.potAwards(&g.pots[i])
+		for _, award := range awards {
+			g.emit(Event{Kind: EventPotAward, PlayerNum: award.playerNum, PotNum: uint(i), Amount: award.amt, Low: award.low})
+			winners = append(winners, award.playerNum)
+			won[award.playerNum] += award.amt
+		}
+	}
+
+	g.paySevenDeuce(winners)
+	g.recordKnockouts(winners)
+	g.tallyHand(won, true)
+	g.discardHands()
+	g.emit(Event{Kind: EventHandEnd})
+
+	return g.resetForNextHand()
+}
+
+// mainPotWinner returns the player who won the whole of the main pot, if one did: in hi-lo games, that means both
+// halves of it
+func (g *Game) mainPotWinner() (uint, bool) {
+	if len(g.pots) == 0 || len(g.pots[0].WinningPlayerNums) != 1 {
+		return 0, false
+	}
+
+	pn := g.pots[0].WinningPlayerNums[0]
+	for _, low := range g.pots[0].LowWinningPlayerNums {
+		if low != pn {
+			return 0, false
+		}
+	}
+
+	return pn, true
+}
+
+// showdownScore returns the score of player pn's best hand (lower is better)
+func (g *Game) showdownScore(pn uint) int {
+	_, score := g.variant().bestHand(g.players[pn].holeCards(), g.board(pn))
+
+	return score
+}
+
+// board returns the cards player pn makes their hand from besides their hole cards: the community cards, or in
+// stud, their up cards
+func (g *Game) board(pn uint) []eval.Card {
+	if g.variant().stud() {
+		return g.players[pn].UpCards[:]
+	}
+
+	return g.communityCards
+}
+
+// computeShowdown determines the order in which players reveal their hands at the end of a hand that
+// has gone to showdown. The last player to bet or raise on the river (or the first player to act, if the
+// river was checked through) shows first. Action then proceeds around the table, and each remaining player
+// shows only if their hand ties or beats the best hand shown so far; otherwise they muck. A player who wins any
+// pot must show to claim it, so they show regardless. This must be called after the pots have been awarded.
+func (g *Game) computeShowdown() {
+	g.showdown = []ShowdownReveal{}
+
+	winners := make(map[uint]bool)
+	for _, pot := range g.pots {
+		for _, pn := range pot.WinningPlayerNums {
+			winners[pn] = true
+		}
+		for _, pn := range pot.LowWinningPlayerNums {
+			winners[pn] = true
+		}
+	}
+
+	scoreToBeat := -1
+
+	for _, pn := range g.seatOrder(g.calledNum) {
+		p := g.players[pn]
+
+		if !p.In {
+			continue
+		}
+
+		score := g.showdownScore(pn)
+
+		reveal := ShowdownReveal{PlayerNum: pn}
+
+		// lower is better for the score
+		if scoreToBeat == -1 || score <= scoreToBeat || winners[pn] {
+			reveal.Cards, reveal.ExtraCards = p.Cards, p.ExtraCards
+			reveal.Score = score
+			if scoreToBeat == -1 || score < scoreToBeat {
+				scoreToBeat = score
+			}
+		} else {
+			reveal.Mucked = true
+		}
+
+		g.showdown = append(g.showdown, reveal)
+	}
+}
+
+func copyShowdown(src []ShowdownReveal) []ShowdownReveal {
+	return append([]ShowdownReveal{}, src...)
+}
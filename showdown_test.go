@@ -0,0 +1,110 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+)
+
+func TestShowOrMuck(t *testing.T) {
+	config := defaultConfig
+	config.Rules.ShowOrMuck = true
+	g := NewGame(&config)
+	// Unshuffled, player 0 is dealt AS KS, player 1 QS JS, and the board is TS 9S 8S 7S 6S, so player 1 has the nuts
+	g.SetRandSource(unshuffled{})
+
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := Bet(g, g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	for g.getBetting() {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+	}
+
+	if !g.showdownPending() || len(g.showdown) != 1 || g.showdown[0].Mucked {
+		t.Fatalf("Test failed - the first player should have shown, and the showdown should wait on the other, got %+v", g.showdown)
+	}
+
+	shower := g.showdown[0].PlayerNum
+	other := g.showdownOrder[0]
+	if other == shower || g.actionNum != other {
+		t.Fatalf("Test failed - the showdown should wait on the player who hasn't shown, got %v", g.showdownOrder)
+	}
+
+	if view := g.GeneratePlayerView(other); view.Players[shower].Cards != g.players[shower].Cards {
+		t.Errorf("Test failed - the shown hand should be face up, got %v", view.Players[shower].Cards)
+	}
+	if err := Deal(g, g.dealingNum(), 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - the next hand should not be dealt during the showdown, got %v", err)
+	}
+	if err := Show(g, shower, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a player should not show out of turn, got %v", err)
+	}
+
+	stacks := []uint{g.players[0].Stack, g.players[1].Stack}
+	if err := Muck(g, other, 0); err != nil {
+		t.Fatalf("Test failed - error mucking: %s", err)
+	}
+
+	if g.getStage() != PreDeal {
+		t.Fatalf("Test failed - the hand should be over once everybody has shown or mucked")
+	}
+	if g.players[shower].Stack != stacks[shower]+50 || g.players[other].Stack != stacks[other] {
+		t.Errorf("Test failed - the only hand shown should win the pot, whatever the mucked hand was, got stacks %d and %d", g.players[0].Stack, g.players[1].Stack)
+	}
+	if view := g.GeneratePlayerView(shower); view.Players[other].Cards[0] != 0 {
+		t.Errorf("Test failed - the mucked hand should stay hidden, got %v", view.Players[other].Cards)
+	}
+
+	for _, e := range g.PlayerEventsSince(shower, 0) {
+		if e.PlayerNum == other && (e.Kind == EventHoleCards || e.Kind == EventMuck) && len(e.Cards) != 0 {
+			t.Errorf("Test failed - the mucked hand should stay hidden in the hand history, got %+v", e)
+		}
+	}
+}
+
+func TestMuck_Disabled(t *testing.T) {
+	g := NewGame(nil)
+	pn := g.AddPlayer()
+
+	if err := Muck(g, pn, 0); err != ErrFeatureDisabled {
+		t.Errorf("Test failed - Muck should be disabled at tables that don't play ShowOrMuck, got %v", err)
+	}
+}
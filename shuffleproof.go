@@ -0,0 +1,142 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	mathrand "math/rand"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// How many random bytes are hashed along with each seed, so the commitment can't be reversed by trying every seed
+const saltLen = 16
+
+// ShuffleCommitment lets players check that a hand was dealt from a deck that was decided before it started (see
+// GameConfig.CommitShuffle). Commitment is the hex-encoded SHA-256 hash of the hand's Seed (as 8 big-endian bytes)
+// followed by its Salt (hex-encoded), and is published before the hand is dealt. Once the hand is over, the rest is
+// revealed: the Seed and Salt, the Variant dealt (which decides the deck shuffled), and every card Dealt from the
+// deck, in order. VerifyShuffle checks them.
+type ShuffleCommitment struct {
+	Commitment string      `json:"commitment"`
+	Seed       int64       `json:"seed"`
+	Salt       string      `json:"salt"`
+	Variant    Variant     `json:"variant"`
+	Dealt      []eval.Card `json:"dealt"`
+}
+
+func copyShuffleCommitment(src *ShuffleCommitment) *ShuffleCommitment {
+	if src == nil {
+		return nil
+	}
+
+	ret := *src
+	ret.Dealt = append([]eval.Card{}, src.Dealt...)
+	return &ret
+}
+
+func shuffleCommitment(seed int64, salt []byte) string {
+	b := make([]byte, 8, 8+len(salt))
+	binary.BigEndian.PutUint64(b, uint64(seed))
+	sum := sha256.Sum256(append(b, salt...))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// commitShuffle draws the seed for the next hand, and commits to it. The seed is drawn from crypto/rand, rather than
+// from the last hand's generator, so revealing one hand's seed gives nothing away about the next.
+func (g *Game) commitShuffle() {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		panic(err)
+	}
+
+	g.config.Seed = int64(cryptoUint64n(1 << 63))
+	g.rand = mathrand.New(mathrand.NewSource(g.config.Seed))
+	g.shuffleCommit = &ShuffleCommitment{
+		Commitment: shuffleCommitment(g.config.Seed, salt),
+		Seed:       g.config.Seed,
+		Salt:       hex.EncodeToString(salt),
+	}
+}
+
+// revealShuffle publishes the commitment of the hand that just ended, and commits to the next hand's seed
+func (g *Game) revealShuffle() {
+	if g.shuffleCommit == nil {
+		return
+	}
+
+	g.lastShuffle = g.shuffleCommit
+	g.shuffleCommit = nil
+
+	if g.config.CommitShuffle && g.rng == nil {
+		g.commitShuffle()
+	}
+}
+
+// draw takes the top card off the deck, noting it in the hand's commitment if there is one
+func (g *Game) draw() eval.Card {
+	c := g.deck.Pop()
+	if g.shuffleCommit != nil {
+		g.shuffleCommit.Dealt = append(g.shuffleCommit.Dealt, c)
+	}
+
+	return c
+}
+
+// VerifyShuffle checks a revealed ShuffleCommitment: that its Seed and Salt hash to its Commitment (or it returns
+// ErrBadCommitment), and that shuffling with its Seed deals exactly the cards in Dealt, in order (or it returns
+// ErrBadShuffle). Players should check the Commitment against the one they were shown before the hand, and pass
+// the cards they saw dealt (like their hole cards and the board) as cards, which must all be in Dealt.
+func VerifyShuffle(s *ShuffleCommitment, cards ...eval.Card) error {
+	salt, err := hex.DecodeString(s.Salt)
+	if err != nil || shuffleCommitment(s.Seed, salt) != s.Commitment {
+		return ErrBadCommitment
+	}
+
+	deck := shuffledDeck(s.Variant.deck(), mathrand.New(mathrand.NewSource(s.Seed)))
+	if len(s.Dealt) > len(deck) {
+		return ErrBadShuffle
+	}
+
+	dealt := map[eval.Card]bool{}
+	for i, c := range s.Dealt {
+		// Cards are dealt off the end of the deck
+		if deck[len(deck)-1-i] != c {
+			return ErrBadShuffle
+		}
+		dealt[c] = true
+	}
+
+	for _, c := range cards {
+		if !dealt[c] {
+			return ErrBadShuffle
+		}
+	}
+
+	return nil
+}
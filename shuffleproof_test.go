@@ -0,0 +1,99 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestVerifyShuffle(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, CommitShuffle: true})
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	before := g.GeneratePlayerView(pn_a).Shuffle
+	if before == nil || before.Commitment == "" || before.Seed != 0 || before.Salt != "" {
+		t.Fatalf("Test failed - players should only see the commitment before the hand, got %+v", before)
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	hole := g.GeneratePlayerView(pn_a).Players[pn_a].Cards
+
+	if err := Fold(g, g.actionNum, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	view := g.GeneratePlayerView(pn_a)
+	revealed := view.LastShuffle
+	if revealed == nil || revealed.Commitment != before.Commitment {
+		t.Fatalf("Test failed - the hand's commitment should be revealed once it is over, got %+v", revealed)
+	}
+	if view.Shuffle == nil || view.Shuffle.Commitment == before.Commitment {
+		t.Errorf("Test failed - the next hand should have a commitment of its own, got %+v", view.Shuffle)
+	}
+
+	if err := VerifyShuffle(revealed, hole[0], hole[1]); err != nil {
+		t.Errorf("Test failed - the revealed shuffle should verify, got %s", err)
+	}
+
+	notDealt := revealed.Dealt[0]
+	for _, c := range eval.DefaultDeck {
+		dealt := false
+		for _, d := range revealed.Dealt {
+			dealt = dealt || c == d
+		}
+		if !dealt {
+			notDealt = c
+			break
+		}
+	}
+	if err := VerifyShuffle(revealed, notDealt); err != ErrBadShuffle {
+		t.Errorf("Test failed - a card that wasn't dealt should not verify, got %v", err)
+	}
+
+	wrongSeed := copyShuffleCommitment(revealed)
+	wrongSeed.Seed++
+	if err := VerifyShuffle(wrongSeed); err != ErrBadCommitment {
+		t.Errorf("Test failed - a seed that doesn't match the commitment should not verify, got %v", err)
+	}
+
+	swapped := copyShuffleCommitment(revealed)
+	swapped.Dealt[0], swapped.Dealt[1] = swapped.Dealt[1], swapped.Dealt[0]
+	if err := VerifyShuffle(swapped); err != ErrBadShuffle {
+		t.Errorf("Test failed - cards dealt out of order should not verify, got %v", err)
+	}
+}
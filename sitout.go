@@ -0,0 +1,74 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// SitOut has a player sit out: they stay seated, but aren't dealt in until they SitIn. If they are in a hand when
+// they sit out, they are marked away (see ToggleAway), except that the engine folds for them whenever the action
+// reaches them, instead of checking, and they stop being dealt in once the hand is over. Sitting out players are
+// shown as away. SitOut will return an error if the player has left, or is already sitting out. SitOut ignores the
+// value passed in as data.
+func SitOut(g *Game, pn uint, data uint) error {
+	p := g.getPlayer(pn)
+
+	if p.Left || p.SittingOut {
+		return ErrIllegalAction
+	}
+
+	if p.Ready && !p.In {
+		if err := ToggleReady(g, pn, 0); err != nil {
+			return err
+		}
+	}
+
+	p.SittingOut = true
+	p.Away = true
+
+	g.emit(Event{Kind: EventSitOut, PlayerNum: pn})
+
+	return g.actForAway()
+}
+
+// SitIn has a player who is sitting out (see SitOut) come back: they are no longer away, and are dealt into the next
+// hand. SitIn will return an error if the player isn't sitting out, or has no chips. SitIn ignores the value passed
+// in as data.
+func SitIn(g *Game, pn uint, data uint) error {
+	p := g.getPlayer(pn)
+
+	if !p.SittingOut || p.Stack == 0 {
+		return ErrIllegalAction
+	}
+
+	if !p.Ready {
+		if err := ToggleReady(g, pn, 0); err != nil {
+			return err
+		}
+	}
+
+	p.SittingOut = false
+	p.Away = false
+
+	g.emit(Event{Kind: EventSitIn, PlayerNum: pn})
+
+	return nil
+}
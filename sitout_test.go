@@ -0,0 +1,129 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestSitOut(t *testing.T) {
+	g := NewGame(nil)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// The small blind sits out while player 0 is to act
+	if err := SitOut(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error sitting out: %s", err)
+	}
+	if err := SitOut(g, 1, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a player can't sit out twice, got %v", err)
+	}
+	if err := ToggleAway(g, 1, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a player sitting out should come back with SitIn, got %v", err)
+	}
+
+	// When the action reaches them, they are folded, even though they could complete the blind
+	if err := Bet(g, 0, 25); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if g.players[1].In || g.actionNum != 2 {
+		t.Fatalf("Test failed - the player sitting out should have been folded")
+	}
+
+	for g.getStage() != PreDeal {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+	}
+
+	if view := g.GeneratePlayerView(0); !view.Players[1].Away || !view.Players[1].SittingOut {
+		t.Errorf("Test failed - a player sitting out should be shown as away")
+	}
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if g.players[1].In {
+		t.Errorf("Test failed - a player sitting out shouldn't be dealt in")
+	}
+	for g.getStage() != PreDeal {
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+
+	if err := SitIn(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error sitting in: %s", err)
+	}
+	if p := g.players[1]; !p.Ready || p.Away || p.SittingOut {
+		t.Errorf("Test failed - a player who sits in should be ready and present, got %+v", p)
+	}
+	if err := SitIn(g, 1, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a player who isn't sitting out can't sit in, got %v", err)
+	}
+}
+
+func TestSitOut_EverybodyHeadsUp(t *testing.T) {
+	g := seatedGame(t, nil, 2, 1000)
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Both players sit out, which folds the hand, and leaves nobody ready to deal the next one
+	waiting := g.next(g.actionNum)
+	if err := SitOut(g, waiting, 0); err != nil {
+		t.Fatalf("Test failed - error sitting out: %s", err)
+	}
+	if err := SitOut(g, g.actionNum, 0); err != nil {
+		t.Fatalf("Test failed - error sitting out the player to act: %s", err)
+	}
+
+	if stage, betting := g.getStageAndBetting(); stage != PreDeal || betting {
+		t.Fatalf("Test failed - expected the hand to be over, got stage %d with betting %t", stage, betting)
+	}
+	if got := g.players[0].Stack + g.players[1].Stack; got != 2000 {
+		t.Errorf("Test failed - expected every chip back in the stacks, got %d", got)
+	}
+
+	// Once they sit back in, the next hand deals as normal
+	for pn := uint(0); pn < 2; pn++ {
+		if err := SitIn(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - error sitting in: %s", err)
+		}
+	}
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Errorf("Test failed - error dealing after sitting back in: %s", err)
+	}
+}
@@ -0,0 +1,390 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+// Package sqlstore is a reference implementation of riverboat.GameStore on database/sql, which also keeps an
+// append-only archive of every hand played and every Event recorded, so operators have a durable history of their
+// tables out of the box.
+//
+// The Store creates and upgrades its own tables (see Migrate), all named with the prefix "riverboat_". Its SQL is
+// kept to what SQLite, PostgreSQL and MySQL all accept, and it works with any driver for them, given the Placeholder
+// style the driver expects.
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexclewontin/riverboat"
+)
+
+// Placeholder is the style of query parameter a database driver expects.
+type Placeholder uint8
+
+const (
+	// Question is the "?" style of SQLite and MySQL
+	Question Placeholder = iota
+	// Dollar is the "$1" style of PostgreSQL
+	Dollar
+)
+
+// migrations are the changes to the schema, in order. A database at schema version n has had the first n applied.
+var migrations = []string{
+	`CREATE TABLE riverboat_games (
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		view TEXT NOT NULL,
+		version BIGINT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE riverboat_hands (
+		game_id VARCHAR(255) NOT NULL,
+		hand_num BIGINT NOT NULL,
+		first_seq BIGINT NOT NULL,
+		last_seq BIGINT NOT NULL,
+		ended_at TIMESTAMP NOT NULL,
+		view TEXT NOT NULL,
+		PRIMARY KEY (game_id, hand_num)
+	)`,
+	`CREATE TABLE riverboat_events (
+		game_id VARCHAR(255) NOT NULL,
+		seq BIGINT NOT NULL,
+		hand_num BIGINT NOT NULL,
+		event TEXT NOT NULL,
+		PRIMARY KEY (game_id, seq)
+	)`,
+}
+
+// Store is a riverboat.GameStore that keeps Games in a SQL database, and archives their hands. It is safe for
+// concurrent use. Stores should not be initialized directly, only through the New factory function.
+type Store struct {
+	db          *sql.DB
+	placeholder Placeholder
+}
+
+// New returns a Store that keeps Games in db, writing query parameters in the style of placeholder. Call Migrate
+// before using it.
+func New(db *sql.DB, placeholder Placeholder) *Store {
+	return &Store{db: db, placeholder: placeholder}
+}
+
+// Migrate brings the Store's tables up to date, creating them if they don't exist yet, and records which migrations
+// it has applied in a riverboat_schema table. Each migration is applied in a transaction of its own. Migrate is safe
+// to call every time a server starts, but not from two servers at once.
+func (s *Store) Migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS riverboat_schema (version BIGINT NOT NULL)`); err != nil {
+		return err
+	}
+
+	var version int
+	err := s.db.QueryRow(`SELECT version FROM riverboat_schema`).Scan(&version)
+	if err == sql.ErrNoRows {
+		if _, err := s.db.Exec(`INSERT INTO riverboat_schema (version) VALUES (0)`); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	for ; version < len(migrations); version++ {
+		tx, err := s.db.Begin()
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(migrations[version]); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := tx.Exec(s.bind(`UPDATE riverboat_schema SET version = ?`), version+1); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Save implements riverboat.GameStore.
+func (s *Store) Save(id string, view *riverboat.GameView, version uint64) (uint64, error) {
+	b, err := json.Marshal(view)
+	if err != nil {
+		return 0, err
+	}
+
+	var res sql.Result
+	if version == 0 {
+		res, err = s.db.Exec(s.bind(`INSERT INTO riverboat_games (id, view, version, updated_at) VALUES (?, ?, 1, ?)`),
+			id, string(b), time.Now().UTC())
+		if err != nil && s.exists(id) {
+			// Somebody else saved it first
+			return 0, riverboat.ErrStaleVersion
+		}
+	} else {
+		res, err = s.db.Exec(s.bind(`UPDATE riverboat_games SET view = ?, version = ?, updated_at = ?
+			WHERE id = ? AND version = ?`), string(b), version+1, time.Now().UTC(), id, version)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 {
+		return 0, riverboat.ErrStaleVersion
+	}
+
+	return version + 1, nil
+}
+
+func (s *Store) exists(id string) bool {
+	var one int
+	return s.db.QueryRow(s.bind(`SELECT 1 FROM riverboat_games WHERE id = ?`), id).Scan(&one) == nil
+}
+
+// Load implements riverboat.GameStore.
+func (s *Store) Load(id string) (*riverboat.GameView, uint64, error) {
+	var b string
+	var version uint64
+	err := s.db.QueryRow(s.bind(`SELECT view, version FROM riverboat_games WHERE id = ?`), id).Scan(&b, &version)
+	if err == sql.ErrNoRows {
+		return nil, 0, riverboat.ErrGameNotFound
+	} else if err != nil {
+		return nil, 0, err
+	}
+
+	view := &riverboat.GameView{}
+	if err := json.Unmarshal([]byte(b), view); err != nil {
+		return nil, 0, err
+	}
+
+	return view, version, nil
+}
+
+// List implements riverboat.GameStore. The IDs are sorted.
+func (s *Store) List() ([]string, error) {
+	rows, err := s.db.Query(`SELECT id FROM riverboat_games ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Hand is a hand in the archive (see Archive). Hands are numbered from 1 for each Game. FirstSeq and LastSeq are the
+// sequence numbers of the first and last Events of the hand, and View is the omniscient view of the table once it
+// was over.
+type Hand struct {
+	HandNum  uint64
+	FirstSeq uint64
+	LastSeq  uint64
+	EndedAt  time.Time
+	View     *riverboat.GameView
+}
+
+// Archive adds every Event g records from now on to the archive, as Events of the Game id, along with a Hand for
+// every hand g finishes (or calls off as a misdeal). The archive is written a hand at a time, in a transaction, when
+// the hand ends, with the Events from the end of the hand before it, so a hand is either archived whole or not at
+// all. The Events are taken from g's log, and the view generated, once g has discarded the hands its Retention
+// doesn't keep, so the archive keeps no more than g does. Errors writing it are passed to onError, if it isn't nil.
+// Archive returns a function that stops archiving g.
+//
+// Like any subscriber's (see riverboat.Game.Subscribe), the archive's writes are made while the Action that ended the
+// hand is still in progress, so they hold up whoever is waiting on the Game until they are done.
+func (s *Store) Archive(g *riverboat.Game, id string, onError func(error)) (cancel func()) {
+	a := &archiver{store: s, id: id, onError: onError, seq: g.LastEventSeq()}
+
+	err := s.db.QueryRow(s.bind(`SELECT COALESCE(MAX(hand_num), 0) FROM riverboat_hands WHERE game_id = ?`), id).
+		Scan(&a.handNum)
+	if err != nil {
+		a.fail(err)
+	}
+
+	return g.Subscribe(func(e riverboat.Event) {
+		a.record(g, e)
+	})
+}
+
+// archiver archives the Events of a Game from its log at the end of each hand. seq is the sequence number of the
+// last Event archived, or recorded before archiving started.
+type archiver struct {
+	mu      sync.Mutex
+	store   *Store
+	id      string
+	onError func(error)
+	handNum uint64
+	seq     uint64
+}
+
+func (a *archiver) record(g *riverboat.Game, e riverboat.Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if e.Kind != riverboat.EventHandEnd && e.Kind != riverboat.EventMisdeal {
+		return
+	}
+
+	events := g.EventsSince(a.seq)
+	if err := a.store.archiveHand(a.id, a.handNum+1, events, g.GenerateOmniView()); err != nil {
+		a.fail(err)
+		return
+	}
+
+	a.handNum++
+	a.seq = e.Seq
+}
+
+func (a *archiver) fail(err error) {
+	if a.onError != nil {
+		a.onError(err)
+	}
+}
+
+// archiveHand writes hand handNum of the Game id, which ended with events, to the archive
+func (s *Store) archiveHand(id string, handNum uint64, events []riverboat.Event, view *riverboat.GameView) error {
+	b, err := json.Marshal(view)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(s.bind(`INSERT INTO riverboat_hands (game_id, hand_num, first_seq, last_seq, ended_at, view)
+		VALUES (?, ?, ?, ?, ?, ?)`),
+		id, handNum, events[0].Seq, events[len(events)-1].Seq, time.Now().UTC(), string(b))
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(s.bind(`INSERT INTO riverboat_events (game_id, seq, hand_num, event) VALUES (?, ?, ?, ?)`),
+			id, e.Seq, handNum, string(b))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Hands returns the archived hands of the Game id, in order.
+func (s *Store) Hands(id string) ([]Hand, error) {
+	rows, err := s.db.Query(s.bind(`SELECT hand_num, first_seq, last_seq, ended_at, view FROM riverboat_hands
+		WHERE game_id = ? ORDER BY hand_num`), id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hands := []Hand{}
+	for rows.Next() {
+		var h Hand
+		var b string
+		if err := rows.Scan(&h.HandNum, &h.FirstSeq, &h.LastSeq, &h.EndedAt, &b); err != nil {
+			return nil, err
+		}
+
+		h.View = &riverboat.GameView{}
+		if err := json.Unmarshal([]byte(b), h.View); err != nil {
+			return nil, err
+		}
+		hands = append(hands, h)
+	}
+
+	return hands, rows.Err()
+}
+
+// Events returns the archived Events of the Game id that were recorded in hand handNum, or since the hand before it
+// ended, in order. If handNum is 0, Events returns every archived Event of the Game, which can be replayed to rebuild
+// it as it was at the end of its last archived hand (see riverboat.ReplayEvents).
+func (s *Store) Events(id string, handNum uint64) ([]riverboat.Event, error) {
+	query := `SELECT event FROM riverboat_events WHERE game_id = ? AND (hand_num = ? OR ? = 0) ORDER BY seq`
+	rows, err := s.db.Query(s.bind(query), id, handNum, handNum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []riverboat.Event{}
+	for rows.Next() {
+		var b string
+		if err := rows.Scan(&b); err != nil {
+			return nil, err
+		}
+
+		var e riverboat.Event
+		if err := json.Unmarshal([]byte(b), &e); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// bind rewrites the "?" parameters of query in the Store's Placeholder style
+func (s *Store) bind(query string) string {
+	if s.placeholder != Dollar {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,223 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/alexclewontin/riverboat"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newStore(t *testing.T) *Store {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	// Every connection to ":memory:" is a database of its own
+	db.SetMaxOpenConns(1)
+
+	s := New(db, Question)
+	if err := s.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	// Migrating an up to date database does nothing
+	if err := s.Migrate(); err != nil {
+		t.Fatalf("Migrate() again error = %v", err)
+	}
+
+	return s
+}
+
+func TestStore(t *testing.T) {
+	s := newStore(t)
+	defer s.db.Close()
+
+	g := riverboat.NewGame(nil)
+	pn := g.AddPlayer()
+	riverboat.BuyIn(g, pn, 1000)
+	view := g.GenerateOmniView()
+
+	if _, _, err := s.Load("a"); err != riverboat.ErrGameNotFound {
+		t.Errorf("Load() of a missing game error = %v, want ErrGameNotFound", err)
+	}
+
+	v, err := s.Save("b", view, 0)
+	if err != nil || v != 1 {
+		t.Fatalf("Save() = %d, %v, want 1, nil", v, err)
+	}
+	if _, err := s.Save("b", view, 0); err != riverboat.ErrStaleVersion {
+		t.Errorf("Save() of a new game over a stored one error = %v, want ErrStaleVersion", err)
+	}
+	if v, err := s.Save("b", view, 1); err != nil || v != 2 {
+		t.Errorf("Save() = %d, %v, want 2, nil", v, err)
+	}
+	if _, err := s.Save("b", view, 1); err != riverboat.ErrStaleVersion {
+		t.Errorf("Save() over a newer version error = %v, want ErrStaleVersion", err)
+	}
+	if _, err := s.Save("a", view, 0); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, v, err := s.Load("b")
+	if err != nil || v != 2 || !reflect.DeepEqual(loaded, view) {
+		t.Errorf("Load() = %+v, %d, %v, want %+v, 2, nil", loaded, v, err, view)
+	}
+
+	if ids, err := s.List(); err != nil || !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("List() = %v, %v, want [a b]", ids, err)
+	}
+}
+
+func TestStore_Archive(t *testing.T) {
+	s := newStore(t)
+	defer s.db.Close()
+
+	config := riverboat.GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7, Rules: riverboat.DefaultRuleSet}
+	g := riverboat.NewGame(&config)
+	cancel := s.Archive(g, "table", func(err error) { t.Errorf("Archive() error = %v", err) })
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		riverboat.BuyIn(g, pn, 1000)
+		riverboat.ToggleReady(g, pn, 0)
+	}
+
+	// Two hands, everybody folding to the big blind
+	for hand := 0; hand < 2; hand++ {
+		view := g.GenerateOmniView()
+		if err := riverboat.Deal(g, view.DealerNum, 0); err != nil {
+			t.Fatalf("Deal() error = %v", err)
+		}
+		for g.GenerateOmniView().Stage != riverboat.PreDeal {
+			if err := riverboat.Fold(g, g.GenerateOmniView().ActionNum, 0); err != nil {
+				t.Fatalf("Fold() error = %v", err)
+			}
+		}
+	}
+	cancel()
+
+	hands, err := s.Hands("table")
+	if err != nil || len(hands) != 2 {
+		t.Fatalf("Hands() = %v, %v, want 2 hands", hands, err)
+	}
+	if hands[0].HandNum != 1 || hands[1].FirstSeq != hands[0].LastSeq+1 || hands[1].View.Players[0].Stack == 0 {
+		t.Errorf("Hands() = %+v", hands)
+	}
+
+	first, err := s.Events("table", 1)
+	if err != nil || first[0].Kind != riverboat.EventBuyIn || first[len(first)-1].Kind != riverboat.EventHandEnd {
+		t.Errorf("Events() of the first hand = %+v, %v", first, err)
+	}
+
+	// The whole archive rebuilds the Game
+	all, err := s.Events("table", 0)
+	if err != nil || !reflect.DeepEqual(all, g.Events()) {
+		t.Fatalf("Events() = %+v, %v, want %+v", all, err, g.Events())
+	}
+	rebuilt, err := riverboat.ReplayEvents(&config, all)
+	if err != nil {
+		t.Fatalf("ReplayEvents() error = %v", err)
+	}
+	got, want := rebuilt.GenerateOmniView(), g.GenerateOmniView()
+	got.HandEnded = want.HandEnded
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReplayEvents() = %+v, want %+v", got, want)
+	}
+
+	// Archiving again carries on numbering the hands
+	s.Archive(g, "table", nil)
+	if err := riverboat.Deal(g, g.GenerateOmniView().DealerNum, 0); err != nil {
+		t.Fatalf("Deal() error = %v", err)
+	}
+	for g.GenerateOmniView().Stage != riverboat.PreDeal {
+		riverboat.Fold(g, g.GenerateOmniView().ActionNum, 0)
+	}
+	if hands, _ := s.Hands("table"); len(hands) != 3 || hands[2].HandNum != 3 {
+		t.Errorf("Hands() = %+v, want a third hand", hands)
+	}
+}
+
+func TestStore_ArchiveRetention(t *testing.T) {
+	s := newStore(t)
+	defer s.db.Close()
+
+	g := riverboat.NewGame(&riverboat.GameConfig{BigBlind: 25, SmallBlind: 10, Retention: riverboat.RetainShown})
+	s.Archive(g, "table", func(err error) { t.Errorf("Archive() error = %v", err) })
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		riverboat.BuyIn(g, pn, 1000)
+		riverboat.ToggleReady(g, pn, 0)
+	}
+
+	// Everybody folds to the big blind, so nobody shows, and no hand is kept
+	if err := riverboat.Deal(g, g.GenerateOmniView().DealerNum, 0); err != nil {
+		t.Fatalf("Deal() error = %v", err)
+	}
+	for g.GenerateOmniView().Stage != riverboat.PreDeal {
+		if err := riverboat.Fold(g, g.GenerateOmniView().ActionNum, 0); err != nil {
+			t.Fatalf("Fold() error = %v", err)
+		}
+	}
+
+	events, err := s.Events("table", 1)
+	if err != nil {
+		t.Fatalf("Events() error = %v", err)
+	}
+	dealt := 0
+	for _, e := range events {
+		if e.Kind != riverboat.EventHoleCards {
+			continue
+		}
+		dealt++
+		for _, c := range e.Cards {
+			if c != 0 {
+				t.Errorf("Events() archived the folded hole cards %v of player %d", e.Cards, e.PlayerNum)
+			}
+		}
+	}
+	if dealt != 3 {
+		t.Errorf("Events() archived %d EventHoleCards, want 3", dealt)
+	}
+
+	hands, err := s.Hands("table")
+	if err != nil || len(hands) != 1 {
+		t.Fatalf("Hands() = %v, %v, want 1 hand", hands, err)
+	}
+	for pn, p := range hands[0].View.Players {
+		if p.Cards[0] != 0 || p.Cards[1] != 0 {
+			t.Errorf("Hands() archived the folded hole cards %v of player %d", p.Cards, pn)
+		}
+	}
+}
+
+func TestBind(t *testing.T) {
+	s := &Store{placeholder: Dollar}
+	if got := s.bind(`SELECT a FROM b WHERE c = ? AND d = ?`); got != `SELECT a FROM b WHERE c = $1 AND d = $2` {
+		t.Errorf("bind() = %q", got)
+	}
+}
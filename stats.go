@@ -0,0 +1,153 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"sort"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds of the buckets used for LatencyStats.Histogram. Every decision
+// longer than the last bound is counted in one final, overflow bucket.
+var LatencyBuckets = []time.Duration{
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	20 * time.Second,
+	30 * time.Second,
+	60 * time.Second,
+}
+
+// LatencyStats summarizes how long a player takes to act, measured from the moment action reached them to the
+// moment their action was applied. Histogram[i] counts the decisions that took no longer than LatencyBuckets[i]
+// (and longer than LatencyBuckets[i-1]), and the last element of Histogram counts the decisions that took longer
+// than every bucket.
+type LatencyStats struct {
+	Count     uint
+	Mean      time.Duration
+	Median    time.Duration
+	P90       time.Duration
+	Min       time.Duration
+	Max       time.Duration
+	Histogram []uint
+}
+
+// VPIP returns how many hands player pn has been dealt into while present, and in how many of those they voluntarily
+// put chips into the pot before the flop. Posting blinds isn't voluntary, and hands the player was away for, when
+// the engine acted for them (see ToggleAway), aren't counted at all.
+func (g *Game) VPIP(pn uint) (voluntary uint, dealt uint) {
+	counting, counted := false, false
+
+	for _, e := range g.events {
+		if e.PlayerNum != pn && e.Kind != EventHandStart {
+			continue
+		}
+
+		switch e.Kind {
+		case EventHandStart:
+			counting, counted = false, false
+		case EventHoleCards:
+			if !e.Away {
+				counting = true
+				dealt++
+			}
+		case EventBet:
+			if counting && !counted && !e.Away && e.Stage == PreFlop && e.Amount > 0 {
+				counted = true
+				voluntary++
+			}
+		}
+	}
+
+	return voluntary, dealt
+}
+
+// DecisionTimes returns how long player pn took to make each of their decisions, in order.
+func (g *Game) DecisionTimes(pn uint) []time.Duration {
+	return append([]time.Duration{}, g.decisionTimes[pn]...)
+}
+
+// LatencyStats returns a summary of how long player pn takes to make decisions.
+func (g *Game) LatencyStats(pn uint) LatencyStats {
+	times := g.DecisionTimes(pn)
+
+	stats := LatencyStats{
+		Count:     uint(len(times)),
+		Histogram: make([]uint, len(LatencyBuckets)+1),
+	}
+
+	if len(times) == 0 {
+		return stats
+	}
+
+	var total time.Duration
+	for _, d := range times {
+		total += d
+
+		bucket := sort.Search(len(LatencyBuckets), func(i int) bool { return d <= LatencyBuckets[i] })
+		stats.Histogram[bucket]++
+	}
+
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+
+	stats.Mean = total / time.Duration(len(times))
+	stats.Median = percentile(times, 50)
+	stats.P90 = percentile(times, 90)
+	stats.Min = times[0]
+	stats.Max = times[len(times)-1]
+
+	return stats
+}
+
+// percentile uses the nearest-rank method on sorted, which must not be empty
+func percentile(sorted []time.Duration, pct int) time.Duration {
+	rank := (pct*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+// markActionAvailable starts timing the decision of whoever the action is on
+func (g *Game) markActionAvailable() {
+	g.actionSince = g.currentTime()
+}
+
+// recordDecision stops timing the decision of player pn, who has just acted
+func (g *Game) recordDecision(pn uint) {
+	if g.actionSince.IsZero() {
+		return
+	}
+
+	if g.decisionTimes == nil {
+		g.decisionTimes = make(map[uint][]time.Duration)
+	}
+
+	used := g.currentTime().Sub(g.actionSince)
+	g.decisionTimes[pn] = append(g.decisionTimes[pn], used)
+	g.spendTimeBank(pn, used)
+	g.actionSince = time.Time{}
+}
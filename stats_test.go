@@ -0,0 +1,82 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGame_LatencyStats(t *testing.T) {
+	g := NewGame(nil)
+	now := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+	g.now = func() time.Time { return now }
+
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	steps := []struct {
+		wait time.Duration
+		pn   uint
+	}{
+		{3 * time.Second, pn_a},  // call preflop
+		{1 * time.Second, pn_b},  // check preflop
+		{45 * time.Second, pn_b}, // check flop
+		{8 * time.Second, pn_a},  // check flop
+	}
+
+	for _, s := range steps {
+		now = now.Add(s.wait)
+		if err := Bet(g, s.pn, g.toCall()-g.players[s.pn].Bet); err != nil {
+			t.Fatalf("Test failed - error betting: %s", err)
+		}
+	}
+
+	stats := g.LatencyStats(pn_a)
+	if stats.Count != 2 || stats.Mean != 5500*time.Millisecond || stats.Min != 3*time.Second || stats.Max != 8*time.Second {
+		t.Errorf("Test failed - bad stats for player a: %+v", stats)
+	}
+
+	stats = g.LatencyStats(pn_b)
+	if stats.Count != 2 || stats.Median != 1*time.Second || stats.P90 != 45*time.Second {
+		t.Errorf("Test failed - bad stats for player b: %+v", stats)
+	}
+
+	if stats.Histogram[0] != 1 || stats.Histogram[len(LatencyBuckets)-1] != 1 {
+		t.Errorf("Test failed - bad histogram for player b: %v", stats.Histogram)
+	}
+
+	if empty := g.LatencyStats(5); empty.Count != 0 || len(empty.Histogram) != len(LatencyBuckets)+1 {
+		t.Errorf("Test failed - bad stats for a player with no decisions: %+v", empty)
+	}
+}
@@ -0,0 +1,256 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// PlayerStats are the counts behind the stats a HUD shows for a player, over the hands they have been dealt into and
+// played for themselves (hands they were away for, when the engine acted for them, aren't counted). Bets, Raises
+// and Calls count actions on every street, preflop included.
+type PlayerStats struct {
+	Hands uint `json:"hands"`
+	// VPIPHands is how many hands the player voluntarily put chips in before the flop, and PFRHands how many they
+	// raised before the flop
+	VPIPHands uint `json:"vpipHands"`
+	PFRHands  uint `json:"pfrHands"`
+	Bets      uint `json:"bets"`
+	Raises    uint `json:"raises"`
+	Calls     uint `json:"calls"`
+	// SawFlop is how many hands the player was still in when the flop was dealt, WentToShowdown how many of those
+	// they took to showdown, and WonAtShowdown how many of those they won at least part of a pot in
+	SawFlop        uint `json:"sawFlop"`
+	WentToShowdown uint `json:"wentToShowdown"`
+	WonAtShowdown  uint `json:"wonAtShowdown"`
+}
+
+// VPIP returns the fraction of hands the player voluntarily put chips in before the flop, or 0 if they haven't been
+// dealt a hand.
+func (s PlayerStats) VPIP() float64 {
+	return fraction(s.VPIPHands, s.Hands)
+}
+
+// PFR returns the fraction of hands the player raised before the flop, or 0 if they haven't been dealt a hand.
+func (s PlayerStats) PFR() float64 {
+	return fraction(s.PFRHands, s.Hands)
+}
+
+// AggressionFactor returns how many times the player has bet or raised for every call, or 0 if they have never
+// called.
+func (s PlayerStats) AggressionFactor() float64 {
+	return fraction(s.Bets+s.Raises, s.Calls)
+}
+
+// WTSD returns the fraction of the hands in which the player saw the flop that they went to showdown, or 0 if they
+// haven't seen a flop.
+func (s PlayerStats) WTSD() float64 {
+	return fraction(s.WentToShowdown, s.SawFlop)
+}
+
+// WSD returns the fraction of the showdowns the player went to that they won, or 0 if they haven't been to one.
+func (s PlayerStats) WSD() float64 {
+	return fraction(s.WonAtShowdown, s.WentToShowdown)
+}
+
+// fraction returns n over d, or 0 if d is 0
+func fraction(n uint, d uint) float64 {
+	if d == 0 {
+		return 0
+	}
+
+	return float64(n) / float64(d)
+}
+
+// StatsTracker accumulates PlayerStats across hands from a Game's Events. Attach one to a Game with SetStatsTracker,
+// or feed it a Game's past Events with Record. Only hands that are over count: a hand in progress is added once it
+// ends, and one called off as a misdeal (see Misdeal) never is. A Bet or Fold taken back with Undo isn't counted.
+// A StatsTracker is not safe for concurrent use, so take a Snapshot under the same lock as the Game's Actions.
+type StatsTracker struct {
+	stats map[uint]PlayerStats
+	hand  handStats
+	// beforeAct is the hand as it was before the last Bet or Fold a player made for themselves, in case they Undo it
+	beforeAct handStats
+}
+
+// handStats is what a StatsTracker has seen of the hand in progress
+type handStats struct {
+	players map[uint]*handPlayerStats
+	// streetIn is what each player has put in on the current street, blinds included, whether or not their hand
+	// counts, and streetHigh the most any of them has
+	streetIn   map[uint]uint
+	streetHigh uint
+	showdown   bool
+}
+
+type handPlayerStats struct {
+	stats  PlayerStats
+	folded bool
+}
+
+// NewStatsTracker returns a StatsTracker that hasn't seen any hands.
+func NewStatsTracker() *StatsTracker {
+	return &StatsTracker{stats: map[uint]PlayerStats{}}
+}
+
+// SetStatsTracker has s record every Event g records from now on, so it keeps track of the stats of g's players.
+// Passing nil detaches the StatsTracker g has.
+func (g *Game) SetStatsTracker(s *StatsTracker) {
+	if g.cancelStats != nil {
+		g.cancelStats()
+		g.cancelStats = nil
+	}
+
+	if s != nil {
+		g.cancelStats = g.Subscribe(s.Record)
+	}
+}
+
+// Snapshot returns the stats of every player who has been dealt a hand that counts, by player number.
+func (s *StatsTracker) Snapshot() map[uint]PlayerStats {
+	ret := make(map[uint]PlayerStats, len(s.stats))
+	for pn, ps := range s.stats {
+		ret[pn] = ps
+	}
+
+	return ret
+}
+
+// Player returns the stats of player pn.
+func (s *StatsTracker) Player(pn uint) PlayerStats {
+	return s.stats[pn]
+}
+
+// Record adds e to what s has seen. Events must be recorded in order.
+func (s *StatsTracker) Record(e Event) {
+	h := &s.hand
+
+	switch e.Kind {
+	case EventHandStart:
+		*h = handStats{players: map[uint]*handPlayerStats{}, streetIn: map[uint]uint{}}
+	case EventHoleCards:
+		if h.players != nil && !e.Away {
+			h.players[e.PlayerNum] = &handPlayerStats{stats: PlayerStats{Hands: 1}}
+		}
+	case EventBlind:
+		h.putIn(e.PlayerNum, e.Amount)
+	case EventCommunityCards, EventUpCard, EventDownCard:
+		h.streetIn, h.streetHigh = map[uint]uint{}, 0
+		for _, p := range h.players {
+			if e.Stage == Flop && !p.folded {
+				p.stats.SawFlop = 1
+			}
+		}
+	case EventBet:
+		if !e.Away {
+			s.beforeAct = h.copy()
+		}
+		p := h.players[e.PlayerNum]
+		raised := h.streetIn[e.PlayerNum]+e.Amount > h.streetHigh
+		if p != nil && e.Amount > 0 {
+			switch {
+			case !raised:
+				p.stats.Calls++
+			case h.streetHigh == 0:
+				p.stats.Bets++
+			default:
+				p.stats.Raises++
+			}
+
+			if e.Stage == PreFlop {
+				p.stats.VPIPHands = 1
+				if raised {
+					p.stats.PFRHands = 1
+				}
+			}
+		}
+		h.putIn(e.PlayerNum, e.Amount)
+	case EventFold:
+		if !e.Away {
+			s.beforeAct = h.copy()
+		}
+		if p := h.players[e.PlayerNum]; p != nil {
+			p.folded = true
+		}
+	case EventUndo:
+		s.hand = s.beforeAct.copy()
+	case EventShow, EventMuck:
+		h.showdown = true
+		if p := h.players[e.PlayerNum]; p != nil && p.stats.SawFlop != 0 {
+			p.stats.WentToShowdown = 1
+		}
+	case EventPotAward:
+		if p := h.players[e.PlayerNum]; p != nil && h.showdown && p.stats.WentToShowdown != 0 {
+			p.stats.WonAtShowdown = 1
+		}
+	case EventHandEnd:
+		for pn, p := range h.players {
+			s.stats[pn] = addStats(s.stats[pn], p.stats)
+		}
+		*h = handStats{}
+	case EventMisdeal:
+		*h = handStats{}
+	}
+}
+
+// putIn notes that player pn put amt in on the current street
+func (h *handStats) putIn(pn uint, amt uint) {
+	if h.streetIn == nil {
+		return
+	}
+
+	h.streetIn[pn] += amt
+	if h.streetIn[pn] > h.streetHigh {
+		h.streetHigh = h.streetIn[pn]
+	}
+}
+
+func (h handStats) copy() handStats {
+	c := h
+	if h.players != nil {
+		c.players = make(map[uint]*handPlayerStats, len(h.players))
+		for pn, p := range h.players {
+			pc := *p
+			c.players[pn] = &pc
+		}
+	}
+	if h.streetIn != nil {
+		c.streetIn = make(map[uint]uint, len(h.streetIn))
+		for pn, amt := range h.streetIn {
+			c.streetIn[pn] = amt
+		}
+	}
+
+	return c
+}
+
+func addStats(a PlayerStats, b PlayerStats) PlayerStats {
+	return PlayerStats{
+		Hands:          a.Hands + b.Hands,
+		VPIPHands:      a.VPIPHands + b.VPIPHands,
+		PFRHands:       a.PFRHands + b.PFRHands,
+		Bets:           a.Bets + b.Bets,
+		Raises:         a.Raises + b.Raises,
+		Calls:          a.Calls + b.Calls,
+		SawFlop:        a.SawFlop + b.SawFlop,
+		WentToShowdown: a.WentToShowdown + b.WentToShowdown,
+		WonAtShowdown:  a.WonAtShowdown + b.WonAtShowdown,
+	}
+}
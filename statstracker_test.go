@@ -0,0 +1,116 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestStatsTracker(t *testing.T) {
+	config := defaultConfig
+	config.Rules.Undo = true
+	g := NewGame(&config)
+
+	s := NewStatsTracker()
+	g.SetStatsTracker(s)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	act := func(a Action, pn uint, data uint) {
+		t.Helper()
+		if err := a(g, pn, data); err != nil {
+			t.Fatalf("Test failed - error acting for player %d: %s", pn, err)
+		}
+	}
+
+	// Player 0 raises, and both blinds call. On the flop, player 2 bets, player 0 raises, player 1 folds, and player 2
+	// calls, then it is checked down, and player 0's aces win.
+	act(Deal, 0, 0)
+	rigHoleCards(g, 0, "As", "Ah")
+	rigHoleCards(g, 2, "7c", "2d")
+	act(Bet, 0, 75)
+	act(Bet, 1, 65)
+	act(Bet, 2, 50)
+	act(Bet, 1, 0)
+	act(Bet, 2, 100)
+	act(Bet, 0, 300)
+	act(Fold, 1, 0)
+	act(Bet, 2, 200)
+	for g.getStage() != PreDeal {
+		if g.getStage() == River {
+			for i, c := range []string{"Kd", "9s", "5h", "3c", "Jd"} {
+				g.communityCards[i] = eval.MustParseCardString(c)
+			}
+		}
+		act(Bet, g.actionNum, 0)
+	}
+
+	want := map[uint]PlayerStats{
+		0: {Hands: 1, VPIPHands: 1, PFRHands: 1, Raises: 2, SawFlop: 1, WentToShowdown: 1, WonAtShowdown: 1},
+		1: {Hands: 1, VPIPHands: 1, Calls: 1, SawFlop: 1},
+		2: {Hands: 1, VPIPHands: 1, Bets: 1, Calls: 2, SawFlop: 1, WentToShowdown: 1},
+	}
+	for pn, w := range want {
+		if got := s.Player(pn); got != w {
+			t.Errorf("Test failed - expected player %d's stats to be %+v, got %+v", pn, w, got)
+		}
+	}
+
+	if got := s.Player(2); got.AggressionFactor() != 0.5 || got.WTSD() != 1 || got.WSD() != 0 {
+		t.Errorf("Test failed - expected player 2's AF, WTSD and W$SD to be 0.5, 1 and 0, got %+v", got)
+	}
+
+	// Player 1 takes back a raise before folding, and nobody's stats count a hand that is misdealt
+	act(Deal, 1, 0)
+	act(Bet, 1, 75)
+	act(Undo, 1, 0)
+	act(Fold, 1, 0)
+	act(Fold, 2, 0)
+
+	act(Deal, 2, 0)
+	act(Misdeal, 2, 0)
+
+	if got := s.Player(1); got.Hands != 2 || got.Raises != 0 || got.VPIP() != 0.5 {
+		t.Errorf("Test failed - expected player 1 to have played 2 hands, raising in neither, got %+v", got)
+	}
+
+	if snap := s.Snapshot(); len(snap) != 3 || snap[0].Hands != 2 {
+		t.Errorf("Test failed - expected a snapshot of 3 players with 2 hands each, got %+v", snap)
+	}
+
+	// Detached, the tracker stops counting
+	g.SetStatsTracker(nil)
+	act(Deal, 2, 0)
+	act(Fold, g.actionNum, 0)
+	act(Fold, g.actionNum, 0)
+	if got := s.Player(0).Hands; got != 2 {
+		t.Errorf("Test failed - expected a detached tracker not to count hands, got %d", got)
+	}
+}
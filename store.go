@@ -0,0 +1,167 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// GameStore persists Games, as omniscient views (see GenerateOmniView), by an ID of the integrator's choosing. Every
+// save of a Game gives it a new version, and a save has to name the version it replaces, so two servers that loaded
+// the same Game can't both save over it: whichever saves second gets ErrStaleVersion, and should load it again.
+// Implementations must be safe for concurrent use.
+type GameStore interface {
+	// Save stores view as the Game id, if the version stored is version (0 for a Game that hasn't been stored yet),
+	// and returns its new version. Save returns ErrStaleVersion if a different version is stored.
+	Save(id string, view *GameView, version uint64) (uint64, error)
+	// Load returns the view stored as the Game id, and its version, or ErrGameNotFound if there is none.
+	Load(id string) (*GameView, uint64, error)
+	// List returns the IDs of every Game stored.
+	List() ([]string, error)
+}
+
+// storeLink is the GameStore a Game is saved to, and what it is saved as
+type storeLink struct {
+	store   GameStore
+	id      string
+	version uint64
+}
+
+// SetStore has g saved to store as the Game id, at version, from now on (see GameStore). g is saved at the safe
+// points between one change and the next: whenever an Action performed through Apply succeeds, and whenever Advance
+// deals or Timeout acts for a player. version is the version g was loaded at, or 0 if it hasn't been stored yet.
+// Passing a nil store stops g being saved.
+//
+// Only what is in g's views is saved, so Events, subscribers, and RNGs of its own (see SetRandSource) have to be set
+// up again when it is loaded (see LoadGame).
+func (g *Game) SetStore(store GameStore, id string, version uint64) {
+	if store == nil {
+		g.store = nil
+		return
+	}
+
+	g.store = &storeLink{store: store, id: id, version: version}
+}
+
+// SaveNow saves g to its GameStore right away (see SetStore). It returns the error of the store's Save, and does
+// nothing if g has no store.
+func (g *Game) SaveNow() error {
+	if g.store == nil {
+		return nil
+	}
+
+	version, err := g.store.store.Save(g.store.id, g.GenerateOmniView(), g.store.version)
+	if err != nil {
+		return err
+	}
+	g.store.version = version
+
+	return nil
+}
+
+// LoadGame loads the Game id from store, and returns it, set up to be saved back to store (see SetStore). It
+// returns the error of the store's Load, like ErrGameNotFound.
+func LoadGame(store GameStore, id string) (*Game, error) {
+	view, version, err := store.Load(id)
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewGame(&view.Config)
+	g.FillFromView(view)
+	g.SetStore(store, id, version)
+
+	return g, nil
+}
+
+// MemoryStore is a GameStore that keeps Games in memory, for tests, and for servers that only need to survive
+// losing a Game's lock holder, not the process. It keeps each Game as JSON, so what is loaded is never shared with
+// what was saved. MemoryStores should not be initialized directly, only through the NewMemoryStore factory function.
+type MemoryStore struct {
+	mu    sync.Mutex
+	games map[string]storedGame
+}
+
+type storedGame struct {
+	view    []byte
+	version uint64
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{games: make(map[string]storedGame)}
+}
+
+// Save implements GameStore.
+func (s *MemoryStore) Save(id string, view *GameView, version uint64) (uint64, error) {
+	b, err := json.Marshal(view)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.games[id].version != version {
+		return 0, ErrStaleVersion
+	}
+
+	s.games[id] = storedGame{view: b, version: version + 1}
+
+	return version + 1, nil
+}
+
+// Load implements GameStore.
+func (s *MemoryStore) Load(id string) (*GameView, uint64, error) {
+	s.mu.Lock()
+	stored, ok := s.games[id]
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, 0, ErrGameNotFound
+	}
+
+	view := &GameView{}
+	if err := json.Unmarshal(stored.view, view); err != nil {
+		return nil, 0, err
+	}
+
+	return view, stored.version, nil
+}
+
+// List implements GameStore. The IDs are sorted.
+func (s *MemoryStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.games))
+	for id := range s.games {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids, nil
+}
@@ -0,0 +1,107 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore()
+	view := NewGame(nil).GenerateOmniView()
+
+	if _, _, err := s.Load("a"); err != ErrGameNotFound {
+		t.Errorf("Test failed - loading a game that isn't stored should return ErrGameNotFound, got %v", err)
+	}
+
+	v, err := s.Save("b", view, 0)
+	if err != nil || v != 1 {
+		t.Fatalf("Test failed - saving a new game returned version %d, error %v", v, err)
+	}
+	if _, err := s.Save("b", view, 0); err != ErrStaleVersion {
+		t.Errorf("Test failed - saving over a newer version should return ErrStaleVersion, got %v", err)
+	}
+	if _, err := s.Save("a", view, 0); err != nil {
+		t.Fatalf("Test failed - saving a second game returned %s", err)
+	}
+
+	if ids, _ := s.List(); !reflect.DeepEqual(ids, []string{"a", "b"}) {
+		t.Errorf("Test failed - List returned %v", ids)
+	}
+
+	loaded, v, err := s.Load("b")
+	if err != nil || v != 1 || !reflect.DeepEqual(loaded, view) {
+		t.Errorf("Test failed - Load returned %+v at version %d, error %v\nwant %+v", loaded, v, err, view)
+	}
+}
+
+func TestGame_SetStore(t *testing.T) {
+	s := NewMemoryStore()
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 7, Rules: DefaultRuleSet})
+	g.SetStore(s, "table", 0)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := g.Apply(BuyIn, pn, 1000); err != nil {
+			t.Fatalf("Test failed - error buying in: %s", err)
+		}
+		if err := g.Apply(ToggleReady, pn, 0); err != nil {
+			t.Fatalf("Test failed - error readying: %s", err)
+		}
+	}
+	if err := g.Apply(Deal, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Every Action was saved as it was performed
+	if _, v, _ := s.Load("table"); v != 7 {
+		t.Errorf("Test failed - expected 7 saves, got %d", v)
+	}
+
+	// Actions that fail aren't saved
+	if err := g.Apply(Fold, g.next(g.actionNum), 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected folding out of turn to fail, got %v", err)
+	}
+
+	loaded, err := LoadGame(s, "table")
+	if err != nil {
+		t.Fatalf("Test failed - LoadGame returned %s", err)
+	}
+	if got, want := loaded.GenerateOmniView(), g.GenerateOmniView(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Test failed - loaded %+v\nwant %+v", got, want)
+	}
+
+	// Whichever copy saves second has to load it again
+	if err := loaded.Apply(Bet, loaded.actionNum, 25); err != nil {
+		t.Fatalf("Test failed - error calling on the loaded copy: %s", err)
+	}
+	if err := g.Apply(Bet, g.actionNum, 25); err != ErrStaleVersion {
+		t.Errorf("Test failed - saving a stale copy should return ErrStaleVersion, got %v", err)
+	}
+
+	if _, err := LoadGame(s, "nope"); err != ErrGameNotFound {
+		t.Errorf("Test failed - loading a game that isn't stored should return ErrGameNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// Straddle is the Action the player on the button takes between hands to straddle the next one, at tables that play
+// RuleSet.Straddle. For Straddle, data is 1 to straddle, or 0 to take it back. This is the Mississippi straddle: when
+// the hand is dealt, the button posts twice the big blind, which becomes the bet to call and the minimum raise.
+// Action still starts to the left of the big blind, but passes over the straddler, who acts last before the flop
+// (after the big blind) unless somebody raises, in which case the action goes round in the usual order. A straddle
+// is only posted with at least three players dealt in, as heads up the button posts one of the blinds.
+//
+// Straddle returns an error if the hand has already been dealt, if pn isn't on the button, or if pn can't cover the
+// straddle.
+func Straddle(g *Game, pn uint, data uint) error {
+	if !g.config.Rules.Straddle {
+		return ErrFeatureDisabled
+	}
+
+	p := g.getPlayer(pn)
+
+	if stage, betting := g.getStageAndBetting(); stage != PreDeal || betting || data > 1 {
+		return ErrIllegalAction
+	}
+
+	if data == 1 && (pn != g.dealerNum || !p.Ready || p.Stack < g.straddleAmt()) {
+		return ErrIllegalAction
+	}
+
+	p.Straddle = data == 1
+	g.emit(Event{Kind: EventStraddle, PlayerNum: pn, Amount: data})
+
+	return nil
+}
+
+func (g *Game) straddleAmt() uint {
+	return 2 * g.config.BigBlind
+}
+
+// postStraddle posts the straddle the player on the button has announced, if there are at least three players dealt
+// in, and returns how much they put in. It should be called once the blinds are in.
+func (g *Game) postStraddle() uint {
+	in := 0
+	for i := range g.players {
+		if g.players[i].In {
+			in++
+		}
+		if uint(i) != g.dealerNum {
+			g.players[i].Straddle = false
+		}
+	}
+
+	p := &g.players[g.dealerNum]
+	if !p.Straddle || !p.In || in < 3 || g.headsUpBlinds() || g.kill {
+		p.Straddle = false
+		return 0
+	}
+
+	before := p.Stack
+	p.putInChips(g.straddleAmt(), g.config.HandCap)
+	// The straddler is passed over until everybody else has acted (see straddleOption)
+	p.Called = true
+	g.minRaise = g.straddleAmt()
+
+	return before - p.Stack
+}
+
+// straddleOption reports whether the straddler is owed the option to act once everybody else has called the
+// straddle. The straddle is cleared once the straddler acts, so this is only the case if nobody has raised.
+func (g *Game) straddleOption() bool {
+	p := g.players[g.dealerNum]
+	return p.Straddle && p.In && !g.allIn(g.dealerNum)
+}
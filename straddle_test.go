@@ -0,0 +1,118 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+// straddleConfig is defaultConfig, with the straddle allowed
+func straddleConfig() *GameConfig {
+	config := defaultConfig
+	config.Rules.Straddle = true
+	return &config
+}
+
+func TestGame_Straddle(t *testing.T) {
+	g := seatedGame(t, straddleConfig(), 4, 1000)
+	dealer := g.dealerNum
+
+	if err := Straddle(g, g.nextReady(dealer), 1); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected ErrIllegalAction when straddling off the button, got %v", err)
+	}
+	if err := Straddle(g, dealer, 1); err != nil {
+		t.Fatalf("Test failed - error straddling: %s", err)
+	}
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := Straddle(g, dealer, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected ErrIllegalAction when straddling after the deal, got %v", err)
+	}
+
+	if got := g.players[dealer].Bet; got != 50 {
+		t.Errorf("Test failed - expected the button to straddle 50, got %d", got)
+	}
+
+	// Action still starts to the left of the big blind
+	utg := g.nextReady(g.bbNum)
+
+	// Everybody calls the straddle, and the straddler gets the option
+	for _, pn := range []uint{utg, g.sbNum, g.bbNum} {
+		if g.actionNum != pn {
+			t.Fatalf("Test failed - expected player %d to act, got %d", pn, g.actionNum)
+		}
+		if err := Bet(g, pn, 50-g.players[pn].Bet); err != nil {
+			t.Fatalf("Test failed - error calling the straddle: %s", err)
+		}
+	}
+	if g.actionNum != dealer || g.getStage() != PreFlop {
+		t.Fatalf("Test failed - expected the straddler to have the option, got player %d to act", g.actionNum)
+	}
+
+	// A raise must be at least the size of the straddle
+	if err := Bet(g, dealer, 49); err != ErrIllegalAction {
+		t.Errorf("Test failed - expected ErrIllegalAction raising less than the straddle, got %v", err)
+	}
+	if err := Bet(g, dealer, 50); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+
+	// The raise re-opens the action in the usual order, and the straddler doesn't get another option
+	for _, pn := range []uint{g.sbNum, g.bbNum, utg} {
+		if g.actionNum != pn {
+			t.Fatalf("Test failed - expected player %d to act after the raise, got %d", pn, g.actionNum)
+		}
+		Bet(g, pn, 50)
+	}
+	if g.getStage() != Flop {
+		t.Errorf("Test failed - expected the flop once the raise was called, got stage %d", g.getStage())
+	}
+}
+
+func TestGame_StraddleHeadsUp(t *testing.T) {
+	g := seatedGame(t, straddleConfig(), 2, 1000)
+
+	if err := Straddle(g, g.dealerNum, 1); err != nil {
+		t.Fatalf("Test failed - error straddling: %s", err)
+	}
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Heads up the button is in the small blind, and no straddle goes in
+	if got := g.players[g.dealerNum].Bet; got != g.config.SmallBlind {
+		t.Errorf("Test failed - expected the button to post only the small blind heads up, got %d", got)
+	}
+	if g.players[g.dealerNum].Straddle {
+		t.Errorf("Test failed - expected the straddle to be cleared heads up")
+	}
+}
+
+func TestGame_StraddleDisabled(t *testing.T) {
+	g := seatedGame(t, straddleConfig(), 3, 1000)
+	g.config.Rules.Straddle = false
+
+	if err := Straddle(g, g.dealerNum, 1); err != ErrFeatureDisabled {
+		t.Errorf("Test failed - expected ErrFeatureDisabled, got %v", err)
+	}
+}
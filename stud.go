@@ -0,0 +1,172 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "github.com/alexclewontin/riverboat/eval"
+
+// studRank returns the rank of c as it counts for showing cards in Razz, from 1 (the ace) up to 13 (the king)
+func studRank(c eval.Card) int {
+	return (int((c>>8)&0xF)+1)%13 + 1
+}
+
+// studSuit returns the suit of c, from 0 (clubs) up to 3 (spades), which breaks ties between up cards of the same
+// rank for the bring-in
+func studSuit(c eval.Card) int {
+	switch (c >> 12) & 0xF {
+	case 0x4:
+		return 1
+	case 0x2:
+		return 2
+	case 0x1:
+		return 3
+	}
+
+	return 0
+}
+
+// postBringIn has everybody dealt into a hand of stud ante, and the player showing the highest card (ties going to
+// the highest suit) bring it in for the SmallBlind. None of the blinds are posted, and announced straddles and kill
+// blinds are let go. The action is on the player after the bring-in, who can call it or complete it to the BigBlind
+// (see raiseMin). The bring-in isn't owed the option if everybody just calls.
+func (g *Game) postBringIn(stage GameStage) {
+	g.kill = false
+	for i, p := range g.players {
+		g.players[i].Straddle = false
+		g.players[i].Kill = false
+		if p.In {
+			g.postAnte(stage, uint(i))
+		}
+	}
+
+	var pn uint
+	found := false
+	for _, q := range g.seatOrder(g.next(g.dealerNum)) {
+		if !g.players[q].In {
+			continue
+		}
+
+		c, best := g.players[q].UpCards[0], g.players[pn].UpCards[0]
+		if !found || studRank(c) > studRank(best) || (studRank(c) == studRank(best) && studSuit(c) > studSuit(best)) {
+			pn, found = q, true
+		}
+	}
+
+	p := &g.players[pn]
+	before := p.Stack
+	p.putInChips(g.config.SmallBlind, g.config.HandCap)
+	p.Called = true
+	g.emit(Event{Kind: EventBlind, Stage: stage, PlayerNum: pn, Amount: before - p.Stack})
+
+	g.actionNum = g.next(pn)
+	for !g.players[g.actionNum].In {
+		g.actionNum = g.next(g.actionNum)
+	}
+}
+
+// bringInPending reports whether nobody has completed the bring-in yet, on third street of a hand of stud
+func (g *Game) bringInPending() bool {
+	return g.variant().stud() && g.getStage() == ThirdStreet && g.toCall() < g.config.BigBlind
+}
+
+// dealUpCards deals every player in the hand the cards of a later street of stud: one face up, or on the last
+// street, one face down
+func (g *Game) dealUpCards(street Street) {
+	for i, p := range g.players {
+		if !p.In {
+			continue
+		}
+
+		if street.HoleCards != 0 {
+			g.players[i].ExtraCards[0] = g.draw()
+			g.emit(Event{Kind: EventDownCard, Stage: street.Stage, PlayerNum: uint(i), Cards: []eval.Card{g.players[i].ExtraCards[0]}})
+			continue
+		}
+
+		g.dealUpCard(street.Stage, uint(i))
+	}
+}
+
+// dealUpCard deals player pn their next card face up
+func (g *Game) dealUpCard(stage GameStage, pn uint) {
+	p := &g.players[pn]
+	for i := range p.UpCards {
+		if p.UpCards[i] == 0 {
+			p.UpCards[i] = g.draw()
+			g.emit(Event{Kind: EventUpCard, Stage: stage, PlayerNum: pn, Cards: []eval.Card{p.UpCards[i]}})
+			return
+		}
+	}
+}
+
+// bestShowingNum returns the player in the hand showing the best low, who acts first on the streets of stud after
+// third street. Pairs count against a hand, and of two players showing the same, the first from the dealer's left
+// acts first.
+func (g *Game) bestShowingNum() uint {
+	var pn uint
+	var best []int
+	for _, q := range g.seatOrder(g.next(g.dealerNum)) {
+		if !g.players[q].In {
+			continue
+		}
+
+		if key := g.showing(q); best == nil || lowerShowing(key, best) {
+			pn, best = q, key
+		}
+	}
+
+	return pn
+}
+
+// showing orders the ranks of the up cards player pn is showing from worst to best for a low: the ranks they have
+// the most of first, and then the highest
+func (g *Game) showing(pn uint) []int {
+	var counts [14]int
+	for _, c := range g.players[pn].UpCards {
+		if c != 0 {
+			counts[studRank(c)]++
+		}
+	}
+
+	ret := []int{}
+	for n := len(g.players[pn].UpCards); n > 0; n-- {
+		for r := 13; r > 0; r-- {
+			if counts[r] == n {
+				ret = append(ret, n<<4|r)
+			}
+		}
+	}
+
+	return ret
+}
+
+// lowerShowing reports whether showing a is a better low than showing b (see showing)
+func lowerShowing(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+
+	return false
+}
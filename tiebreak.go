@@ -0,0 +1,124 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "sort"
+
+// OddChipRule decides who gets the chips left over when a pot doesn't split evenly between its winners. Whatever the
+// rule, no winner gets more than one chip more than another.
+type OddChipRule uint8
+
+const (
+	// OddChipLeftOfButton gives the odd chips to the winners closest to the dealer's left, in order. This is the
+	// usual rule in casinos, and the default.
+	OddChipLeftOfButton OddChipRule = iota
+	// OddChipLowestPlayerNum gives the odd chips to the winners with the lowest player numbers, in order
+	OddChipLowestPlayerNum
+	// OddChipCarryOver doesn't give the odd chips to anybody: they are held over, and added to the next hand's
+	// main pot (a common home game rule). Chips being held over are shown in the Carryover field of views.
+	OddChipCarryOver
+)
+
+// EliminationRule decides the finishing order of Tournament entrants who bust on the same hand at the same table.
+type EliminationRule uint8
+
+const (
+	// EliminationStartingStack places the entrant who started the hand with more chips higher. Entrants who
+	// started the hand with the same number of chips are placed by entrant number, lowest highest.
+	// This is the default.
+	EliminationStartingStack EliminationRule = iota
+	// EliminationEntrantNum places entrants by entrant number alone, lowest highest
+	EliminationEntrantNum
+)
+
+// splitAmt returns how much of amt each of winners is awarded, in the same order as winners, and how much is left
+// over to carry into the next hand.
+func (g *Game) splitAmt(amt uint, winners []uint) ([]uint, uint) {
+	n := uint(len(winners))
+	shares := make([]uint, n)
+	if n == 0 {
+		return shares, 0
+	}
+
+	if g.config.Rules.OddChip == OddChipCarryOver {
+		for i := range shares {
+			shares[i] = amt / n
+		}
+		return shares, amt % n
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	key := func(pn uint) uint { return pn }
+	if g.config.Rules.OddChip == OddChipLeftOfButton {
+		left := make([]uint, len(g.players))
+		for i, pn := range g.seatOrder(g.next(g.dealerNum)) {
+			left[pn] = uint(i)
+		}
+		key = func(pn uint) uint { return left[pn] }
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return key(winners[order[i]]) < key(winners[order[j]])
+	})
+
+	for i, ndx := range order {
+		shares[ndx] = amt / n
+		if uint(i) < amt%n {
+			shares[ndx]++
+		}
+	}
+
+	return shares, 0
+}
+
+// handStartStack returns how many chips player pn had when the last hand was dealt, or 0 if they weren't dealt in.
+func (g *Game) handStartStack(pn uint) uint {
+	if pn >= uint(len(g.startStacks)) {
+		return 0
+	}
+
+	return g.startStacks[pn]
+}
+
+// sortEliminations sorts the entrants busted on the same hand at table tn into the order they are considered to
+// have busted, according to the Tournament's EliminationRule: the entrant placed lowest first.
+func (t *Tournament) sortEliminations(tn uint, busted []Seat) {
+	g := t.tables[tn]
+
+	sort.Slice(busted, func(i, j int) bool {
+		a, b := busted[i], busted[j]
+		if t.config.Elimination == EliminationStartingStack {
+			sa, sb := g.handStartStack(a.PlayerNum), g.handStartStack(b.PlayerNum)
+			if sa != sb {
+				return sa < sb
+			}
+		}
+
+		return t.entrants[a] > t.entrants[b]
+	})
+}
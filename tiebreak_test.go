@@ -0,0 +1,157 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestGame_SplitAmt(t *testing.T) {
+	tests := []struct {
+		rule OddChipRule
+		want []uint
+	}{
+		// Player 3 is first to the dealer's left, then player 0, then player 1
+		{OddChipLeftOfButton, []uint{4, 3, 4}},
+		{OddChipLowestPlayerNum, []uint{4, 4, 3}},
+	}
+
+	for _, tt := range tests {
+		g := NewGame(nil)
+		for i := 0; i < 4; i++ {
+			g.AddPlayer()
+		}
+		g.dealerNum = 2
+		g.config.Rules.OddChip = tt.rule
+
+		if got, _ := g.splitAmt(11, []uint{0, 1, 3}); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Test failed - rule %d split the pot %v, expected %v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestTournament_SimultaneousElimination(t *testing.T) {
+	tests := []struct {
+		rule EliminationRule
+		want []uint
+	}{
+		// Entrant 2 started the hand with fewer chips, so busted first; 1 and 3 tied, so 1 places higher
+		{EliminationStartingStack, []uint{2, 3, 1}},
+		// Stacks don't matter; the higher entrant number busts first
+		{EliminationEntrantNum, []uint{3, 2, 1}},
+	}
+
+	for _, tt := range tests {
+		tr := newTestTournament(t, 4, 9)
+		tr.config.Elimination = tt.rule
+
+		g, _ := tr.Table(0)
+		g.startStacks = make([]uint, 4)
+
+		for en, stack := range map[uint]uint{1: 100, 2: 50, 3: 100} {
+			s, _ := tr.SeatOf(en)
+			g.startStacks[s.PlayerNum] = stack
+			bust(t, tr, en)
+		}
+
+		if _, err := tr.Balance(); err != nil {
+			t.Fatalf("Test failed - error balancing: %s", err)
+		}
+
+		if got := tr.BustOrder(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Test failed - rule %d eliminated entrants in order %v, expected %v", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestGame_OddChipCarryOver(t *testing.T) {
+	config := defaultConfig
+	config.Rules.OddChip = OddChipCarryOver
+	g := NewGame(&config)
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	// One dead chip makes the pot 76, which doesn't split three ways
+	if err := PostDead(g, 0, 1); err != nil {
+		t.Fatalf("Test failed - error posting dead: %s", err)
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Everyone plays the royal flush on the board
+	g.deck = eval.Deck{}
+	for _, s := range []string{"Ts", "Js", "Qs", "Ks", "As"} {
+		g.deck.Push(eval.MustParseCardString(s))
+	}
+	for i, s := range [][2]string{{"2c", "3c"}, {"2d", "3d"}, {"2h", "3h"}} {
+		g.players[i].Cards = [2]eval.Card{eval.MustParseCardString(s[0]), eval.MustParseCardString(s[1])}
+	}
+
+	for _, bet := range []uint{25, 15, 0} {
+		if err := Bet(g, g.actionNum, bet); err != nil {
+			t.Fatalf("Test failed - error calling: %s", err)
+		}
+	}
+	for g.getBetting() {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+	}
+
+	view := g.GenerateOmniView()
+	if view.Carryover != 1 {
+		t.Fatalf("Test failed - expected 1 chip to be carried over, got %d", view.Carryover)
+	}
+	if total := g.ChipsInPlay(); total != 3000 {
+		t.Errorf("Test failed - there should be 3000 chips in play, got %d", total)
+	}
+
+	// The next hand's winner gets the chip carried over
+	dealer := g.dealerNum
+	if err := Deal(g, dealer, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	for g.getStage() == PreFlop {
+		if err := Fold(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error folding: %s", err)
+		}
+	}
+
+	if g.carryover != 0 || g.ChipsInPlay() != 3000 {
+		t.Errorf("Test failed - the carried over chip should have been won, leaving 3000 chips in play")
+	}
+}
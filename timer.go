@@ -0,0 +1,180 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"time"
+)
+
+// ActionDeadline returns when the player the Game is waiting on runs out of time to decide, for Games with an
+// ActionTime, so servers can schedule a call to Timeout. Each decision is allowed ActionTime, and then whatever is
+// left of the player's TimeBank. That is the player the action is on, in a betting round or when it is their turn to
+// show or muck; in the discard stage, when everybody decides at once, it is whoever still has to discard and has the
+// least left in their TimeBank. The second return value is false if there is no deadline: ActionTime is 0, or
+// nobody is being waited on.
+func (g *Game) ActionDeadline() (time.Time, bool) {
+	pn, ok := g.timedNum()
+	if g.config.ActionTime == 0 || !ok || g.actionSince.IsZero() {
+		return time.Time{}, false
+	}
+
+	return g.actionSince.Add(g.config.ActionTime + g.players[pn].TimeBank), true
+}
+
+// timedNum returns the player whose time runs out first (see ActionDeadline), and false if nobody is being waited on
+func (g *Game) timedNum() (uint, bool) {
+	if g.getBetting() || g.showdownPending() {
+		return g.actionNum, true
+	}
+
+	if !g.discardsPending() {
+		return 0, false
+	}
+
+	first, found := uint(0), false
+	for i, p := range g.players {
+		if p.In && p.ThirdCard != 0 && (!found || p.TimeBank < g.players[first].TimeBank) {
+			first, found = uint(i), true
+		}
+	}
+
+	return first, found
+}
+
+// TimeoutAction decides what happens to a player who runs out of time to act (see Timeout)
+type TimeoutAction uint8
+
+const (
+	// TimeoutAway marks the player away (see ToggleAway), so the engine checks or folds for them, now and until they
+	// come back. This is the default.
+	TimeoutAway TimeoutAction = iota
+	// TimeoutCheckFold checks for the player if they can, and folds for them if they can't, for this decision only
+	TimeoutCheckFold
+)
+
+// Timeout applies the consequence of running out of time, if the player the Game is waiting on has (see
+// ActionDeadline): their TimeBank is emptied, and the engine acts for them as the Game's TimeoutAction says. A player
+// who runs out of time to show or muck mucks, and one who runs out of time to discard discards their ThirdCard,
+// whatever the TimeoutAction, though under TimeoutAway they are still marked away. If the Game has a
+// TimeoutsToSitOut, and the player has now timed out that many times in a row (without acting for themselves in
+// between), they are also sat out (see SitOut). Timeout returns true if the player had run out of time. If there is
+// no deadline, Timeout does nothing.
+//
+// Like Advance, Timeout doesn't run on its own: servers should call it at the time returned by ActionDeadline. Only
+// one player is timed out per call, so in the discard stage, a server should check ActionDeadline again after each.
+func (g *Game) Timeout() (bool, error) {
+	timedOut, err := g.timeout()
+	if timedOut && err == nil {
+		err = g.SaveNow()
+	}
+
+	return timedOut, err
+}
+
+// timeout is Timeout, without saving g
+func (g *Game) timeout() (bool, error) {
+	at, ok := g.ActionDeadline()
+	if !ok || g.currentTime().Before(at) {
+		return false, nil
+	}
+
+	pn, _ := g.timedNum()
+
+	return true, g.timeOut(pn)
+}
+
+// timeOut applies the consequence of running out of time to player pn, who must be someone the Game is waiting on
+func (g *Game) timeOut(pn uint) error {
+	if !g.waitingOn(pn) {
+		return ErrIllegalAction
+	}
+
+	p := g.getPlayer(pn)
+	timeouts := p.Timeouts + 1
+
+	p.TimeBank = 0
+	g.emit(Event{Kind: EventTimeout, PlayerNum: pn})
+
+	if g.config.TimeoutAction == TimeoutAway {
+		p.Away = true
+		g.emit(Event{Kind: EventAway, PlayerNum: pn})
+	}
+
+	var err error
+	switch {
+	case g.showdownPending():
+		err = Muck(g, pn, 0)
+	case g.discardsPending():
+		err = Discard(g, pn, 2)
+	case g.config.TimeoutAction == TimeoutCheckFold:
+		err = g.checkOrFold(pn)
+	default:
+		err = g.actForAway()
+	}
+	if err != nil {
+		return err
+	}
+
+	// Acting for the player reset their count
+	p.Timeouts = timeouts
+
+	if g.config.TimeoutsToSitOut != 0 && timeouts >= g.config.TimeoutsToSitOut && !p.SittingOut {
+		return SitOut(g, pn, 0)
+	}
+
+	return nil
+}
+
+// waitingOn returns true if the Game is waiting on player pn to decide something: to act, to show or muck, or to
+// discard
+func (g *Game) waitingOn(pn uint) bool {
+	if g.getBetting() || g.showdownPending() {
+		return g.actionNum == pn
+	}
+
+	if pn >= uint(len(g.players)) || !g.discardsPending() {
+		return false
+	}
+
+	p := g.getPlayer(pn)
+
+	return p.In && p.ThirdCard != 0
+}
+
+// spendTimeBank takes whatever player pn used beyond ActionTime to make the decision they just made out of their
+// TimeBank, and resets their count of consecutive timeouts
+func (g *Game) spendTimeBank(pn uint, used time.Duration) {
+	p := g.getPlayer(pn)
+	p.Timeouts = 0
+
+	if g.config.ActionTime == 0 || used <= g.config.ActionTime {
+		return
+	}
+
+	if over := used - g.config.ActionTime; over < p.TimeBank {
+		p.TimeBank -= over
+	} else {
+		p.TimeBank = 0
+	}
+}
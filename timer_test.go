@@ -0,0 +1,244 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGame_ActionTimers(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, ActionTime: 10 * time.Second, TimeBank: 30 * time.Second})
+	g.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if _, ok := g.ActionDeadline(); ok {
+		t.Errorf("Test failed - there should be no deadline before the hand is dealt")
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	if at, ok := g.ActionDeadline(); !ok || !at.Equal(now.Add(40*time.Second)) {
+		t.Errorf("Test failed - expected player 0 to have 40 seconds, got a deadline of %v", at)
+	}
+
+	// Player 0 takes 25 seconds, which uses 15 seconds of their time bank
+	now = now.Add(25 * time.Second)
+	if timedOut, err := g.Timeout(); timedOut || err != nil {
+		t.Errorf("Test failed - player 0 should not have timed out yet")
+	}
+	if err := Bet(g, 0, 25); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if g.players[0].TimeBank != 15*time.Second {
+		t.Errorf("Test failed - expected player 0 to have 15 seconds left in their time bank, got %v", g.players[0].TimeBank)
+	}
+
+	// The deadline survives a round trip through a view
+	at, _ := g.ActionDeadline()
+	restored := &Game{now: g.now}
+	restored.FillFromView(g.GenerateOmniView())
+	if got, ok := restored.ActionDeadline(); !ok || !got.Equal(at) {
+		t.Errorf("Test failed - expected the restored deadline to be %v, got %v", at, got)
+	}
+
+	// Player 1 runs out of time, so they are folded and marked away
+	now = at
+	if timedOut, err := g.Timeout(); !timedOut || err != nil {
+		t.Fatalf("Test failed - player 1 should have timed out, got %v", err)
+	}
+	if p := g.players[1]; p.In || !p.Away || p.TimeBank != 0 {
+		t.Errorf("Test failed - player 1 should have been folded and marked away, got %+v", p)
+	}
+	if g.actionNum != 2 {
+		t.Errorf("Test failed - expected the action to be on player 2, got %d", g.actionNum)
+	}
+}
+
+func TestGame_TimeoutCheckFold(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, ActionTime: 10 * time.Second, TimeoutAction: TimeoutCheckFold, TimeoutsToSitOut: 2})
+	g.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	timeout := func() {
+		now, _ = g.ActionDeadline()
+		if timedOut, err := g.Timeout(); !timedOut || err != nil {
+			t.Fatalf("Test failed - expected player %d to time out, got %v", g.actionNum, err)
+		}
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Player 0 can't check, so they are folded, but only for this hand
+	timeout()
+	if p := g.players[0]; p.In || p.Away || p.Timeouts != 1 {
+		t.Errorf("Test failed - player 0 should have been folded, and nothing else, got %+v", p)
+	}
+	if err := Fold(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	if err := Deal(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := Fold(g, 1, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+	if err := Bet(g, 2, 15); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+
+	// Player 0, in the big blind, is checked for, and sat out after their second timeout in a row
+	timeout()
+	if p := g.players[0]; !p.In || !p.SittingOut || p.Timeouts != 2 {
+		t.Errorf("Test failed - player 0 should have checked, and been sat out, got %+v", p)
+	}
+	if g.getStage() != Flop {
+		t.Errorf("Test failed - expected the flop to have been dealt, got stage %d", g.getStage())
+	}
+}
+
+func TestGame_TimeoutShowdown(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	config := defaultConfig
+	config.ActionTime = 10 * time.Second
+	config.Rules.ShowOrMuck = true
+	g := seatedGame(t, &config, 2, 100)
+	g.now = func() time.Time { return now }
+
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	if err := Bet(g, g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	for g.getBetting() {
+		if err := Bet(g, g.actionNum, 0); err != nil {
+			t.Fatalf("Test failed - error checking: %s", err)
+		}
+	}
+
+	if !g.showdownPending() {
+		t.Fatalf("Test failed - expected the showdown to be waiting on a player")
+	}
+	pn := g.showdownOrder[0]
+
+	at, ok := g.ActionDeadline()
+	if !ok || !at.Equal(now.Add(10*time.Second)) {
+		t.Fatalf("Test failed - expected player %d to have 10 seconds to show or muck, got a deadline of %v", pn, at)
+	}
+
+	// The player who doesn't decide mucks, and the hand ends
+	now = at
+	if timedOut, err := g.Timeout(); !timedOut || err != nil {
+		t.Fatalf("Test failed - player %d should have timed out, got %v", pn, err)
+	}
+	if g.showdownPending() || g.handEnded.IsZero() {
+		t.Fatalf("Test failed - the hand should be over")
+	}
+	for _, r := range g.showdown {
+		if r.PlayerNum == pn && !r.Mucked {
+			t.Errorf("Test failed - player %d should have mucked, got %+v", pn, r)
+		}
+	}
+	if !g.players[pn].Away {
+		t.Errorf("Test failed - player %d should have been marked away", pn)
+	}
+}
+
+func TestGame_TimeoutDiscard(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	config := defaultConfig
+	config.Variant = Pineapple
+	config.ActionTime = 10 * time.Second
+	config.TimeBank = 30 * time.Second
+	config.TimeoutAction = TimeoutCheckFold
+	g := seatedGame(t, &config, 3, 1000)
+	g.now = func() time.Time { return now }
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Player 1 takes 20 seconds to discard, which uses 10 seconds of their time bank
+	now = now.Add(20 * time.Second)
+	if err := Discard(g, 1, 2); err != nil {
+		t.Fatalf("Test failed - error discarding: %s", err)
+	}
+	if g.players[1].TimeBank != 20*time.Second {
+		t.Errorf("Test failed - expected player 1 to have 20 seconds left in their time bank, got %v", g.players[1].TimeBank)
+	}
+
+	// The others still have until their own time banks run out
+	at, ok := g.ActionDeadline()
+	if !ok || !at.Equal(now.Add(20*time.Second)) {
+		t.Fatalf("Test failed - expected the discard deadline to be 20 seconds away, got %v", at)
+	}
+
+	// The restored Game waits on the same deadline
+	restored := &Game{now: g.now}
+	restored.FillFromView(g.GenerateOmniView())
+	if got, ok := restored.ActionDeadline(); !ok || !got.Equal(at) {
+		t.Errorf("Test failed - expected the restored deadline to be %v, got %v", at, got)
+	}
+
+	// Players 0 and 2 run out of time one at a time, and have their third cards discarded for them
+	now = at
+	for _, pn := range []uint{0, 2} {
+		if timedOut, err := g.Timeout(); !timedOut || err != nil {
+			t.Fatalf("Test failed - player %d should have timed out, got %v", pn, err)
+		}
+		if p := g.players[pn]; p.ThirdCard != 0 || p.Discarded == 0 || p.Away || p.TimeBank != 0 || p.Timeouts != 1 {
+			t.Errorf("Test failed - player %d should have had their third card discarded for them, got %+v", pn, p)
+		}
+	}
+
+	if !g.getBetting() {
+		t.Errorf("Test failed - betting should open once everybody has discarded")
+	}
+}
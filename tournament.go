@@ -0,0 +1,561 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"sort"
+	"time"
+)
+
+// The default number of seats per table, if TournamentConfig.TableSize is left as 0
+const defaultTableSize = 9
+
+// TournamentConfig holds the settings for a Tournament. Game is the config every table is created
+// with, TableSize is the maximum number of entrants seated at a single table, and StartingStack is the
+// number of chips each entrant starts with. Levels is the blind structure; if it is empty, the blinds
+// in Game are used for the whole tournament.
+//
+// BuyIn is how much each entry adds to the prize pool. LateRegLevels is how many blind levels registration stays
+// open for once the tournament has started; 0 means entrants can only register before it starts. Bounty is the
+// bounty each entrant carries, which is paid to whoever knocks them out (see Tournament.Bounty); 0 means no bounties.
+// If Progressive is true, the bounties are progressive knockouts: only half of each bounty collected is paid out
+// straight away, and the other half is added to the bounty on the collector's own head.
+//
+// ReEntries is how many times an entrant who busts may re-enter while late registration is open (see ReEnter).
+// AddOnStack is the chips an entrant may buy once, at the cost of AddOnCost to the prize pool, during the break:
+// the blind level numbered AddOnLevel (see AddOn). An AddOnStack of 0 means no add-ons. Payouts decides how the prize
+// pool is paid out (see Tournament.Payouts); if it is nil, DefaultPayouts is used.
+type TournamentConfig struct {
+	TableSize     uint
+	StartingStack uint
+	BuyIn         uint
+	LateRegLevels uint
+	Bounty        uint
+	Progressive   bool
+	ReEntries     uint
+	AddOnStack    uint
+	AddOnCost     uint
+	AddOnLevel    uint
+	Payouts       PayoutModel
+	Game          GameConfig
+	Levels        []BlindLevel
+	// Elimination decides the finishing order of entrants who bust on the same hand
+	Elimination EliminationRule
+}
+
+// Seat identifies a position in a Tournament: the table, and the player number within that table's Game.
+type Seat struct {
+	TableNum  uint
+	PlayerNum uint
+}
+
+// Move describes an entrant being moved from one Seat to another, either to balance the tables
+// or because their old table was broken.
+type Move struct {
+	EntrantNum uint
+	From       Seat
+	To         Seat
+}
+
+// Tournament manages a multi-table tournament. It owns one Game per table, and keeps track of where
+// each entrant is seated. Entrants are identified by entrant numbers, which (unlike player numbers)
+// stay the same as an entrant is moved from table to table.
+//
+// The Tournament does not play hands itself: the individual tables are driven with Actions exactly
+// like any other Game. Between hands, Balance should be called to eliminate busted entrants, break
+// tables that are no longer needed, and even out the tables that remain. Tournaments should not be
+// initialized directly, only through the NewTournament factory function.
+type Tournament struct {
+	config      TournamentConfig
+	started     bool
+	tables      []*Game
+	broken      []bool
+	seats       []Seat
+	entrants    map[Seat]uint
+	busted      []bool
+	bustOrder   []uint
+	bounties    []uint
+	bountiesWon []uint
+	firstEntry  []uint
+	reEntries   map[uint]uint
+	addedOn     []bool
+	chop        *Chop
+	chopped     map[uint]uint
+	finished    bool
+	clock       clock
+	now         func() time.Time
+}
+
+// NewTournament is a factory method that returns a pointer to an initialized Tournament, with no entrants.
+// If config is nil, the tables use the same defaults as NewGame, seat 9 entrants each, and entrants start
+// with 1000 chips.
+func NewTournament(config *TournamentConfig) (*Tournament, error) {
+	t := Tournament{}
+
+	if config == nil {
+		t.config = TournamentConfig{
+			TableSize:     defaultTableSize,
+			StartingStack: 1000,
+			Game:          defaultConfig,
+		}
+	} else {
+		t.config = *config
+	}
+
+	if t.config.TableSize == 0 {
+		t.config.TableSize = defaultTableSize
+	}
+
+	if t.config.TableSize < minPlayers || t.config.TableSize > maxPlayers {
+		return nil, ErrBadTableSize
+	}
+
+	t.entrants = make(map[Seat]uint)
+	t.reEntries = make(map[uint]uint)
+	t.chopped = make(map[uint]uint)
+
+	t.clock.levels = append([]BlindLevel{}, t.config.Levels...)
+	if len(t.clock.levels) == 0 {
+		t.clock.levels = []BlindLevel{{SmallBlind: t.config.Game.SmallBlind, BigBlind: t.config.Game.BigBlind}}
+	}
+
+	t.now = time.Now
+
+	return &t, nil
+}
+
+// Register adds a new entrant to the Tournament, and returns their entrant number. Entrants can be registered
+// before the Tournament starts, or afterwards while late registration is open (see LateRegOpen). A late entrant
+// is seated straight away with the starting stack, at the table with the fewest entrants, or at a new table if
+// every table is full; the next call to Balance evens the tables out again. Once registration has closed,
+// Register returns ErrTournamentStarted.
+func (t *Tournament) Register() (uint, error) {
+	if t.started && !t.LateRegOpen() {
+		return 0, ErrTournamentStarted
+	}
+
+	en := uint(len(t.seats))
+	if err := t.addEntry(en); err != nil {
+		return 0, err
+	}
+
+	return en, nil
+}
+
+// addEntry adds a new entry to the Tournament, for the entrant whose first entry is first, and if the Tournament has
+// started, seats it at the table with the fewest entrants (or a new table, if every table is full)
+func (t *Tournament) addEntry(first uint) error {
+	t.seats = append(t.seats, Seat{})
+	t.busted = append(t.busted, false)
+	t.bounties = append(t.bounties, t.config.Bounty)
+	t.bountiesWon = append(t.bountiesWon, 0)
+	t.firstEntry = append(t.firstEntry, first)
+	t.addedOn = append(t.addedOn, false)
+	en := uint(len(t.seats) - 1)
+
+	if !t.started {
+		return nil
+	}
+
+	tn, _ := t.smallestTable(false)
+	if t.tableCount(tn) >= t.config.TableSize {
+		tn = t.newTable()
+		t.applyLevel()
+	}
+
+	if err := t.seatEntrant(en, tn, t.config.StartingStack); err != nil {
+		t.seats = t.seats[:en]
+		t.busted = t.busted[:en]
+		t.bounties = t.bounties[:en]
+		t.bountiesWon = t.bountiesWon[:en]
+		t.firstEntry = t.firstEntry[:en]
+		t.addedOn = t.addedOn[:en]
+		return err
+	}
+
+	return nil
+}
+
+// LateRegOpen returns true if new entrants can still register for a Tournament that has started, that is, if
+// the clock hasn't yet passed the last of the first LateRegLevels levels.
+func (t *Tournament) LateRegOpen() bool {
+	if !t.started || t.finished {
+		return false
+	}
+
+	levelNum, _ := t.Level()
+	return levelNum < t.config.LateRegLevels
+}
+
+// Entries returns the number of entries into the Tournament, including re-entries and any that have since busted.
+func (t *Tournament) Entries() uint {
+	return uint(len(t.seats))
+}
+
+// PrizePool returns the total that every entry has added to the prize pool, late entries and re-entries included,
+// along with every add-on bought.
+func (t *Tournament) PrizePool() uint {
+	return t.Entries()*t.config.BuyIn + t.AddOns()*t.config.AddOnCost
+}
+
+// Start creates as few tables as are needed to seat every entrant, seats the entrants as evenly as possible,
+// buys each of them in for the starting stack, marks them ready, and starts the clock on the first blind level.
+// Once Start returns, each table's dealer may Deal the first hand.
+func (t *Tournament) Start() error {
+	if t.started {
+		return ErrTournamentStarted
+	}
+
+	if len(t.seats) < minPlayers {
+		return ErrNotEnoughEntrants
+	}
+
+	tableCount := t.tablesNeeded(uint(len(t.seats)))
+	for i := uint(0); i < tableCount; i++ {
+		t.newTable()
+	}
+
+	for en := range t.seats {
+		if err := t.seatEntrant(uint(en), uint(en)%tableCount, t.config.StartingStack); err != nil {
+			return err
+		}
+	}
+
+	t.started = true
+	t.clock.start(t.now())
+	t.applyLevel()
+
+	return nil
+}
+
+// Table returns the Game being played at table tn.
+func (t *Tournament) Table(tn uint) (*Game, error) {
+	if tn >= uint(len(t.tables)) || t.broken[tn] {
+		return nil, ErrUnknownTable
+	}
+
+	return t.tables[tn], nil
+}
+
+// TableNums returns the numbers of all the tables still in play, in ascending order.
+func (t *Tournament) TableNums() []uint {
+	ret := []uint{}
+	for i := range t.tables {
+		if !t.broken[i] {
+			ret = append(ret, uint(i))
+		}
+	}
+
+	return ret
+}
+
+// SeatOf returns the Seat entrant en is currently sitting in. If en has busted, it returns the
+// last Seat they occupied.
+func (t *Tournament) SeatOf(en uint) (Seat, error) {
+	if en >= uint(len(t.seats)) {
+		return Seat{}, ErrUnknownEntrant
+	}
+
+	return t.seats[en], nil
+}
+
+// SetAway marks entrant en as away or back. Away entrants keep being dealt in and posting blinds, but the engine
+// folds for them whenever they face a bet (see ToggleAway). They stay away if they are moved to another table.
+func (t *Tournament) SetAway(en uint, away bool) error {
+	if !t.started {
+		return ErrTournamentNotStarted
+	}
+
+	s, err := t.SeatOf(en)
+	if err != nil {
+		return err
+	}
+
+	if t.busted[en] {
+		return ErrUnknownEntrant
+	}
+
+	g := t.tables[s.TableNum]
+	if g.getPlayer(s.PlayerNum).Away == away {
+		return nil
+	}
+
+	return ToggleAway(g, s.PlayerNum, 0)
+}
+
+// EntrantAt returns the entrant sitting at Seat s. The second return value is false if no
+// entrant is currently sitting there.
+func (t *Tournament) EntrantAt(s Seat) (uint, bool) {
+	en, ok := t.entrants[s]
+	return en, ok
+}
+
+// Remaining returns the number of entrants that have not busted.
+func (t *Tournament) Remaining() uint {
+	return uint(len(t.seats) - len(t.bustOrder))
+}
+
+// BustOrder returns the entrant numbers of every entrant that has busted, in the order they busted.
+func (t *Tournament) BustOrder() []uint {
+	return append([]uint{}, t.bustOrder...)
+}
+
+// FinalTable returns true once every remaining entrant is seated at a single table.
+func (t *Tournament) FinalTable() bool {
+	return t.started && len(t.TableNums()) == 1
+}
+
+// Balance should be called between hands. It brings every table that is between hands up to the current blind level,
+// eliminates any entrants who have run out of chips, breaks tables
+// once the remaining entrants fit at fewer tables, and moves entrants from the largest tables to the smallest until
+// no two tables differ by more than one entrant. Entrants are only ever moved away from a table that is between hands;
+// if a table that needs to give up entrants is in the middle of a hand, it is left alone, and will be dealt
+// with by a later call to Balance. Balance returns every Move it made, in the order it made them.
+func (t *Tournament) Balance() ([]Move, error) {
+	if !t.started {
+		return nil, ErrTournamentNotStarted
+	}
+
+	moves := []Move{}
+
+	t.applyLevel()
+
+	for _, tn := range t.TableNums() {
+		if t.betweenHands(tn) {
+			t.eliminateBusted(tn)
+		}
+	}
+
+	// Break tables, smallest first, while the remaining entrants fit at fewer of them
+	for uint(len(t.TableNums())) > t.tablesNeeded(t.Remaining()) {
+		tn, ok := t.smallestTable(true)
+		if !ok {
+			break
+		}
+
+		for _, pn := range t.seatedPlayerNums(tn) {
+			dest, _ := t.smallestTableExcept(tn)
+			m, err := t.move(t.entrants[Seat{tn, pn}], dest)
+			if err != nil {
+				return moves, err
+			}
+			moves = append(moves, m)
+		}
+
+		t.broken[tn] = true
+	}
+
+	// Move entrants from the largest table to the smallest until they're within one of each other
+	for {
+		src, ok := t.largestTable()
+		dest, _ := t.smallestTable(false)
+		if !ok || t.tableCount(src) <= t.tableCount(dest)+1 || !t.betweenHands(src) {
+			break
+		}
+
+		m, err := t.move(t.entrants[Seat{src, t.playerToMove(src)}], dest)
+		if err != nil {
+			return moves, err
+		}
+		moves = append(moves, m)
+	}
+
+	return moves, nil
+}
+
+func (t *Tournament) tablesNeeded(entrantCount uint) uint {
+	return (entrantCount + t.config.TableSize - 1) / t.config.TableSize
+}
+
+func (t *Tournament) newTable() uint {
+	config := t.config.Game
+	// A busted entrant is eliminated, so there are no rematches, and entrants are always dealt in, even when
+	// they are moved to the table mid-orbit. Entrants bring their stack with them, whatever its size, so there
+	// are no buy-in limits either.
+	config.RematchTimeout = 0
+	config.Rules.MissedBlinds = false
+	config.MinBuy = 0
+	config.MaxBuy = 0
+	t.tables = append(t.tables, NewGame(&config))
+	t.broken = append(t.broken, false)
+
+	return uint(len(t.tables) - 1)
+}
+
+func (t *Tournament) betweenHands(tn uint) bool {
+	stage, betting := t.tables[tn].getStageAndBetting()
+	return stage == PreDeal && !betting
+}
+
+// seatEntrant adds entrant en to table tn with a stack of amt, and marks them ready
+func (t *Tournament) seatEntrant(en uint, tn uint, amt uint) error {
+	g := t.tables[tn]
+	pn := g.AddPlayer()
+
+	if err := BuyIn(g, pn, amt); err != nil {
+		return err
+	}
+
+	if err := ToggleReady(g, pn, 0); err != nil {
+		return err
+	}
+
+	t.seats[en] = Seat{tn, pn}
+	t.entrants[Seat{tn, pn}] = en
+	g.getPlayer(pn).Bounty = t.bounties[en]
+
+	return nil
+}
+
+// move takes entrant en (and their chips) away from their current table, and seats them at table tn
+func (t *Tournament) move(en uint, tn uint) (Move, error) {
+	from := t.seats[en]
+	g := t.tables[from.TableNum]
+	p := g.getPlayer(from.PlayerNum)
+
+	stack := p.Stack
+	away := p.Away
+
+	// The last entrant off of a table that is being broken leaves no valid dealer behind, which is fine
+	if err := Leave(g, from.PlayerNum, 0); err != nil && err != ErrNoValidDealer {
+		return Move{}, err
+	}
+	p.Left = true
+	p.Stack = 0
+	p.In = false
+	delete(t.entrants, from)
+
+	if err := t.seatEntrant(en, tn, stack); err != nil {
+		return Move{}, err
+	}
+
+	if away {
+		to := t.seats[en]
+		if err := ToggleAway(t.tables[to.TableNum], to.PlayerNum, 0); err != nil {
+			return Move{}, err
+		}
+	}
+
+	return Move{EntrantNum: en, From: from, To: t.seats[en]}, nil
+}
+
+func (t *Tournament) eliminateBusted(tn uint) {
+	busted := []Seat{}
+	for _, pn := range t.seatedPlayerNums(tn) {
+		p := t.tables[tn].getPlayer(pn)
+		if p.Stack == 0 && !p.In {
+			busted = append(busted, Seat{tn, pn})
+		}
+	}
+
+	t.sortEliminations(tn, busted)
+
+	for _, s := range busted {
+		en := t.entrants[s]
+		t.collectBounty(en, tn, s.PlayerNum)
+		t.busted[en] = true
+		t.bustOrder = append(t.bustOrder, en)
+		delete(t.entrants, s)
+		t.tables[tn].getPlayer(s.PlayerNum).Left = true
+	}
+}
+
+// seatedPlayerNums returns the player numbers of every entrant still seated at table tn, in ascending order
+func (t *Tournament) seatedPlayerNums(tn uint) []uint {
+	ret := []uint{}
+	for s := range t.entrants {
+		if s.TableNum == tn {
+			ret = append(ret, s.PlayerNum)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+func (t *Tournament) tableCount(tn uint) uint {
+	return uint(len(t.seatedPlayerNums(tn)))
+}
+
+// smallestTable returns the open table with the fewest entrants. If idleOnly is true, only tables
+// that are between hands are considered. Ties go to the highest numbered table.
+func (t *Tournament) smallestTable(idleOnly bool) (uint, bool) {
+	var best uint
+	found := false
+	for _, tn := range t.TableNums() {
+		if idleOnly && !t.betweenHands(tn) {
+			continue
+		}
+		if !found || t.tableCount(tn) <= t.tableCount(best) {
+			best = tn
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// smallestTableExcept returns the open table other than tn with the fewest entrants. Ties go to the lowest numbered table.
+func (t *Tournament) smallestTableExcept(tn uint) (uint, bool) {
+	var best uint
+	found := false
+	for _, i := range t.TableNums() {
+		if i == tn {
+			continue
+		}
+		if !found || t.tableCount(i) < t.tableCount(best) {
+			best = i
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// largestTable returns the open table with the most entrants. Ties go to the lowest numbered table.
+func (t *Tournament) largestTable() (uint, bool) {
+	var best uint
+	found := false
+	for _, tn := range t.TableNums() {
+		if !found || t.tableCount(tn) > t.tableCount(best) {
+			best = tn
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// playerToMove picks which entrant leaves table tn when it is being balanced. It avoids
+// moving the dealer, so that the button doesn't have to jump.
+func (t *Tournament) playerToMove(tn uint) uint {
+	seated := t.seatedPlayerNums(tn)
+	for i := len(seated) - 1; i >= 0; i-- {
+		if seated[i] != t.tables[tn].dealerNum {
+			return seated[i]
+		}
+	}
+
+	return seated[0]
+}
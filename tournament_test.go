@@ -0,0 +1,484 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func newTestTournament(t *testing.T, entrants int, tableSize uint) *Tournament {
+	tr, err := NewTournament(&TournamentConfig{TableSize: tableSize, StartingStack: 100, Game: defaultConfig})
+	if err != nil {
+		t.Fatalf("Test failed - error creating tournament: %s", err)
+	}
+
+	for i := 0; i < entrants; i++ {
+		if _, err := tr.Register(); err != nil {
+			t.Fatalf("Test failed - error registering: %s", err)
+		}
+	}
+
+	if err := tr.Start(); err != nil {
+		t.Fatalf("Test failed - error starting: %s", err)
+	}
+
+	return tr
+}
+
+// bust simulates entrant en losing all their chips in a hand that has just finished
+func bust(t *testing.T, tr *Tournament, en uint) {
+	s, err := tr.SeatOf(en)
+	if err != nil {
+		t.Fatalf("Test failed - error finding seat: %s", err)
+	}
+
+	g, _ := tr.Table(s.TableNum)
+	p := g.getPlayer(s.PlayerNum)
+	p.Stack = 0
+	p.In = false
+	p.Ready = false
+}
+
+func TestTournament_Start(t *testing.T) {
+	tr := newTestTournament(t, 20, 9)
+
+	if len(tr.TableNums()) != 3 {
+		t.Fatalf("Test failed - expected 3 tables, got %d", len(tr.TableNums()))
+	}
+
+	for _, tn := range tr.TableNums() {
+		if c := tr.tableCount(tn); c < 6 || c > 7 {
+			t.Errorf("Test failed - table %d has %d entrants", tn, c)
+		}
+	}
+
+	if _, err := tr.Register(); err != ErrTournamentStarted {
+		t.Error("Test failed - Register must return ErrTournamentStarted once the tournament has started")
+	}
+}
+
+func TestTournament_Balance(t *testing.T) {
+	tr := newTestTournament(t, 20, 9)
+
+	// Bust everyone at table 0 but one
+	for _, pn := range tr.seatedPlayerNums(0)[1:] {
+		en, _ := tr.EntrantAt(Seat{0, pn})
+		bust(t, tr, en)
+	}
+
+	moves, err := tr.Balance()
+	if err != nil {
+		t.Fatalf("Test failed - error balancing: %s", err)
+	}
+
+	if tr.Remaining() != 14 || len(tr.BustOrder()) != 6 {
+		t.Errorf("Test failed - expected 14 remaining, got %d", tr.Remaining())
+	}
+
+	if len(tr.TableNums()) != 2 {
+		t.Fatalf("Test failed - expected 2 tables, got %d", len(tr.TableNums()))
+	}
+
+	for _, tn := range tr.TableNums() {
+		if c := tr.tableCount(tn); c != 7 {
+			t.Errorf("Test failed - table %d has %d entrants, expected 7", tn, c)
+		}
+	}
+
+	for _, m := range moves {
+		s, _ := tr.SeatOf(m.EntrantNum)
+		if s != m.To {
+			t.Errorf("Test failed - entrant %d is at %+v, but was moved to %+v", m.EntrantNum, s, m.To)
+		}
+
+		g, _ := tr.Table(m.To.TableNum)
+		if g.getPlayer(m.To.PlayerNum).Stack != 100 {
+			t.Errorf("Test failed - entrant %d did not keep their chips when moved", m.EntrantNum)
+		}
+	}
+
+	// Bust down to a single table
+	for en, left := uint(0), tr.Remaining(); left > 9; en++ {
+		if !tr.busted[en] {
+			bust(t, tr, en)
+			left--
+		}
+	}
+
+	if _, err = tr.Balance(); err != nil {
+		t.Fatalf("Test failed - error balancing: %s", err)
+	}
+
+	if tr.Remaining() != 9 || !tr.FinalTable() {
+		t.Errorf("Test failed - %d entrants remaining should be seated at the final table", tr.Remaining())
+	}
+}
+
+func TestTournament_BalanceMidHand(t *testing.T) {
+	tr := newTestTournament(t, 6, 3)
+
+	g, _ := tr.Table(0)
+	if err := Deal(g, g.dealerNum, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	en, _ := tr.EntrantAt(Seat{1, 0})
+	bust(t, tr, en)
+
+	moves, err := tr.Balance()
+	if err != nil {
+		t.Fatalf("Test failed - error balancing: %s", err)
+	}
+
+	if len(moves) != 0 {
+		t.Errorf("Test failed - table 0 is mid-hand and should not give up entrants, got %+v", moves)
+	}
+}
+
+func TestTournament_Clock(t *testing.T) {
+	levels := []BlindLevel{
+		{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Duration: 10 * time.Minute},
+		{SmallBlind: 50, BigBlind: 100, Duration: 10 * time.Minute},
+	}
+
+	tr, _ := NewTournament(&TournamentConfig{TableSize: 9, StartingStack: 1000, Levels: levels})
+	now := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+	tr.now = func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		tr.Register()
+	}
+	if err := tr.Start(); err != nil {
+		t.Fatalf("Test failed - error starting: %s", err)
+	}
+
+	g, _ := tr.Table(0)
+	if g.config.BigBlind != 20 {
+		t.Errorf("Test failed - expected big blind of 20, got %d", g.config.BigBlind)
+	}
+
+	now = now.Add(5 * time.Minute)
+	if err := tr.PauseClock(); err != nil {
+		t.Fatalf("Test failed - error pausing: %s", err)
+	}
+	if err := tr.PauseClock(); err != ErrClockPaused {
+		t.Errorf("Test failed - pausing twice must return ErrClockPaused")
+	}
+
+	now = now.Add(time.Hour)
+	if n, _ := tr.Level(); n != 0 || tr.LevelRemaining() != 5*time.Minute {
+		t.Errorf("Test failed - paused clock advanced to level %d with %s remaining", n, tr.LevelRemaining())
+	}
+
+	tr.ResumeClock()
+	tr.ExtendLevel(2 * time.Minute)
+
+	now = now.Add(6 * time.Minute)
+	if n, _ := tr.Level(); n != 0 {
+		t.Errorf("Test failed - extended level ended early, on level %d", n)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if n, _ := tr.Level(); n != 1 || tr.LevelRemaining() != 9*time.Minute {
+		t.Errorf("Test failed - expected level 1 with 9m remaining, got level %d with %s", n, tr.LevelRemaining())
+	}
+
+	if err := tr.SetLevel(5); err != ErrBadLevel {
+		t.Errorf("Test failed - SetLevel must return ErrBadLevel for a level that doesn't exist")
+	}
+	tr.SetLevel(2)
+
+	now = now.Add(24 * time.Hour)
+	if n, _ := tr.Level(); n != 2 {
+		t.Errorf("Test failed - the last level should never end, got level %d", n)
+	}
+
+	tr.Balance()
+	if g.config.BigBlind != 100 {
+		t.Errorf("Test failed - expected big blind of 100, got %d", g.config.BigBlind)
+	}
+
+	wantActions := []ClockAction{ClockPaused, ClockResumed, ClockExtended, ClockLevelSet}
+	log := tr.AuditLog()
+	if len(log) != len(wantActions) {
+		t.Fatalf("Test failed - expected %d audit entries, got %+v", len(wantActions), log)
+	}
+	for i := range log {
+		if log[i].Action != wantActions[i] {
+			t.Errorf("Test failed - audit entry %d is %+v", i, log[i])
+		}
+	}
+}
+
+func TestTournament_LateRegistration(t *testing.T) {
+	levels := []BlindLevel{
+		{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Duration: 10 * time.Minute},
+		{SmallBlind: 50, BigBlind: 100, Duration: 10 * time.Minute},
+	}
+
+	tr, _ := NewTournament(&TournamentConfig{TableSize: 3, StartingStack: 1000, BuyIn: 50, LateRegLevels: 2, Levels: levels})
+	now := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+	tr.now = func() time.Time { return now }
+
+	if tr.LateRegOpen() {
+		t.Errorf("Test failed - late registration should not be open before the tournament starts")
+	}
+
+	for i := 0; i < 5; i++ {
+		tr.Register()
+	}
+	if err := tr.Start(); err != nil {
+		t.Fatalf("Test failed - error starting: %s", err)
+	}
+
+	// Late entrants go to the table with an open seat
+	now = now.Add(15 * time.Minute)
+	en, err := tr.Register()
+	if err != nil {
+		t.Fatalf("Test failed - error registering late: %s", err)
+	}
+	s, _ := tr.SeatOf(en)
+	g, _ := tr.Table(s.TableNum)
+	if p := g.getPlayer(s.PlayerNum); p.Stack != 1000 || !p.Ready {
+		t.Errorf("Test failed - expected the late entrant to be seated with the starting stack, got %+v", p)
+	}
+	if tr.tableCount(0) != 3 || tr.tableCount(1) != 3 {
+		t.Errorf("Test failed - expected the late entrant to fill the open seat, got tables of %d and %d", tr.tableCount(0), tr.tableCount(1))
+	}
+
+	// Once every table is full, a new one is opened
+	en, _ = tr.Register()
+	if s, _ := tr.SeatOf(en); s.TableNum != 2 {
+		t.Errorf("Test failed - expected a new table to be opened, got seated at %+v", s)
+	}
+	if g, _ := tr.Table(2); g.config.BigBlind != 40 {
+		t.Errorf("Test failed - expected the new table to play the current level, got big blind %d", g.config.BigBlind)
+	}
+	if tr.Entries() != 7 || tr.Remaining() != 7 || tr.PrizePool() != 350 {
+		t.Errorf("Test failed - expected 7 entries and a prize pool of 350, got %d and %d", tr.Entries(), tr.PrizePool())
+	}
+
+	now = now.Add(10 * time.Minute)
+	if _, err := tr.Register(); err != ErrTournamentStarted {
+		t.Errorf("Test failed - registering after late registration closes should return ErrTournamentStarted, got %v", err)
+	}
+	if tr.PrizePool() != 350 {
+		t.Errorf("Test failed - a refused registration shouldn't change the prize pool, got %d", tr.PrizePool())
+	}
+}
+
+// chopKnockout starts a Tournament of three entrants at one table, and plays a hand in which entrants 0 and 1 chop
+// the pot holding the last of entrant 2's chips
+func chopKnockout(t *testing.T, config *TournamentConfig) (*Tournament, *Game) {
+	tr, _ := NewTournament(config)
+	for i := 0; i < 3; i++ {
+		tr.Register()
+	}
+	if err := tr.Start(); err != nil {
+		t.Fatalf("Test failed - error starting: %s", err)
+	}
+
+	g, _ := tr.Table(0)
+	g.players[2].Stack = 40
+	if err := Deal(g, g.dealingNum(), 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	rigHoleCards(g, 0, "As", "Ad")
+	rigHoleCards(g, 1, "Ah", "Ac")
+	rigHoleCards(g, 2, "7c", "2d")
+
+	// Stack the deck so the board helps nobody, whatever the shuffle. Cards are drawn from the end.
+	for i, s := range []string{"3h", "4s", "8d", "9c", "Kh"} {
+		c := eval.MustParseCardString(s)
+		top := len(g.deck) - 1 - i
+		for j := range g.deck {
+			if g.deck[j] == c {
+				g.deck[j], g.deck[top] = g.deck[top], g.deck[j]
+				break
+			}
+		}
+	}
+
+	for g.getStage() != PreDeal {
+		pn := g.actionNum
+		if err := Bet(g, pn, g.players[pn].Stack); err != nil {
+			t.Fatalf("Test failed - error going all in: %s", err)
+		}
+	}
+
+	return tr, g
+}
+
+func TestTournament_Bounties(t *testing.T) {
+	tr, g := chopKnockout(t, &TournamentConfig{TableSize: 9, StartingStack: 100, Bounty: 31, Game: defaultConfig})
+
+	// Entrants 0 and 1 split entrant 2's bounty
+	if by := g.KnockedOutBy(2); len(by) != 2 {
+		t.Fatalf("Test failed - expected player 2 to be knocked out by both other players, got %v", by)
+	}
+	if by := g.KnockedOutBy(0); by != nil {
+		t.Errorf("Test failed - player 0 wasn't knocked out, got %v", by)
+	}
+
+	if _, err := tr.Balance(); err != nil {
+		t.Fatalf("Test failed - error balancing: %s", err)
+	}
+
+	for en, want := range []uint{16, 15, 0} {
+		if won, _ := tr.BountiesWon(uint(en)); won != want {
+			t.Errorf("Test failed - expected entrant %d to have won %d in bounties, got %d", en, want, won)
+		}
+	}
+	if b, _ := tr.Bounty(2); b != 0 {
+		t.Errorf("Test failed - expected entrant 2's bounty to have been collected, got %d", b)
+	}
+	if b, _ := tr.Bounty(0); b != 31 {
+		t.Errorf("Test failed - expected entrant 0 to still carry a bounty of 31, got %d", b)
+	}
+	if _, err := tr.Bounty(3); err != ErrUnknownEntrant {
+		t.Errorf("Test failed - expected ErrUnknownEntrant, got %v", err)
+	}
+}
+
+func TestTournament_ProgressiveBounties(t *testing.T) {
+	config := TournamentConfig{TableSize: 9, StartingStack: 100, Bounty: 42, Progressive: true, Game: defaultConfig}
+	tr, g := chopKnockout(t, &config)
+
+	if view := g.GenerateSpectatorView(); view.Players[2].Bounty != 42 {
+		t.Errorf("Test failed - expected player views to show a bounty of 42, got %d", view.Players[2].Bounty)
+	}
+
+	if _, err := tr.Balance(); err != nil {
+		t.Fatalf("Test failed - error balancing: %s", err)
+	}
+
+	// Each of entrants 0 and 1 collects 21, banks 11 of it, and adds 10 to their own bounty
+	view := g.GenerateSpectatorView()
+	for en := uint(0); en < 2; en++ {
+		if won, _ := tr.BountiesWon(en); won != 11 {
+			t.Errorf("Test failed - expected entrant %d to have banked 11, got %d", en, won)
+		}
+		if b, _ := tr.Bounty(en); b != 52 || view.Players[en].Bounty != 52 {
+			t.Errorf("Test failed - expected entrant %d's bounty to grow to 52, got %d (%d in the view)", en, b, view.Players[en].Bounty)
+		}
+	}
+
+	// Their bounty goes with them when they move tables
+	m, err := tr.move(0, tr.newTable())
+	if err != nil {
+		t.Fatalf("Test failed - error moving: %s", err)
+	}
+	if g, _ := tr.Table(m.To.TableNum); g.getPlayer(m.To.PlayerNum).Bounty != 52 {
+		t.Errorf("Test failed - expected entrant 0 to keep their bounty after moving, got %d", g.getPlayer(m.To.PlayerNum).Bounty)
+	}
+}
+
+func TestTournament_ReEntryAndAddOn(t *testing.T) {
+	levels := []BlindLevel{
+		{SmallBlind: 10, BigBlind: 20, Duration: 10 * time.Minute},
+		{SmallBlind: 0, BigBlind: 0, Duration: 5 * time.Minute},
+		{SmallBlind: 20, BigBlind: 40, Duration: 10 * time.Minute},
+	}
+	config := TournamentConfig{
+		TableSize:     9,
+		StartingStack: 100,
+		BuyIn:         10,
+		LateRegLevels: 1,
+		ReEntries:     1,
+		AddOnStack:    200,
+		AddOnCost:     5,
+		AddOnLevel:    1,
+		Levels:        levels,
+	}
+
+	tr, _ := NewTournament(&config)
+	now := time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC)
+	tr.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		tr.Register()
+	}
+	if err := tr.Start(); err != nil {
+		t.Fatalf("Test failed - error starting: %s", err)
+	}
+
+	if _, err := tr.ReEnter(0); err != ErrCannotReEnter {
+		t.Errorf("Test failed - an entrant who hasn't busted shouldn't be able to re-enter, got %v", err)
+	}
+
+	bust(t, tr, 0)
+	tr.Balance()
+	en, err := tr.ReEnter(0)
+	if err != nil {
+		t.Fatalf("Test failed - error re-entering: %s", err)
+	}
+	if first, _ := tr.FirstEntry(en); en != 3 || first != 0 {
+		t.Errorf("Test failed - expected re-entry 3 of entrant 0, got entry %d of entrant %d", en, first)
+	}
+	if s, _ := tr.SeatOf(en); tr.tables[s.TableNum].getPlayer(s.PlayerNum).Stack != 100 {
+		t.Errorf("Test failed - expected the re-entry to be seated with the starting stack")
+	}
+
+	bust(t, tr, en)
+	tr.Balance()
+	for _, en := range []uint{0, en} {
+		if _, err := tr.ReEnter(en); err != ErrCannotReEnter {
+			t.Errorf("Test failed - entrant 0 has no re-entries left, but re-entering entry %d returned %v", en, err)
+		}
+	}
+
+	if err := tr.AddOn(1); err != ErrAddOnUnavailable {
+		t.Errorf("Test failed - add-ons should only be available at the break, got %v", err)
+	}
+
+	now = now.Add(12 * time.Minute)
+	if _, err := tr.ReEnter(3); err != ErrTournamentStarted {
+		t.Errorf("Test failed - re-entering after late registration closes should return ErrTournamentStarted, got %v", err)
+	}
+	if err := tr.AddOn(1); err != nil {
+		t.Fatalf("Test failed - error buying an add-on: %s", err)
+	}
+	if s, _ := tr.SeatOf(1); tr.tables[s.TableNum].getPlayer(s.PlayerNum).Stack != 300 {
+		t.Errorf("Test failed - expected the add-on to be added to the entrant's stack")
+	}
+	if s, _ := tr.SeatOf(1); tr.tables[s.TableNum].SessionSummary()[s.PlayerNum].Rebuys != 0 {
+		t.Errorf("Test failed - expected the add-on not to count as a rebuy")
+	}
+	if err := tr.AddOn(1); err != ErrAddOnUnavailable {
+		t.Errorf("Test failed - only one add-on may be bought, got %v", err)
+	}
+	if err := tr.AddOn(3); err != ErrUnknownEntrant {
+		t.Errorf("Test failed - a busted entrant can't buy an add-on, got %v", err)
+	}
+
+	if tr.Entries() != 4 || tr.ReEntries() != 1 || tr.AddOns() != 1 || tr.PrizePool() != 45 {
+		t.Errorf("Test failed - expected 4 entries, 1 re-entry, 1 add-on and a prize pool of 45, got %d, %d, %d and %d",
+			tr.Entries(), tr.ReEntries(), tr.AddOns(), tr.PrizePool())
+	}
+}
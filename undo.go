@@ -0,0 +1,99 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+// undoSnapshot is the state of a Game just before a player's Bet or Fold, kept so they can take it back (see Undo)
+type undoSnapshot struct {
+	game *Game
+	pn   uint
+	// seq is the sequence number of the last Event recorded by the Bet or Fold, so Undo can tell whether anything
+	// has happened since
+	seq uint64
+}
+
+// Undo is the Action for taking back a misclick, at tables that play RuleSet.Undo. It undoes player pn's last Bet
+// (including a check or call) or Fold as if it never happened: their chips go back to their stack, the bets and the
+// minimum raise are as they were, and the action is back on them, with whatever time they had left. A player can
+// only undo until anything else happens at the table, like the next player acting, and can't undo an action that
+// ended the betting round or the hand, or one taken for them because they were away or out of time. The Events
+// recorded by the action taken back are kept, followed by an EventUndo. Undo returns ErrFeatureDisabled if the table
+// doesn't play Undo, and ErrIllegalAction if pn has nothing to undo. It ignores data.
+func Undo(g *Game, pn uint, data uint) error {
+	if !g.config.Rules.Undo {
+		return ErrFeatureDisabled
+	}
+
+	u := g.undo
+	if u == nil || u.pn != pn || u.seq != g.eventSeq {
+		return ErrIllegalAction
+	}
+
+	// Once the street is over, the next street's cards could already have been seen
+	if g.getStage() != u.game.getStage() || !g.getBetting() {
+		return ErrIllegalAction
+	}
+
+	s := u.game.clone()
+
+	// What has already been recorded or drawn can't be taken back, and the Game's hooks and settings stay as they are
+	s.events, s.eventSeq, s.subscribers = g.events, g.eventSeq, g.subscribers
+	s.rangeModel, s.cancelRanges, s.cancelStats = g.rangeModel, g.cancelRanges, g.cancelStats
+	s.rand, s.rng, s.now = g.rand, g.rng, g.now
+	s.lastEmote, s.rejections = g.lastEmote, g.rejections
+	s.store = g.store
+	s.undo = nil
+
+	*g = *s
+	g.emit(Event{Kind: EventUndo, PlayerNum: pn})
+
+	return nil
+}
+
+// snapshotForUndo returns a copy of g to restore if player pn takes back the Bet or Fold they are about to make, or
+// nil if they won't be able to
+func (g *Game) snapshotForUndo(pn uint) *Game {
+	if !g.config.Rules.Undo || g.players[pn].Away {
+		return nil
+	}
+
+	// A player who ran out of time didn't make the decision themselves
+	if n := len(g.events); n > 0 && g.events[n-1].Kind == EventTimeout {
+		return nil
+	}
+
+	s := g.clone()
+	s.undo = nil
+
+	return s
+}
+
+// saveUndo keeps snap for player pn to undo the Bet or Fold they just made, if it succeeded with err, and returns err
+func (g *Game) saveUndo(snap *Game, pn uint, err error) error {
+	g.undo = nil
+	if snap != nil && err == nil {
+		g.undo = &undoSnapshot{game: snap, pn: pn, seq: g.eventSeq}
+	}
+
+	return err
+}
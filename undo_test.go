@@ -0,0 +1,113 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+)
+
+func TestUndo(t *testing.T) {
+	config := defaultConfig
+	config.Rules.Undo = true
+	g := seatedGame(t, &config, 3, 1000)
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	first := g.actionNum
+	before := g.GenerateOmniView()
+
+	if err := Bet(g, first, 100); err != nil {
+		t.Fatalf("Test failed - error raising: %s", err)
+	}
+	second := g.actionNum
+
+	if err := Undo(g, second, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a player shouldn't be able to undo someone else's raise, got %v", err)
+	}
+
+	if err := Undo(g, first, 0); err != nil {
+		t.Fatalf("Test failed - error undoing the raise: %s", err)
+	}
+
+	after := g.GenerateOmniView()
+	if g.actionNum != first || after.MinRaise != before.MinRaise || after.Players[first] != before.Players[first] {
+		t.Errorf("Test failed - expected the raise to be taken back, got action on %d, %+v", g.actionNum, after.Players[first])
+	}
+
+	if events := g.Events(); events[len(events)-1].Kind != EventUndo || events[len(events)-2].Kind != EventBet {
+		t.Errorf("Test failed - expected the raise to stay recorded, followed by an EventUndo")
+	}
+
+	if err := Undo(g, first, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a raise should only be undone once, got %v", err)
+	}
+
+	// A fold can be taken back too, but not once the next player has acted
+	if err := Fold(g, first, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+	if err := Undo(g, first, 0); err != nil || !g.players[first].In {
+		t.Errorf("Test failed - expected the fold to be taken back, got %v", err)
+	}
+
+	if err := Bet(g, first, 25); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if err := Bet(g, g.actionNum, g.toCall()-g.players[g.actionNum].Bet); err != nil {
+		t.Fatalf("Test failed - error calling: %s", err)
+	}
+	if err := Undo(g, first, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - a call shouldn't be undone after the next player acts, got %v", err)
+	}
+
+	// Checking closes the betting, and the flop is dealt
+	last := g.actionNum
+	if err := Bet(g, last, 0); err != nil {
+		t.Fatalf("Test failed - error checking: %s", err)
+	}
+	if g.getStage() != Flop {
+		t.Fatalf("Test failed - expected the flop to be dealt, got stage %d", g.getStage())
+	}
+	if err := Undo(g, last, 0); err != ErrIllegalAction {
+		t.Errorf("Test failed - an action that ended the betting round shouldn't be undone, got %v", err)
+	}
+}
+
+func TestUndo_Disabled(t *testing.T) {
+	g := seatedGame(t, nil, 2, 1000)
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+	pn := g.actionNum
+	if err := Fold(g, pn, 0); err != nil {
+		t.Fatalf("Test failed - error folding: %s", err)
+	}
+
+	if err := Undo(g, pn, 0); err != ErrFeatureDisabled {
+		t.Errorf("Test failed - expected Undo to be disabled, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// ValidateAction reports whether pn could perform a with data right now, by performing it on a copy of g, so clients
+// can check an Action (or a bet size) before committing to it. It returns exactly the error the Action would return,
+// or nil if the Action would succeed. g itself is never changed: no Events are recorded or passed to subscribers, and
+// a rejection doesn't count against g's RejectLimit.
+func ValidateAction(g *Game, a Action, pn uint, data uint) error {
+	c := g.clone()
+	// The Action may end the hand, which rewrites its Events (see discardHands), but never reads further back
+	c.events = copyEvents(g.events[g.handStartIndex():])
+
+	return a(c, pn, data)
+}
+
+// clone returns a copy of g that can be mutated without affecting g. The copy has no subscribers, and no Events: the
+// event log grows with every hand, so copying it would make every clone slower than the last, and callers copy only
+// what they need of it.
+func (g *Game) clone() *Game {
+	c := *g
+
+	c.communityCards = append([]eval.Card{}, g.communityCards...)
+	c.config = copyConfig(g.config)
+	c.players = append([]Player{}, g.players...)
+	c.deck = append([]eval.Card{}, g.deck...)
+	c.burns = append([]eval.Card{}, g.burns...)
+	c.showdownOrder = append([]uint{}, g.showdownOrder...)
+	c.pots = copyPots(g.pots)
+	c.rand = rand.New(rand.NewSource(g.config.Seed))
+	// Shuffling with g's own RNG would use up its randomness, and leave g dealing different cards
+	c.rng = nil
+	c.shuffleCommit = copyShuffleCommitment(g.shuffleCommit)
+	c.lastShuffle = copyShuffleCommitment(g.lastShuffle)
+	c.showdown = copyShowdown(g.showdown)
+	c.events = nil
+	c.subscribers = nil
+	c.cancelRanges = nil
+	c.cancelStats = nil
+	c.store = nil
+	c.decisionTimes = make(map[uint][]time.Duration, len(g.decisionTimes))
+	for pn, times := range g.decisionTimes {
+		// Capped, so the copy appending to them never writes into g's
+		c.decisionTimes[pn] = times[:len(times):len(times)]
+	}
+	c.lastEmote = make(map[uint]time.Time, len(g.lastEmote))
+	for pn, t := range g.lastEmote {
+		c.lastEmote[pn] = t
+	}
+	c.rejections = make(map[uint][]time.Time, len(g.rejections))
+	for pn, times := range g.rejections {
+		c.rejections[pn] = append([]time.Time{}, times...)
+	}
+	c.ranges = copyRanges(g.ranges)
+	c.startStacks = append([]uint{}, g.startStacks...)
+	if g.knockouts != nil {
+		c.knockouts = make(map[uint][]uint, len(g.knockouts))
+		for pn, by := range g.knockouts {
+			c.knockouts[pn] = append([]uint{}, by...)
+		}
+	}
+	c.rematch = copyRematch(g.rematch)
+
+	return &c
+}
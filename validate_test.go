@@ -0,0 +1,103 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateAction(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, RejectLimit: 1})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	notified := 0
+	cancel := g.Subscribe(func(Event) { notified++ })
+	defer cancel()
+
+	before := g.GenerateOmniView()
+	events := len(g.Events())
+
+	tests := []struct {
+		pn   uint
+		a    Action
+		data uint
+		want error
+	}{
+		{0, Bet, 25, nil},
+		{0, Bet, 1000, nil},
+		{0, Bet, 30, ErrIllegalAction},
+		{1, Bet, 25, ErrIllegalAction},
+		{0, Fold, 0, nil},
+		{0, Deal, 0, ErrIllegalAction},
+	}
+
+	for _, tt := range tests {
+		if err := ValidateAction(g, tt.a, tt.pn, tt.data); err != tt.want {
+			t.Errorf("Test failed - validating player %d's action with %d returned %v, expected %v", tt.pn, tt.data, err, tt.want)
+		}
+	}
+
+	if !reflect.DeepEqual(g.GenerateOmniView(), before) || len(g.Events()) != events || notified != 0 {
+		t.Errorf("Test failed - validating actions should not change the game")
+	}
+
+	// Rejected dry runs don't count against the RejectLimit
+	if err := g.Apply(Bet, 0, 25); err != nil {
+		t.Errorf("Test failed - error betting after dry runs: %s", err)
+	}
+}
+
+func TestValidateAction_HandEnd(t *testing.T) {
+	config := defaultConfig
+	config.Retention = RetainShown
+	g := seatedGame(t, &config, 2, 1000)
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Folding ends the hand, and discards the hole cards, but only in the copy
+	events := g.Events()
+	if err := ValidateAction(g, Fold, g.actionNum, 0); err != nil {
+		t.Fatalf("Test failed - error validating a fold: %s", err)
+	}
+
+	if !reflect.DeepEqual(g.Events(), events) {
+		t.Errorf("Test failed - validating a hand-ending action should not change the event log")
+	}
+}
@@ -0,0 +1,198 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+// Variant is a form of poker that a Game can deal.
+type Variant uint8
+
+const (
+	// HoldEm is no-limit Texas hold'em, and the default
+	HoldEm Variant = iota
+	// ShortDeck is short deck (6+) hold'em: hold'em dealt from a 36 card deck with the deuces through fives
+	// removed, in which a flush beats a full house, and A-9-8-7-6 is a straight. See eval.ShortDeckHandValue.
+	ShortDeck
+	// Pineapple is hold'em in which each player is dealt three hole cards, and discards one (see Discard) before
+	// the betting before the flop
+	Pineapple
+	// CrazyPineapple is like Pineapple, but players discard after the flop is dealt, before the betting on it
+	CrazyPineapple
+	// Omaha is hold'em in which each player is dealt four hole cards (see Player.ExtraCards), and makes their hand
+	// from exactly two of them and exactly three community cards. Played with the HiLo rule, it is Omaha hi-lo
+	// (eight or better), in which the low hand is made the same way.
+	Omaha
+	// Razz is seven card stud played for ace-to-five low: each player is dealt two cards face down and one face up
+	// (see Player.UpCards), three more face up on the streets after, and a last one face down (see
+	// Player.ExtraCards), and makes their best low from any five of their seven. There are no community cards, and
+	// no blinds: everybody antes, the highest card showing brings it in for the SmallBlind, and the best low showing
+	// acts first on every street after. The BigBlind is the small bet. Flushes and straights don't count against a
+	// low, and there is no qualifier, so the best low always wins. Having no blinds or community cards, Razz ignores
+	// the rules built on them, like straddles, kill blinds, missed blinds, HiLo, the seven-deuce bounty and the
+	// high-hand promotion. It deals seven players at most.
+	Razz
+)
+
+// stud reports whether the variant is a stud game, which deals each player cards of their own, some face up, instead
+// of community cards
+func (v Variant) stud() bool {
+	return v == Razz
+}
+
+// deck returns every card the variant is dealt from
+func (v Variant) deck() eval.Deck {
+	if v == ShortDeck {
+		return eval.ShortDeck
+	}
+
+	return eval.DefaultDeck
+}
+
+// Street describes one stage of a hand of some Variant: the cards dealt at the start of it, and whether players
+// discard before it is bet. Every street ends with a round of betting, and a hand that gets through its last street
+// goes to showdown.
+type Street struct {
+	Stage GameStage
+	// HoleCards is how many cards each player in the hand is dealt face down
+	HoleCards int
+	// CommunityCards is how many community cards are dealt face up
+	CommunityCards int
+	// UpCards is how many cards each player in the hand is dealt face up, in stud variants
+	UpCards int
+	// Discard is true if players discard down to two hole cards before betting opens (see Discard)
+	Discard bool
+}
+
+// The streets of flop games, which the other variants vary
+var (
+	preFlop = Street{Stage: PreFlop, HoleCards: 2}
+	flop    = Street{Stage: Flop, CommunityCards: 3}
+	turn    = Street{Stage: Turn, CommunityCards: 1}
+	river   = Street{Stage: River, CommunityCards: 1}
+)
+
+// razzStreets is the schedule of Razz, which doesn't vary the flop games' streets, but replaces them
+var razzStreets = []Street{
+	{Stage: ThirdStreet, HoleCards: 2, UpCards: 1},
+	{Stage: FourthStreet, UpCards: 1},
+	{Stage: FifthStreet, UpCards: 1},
+	{Stage: SixthStreet, UpCards: 1},
+	{Stage: SeventhStreet, HoleCards: 1},
+}
+
+// Schedule returns the streets of a hand of the variant, in the order they are dealt. The first street deals the
+// hole cards, and the blinds (or in stud, the antes and bring-in) are posted before it is bet.
+func (v Variant) Schedule() []Street {
+	switch v {
+	case Razz:
+		return append([]Street{}, razzStreets...)
+	case Pineapple:
+		return []Street{{Stage: PreFlop, HoleCards: 3, Discard: true}, flop, turn, river}
+	case CrazyPineapple:
+		return []Street{{Stage: PreFlop, HoleCards: 3}, {Stage: Flop, CommunityCards: 3, Discard: true}, turn, river}
+	case Omaha:
+		return []Street{{Stage: PreFlop, HoleCards: 4}, flop, turn, river}
+	}
+
+	return []Street{preFlop, flop, turn, river}
+}
+
+// street returns the variant's street for stage, and false if it doesn't have one
+func (v Variant) street(stage GameStage) (Street, bool) {
+	for _, s := range v.Schedule() {
+		if s.Stage == stage {
+			return s, true
+		}
+	}
+
+	return Street{}, false
+}
+
+// lastStage returns the stage of the variant's last street, after which the hand goes to showdown
+func (v Variant) lastStage() GameStage {
+	schedule := v.Schedule()
+	return schedule[len(schedule)-1].Stage
+}
+
+// discardStage returns the stage at the start of which players discard down to two hole cards, or 0 if they don't
+func (v Variant) discardStage() GameStage {
+	for _, s := range v.Schedule() {
+		if s.Discard {
+			return s.Stage
+		}
+	}
+
+	return 0
+}
+
+// handValue scores a five card hand according to the variant's hand rankings. Lower scores are better.
+func (v Variant) handValue(c0, c1, c2, c3, c4 eval.Card) int {
+	if v == ShortDeck {
+		return eval.ShortDeckHandValue(c0, c1, c2, c3, c4)
+	}
+
+	return eval.HandValue(c0, c1, c2, c3, c4)
+}
+
+// bestFiveOfSeven finds the best hand, and its score, from a player's two hole cards and the five community cards,
+// according to the variant's hand rankings. Lower scores are better.
+func (v Variant) bestFiveOfSeven(c0, c1, c2, c3, c4, c5, c6 eval.Card) ([]eval.Card, int) {
+	if v == ShortDeck {
+		return eval.ShortDeckBestFiveOfSeven(c0, c1, c2, c3, c4, c5, c6)
+	}
+
+	return eval.BestFiveOfSeven(c0, c1, c2, c3, c4, c5, c6)
+}
+
+// bestHand finds the best hand, and its score, from a player's hole cards (see Player.holeCards) and the five
+// community cards (in stud, their four up cards; see Game.board), according to the variant's hand rankings: in
+// Omaha, exactly two hole cards and three community cards, in Razz, the best ace-to-five low, and otherwise, any five
+// of the seven. Lower scores are better.
+func (v Variant) bestHand(hole []eval.Card, board []eval.Card) ([]eval.Card, int) {
+	switch v {
+	case Razz:
+		return eval.BestLowFiveOfSeven(hole[0], hole[1], hole[2], board[0], board[1], board[2], board[3])
+	case Omaha:
+		return eval.BestOmahaHand(
+			[4]eval.Card{hole[0], hole[1], hole[2], hole[3]},
+			[5]eval.Card{board[0], board[1], board[2], board[3], board[4]},
+		)
+	}
+
+	return v.bestFiveOfSeven(hole[0], hole[1], board[0], board[1], board[2], board[3], board[4])
+}
+
+// bestLow is bestHand for the best ace-to-five low, which is made the same way in every variant that has one
+func (v Variant) bestLow(hole []eval.Card, board []eval.Card) ([]eval.Card, int) {
+	if v == Omaha {
+		return eval.BestLowOmahaHand(
+			[4]eval.Card{hole[0], hole[1], hole[2], hole[3]},
+			[5]eval.Card{board[0], board[1], board[2], board[3], board[4]},
+		)
+	}
+
+	return eval.BestLowFiveOfSeven(hole[0], hole[1], board[0], board[1], board[2], board[3], board[4])
+}
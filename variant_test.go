@@ -0,0 +1,132 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"testing"
+
+	"github.com/alexclewontin/riverboat/eval"
+)
+
+func TestGame_ShortDeck(t *testing.T) {
+	config := defaultConfig
+	config.Variant = ShortDeck
+	g := NewGame(&config)
+
+	for i := 0; i < 4; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 1000); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	if want := len(eval.ShortDeck) - 8; len(g.deck) != want {
+		t.Errorf("Test failed - expected %d cards left in the deck, got %d", want, len(g.deck))
+	}
+
+	inShortDeck := make(map[eval.Card]bool)
+	for _, c := range eval.ShortDeck {
+		inShortDeck[c] = true
+	}
+
+	for _, p := range g.players {
+		for _, c := range p.Cards {
+			if !inShortDeck[c] {
+				t.Errorf("Test failed - %s was dealt, but isn't in the short deck", c)
+			}
+		}
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	for _, v := range []Variant{HoldEm, ShortDeck, Pineapple, CrazyPineapple, Omaha} {
+		schedule := v.Schedule()
+
+		if schedule[0].Stage != PreFlop || schedule[0].HoleCards == 0 {
+			t.Errorf("Test failed - variant %d doesn't deal hole cards preflop", v)
+		}
+
+		cc := 0
+		for i, s := range schedule {
+			if i > 0 && s.Stage != schedule[i-1].Stage+1 {
+				t.Errorf("Test failed - variant %d skips from stage %d to %d", v, schedule[i-1].Stage, s.Stage)
+			}
+			cc += s.CommunityCards
+		}
+
+		if cc != 5 {
+			t.Errorf("Test failed - variant %d deals %d community cards, expected 5", v, cc)
+		}
+
+		if v.lastStage() != River {
+			t.Errorf("Test failed - variant %d ends on stage %d, expected the river", v, v.lastStage())
+		}
+	}
+
+	if Pineapple.discardStage() != PreFlop || CrazyPineapple.discardStage() != Flop || HoldEm.discardStage() != 0 {
+		t.Errorf("Test failed - discard stages don't match the schedules")
+	}
+}
+
+func TestGame_Omaha(t *testing.T) {
+	config := defaultConfig
+	config.Variant = Omaha
+	g := seatedGame(t, &config, 3, 1000)
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	if want := len(eval.DefaultDeck) - 12; len(g.deck) != want {
+		t.Errorf("Test failed - expected %d cards left in the deck, got %d", want, len(g.deck))
+	}
+
+	for _, e := range g.Events() {
+		if e.Kind == EventHoleCards && len(e.Cards) != 4 {
+			t.Errorf("Test failed - expected player %d to be dealt four hole cards, got %v", e.PlayerNum, e.Cards)
+		}
+	}
+
+	view := g.GeneratePlayerView(0)
+	if view.Players[0].ExtraCards != g.players[0].ExtraCards || view.Players[1].ExtraCards != [2]eval.Card{} {
+		t.Errorf("Test failed - player 0 should see only their own four hole cards")
+	}
+
+	if m := view.ToProto(); len(m.GetPlayers()[0].GetCards()) != 4 {
+		t.Errorf("Test failed - expected all four hole cards in the protobuf message")
+	}
+
+	back := &GameView{}
+	back.FromProto(view.ToProto())
+	if back.Players[0].Cards != g.players[0].Cards || back.Players[0].ExtraCards != g.players[0].ExtraCards {
+		t.Errorf("Test failed - the third and fourth hole cards didn't survive a round trip through protobuf")
+	}
+}
@@ -0,0 +1,176 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ViewField is a set of GameView fields, for consumers that only need part of the table state (like a ticker that
+// only shows the pots and whose turn it is). Fields are combined with bitwise or.
+type ViewField uint64
+
+const (
+	FieldDealerNum ViewField = 1 << iota
+	FieldActionNum
+	FieldUTGNum
+	FieldSBNum
+	FieldBBNum
+	FieldCalledNum
+	FieldCommunityCards
+	FieldStage
+	FieldBetting
+	FieldConfig
+	FieldPlayers
+	FieldDeck
+	FieldPots
+	FieldMinRaise
+	FieldReadyCount
+	FieldShowdown
+	FieldRanges
+	FieldCarryover
+	FieldVariant
+	FieldRotationNum
+	FieldRotationHands
+	FieldRematch
+	FieldHandEnded
+	FieldActionDeadline
+	FieldShuffle
+	FieldLastShuffle
+	FieldBurns
+	FieldShowdownOrder
+	FieldPaused
+	FieldKill
+	FieldKillNum
+	FieldLastPotWinner
+	FieldLastPotWon
+	FieldHighHand
+
+	// FieldAll is every field of GameView
+	FieldAll ViewField = 1<<iota - 1
+)
+
+// viewFields describes each ViewField: its name in GameView's JSON encoding, and how to get it from a GameView
+var viewFields = []struct {
+	field ViewField
+	name  string
+	get   func(gv *GameView) interface{}
+}{
+	{FieldDealerNum, "dealerNum", func(gv *GameView) interface{} { return gv.DealerNum }},
+	{FieldActionNum, "actionNum", func(gv *GameView) interface{} { return gv.ActionNum }},
+	{FieldUTGNum, "utgNum", func(gv *GameView) interface{} { return gv.UTGNum }},
+	{FieldSBNum, "sbNum", func(gv *GameView) interface{} { return gv.SBNum }},
+	{FieldBBNum, "bbNum", func(gv *GameView) interface{} { return gv.BBNum }},
+	{FieldCalledNum, "calledNum", func(gv *GameView) interface{} { return gv.CalledNum }},
+	{FieldCommunityCards, "communityCards", func(gv *GameView) interface{} { return gv.CommunityCards }},
+	{FieldStage, "stage", func(gv *GameView) interface{} { return gv.Stage }},
+	{FieldBetting, "betting", func(gv *GameView) interface{} { return gv.Betting }},
+	{FieldConfig, "config", func(gv *GameView) interface{} { return gv.Config }},
+	{FieldPlayers, "players", func(gv *GameView) interface{} { return gv.Players }},
+	{FieldDeck, "deck", func(gv *GameView) interface{} { return gv.Deck }},
+	{FieldPots, "pots", func(gv *GameView) interface{} { return gv.Pots }},
+	{FieldMinRaise, "minRaise", func(gv *GameView) interface{} { return gv.MinRaise }},
+	{FieldReadyCount, "readyCount", func(gv *GameView) interface{} { return gv.ReadyCount }},
+	{FieldShowdown, "showdown", func(gv *GameView) interface{} { return gv.Showdown }},
+	{FieldRanges, "ranges", func(gv *GameView) interface{} { return gv.Ranges }},
+	{FieldCarryover, "carryover", func(gv *GameView) interface{} { return gv.Carryover }},
+	{FieldVariant, "variant", func(gv *GameView) interface{} { return gv.Variant }},
+	{FieldRotationNum, "rotationNum", func(gv *GameView) interface{} { return gv.RotationNum }},
+	{FieldRotationHands, "rotationHands", func(gv *GameView) interface{} { return gv.RotationHands }},
+	{FieldRematch, "rematch", func(gv *GameView) interface{} { return gv.Rematch }},
+	{FieldHandEnded, "handEnded", func(gv *GameView) interface{} { return gv.HandEnded }},
+	{FieldActionDeadline, "actionDeadline", func(gv *GameView) interface{} { return gv.ActionDeadline }},
+	{FieldShuffle, "shuffle", func(gv *GameView) interface{} { return gv.Shuffle }},
+	{FieldLastShuffle, "lastShuffle", func(gv *GameView) interface{} { return gv.LastShuffle }},
+	{FieldBurns, "burns", func(gv *GameView) interface{} { return gv.Burns }},
+	{FieldShowdownOrder, "showdownOrder", func(gv *GameView) interface{} { return gv.ShowdownOrder }},
+	{FieldPaused, "paused", func(gv *GameView) interface{} { return gv.Paused }},
+	{FieldKill, "kill", func(gv *GameView) interface{} { return gv.Kill }},
+	{FieldKillNum, "killNum", func(gv *GameView) interface{} { return gv.KillNum }},
+	{FieldLastPotWinner, "lastPotWinner", func(gv *GameView) interface{} { return gv.LastPotWinner }},
+	{FieldLastPotWon, "lastPotWon", func(gv *GameView) interface{} { return gv.LastPotWon }},
+	{FieldHighHand, "highHand", func(gv *GameView) interface{} { return gv.HighHand }},
+}
+
+// ParseViewFields parses a comma-separated list of GameView JSON field names (like "pots,actionNum") into a
+// ViewField. It returns ErrUnknownField if any of the names isn't one.
+func ParseViewFields(s string) (ViewField, error) {
+	var fields ViewField
+
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, f := range viewFields {
+			if f.name == name {
+				fields |= f.field
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, ErrUnknownField
+		}
+	}
+
+	return fields, nil
+}
+
+// ViewDelta holds the fields of a GameView that have changed, keyed by their names in GameView's JSON encoding.
+type ViewDelta map[string]interface{}
+
+// ViewFilter turns a stream of GameViews into a stream of ViewDeltas, containing only the fields a consumer asked
+// for, and only when they change. ViewFilters should not be initialized directly, only through the NewViewFilter
+// factory function.
+type ViewFilter struct {
+	fields ViewField
+	last   *GameView
+}
+
+// NewViewFilter returns a ViewFilter for fields, which has not yet seen any views.
+func NewViewFilter(fields ViewField) *ViewFilter {
+	return &ViewFilter{fields: fields}
+}
+
+// Delta returns the filter's fields of gv that differ from the last view passed to Delta. The first call returns
+// every one of the filter's fields. If nothing has changed, Delta returns an empty ViewDelta. The filter keeps gv, so
+// it must not be modified afterwards.
+func (f *ViewFilter) Delta(gv *GameView) ViewDelta {
+	delta := ViewDelta{}
+
+	for _, vf := range viewFields {
+		if f.fields&vf.field == 0 {
+			continue
+		}
+
+		val := vf.get(gv)
+		if f.last == nil || !reflect.DeepEqual(val, vf.get(f.last)) {
+			delta[vf.name] = val
+		}
+	}
+
+	f.last = gv
+
+	return delta
+}
@@ -0,0 +1,74 @@
+//* Copyright (c) 2020, Alex Lewontin
+//* All rights reserved.
+//*
+//* Redistribution and use in source and binary forms, with or without
+//* modification, are permitted provided that the following conditions are met:
+//*
+//* - Redistributions of source code must retain the above copyright notice, this
+//* list of conditions and the following disclaimer.
+//* - Redistributions in binary form must reproduce the above copyright notice,
+//* this list of conditions and the following disclaimer in the documentation
+//* and/or other materials provided with the distribution.
+//*
+//* THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS" AND
+//* ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE IMPLIED
+//* WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE ARE
+//* DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE
+//* FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL
+//* DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR
+//* SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER
+//* CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
+//* OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+//* OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+
+package riverboat
+
+import "testing"
+
+func TestParseViewFields(t *testing.T) {
+	fields, err := ParseViewFields("pots, actionNum")
+	if err != nil || fields != FieldPots|FieldActionNum {
+		t.Errorf("Test failed - ParseViewFields returned %b, %v", fields, err)
+	}
+
+	if _, err := ParseViewFields("pots,nope"); err != ErrUnknownField {
+		t.Errorf("Test failed - ParseViewFields should reject unknown fields, got %v", err)
+	}
+}
+
+func TestViewFilter_Delta(t *testing.T) {
+	g := NewGame(nil)
+	pn_a := g.AddPlayer()
+	pn_b := g.AddPlayer()
+
+	for _, pn := range []uint{pn_a, pn_b} {
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	f := NewViewFilter(FieldActionNum | FieldStage)
+
+	if delta := f.Delta(g.GeneratePlayerView(pn_a)); len(delta) != 2 {
+		t.Errorf("Test failed - the first delta should hold every field asked for, got %v", delta)
+	}
+
+	if delta := f.Delta(g.GeneratePlayerView(pn_a)); len(delta) != 0 {
+		t.Errorf("Test failed - nothing has changed, but got %v", delta)
+	}
+
+	if err := Deal(g, pn_a, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	delta := f.Delta(g.GeneratePlayerView(pn_a))
+	if delta["stage"] != PreFlop {
+		t.Errorf("Test failed - the delta should hold the new stage, got %v", delta)
+	}
+	if _, ok := delta["players"]; ok {
+		t.Errorf("Test failed - the delta should not hold fields that weren't asked for, got %v", delta)
+	}
+}
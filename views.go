@@ -25,30 +25,85 @@ package riverboat
 
 import (
 	"math/rand"
+	"time"
 
 	"github.com/alexclewontin/riverboat/eval"
 )
 
 // GameView is the type that represents a snapshot of a Game's state.
 type GameView struct {
-	DealerNum      uint
-	ActionNum      uint
-	UTGNum         uint
-	SBNum          uint
-	BBNum          uint
-	CalledNum      uint
-	CommunityCards []eval.Card
-	Stage          GameStage
-	Betting        bool
-	Config         GameConfig
-	Players        []Player
-	Deck           eval.Deck
-	Pots           []Pot
-	MinRaise       uint
-	ReadyCount     uint
+	DealerNum      uint             `json:"dealerNum"`
+	ActionNum      uint             `json:"actionNum"`
+	UTGNum         uint             `json:"utgNum"`
+	SBNum          uint             `json:"sbNum"`
+	BBNum          uint             `json:"bbNum"`
+	CalledNum      uint             `json:"calledNum"`
+	CommunityCards []eval.Card      `json:"communityCards"`
+	Stage          GameStage        `json:"stage"`
+	Betting        bool             `json:"betting"`
+	Config         GameConfig       `json:"config"`
+	Players        []Player         `json:"players"`
+	Deck           eval.Deck        `json:"deck"`
+	Pots           []Pot            `json:"pots"`
+	MinRaise       uint             `json:"minRaise"`
+	ReadyCount     uint             `json:"readyCount"`
+	Showdown       []ShowdownReveal `json:"showdown"`
+	Ranges         []Range          `json:"ranges,omitempty"`
+	// Carryover is the amount held over from the last hand to be added to the main pot (see OddChipCarryOver)
+	Carryover uint `json:"carryover"`
+	// Variant is the variant being dealt. In a mixed game, RotationNum is its index in Config.Rotation, and
+	// RotationHands is how many hands of it have been played so far.
+	Variant       Variant `json:"variant"`
+	RotationNum   uint    `json:"rotationNum"`
+	RotationHands uint    `json:"rotationHands"`
+	// Rematch is the open rematch offer, if there is one
+	Rematch *RematchOffer `json:"rematch,omitempty"`
+	// HandEnded is when the last hand ended. It is kept as a time, rather than as the time left before the next
+	// deal, so a Game restored from the view (even much later) still deals on schedule (see NextAdvance).
+	HandEnded time.Time `json:"handEnded"`
+	// ActionDeadline is when the player the Game is waiting on runs out of time, or the zero time if there is no
+	// deadline (see Game.ActionDeadline)
+	ActionDeadline time.Time `json:"actionDeadline"`
+	// Seq is the sequence number of the last Event recorded before the view was generated (see Event), so views of
+	// the same Game can be put in order
+	Seq uint64 `json:"seq"`
+	// Shuffle is the commitment to the current hand's shuffle (or, between hands, the next one's), and LastShuffle
+	// the revealed commitment of the last hand, if the Game commits to its shuffles (see GameConfig.CommitShuffle).
+	// Only omniscient views reveal anything about Shuffle but its Commitment.
+	Shuffle     *ShuffleCommitment `json:"shuffle,omitempty"`
+	LastShuffle *ShuffleCommitment `json:"lastShuffle,omitempty"`
+	// Burns are the cards burned so far this hand, in order (see GameConfig.BurnCards). Like the Deck, only
+	// omniscient views show them.
+	Burns []eval.Card `json:"burns"`
+	// ShowdownOrder holds the players still to show or muck at a showdown, in turn, at tables that play
+	// RuleSet.ShowOrMuck. The first of them is the one the showdown is waiting on (see Show).
+	ShowdownOrder []uint `json:"showdownOrder"`
+	// Paused is true if the Game has been paused, so no new hand will be dealt until it is resumed (see Game.Pause).
+	// A hand that was being played when the Game was paused is played out.
+	Paused bool `json:"paused"`
+	// Kill is true if the hand being played (or, between hands, the next one) is a kill pot, with KillNum posting the
+	// kill blind (see GameConfig.Kill)
+	Kill    bool `json:"kill"`
+	KillNum uint `json:"killNum"`
+	// LastPotWinner is the player who won the whole of the main pot in the last hand, if LastPotWon is true. A player
+	// who wins the next one too kills the hand after it.
+	LastPotWinner uint `json:"lastPotWinner"`
+	LastPotWon    bool `json:"lastPotWon"`
+	// HighHand is the best hand shown down in the high-hand promotion, if the Game runs one and a hand has
+	// qualified. It may have been shown in an earlier promotion than the current one (see GenerateHighHandView).
+	HighHand *HighHand `json:"highHand,omitempty"`
 }
 
 func (g *Game) copyToView() *GameView {
+	view := &GameView{}
+	g.fillView(view)
+
+	return view
+}
+
+// fillView overwrites every field of view with the Game's state, reusing the memory behind view's slices and
+// pointers where it can.
+func (g *Game) fillView(view *GameView) {
 	//TODO: Is there some way to do this programatically? I considered using
 	// reflection, but since that happens at runtime it is less performant.
 	// Something like reflection, but evaluated at compile-time would be ideal
@@ -57,43 +112,168 @@ func (g *Game) copyToView() *GameView {
 	//WARNING: This needs to be the deepest of deep copies. If adding a field,
 	//make sure that it is. An example: copying a slice of structs, where the struct
 	//has a field that is a slice: this doesn't work by default. Write a helper function.
-	view := &GameView{
+	*view = GameView{
 		DealerNum:      g.dealerNum,
 		ActionNum:      g.actionNum,
 		UTGNum:         g.utgNum,
 		SBNum:          g.sbNum,
 		BBNum:          g.bbNum,
-		CommunityCards: append([]eval.Card{}, g.communityCards...),
+		CommunityCards: fillCards(view.CommunityCards, g.communityCards),
 		Stage:          g.getStage(),
 		Betting:        g.getBetting(),
-		Config:         g.config,
-		Players:        append([]Player{}, g.players...),
-		Deck:           append([]eval.Card{}, g.deck...),
-		Pots:           copyPots(g.pots),
+		Config:         fillConfig(view.Config, g.config),
+		Players:        fillPlayers(view.Players, g.players),
+		Deck:           fillCards(view.Deck, g.deck),
+		Pots:           fillPots(view.Pots, g.pots),
 		MinRaise:       g.minRaise,
 		ReadyCount:     g.readyCount(),
 		CalledNum:      g.calledNum,
+		Showdown:       fillShowdown(view.Showdown, g.showdown),
+		Ranges:         fillRanges(view.Ranges, g.ranges),
+		Carryover:      g.carryover,
+		Variant:        g.variant(),
+		RotationNum:    g.rotationNum,
+		RotationHands:  g.rotationHands,
+		Rematch:        fillRematch(view.Rematch, g.rematch),
+		HandEnded:      g.handEnded,
+		Seq:            g.eventSeq,
+		Shuffle:        fillShuffleCommitment(view.Shuffle, g.shuffleCommit),
+		LastShuffle:    fillShuffleCommitment(view.LastShuffle, g.lastShuffle),
+		Burns:          fillCards(view.Burns, g.burns),
+		ShowdownOrder:  fillUints(view.ShowdownOrder, g.showdownOrder),
+		Paused:         g.paused,
+		Kill:           g.kill,
+		KillNum:        g.killNum,
+		LastPotWinner:  g.lastWinner,
+		LastPotWon:     g.lastWon,
+		HighHand:       copyHighHand(g.highHand),
 	}
 
-	return view
+	view.ActionDeadline, _ = g.ActionDeadline()
 }
 
 func copyPots(src []Pot) []Pot {
-	ret := make([]Pot, len(src))
+	return fillPots(nil, src)
+}
+
+// The fill helpers below copy src into dst's memory if it has room, and return the copy. Like copying with append
+// into an empty slice, they never return a nil slice, even for a nil src (except for Ranges, where nil means
+// range tracking is off).
+
+func fillCards(dst, src []eval.Card) []eval.Card {
+	if dst == nil {
+		dst = []eval.Card{}
+	}
+	return append(dst[:0], src...)
+}
+
+func fillUints(dst, src []uint) []uint {
+	if dst == nil {
+		dst = []uint{}
+	}
+	return append(dst[:0], src...)
+}
+
+func fillPlayers(dst, src []Player) []Player {
+	if dst == nil {
+		dst = []Player{}
+	}
+	return append(dst[:0], src...)
+}
+
+func fillShowdown(dst, src []ShowdownReveal) []ShowdownReveal {
+	if dst == nil {
+		dst = []ShowdownReveal{}
+	}
+	return append(dst[:0], src...)
+}
+
+func fillConfig(dst, src GameConfig) GameConfig {
+	rotation := dst.Rotation
+	dst = src
+	dst.Rotation = nil
+	if len(src.Rotation) > 0 {
+		dst.Rotation = append(rotation[:0], src.Rotation...)
+	}
+
+	return dst
+}
+
+func fillPots(dst, src []Pot) []Pot {
+	if dst == nil {
+		dst = []Pot{}
+	}
+	// Growing dst keeps the Pots already in it, so their slices can be reused too
+	if cap(dst) < len(src) {
+		dst = append(dst[:cap(dst)], make([]Pot, len(src)-cap(dst))...)
+	}
+	dst = dst[:len(src)]
+
 	for i := range src {
-		ret[i].Amt = src[i].Amt
-		ret[i].TopShare = src[i].TopShare
-		ret[i].WinningScore = src[i].WinningScore
-		ret[i].EligiblePlayerNums = append([]uint{}, src[i].EligiblePlayerNums...)
-		ret[i].WinningPlayerNums = append([]uint{}, src[i].WinningPlayerNums...)
-		ret[i].WinningHand = append([]eval.Card{}, src[i].WinningHand...)
+		p := src[i]
+		p.EligiblePlayerNums = fillUints(dst[i].EligiblePlayerNums, src[i].EligiblePlayerNums)
+		p.WinningPlayerNums = fillUints(dst[i].WinningPlayerNums, src[i].WinningPlayerNums)
+		p.WinningHand = fillCards(dst[i].WinningHand, src[i].WinningHand)
+		p.Contributions = fillUints(dst[i].Contributions, src[i].Contributions)
+		p.LowWinningPlayerNums = fillUints(dst[i].LowWinningPlayerNums, src[i].LowWinningPlayerNums)
+		p.LowWinningHand = fillCards(dst[i].LowWinningHand, src[i].LowWinningHand)
+		dst[i] = p
 	}
 
-	return ret
+	return dst
 }
 
-// FillFromView is primarily for loading a stored view from a persistence layer
+func fillRanges(dst, src []Range) []Range {
+	if src == nil {
+		return nil
+	}
+
+	if dst == nil {
+		dst = []Range{}
+	}
+	if cap(dst) < len(src) {
+		dst = append(dst[:cap(dst)], make([]Range, len(src)-cap(dst))...)
+	}
+	dst = dst[:len(src)]
+
+	for i := range src {
+		if src[i] == nil {
+			dst[i] = nil
+		} else {
+			if dst[i] == nil {
+				dst[i] = Range{}
+			}
+			dst[i] = append(dst[i][:0], src[i]...)
+		}
+	}
+
+	return dst
+}
+
+func fillRematch(dst, src *RematchOffer) *RematchOffer {
+	if src == nil || dst == nil {
+		return copyRematch(src)
+	}
+
+	*dst = *src
+	return dst
+}
+
+func fillShuffleCommitment(dst, src *ShuffleCommitment) *ShuffleCommitment {
+	if src == nil || dst == nil {
+		return copyShuffleCommitment(src)
+	}
+
+	dealt := dst.Dealt
+	*dst = *src
+	dst.Dealt = fillCards(dealt, src.Dealt)
+	return dst
+}
+
+// FillFromView is primarily for loading a stored view from a persistence layer. Views decoded
+// from older JSON schema versions are migrated as they are loaded.
 func (g *Game) FillFromView(gv *GameView) {
+
 	g.dealerNum = gv.DealerNum
 	g.actionNum = gv.ActionNum
 	g.utgNum = gv.UTGNum
@@ -101,13 +281,38 @@ func (g *Game) FillFromView(gv *GameView) {
 	g.sbNum = gv.SBNum
 	g.communityCards = append([]eval.Card{}, gv.CommunityCards...)
 	g.setStageAndBetting(gv.Stage, gv.Betting)
-	g.config = gv.Config
+	g.config = copyConfig(gv.Config)
 	g.players = append([]Player{}, gv.Players...)
+	migrateSeats(g.players)
 	g.deck = append([]eval.Card{}, gv.Deck...)
 	g.pots = copyPots(gv.Pots)
+	migratePots(g.pots)
 	g.minRaise = gv.MinRaise
 	g.rand = rand.New(rand.NewSource(g.config.Seed))
 	g.calledNum = gv.CalledNum
+	g.showdown = copyShowdown(gv.Showdown)
+	g.ranges = copyRanges(gv.Ranges)
+	g.carryover = gv.Carryover
+	g.rotationNum = gv.RotationNum
+	g.rotationHands = gv.RotationHands
+	g.rematch = copyRematch(gv.Rematch)
+	g.handEnded = gv.HandEnded
+	g.eventSeq = gv.Seq
+	g.shuffleCommit = copyShuffleCommitment(gv.Shuffle)
+	g.lastShuffle = copyShuffleCommitment(gv.LastShuffle)
+	g.burns = append([]eval.Card{}, gv.Burns...)
+	g.showdownOrder = append([]uint{}, gv.ShowdownOrder...)
+	g.paused = gv.Paused
+	g.kill = gv.Kill
+	g.killNum = gv.KillNum
+	g.lastWinner = gv.LastPotWinner
+	g.lastWon = gv.LastPotWon
+	g.highHand = copyHighHand(gv.HighHand)
+
+	// The decision being timed started long enough before the deadline for the player to have had all their time
+	if pn, ok := g.timedNum(); ok && !gv.ActionDeadline.IsZero() {
+		g.actionSince = gv.ActionDeadline.Add(-g.config.ActionTime - g.players[pn].TimeBank)
+	}
 }
 
 // GeneratePlayerView is primarily for creating a view that can be serialized for delivery to a specific player
@@ -115,11 +320,26 @@ func (g *Game) FillFromView(gv *GameView) {
 func (g *Game) GeneratePlayerView(pn uint) *GameView {
 	gv := g.copyToView()
 	gv.Deck = nil
+	gv.Burns = nil
 	gv.Config.Seed = 0
+	gv.Ranges = nil
+	if gv.Shuffle != nil {
+		gv.Shuffle = &ShuffleCommitment{Commitment: gv.Shuffle.Commitment}
+	}
 
 	// D. R. Y.!
-	hideCards := func(pn2 uint) { gv.Players[pn2].Cards = [2]eval.Card{0, 0} }
-	showCards := func(pn2 uint) { gv.Players[pn2].Cards = [2]eval.Card{g.players[pn2].Cards[0], g.players[pn2].Cards[1]} }
+	hideCards := func(pn2 uint) {
+		gv.Players[pn2].Cards = [2]eval.Card{0, 0}
+		gv.Players[pn2].ThirdCard = 0
+		gv.Players[pn2].ExtraCards = [2]eval.Card{0, 0}
+		// Discarded cards are never shown to anyone else
+		gv.Players[pn2].Discarded = 0
+	}
+	showCards := func(pn2 uint) {
+		gv.Players[pn2].Cards = [2]eval.Card{g.players[pn2].Cards[0], g.players[pn2].Cards[1]}
+		gv.Players[pn2].ThirdCard = g.players[pn2].ThirdCard
+		gv.Players[pn2].ExtraCards = g.players[pn2].ExtraCards
+	}
 
 	allInCount := 0
 	inCount := 0
@@ -130,7 +350,7 @@ func (g *Game) GeneratePlayerView(pn uint) *GameView {
 			hideCards(uint(i))
 		}
 
-		if p.allIn(gv.Stage) {
+		if p.allIn(gv.Stage, g.config.HandCap) {
 			allInCount++
 		}
 
@@ -157,40 +377,21 @@ func (g *Game) GeneratePlayerView(pn uint) *GameView {
 		}
 	}
 
-	if g.getStage() == PreDeal && inCount > 1 {
-		showCards(g.calledNum)
-
-		_, scoreToBeat := eval.BestFiveOfSeven(
-			g.players[g.calledNum].Cards[0],
-			g.players[g.calledNum].Cards[1],
-			g.communityCards[0],
-			g.communityCards[1],
-			g.communityCards[2],
-			g.communityCards[3],
-			g.communityCards[4],
-		)
-
-		for i := range g.players {
-			pni := (g.calledNum + uint(i)) % uint(len(g.players))
-			_, iScore := eval.BestFiveOfSeven(
-				g.players[pni].Cards[0],
-				g.players[pni].Cards[1],
-				g.communityCards[0],
-				g.communityCards[1],
-				g.communityCards[2],
-				g.communityCards[3],
-				g.communityCards[4],
-			)
-
-			if (iScore <= scoreToBeat) && g.players[pni].In {
-				showCards(pni)
-				scoreToBeat = iScore
+	for i, p := range g.players {
+		for j := range p.Shown {
+			if p.Shown[j] {
+				gv.Players[i].Cards[j] = p.Cards[j]
 			}
 		}
+	}
 
-		for _, pot := range g.pots {
-			for _, j := range pot.WinningPlayerNums {
-				showCards(j)
+	if g.getStage() == PreDeal || g.showdownPending() {
+		for i, r := range gv.Showdown {
+			if r.Mucked {
+				gv.Showdown[i].Cards = [2]eval.Card{0, 0}
+				gv.Showdown[i].ExtraCards = [2]eval.Card{0, 0}
+			} else {
+				showCards(r.PlayerNum)
 			}
 		}
 	}
@@ -198,8 +399,24 @@ func (g *Game) GeneratePlayerView(pn uint) *GameView {
 	return gv
 }
 
+// GenerateSpectatorView is primarily for creating a view that can be serialized for delivery to someone watching the
+// game without playing in it. The generated view holds only what anyone at the table can see at the moment it is
+// generated: nobody's hole cards (unless they have been turned face up, at an all-in or a showdown), and not the deck.
+func (g *Game) GenerateSpectatorView() *GameView {
+	// No player has this number, so GeneratePlayerView shows nobody's own cards
+	return g.GeneratePlayerView(uint(len(g.players)))
+}
+
 // GenerateOmniView is primarily for creating a view that can be serialized for delivery to a persistance layer, like a db or in-memory store
 // Nothing is censored, not even the contents of the deck
 func (g *Game) GenerateOmniView() *GameView {
 	return g.copyToView()
 }
+
+// FillOmniView is like GenerateOmniView, but overwrites gv instead of allocating a new GameView. The memory behind
+// gv's slices is reused wherever it has room, so a server that keeps a GameView to fill on every action rarely
+// allocates for it once the view has grown to size. Because of that, nothing else may still be using gv or anything
+// in it (like a slice of its Players) when FillOmniView is called.
+func (g *Game) FillOmniView(gv *GameView) {
+	g.fillView(gv)
+}
@@ -93,13 +93,16 @@ func TestGame_GenerateOmniView(t *testing.T) {
 					67115551,
 					134224677,
 				},
-				Stage:    PreDeal,
-				Betting:  true,
-				Config:   GameConfig{},
-				Players:  []Player{},
-				Deck:     eval.DefaultDeck,
-				Pots:     []Pot{},
-				MinRaise: 25,
+				Stage:         PreDeal,
+				Betting:       true,
+				Config:        GameConfig{},
+				Players:       []Player{},
+				Deck:          eval.DefaultDeck,
+				Pots:          []Pot{},
+				MinRaise:      25,
+				Showdown:      []ShowdownReveal{},
+				Burns:         []eval.Card{},
+				ShowdownOrder: []uint{},
 			},
 		},
 	}
@@ -292,3 +295,146 @@ func (g *Game) String() string {
 		g.minRaise,
 	)
 }
+
+func TestGame_GeneratePlayerView_Showdown(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 42})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	// Everyone calls preflop, then checks it down
+	for _, b := range [][2]uint{{0, 25}, {1, 15}, {2, 0}} {
+		if err := Bet(g, b[0], b[1]); err != nil {
+			t.Fatalf("Test failed - error betting: %s", err)
+		}
+	}
+	for street := 0; street < 3; street++ {
+		for _, pn := range []uint{1, 2, 0} {
+			if err := Bet(g, pn, 0); err != nil {
+				t.Fatalf("Test failed - error betting: %s", err)
+			}
+		}
+	}
+
+	omni := g.GenerateOmniView()
+	if len(omni.Showdown) != 3 {
+		t.Fatalf("Test failed - expected 3 reveals, got %+v", omni.Showdown)
+	}
+
+	if omni.Showdown[0].PlayerNum != 1 || omni.Showdown[0].Mucked {
+		t.Errorf("Test failed - first player to act must show first, got %+v", omni.Showdown[0])
+	}
+
+	for _, pn := range []uint{0, 1, 2} {
+		pv := g.GeneratePlayerView(pn)
+		for _, r := range pv.Showdown {
+			shown := pv.Players[r.PlayerNum].Cards != [2]eval.Card{0, 0}
+			if r.Mucked && r.PlayerNum != pn && (shown || r.Cards != [2]eval.Card{0, 0}) {
+				t.Errorf("Test failed - player %d can see mucked cards of player %d", pn, r.PlayerNum)
+			}
+			if !r.Mucked && !shown {
+				t.Errorf("Test failed - player %d cannot see shown cards of player %d", pn, r.PlayerNum)
+			}
+		}
+	}
+}
+
+func TestGame_GenerateSpectatorView(t *testing.T) {
+	g := NewGame(&GameConfig{BigBlind: 25, SmallBlind: 10, Seed: 42})
+
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		if err := BuyIn(g, pn, 100); err != nil {
+			t.Fatalf("Test failed - Error buying in: %s", err)
+		}
+		if err := ToggleReady(g, pn, 0); err != nil {
+			t.Fatalf("Test failed - Error marking ready: %s", err)
+		}
+	}
+
+	if err := Deal(g, 0, 0); err != nil {
+		t.Fatalf("Test failed - error dealing: %s", err)
+	}
+
+	sv := g.GenerateSpectatorView()
+	if len(sv.Deck) != 0 || sv.Config.Seed != 0 {
+		t.Errorf("Test failed - spectators should not see the deck, got %v and seed %d", sv.Deck, sv.Config.Seed)
+	}
+	for pn, p := range sv.Players {
+		if p.Cards != [2]eval.Card{0, 0} {
+			t.Errorf("Test failed - spectators should not see player %d's cards", pn)
+		}
+	}
+
+	// Everyone calls preflop, then checks it down
+	for _, b := range [][2]uint{{0, 25}, {1, 15}, {2, 0}} {
+		if err := Bet(g, b[0], b[1]); err != nil {
+			t.Fatalf("Test failed - error betting: %s", err)
+		}
+	}
+	for street := 0; street < 3; street++ {
+		for _, pn := range []uint{1, 2, 0} {
+			if err := Bet(g, pn, 0); err != nil {
+				t.Fatalf("Test failed - error betting: %s", err)
+			}
+		}
+	}
+
+	sv = g.GenerateSpectatorView()
+	if len(sv.Pots) == 0 || sv.Pots[0].Amt != 75 {
+		t.Errorf("Test failed - spectators should see the pot, got %+v", sv.Pots)
+	}
+	if len(sv.Showdown) != 3 {
+		t.Fatalf("Test failed - spectators should see the showdown, got %+v", sv.Showdown)
+	}
+	for _, r := range sv.Showdown {
+		if shown := sv.Players[r.PlayerNum].Cards != [2]eval.Card{0, 0}; shown == r.Mucked {
+			t.Errorf("Test failed - spectators should see player %d's cards only if they were shown", r.PlayerNum)
+		}
+	}
+}
+
+func TestGame_FillOmniView(t *testing.T) {
+	g := NewGame(nil)
+	g.SetRangeModel(RangeModelFunc(func(r Range, e Event, gv *GameView) Range { return r }))
+	for i := 0; i < 3; i++ {
+		pn := g.AddPlayer()
+		BuyIn(g, pn, 1000)
+		ToggleReady(g, pn, 0)
+	}
+
+	Deal(g, 0, 0)
+	Bet(g, g.actionNum, 100)
+
+	gv := &GameView{}
+	g.FillOmniView(gv)
+	if want := g.GenerateOmniView(); !reflect.DeepEqual(gv, want) {
+		t.Errorf("Test failed - FillOmniView() = %+v\nwant %+v", gv, want)
+	}
+
+	// Everyone but the raiser folds, so the next hand's view has fewer pots and cards than the last
+	for g.getStage() != PreDeal {
+		Fold(g, g.actionNum, 0)
+	}
+	Deal(g, 0, 0)
+
+	g.FillOmniView(gv)
+	if want := g.GenerateOmniView(); !reflect.DeepEqual(gv, want) {
+		t.Errorf("Test failed - FillOmniView() kept state from the last view: %+v\nwant %+v", gv, want)
+	}
+
+	if allocs := testing.AllocsPerRun(10, func() { g.FillOmniView(gv) }); allocs != 0 {
+		t.Errorf("Test failed - expected refilling a view not to allocate, got %f allocations", allocs)
+	}
+}